@@ -77,6 +77,9 @@ func (l *lockTracker) Lock(id string) {
 type ItemInfo struct {
 	// This is the file's name relative to the cache's filesystem.
 	Name string
+
+	// Whether this is an expired copy, see GetOrCreateBytesOrStale.
+	Stale bool
 }
 
 // NewCache creates a new file cache with the given filesystem and max age.
@@ -213,6 +216,44 @@ func (c *Cache) GetOrCreateBytes(id string, create func() ([]byte, error)) (Item
 
 }
 
+// GetOrCreateBytesOrStale is the same as GetOrCreateBytes, but if create fails
+// and there is an expired copy in the cache, that copy is returned with
+// ItemInfo.Stale set, along with the error from create.
+func (c *Cache) GetOrCreateBytesOrStale(id string, create func() ([]byte, error)) (ItemInfo, []byte, error) {
+	id = cleanID(id)
+
+	c.nlocker.Lock(id)
+	defer c.nlocker.Unlock(id)
+
+	info := ItemInfo{Name: id}
+
+	if r := c.get(id, false); r != nil {
+		defer r.Close()
+		b, err := ioutil.ReadAll(r)
+		return info, b, err
+	}
+
+	b, err := create()
+	if err != nil {
+		if c.maxAge != 0 {
+			if stale, staleErr := afero.ReadFile(c.Fs, id); staleErr == nil {
+				info.Stale = true
+				return info, stale, err
+			}
+		}
+		return info, nil, err
+	}
+
+	if c.maxAge == 0 {
+		return info, b, nil
+	}
+
+	if err := afero.WriteReader(c.Fs, id, bytes.NewReader(b)); err != nil {
+		return info, nil, err
+	}
+	return info, b, nil
+}
+
 // GetBytes gets the file content with the given id from the cahce, nil if none found.
 func (c *Cache) GetBytes(id string) (ItemInfo, []byte, error) {
 	id = cleanID(id)
@@ -248,6 +289,12 @@ func (c *Cache) Get(id string) (ItemInfo, io.ReadCloser, error) {
 // getOrRemove gets the file with the given id. If it's expired, it will
 // be removed.
 func (c *Cache) getOrRemove(id string) hugio.ReadSeekCloser {
+	return c.get(id, true)
+}
+
+// get gets the file with the given id, nil if it's expired. If remove is set,
+// an expired file will be removed.
+func (c *Cache) get(id string, remove bool) hugio.ReadSeekCloser {
 	if c.maxAge == 0 {
 		// No caching.
 		return nil
@@ -260,7 +307,9 @@ func (c *Cache) getOrRemove(id string) hugio.ReadSeekCloser {
 		}
 
 		if c.isExpired(fi.ModTime()) {
-			c.Fs.Remove(id)
+			if remove {
+				c.Fs.Remove(id)
+			}
 			return nil
 		}
 	}
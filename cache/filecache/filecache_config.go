@@ -14,6 +14,7 @@
 package filecache
 
 import (
+	"os"
 	"path"
 	"path/filepath"
 	"strings"
@@ -212,7 +213,7 @@ func DecodeConfig(fs afero.Fs, cfg config.Provider) (Configs, error) {
 	return c, nil
 }
 
-// Resolves :resourceDir => /myproject/resources etc., :cacheDir => ...
+// Resolves :resourceDir => /myproject/resources etc., :cacheDir => ..., :osCacheDir => ~/.cache etc.
 func resolveDirPlaceholder(fs afero.Fs, cfg config.Provider, placeholder string) (cacheDir string, isResource bool, err error) {
 	workingDir := cfg.GetString("workingDir")
 
@@ -222,9 +223,16 @@ func resolveDirPlaceholder(fs afero.Fs, cfg config.Provider, placeholder string)
 	case ":cachedir":
 		d, err := helpers.GetCacheDir(fs, cfg)
 		return d, false, err
+	case ":oscachedir":
+		// The user's cache dir, e.g. ~/.cache on Linux.
+		d, err := os.UserCacheDir()
+		if err != nil {
+			return "", false, errors.Wrap(err, "failed to resolve :osCacheDir")
+		}
+		return d, false, nil
 	case ":project":
 		return filepath.Base(workingDir), false, nil
 	}
 
-	return "", false, errors.Errorf("%q is not a valid placeholder (valid values are :cacheDir or :resourceDir)", placeholder)
+	return "", false, errors.Errorf("%q is not a valid placeholder (valid values are :cacheDir, :osCacheDir, :resourceDir or :project)", placeholder)
 }
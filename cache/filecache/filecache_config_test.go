@@ -14,6 +14,7 @@
 package filecache
 
 import (
+	"os"
 	"path/filepath"
 	"runtime"
 	"strings"
@@ -72,6 +73,35 @@ dir = "/path/to/c3"
 
 }
 
+func TestDecodeConfigOsCacheDir(t *testing.T) {
+	t.Parallel()
+
+	c := qt.New(t)
+
+	osCacheDir, err := os.UserCacheDir()
+	if err != nil {
+		t.Skip("no user cache dir on this system")
+	}
+
+	configStr := `
+[caches]
+[caches.getJSON]
+maxAge = "1h"
+dir = ":osCacheDir/hugo/:project"
+`
+
+	cfg, err := config.FromConfigString(configStr, "toml")
+	c.Assert(err, qt.IsNil)
+	cfg.Set("workingDir", "/my/project")
+
+	decoded, err := DecodeConfig(afero.NewMemMapFs(), cfg)
+	c.Assert(err, qt.IsNil)
+
+	getJSON := decoded["getjson"]
+	c.Assert(getJSON.MaxAge, qt.Equals, time.Hour)
+	c.Assert(getJSON.Dir, qt.Equals, filepath.Join(osCacheDir, "hugo", "project", "filecache", "getjson"))
+}
+
 func TestDecodeConfigIgnoreCache(t *testing.T) {
 	t.Parallel()
 
@@ -243,6 +243,49 @@ dir = "/cache/c"
 	wg.Wait()
 }
 
+func TestFileCacheStale(t *testing.T) {
+	c := qt.New(t)
+
+	fs := afero.NewMemMapFs()
+	cache := NewCache(fs, time.Hour, "")
+
+	failing := func() ([]byte, error) {
+		return nil, fmt.Errorf("failed")
+	}
+
+	info, b, err := cache.GetOrCreateBytesOrStale("a", failing)
+	c.Assert(err, qt.Not(qt.IsNil))
+	c.Assert(b, qt.IsNil)
+	c.Assert(info.Stale, qt.Equals, false)
+
+	_, b, err = cache.GetOrCreateBytesOrStale("a", func() ([]byte, error) {
+		return []byte("v1"), nil
+	})
+	c.Assert(err, qt.IsNil)
+	c.Assert(string(b), qt.Equals, "v1")
+
+	// Not expired.
+	info, b, err = cache.GetOrCreateBytesOrStale("a", failing)
+	c.Assert(err, qt.IsNil)
+	c.Assert(string(b), qt.Equals, "v1")
+	c.Assert(info.Stale, qt.Equals, false)
+
+	old := time.Now().Add(-2 * time.Hour)
+	c.Assert(fs.Chtimes("a", old, old), qt.IsNil)
+
+	info, b, err = cache.GetOrCreateBytesOrStale("a", failing)
+	c.Assert(err, qt.Not(qt.IsNil))
+	c.Assert(string(b), qt.Equals, "v1")
+	c.Assert(info.Stale, qt.Equals, true)
+
+	_, b, err = cache.GetOrCreateBytesOrStale("a", func() ([]byte, error) {
+		return []byte("v2"), nil
+	})
+	c.Assert(err, qt.IsNil)
+	c.Assert(string(b), qt.Equals, "v2")
+	c.Assert(cache.getString("a"), qt.Equals, "v2")
+}
+
 func TestCleanID(t *testing.T) {
 	c := qt.New(t)
 	c.Assert(cleanID(filepath.FromSlash("/a/b//c.txt")), qt.Equals, filepath.FromSlash("a/b/c.txt"))
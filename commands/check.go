@@ -26,9 +26,13 @@ type checkCmd struct {
 }
 
 func newCheckCmd() *checkCmd {
-	return &checkCmd{baseCmd: &baseCmd{cmd: &cobra.Command{
+	cc := &checkCmd{baseCmd: &baseCmd{cmd: &cobra.Command{
 		Use:   "check",
 		Short: "Contains some verification checks",
 	},
 	}}
+
+	cc.cmd.AddCommand(newCheckLinksCmd().getCommand())
+
+	return cc
 }
@@ -30,7 +30,7 @@ func newCheckCmd() *checkCmd {
 	},
 	}}
 
-	cc.cmd.AddCommand(newLimitCmd().getCommand())
+	cc.cmd.AddCommand(newLimitCmd().getCommand(), newCheckLinksCmd().getCommand())
 
 	return cc
 }
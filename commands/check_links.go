@@ -0,0 +1,276 @@
+// Copyright 2019 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/gohugoio/hugo/hugolib"
+	"github.com/gohugoio/hugo/publisher"
+	"github.com/gohugoio/hugo/resources/page"
+	"github.com/spf13/afero"
+	"github.com/spf13/cast"
+	"github.com/spf13/cobra"
+	jww "github.com/spf13/jwalterweatherman"
+	"golang.org/x/net/html"
+)
+
+var _ cmder = (*checkLinksCmd)(nil)
+
+type checkLinksCmd struct {
+	hugoBuilderCommon
+	*baseCmd
+
+	report linkReport
+}
+
+func newCheckLinksCmd() *checkLinksCmd {
+	cc := &checkLinksCmd{}
+
+	cc.baseCmd = newBaseCmd(&cobra.Command{
+		Use:   "links",
+		Short: "Check the site for broken links and missing resources",
+		Long: `Build the site in memory and check it for:
+
+* ref and relref shortcodes pointing to pages that do not exist
+* links (href and src) in the rendered HTML to pages or files that do not exist
+* images listed in front matter that are neither page resources nor files in the site
+* page bundle resources not linked from any page (reported only)
+
+The command exits with an error if anything other than orphaned resources
+is found, which makes it suitable for CI.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cc.check()
+		},
+	})
+
+	cc.hugoBuilderCommon.handleFlags(cc.cmd)
+
+	return cc
+}
+
+func (cc *checkLinksCmd) check() error {
+	cfgInit := func(c *commandeer) error {
+		c.Set("renderToMemory", true)
+		// Make missing ref targets fail the build.
+		c.Set("refLinksErrorLevel", "ERROR")
+		return nil
+	}
+
+	c, err := initializeConfig(true, false, &cc.hugoBuilderCommon, cc, cfgInit)
+	if err != nil {
+		return err
+	}
+
+	if err := c.fullBuild(); err != nil {
+		return err
+	}
+
+	report, err := checkLinks(c.hugo())
+	if err != nil {
+		return err
+	}
+	cc.report = report
+
+	for _, line := range report.lines() {
+		jww.FEEDBACK.Println(line)
+	}
+
+	if n := report.errors(); n > 0 {
+		return newUserError(fmt.Sprintf("found %d broken link(s) and missing image(s)", n))
+	}
+
+	return nil
+}
+
+type linkReport struct {
+	// HTML file => broken link targets.
+	brokenLinks map[string][]string
+
+	// Content file => missing front matter images.
+	missingImages map[string][]string
+
+	// Bundle resources not linked from any page.
+	orphanedResources []string
+}
+
+// errors returns the number of broken links and missing images.
+func (r linkReport) errors() int {
+	var n int
+	for _, links := range r.brokenLinks {
+		n += len(links)
+	}
+	for _, images := range r.missingImages {
+		n += len(images)
+	}
+	return n
+}
+
+func (r linkReport) lines() []string {
+	var lines []string
+	for _, filename := range sortedKeys(r.brokenLinks) {
+		for _, link := range r.brokenLinks[filename] {
+			lines = append(lines, fmt.Sprintf("BROKEN_LINK: %s: %s", filename, link))
+		}
+	}
+	for _, filename := range sortedKeys(r.missingImages) {
+		for _, image := range r.missingImages[filename] {
+			lines = append(lines, fmt.Sprintf("MISSING_IMAGE: %s: %s", filename, image))
+		}
+	}
+	for _, resource := range r.orphanedResources {
+		lines = append(lines, fmt.Sprintf("ORPHANED_RESOURCE: %s", resource))
+	}
+	return lines
+}
+
+// checkLinks checks the published files of the built sites in h.
+func checkLinks(h *hugolib.HugoSites) (linkReport, error) {
+	report := linkReport{
+		brokenLinks:   make(map[string][]string),
+		missingImages: make(map[string][]string),
+	}
+
+	fs := h.BaseFs.PublishFs
+	basePath := "/"
+	if u, err := url.Parse(h.Cfg.GetString("baseURL")); err == nil && u.Path != "" {
+		basePath = strings.TrimSuffix(u.Path, "/") + "/"
+	}
+
+	var htmlFiles []string
+	err := afero.Walk(fs, "", func(filename string, fi os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !fi.IsDir() && strings.EqualFold(filepath.Ext(filename), ".html") {
+			htmlFiles = append(htmlFiles, filepath.ToSlash(strings.TrimPrefix(filename, string(os.PathSeparator))))
+		}
+		return nil
+	})
+	if err != nil {
+		return report, err
+	}
+	sort.Strings(htmlFiles)
+
+	exists := func(p string) bool {
+		return publisher.LinkTargetExists(fs, p)
+	}
+
+	// The site relative paths linked to from the HTML files.
+	linked := make(map[string]bool)
+
+	for _, filename := range htmlFiles {
+		links, err := collectLinks(fs, filename)
+		if err != nil {
+			return report, err
+		}
+
+		for _, link := range links {
+			target, internal := publisher.ResolveLink(link, "/"+path.Dir(filename)+"/", basePath, h.Cfg.GetString("baseURL"))
+			if !internal {
+				continue
+			}
+			linked[target] = true
+			if !exists(target) {
+				report.brokenLinks[filename] = append(report.brokenLinks[filename], link)
+			}
+		}
+	}
+
+	seen := make(map[string]bool)
+	for _, p := range h.Pages() {
+		if p.File().IsZero() || seen[p.File().Filename()] {
+			continue
+		}
+		seen[p.File().Filename()] = true
+
+		for _, image := range frontMatterImages(p) {
+			if p.Resources().GetMatch(image) != nil {
+				continue
+			}
+			target, internal := publisher.ResolveLink(image, path.Dir(strings.TrimPrefix(p.RelPermalink(), strings.TrimSuffix(basePath, "/")))+"/", basePath, h.Cfg.GetString("baseURL"))
+			if internal && !exists(target) {
+				report.missingImages[p.Path()] = append(report.missingImages[p.Path()], image)
+			}
+		}
+
+		for _, r := range p.Resources() {
+			if r.ResourceType() == page.KindPage {
+				continue
+			}
+			target, internal := publisher.ResolveLink(r.RelPermalink(), "/", basePath, "")
+			if internal && !linked[target] && !frontMatterHasImage(p, r.Name()) {
+				report.orphanedResources = append(report.orphanedResources, strings.TrimPrefix(target, "/"))
+			}
+		}
+	}
+
+	sort.Strings(report.orphanedResources)
+
+	return report, nil
+}
+
+// collectLinks returns the href and src attribute values in the HTML file.
+func collectLinks(fs afero.Fs, filename string) ([]string, error) {
+	f, err := fs.Open(filepath.FromSlash(filename))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var links []string
+	tokenizer := html.NewTokenizer(f)
+	for {
+		tt := tokenizer.Next()
+		switch tt {
+		case html.ErrorToken:
+			return links, nil
+		case html.StartTagToken, html.SelfClosingTagToken:
+			for _, attr := range tokenizer.Token().Attr {
+				if attr.Key == "href" || attr.Key == "src" {
+					links = append(links, attr.Val)
+				}
+			}
+		}
+	}
+}
+
+func frontMatterImages(p page.Page) []string {
+	images, _ := cast.ToStringSliceE(p.Params()["images"])
+	return images
+}
+
+func frontMatterHasImage(p page.Page, name string) bool {
+	for _, image := range frontMatterImages(p) {
+		if image == name {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedKeys(m map[string][]string) []string {
+	var keys []string
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,116 @@
+// Copyright 2019 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestCheckLinks(t *testing.T) {
+	c := qt.New(t)
+	dir, err := createSimpleTestSite(t, testSiteConfig{})
+	c.Assert(err, qt.IsNil)
+
+	defer func() {
+		os.RemoveAll(dir)
+	}()
+
+	writeFile(t, filepath.Join(dir, "content", "posts", "mybundle", "index.md"), `---
+title: "My Bundle"
+images: ["cover.jpg", "missing.jpg", "/images/site.png", "/images/nope.png"]
+---
+[P1](/p1/) [Nope](/nope/) [Relative](../../p1/) [External](https://gohugo.io/) [Anchor](#top)
+
+![Linked](linked.txt)
+`)
+	writeFile(t, filepath.Join(dir, "content", "posts", "mybundle", "cover.jpg"), "cover")
+	writeFile(t, filepath.Join(dir, "content", "posts", "mybundle", "linked.txt"), "linked")
+	writeFile(t, filepath.Join(dir, "content", "posts", "mybundle", "orphan.txt"), "orphan")
+	writeFile(t, filepath.Join(dir, "static", "images", "site.png"), "site")
+	writeFile(t, filepath.Join(dir, "layouts", "_default", "single.html"), `Single: {{ .Title }}|{{ .Content }}`)
+
+	cc := newCheckLinksCmd()
+	cmd := cc.getCommand()
+	cmd.SetArgs([]string{"-s=" + dir})
+
+	_, err = cmd.ExecuteC()
+	c.Assert(err, qt.Not(qt.IsNil))
+	c.Assert(err.Error(), qt.Contains, "found 3 broken link(s)")
+
+	c.Assert(cc.report.brokenLinks, qt.DeepEquals, map[string][]string{
+		"posts/mybundle/index.html": {"/nope/"},
+	})
+	c.Assert(cc.report.missingImages, qt.DeepEquals, map[string][]string{
+		filepath.Join("posts", "mybundle", "index.md"): {"missing.jpg", "/images/nope.png"},
+	})
+	c.Assert(cc.report.orphanedResources, qt.DeepEquals, []string{"posts/mybundle/orphan.txt"})
+}
+
+func TestCheckLinksOutsideBasePath(t *testing.T) {
+	c := qt.New(t)
+	dir, err := createSimpleTestSite(t, testSiteConfig{configTOML: `
+baseURL = "https://example.org/docs/"
+title = "Hugo Commands"
+`})
+	c.Assert(err, qt.IsNil)
+
+	defer func() {
+		os.RemoveAll(dir)
+	}()
+
+	writeFile(t, filepath.Join(dir, "content", "p2.md"), `---
+title: "P2"
+---
+[P1](/docs/p1/) [Other](/other/) [Other absolute](https://example.org/other/) [Nope](/docs/nope/)
+`)
+	writeFile(t, filepath.Join(dir, "layouts", "_default", "single.html"), `Single: {{ .Title }}|{{ .Content }}`)
+
+	cc := newCheckLinksCmd()
+	cmd := cc.getCommand()
+	cmd.SetArgs([]string{"-s=" + dir})
+
+	_, err = cmd.ExecuteC()
+	c.Assert(err, qt.Not(qt.IsNil))
+
+	// The links on the same host outside of the site are not checked.
+	c.Assert(cc.report.brokenLinks, qt.DeepEquals, map[string][]string{
+		"p2/index.html": {"/docs/nope/"},
+	})
+}
+
+func TestCheckLinksRefNotFound(t *testing.T) {
+	c := qt.New(t)
+	dir, err := createSimpleTestSite(t, testSiteConfig{})
+	c.Assert(err, qt.IsNil)
+
+	defer func() {
+		os.RemoveAll(dir)
+	}()
+
+	writeFile(t, filepath.Join(dir, "content", "p2.md"), `---
+title: "P2"
+---
+[Nope]({{< ref "nope.md" >}})
+`)
+
+	cmd := newCheckLinksCmd().getCommand()
+	cmd.SetArgs([]string{"-s=" + dir})
+
+	_, err = cmd.ExecuteC()
+	c.Assert(err, qt.Not(qt.IsNil))
+}
@@ -16,6 +16,7 @@ package commands
 import (
 	"bytes"
 	"errors"
+	"fmt"
 	"sync"
 
 	"golang.org/x/sync/semaphore"
@@ -89,6 +90,9 @@ type commandeer struct {
 	fastRenderMode      bool
 	showErrorInBrowser  bool
 
+	// Files matching these are not watched, set in ignoreWatchFiles.
+	ignoreWatchFilesRe []*regexp.Regexp
+
 	configured bool
 	paused     bool
 
@@ -327,6 +331,16 @@ func (c *commandeer) loadConfig(mustHaveConfigFile, running bool) error {
 	c.fastRenderMode = c.doLiveReload && !c.Cfg.GetBool("disableFastRender")
 	c.showErrorInBrowser = c.doLiveReload && !c.Cfg.GetBool("disableBrowserError")
 
+	var ignoreWatchFilesRe []*regexp.Regexp
+	for _, pattern := range c.Cfg.GetStringSlice("ignoreWatchFiles") {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return fmt.Errorf("invalid regexp %q in ignoreWatchFiles: %s", pattern, err)
+		}
+		ignoreWatchFilesRe = append(ignoreWatchFilesRe, re)
+	}
+	c.ignoreWatchFilesRe = ignoreWatchFilesRe
+
 	// This is potentially double work, but we need to do this one more time now
 	// that all the languages have been configured.
 	if c.doWithCommandeer != nil {
@@ -343,7 +357,9 @@ func (c *commandeer) loadConfig(mustHaveConfigFile, running bool) error {
 	cfg.Logger = logger
 	c.logger = logger
 
-	createMemFs := config.GetBool("renderToMemory")
+	// The image audit does not create the processed images, so do not
+	// publish the pages linking to them.
+	createMemFs := config.GetBool("renderToMemory") || config.GetBool("imageAudit")
 
 	if createMemFs {
 		// Rendering to memoryFS, publish to Root regardless of publishDir.
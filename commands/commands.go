@@ -16,6 +16,7 @@ package commands
 import (
 	"os"
 
+	"github.com/gohugoio/hugo/hugolib"
 	"github.com/gohugoio/hugo/hugolib/paths"
 
 	"github.com/gohugoio/hugo/common/hugo"
@@ -51,8 +52,10 @@ func (b *commandsBuilder) addAll() *commandsBuilder {
 		newConvertCmd(),
 		b.newNewCmd(),
 		newListCmd(),
+		newWarmCmd(),
 		newImportCmd(),
 		newGenCmd(),
+		newCompletionCmd(),
 		createReleaser(),
 		b.newModCmd(),
 	)
@@ -175,6 +178,9 @@ Complete documentation is available at http://gohugo.io/.`,
 	cc.cmd.PersistentFlags().BoolVar(&cc.logging, "log", false, "enable Logging")
 	cc.cmd.PersistentFlags().StringVar(&cc.logFile, "logFile", "", "log File path (if set, logging enabled automatically)")
 	cc.cmd.PersistentFlags().BoolVar(&cc.verboseLog, "verboseLog", false, "verbose logging")
+	cc.cmd.PersistentFlags().String("logLevel", "", "log level, one of debug, info, warn or error (overrides --verbose and --debug)")
+	cc.cmd.PersistentFlags().String("logFormat", "", "log format, one of text (default) or json")
+	cc.cmd.PersistentFlags().StringSlice("logSuppress", []string{}, "log message categories to suppress, e.g. i18n")
 
 	cc.cmd.Flags().BoolVarP(&cc.buildWatch, "watch", "w", false, "watch filesystem for changes and recreate as needed")
 
@@ -182,6 +188,10 @@ Complete documentation is available at http://gohugo.io/.`,
 
 	// Set bash-completion
 	_ = cc.cmd.PersistentFlags().SetAnnotation("logFile", cobra.BashCompFilenameExt, []string{})
+	setFlagCompletionValues(cc.cmd.PersistentFlags(), "logLevel", "debug", "info", "warn", "error")
+	setFlagCompletionValues(cc.cmd.PersistentFlags(), "logFormat", loggers.FormatText, loggers.FormatJSON)
+
+	cc.cmd.BashCompletionFunction = bashCompletionFunction
 
 	cc.cmd.SetGlobalNormalizationFunc(helpers.NormalizeHugoFlags)
 	cc.cmd.SilenceUsage = true
@@ -248,6 +258,7 @@ func (cc *hugoBuilderCommon) handleCommonBuilderFlags(cmd *cobra.Command) {
 	cmd.PersistentFlags().StringVarP(&cc.source, "source", "s", "", "filesystem path to read files relative from")
 	cmd.PersistentFlags().SetAnnotation("source", cobra.BashCompSubdirsInDir, []string{})
 	cmd.PersistentFlags().StringVarP(&cc.environment, "environment", "e", "", "build environment")
+	setFlagCompletionValues(cmd.PersistentFlags(), "environment", hugo.EnvironmentDevelopment, hugo.EnvironmentProduction)
 	cmd.PersistentFlags().StringP("themesDir", "", "", "filesystem path to themes directory")
 	cmd.PersistentFlags().BoolP("ignoreVendor", "", false, "ignores any _vendor directory")
 }
@@ -270,10 +281,15 @@ func (cc *hugoBuilderCommon) handleFlags(cmd *cobra.Command) {
 
 	cmd.Flags().Bool("templateMetrics", false, "display metrics about template executions")
 	cmd.Flags().Bool("templateMetricsHints", false, "calculate some improvement hints when combined with --templateMetrics")
+	cmd.Flags().String("templateMetricsFile", "", "also write the template metrics as JSON to `file` when combined with --templateMetrics")
+	cmd.Flags().String("buildReport", "", "display the time spent in the build phases and write it as JSON to `file`")
+	cmd.Flags().Bool("imageAudit", false, "display the image processing requested by the templates, grouped by source image, instead of processing the images")
 	cmd.Flags().BoolP("forceSyncStatic", "", false, "copy all files when static is changed.")
 	cmd.Flags().BoolP("noTimes", "", false, "don't sync modification time of files")
 	cmd.Flags().BoolP("noChmod", "", false, "don't sync permission mode of files")
 	cmd.Flags().BoolP("i18n-warnings", "", false, "print missing translations")
+	cmd.Flags().String("i18n-report", "", "write a JSON report of missing translations per language to `file`")
+	cmd.Flags().Int("i18n-min-coverage", 0, "fail the build if the translation coverage of a language is below this percentage")
 	cmd.Flags().BoolP("path-warnings", "", false, "print warnings on duplicate target paths etc.")
 	cmd.Flags().StringVarP(&cc.cpuprofile, "profile-cpu", "", "", "write cpu profile to `file`")
 	cmd.Flags().StringVarP(&cc.memprofile, "profile-mem", "", "", "write memory profile to `file`")
@@ -286,8 +302,12 @@ func (cc *hugoBuilderCommon) handleFlags(cmd *cobra.Command) {
 	cmd.Flags().MarkHidden("profile-mutex")
 
 	cmd.Flags().StringSlice("disableKinds", []string{}, "disable different kind of pages (home, RSS etc.)")
+	cmd.Flags().StringSlice("renderSegments", []string{}, "named segments to render (configured in the segments config)")
+	cmd.Flags().Int("renderWorkers", 0, "number of pages to render in parallel (default is the number of logical CPUs)")
+	cmd.Flags().Int("memoryBudget", 0, "memory budget in megabytes; when above it, the content of already published pages is freed")
 
 	cmd.Flags().Bool("minify", false, "minify any supported output format (HTML, XML etc.)")
+	cmd.Flags().String("poll", "", "set this to a poll interval, e.g --poll 700ms, to poll for file system changes instead of relying on file system events")
 
 	// Set bash-completion.
 	// Each flag must first be defined before using the SetAnnotation() call.
@@ -295,6 +315,7 @@ func (cc *hugoBuilderCommon) handleFlags(cmd *cobra.Command) {
 	_ = cmd.Flags().SetAnnotation("cacheDir", cobra.BashCompSubdirsInDir, []string{})
 	_ = cmd.Flags().SetAnnotation("destination", cobra.BashCompSubdirsInDir, []string{})
 	_ = cmd.Flags().SetAnnotation("theme", cobra.BashCompSubdirsInDir, []string{"themes"})
+	setFlagCompletionValues(cmd.Flags(), "disableKinds", hugolib.AllKinds()...)
 }
 
 func checkErr(logger *loggers.Logger, err error, s ...string) {
@@ -78,6 +78,9 @@ func TestCommandsPersistentFlags(t *testing.T) {
 		"--renderToDisk",
 		"--source=mysource",
 		"--path-warnings",
+		"--logLevel=info",
+		"--logFormat=json",
+		"--logSuppress=i18n",
 	}, func(commands []cmder) {
 		var sc *serverCmd
 		for _, command := range commands {
@@ -121,6 +124,10 @@ func TestCommandsPersistentFlags(t *testing.T) {
 		// The flag is named i18n-warnings
 		c.Assert(cfg.GetBool("logI18nWarnings"), qt.Equals, true)
 
+		c.Assert(cfg.GetString("logLevel"), qt.Equals, "info")
+		c.Assert(cfg.GetString("logFormat"), qt.Equals, "json")
+		c.Assert(cfg.GetStringSlice("logSuppress"), qt.DeepEquals, []string{"i18n"})
+
 	}}}
 
 	for _, test := range tests {
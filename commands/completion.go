@@ -0,0 +1,244 @@
+// Copyright 2019 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/gohugoio/hugo/helpers"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+var _ cmder = (*completionCmd)(nil)
+
+// completionValuesAnnotation holds the values to complete for a flag.
+const completionValuesAnnotation = "hugo_annotation_completion_values"
+
+// bashCompletionFunction is added to the generated Bash completion script.
+const bashCompletionFunction = `
+__hugo_complete_words()
+{
+    COMPREPLY=( $(compgen -W "$*" -- "$cur") )
+}
+`
+
+var completionShells = []string{"bash", "fish", "powershell", "zsh"}
+
+type completionCmd struct {
+	*baseCmd
+}
+
+func newCompletionCmd() *completionCmd {
+	cc := &completionCmd{}
+
+	cc.baseCmd = newBaseCmd(&cobra.Command{
+		Use:   "completion [bash|zsh|fish|powershell]",
+		Short: "Generate the shell completion script for Hugo",
+		Long: `Generate the shell completion script for Hugo and write it to stdout.
+
+To load the completions in the current Bash session:
+
+	$ source <(hugo completion bash)
+
+To load them for every new session, write the script to a file in your
+shell's completion directory, e.g.:
+
+	$ hugo completion bash > /etc/bash_completion.d/hugo
+	$ hugo completion zsh > "${fpath[1]}/_hugo"
+	$ hugo completion fish > ~/.config/fish/completions/hugo.fish
+	PS> hugo completion powershell | Out-String | Invoke-Expression`,
+		ValidArgs: completionShells,
+		Args:      cobra.ExactValidArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return writeCompletion(cmd.Root(), args[0], os.Stdout)
+		},
+	})
+
+	return cc
+}
+
+// setFlagCompletionValues sets the values to complete for the named flag.
+func setFlagCompletionValues(flags *pflag.FlagSet, name string, values ...string) {
+	_ = flags.SetAnnotation(name, completionValuesAnnotation, values)
+	_ = flags.SetAnnotation(name, cobra.BashCompCustom, []string{"__hugo_complete_words " + strings.Join(values, " ")})
+}
+
+func writeCompletion(root *cobra.Command, shell string, w io.Writer) error {
+	switch shell {
+	case "bash":
+		return root.GenBashCompletion(w)
+	case "zsh":
+		return root.GenZshCompletion(w)
+	case "fish":
+		return genFishCompletion(root, w)
+	case "powershell":
+		return genPowerShellCompletion(root, w)
+	default:
+		return newUserError(fmt.Sprintf("unsupported shell %q, must be one of %s", shell, strings.Join(completionShells, ", ")))
+	}
+}
+
+func genFishCompletion(root *cobra.Command, w io.Writer) error {
+	var buf bytes.Buffer
+	name := root.Name()
+
+	var commandNames []string
+	visitCommands(root, func(cmd *cobra.Command) {
+		if cmd != root {
+			commandNames = append(commandNames, cmd.Name())
+		}
+	})
+	commandNames = helpers.UniqueStringsSorted(commandNames)
+
+	fmt.Fprintf(&buf, "# fish completion for %s\n\n", name)
+	fmt.Fprintf(&buf, `function __%[1]s_using_command
+    set -l path
+    for token in (commandline -opc)[2..-1]
+        if contains -- $token %[2]s
+            set path $path $token
+        end
+    end
+    test "$path" = "$argv"
+end
+`, name, strings.Join(commandNames, " "))
+
+	visitCommands(root, func(cmd *cobra.Command) {
+		path := strings.Fields(cmd.CommandPath())[1:]
+		condition := strings.Join(append([]string{"__" + name + "_using_command"}, path...), " ")
+
+		buf.WriteString("\n")
+
+		for _, sub := range availableCommands(cmd) {
+			fmt.Fprintf(&buf, "complete -c %s -n '%s' -a %s -d %s\n", name, condition, sub.Name(), fishQuote(sub.Short))
+		}
+
+		if len(cmd.ValidArgs) > 0 {
+			fmt.Fprintf(&buf, "complete -c %s -n '%s' -a %s\n", name, condition, fishQuote(strings.Join(cmd.ValidArgs, " ")))
+		}
+
+		visitCommandFlags(cmd, func(f *pflag.Flag) {
+			fmt.Fprintf(&buf, "complete -c %s -n '%s' -l %s", name, condition, f.Name)
+			if f.Shorthand != "" {
+				fmt.Fprintf(&buf, " -s %s", f.Shorthand)
+			}
+			if values := f.Annotations[completionValuesAnnotation]; len(values) > 0 {
+				fmt.Fprintf(&buf, " -x -a %s", fishQuote(strings.Join(values, " ")))
+			} else if f.Value.Type() != "bool" {
+				buf.WriteString(" -r")
+			}
+			fmt.Fprintf(&buf, " -d %s\n", fishQuote(f.Usage))
+		})
+	})
+
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+func genPowerShellCompletion(root *cobra.Command, w io.Writer) error {
+	var buf bytes.Buffer
+	name := root.Name()
+
+	fmt.Fprintf(&buf, `using namespace System.Management.Automation
+using namespace System.Management.Automation.Language
+
+Register-ArgumentCompleter -Native -CommandName '%s' -ScriptBlock {
+    param($wordToComplete, $commandAst, $cursorPosition)
+    $commandElements = $commandAst.CommandElements
+    $command = @(
+        '%s'
+        for ($i = 1; $i -lt $commandElements.Count; $i++) {
+            $element = $commandElements[$i]
+            if ($element -isnot [StringConstantExpressionAst] -or
+                $element.StringConstantType -ne [StringConstantType]::BareWord -or
+                $element.Value.StartsWith('-')) {
+                break
+            }
+            $element.Value
+        }
+    ) -join ';'
+    $completions = @(switch ($command) {
+`, name, name)
+
+	visitCommands(root, func(cmd *cobra.Command) {
+		fmt.Fprintf(&buf, "        %s {\n", powerShellQuote(strings.Replace(cmd.CommandPath(), " ", ";", -1)))
+		for _, sub := range availableCommands(cmd) {
+			fmt.Fprintf(&buf, "            [CompletionResult]::new(%s, %s, [CompletionResultType]::ParameterValue, %s)\n",
+				powerShellQuote(sub.Name()), powerShellQuote(sub.Name()), powerShellQuote(sub.Short))
+		}
+		for _, arg := range cmd.ValidArgs {
+			fmt.Fprintf(&buf, "            [CompletionResult]::new(%s, %s, [CompletionResultType]::ParameterValue, %s)\n",
+				powerShellQuote(arg), powerShellQuote(arg), powerShellQuote(arg))
+		}
+		visitCommandFlags(cmd, func(f *pflag.Flag) {
+			if f.Shorthand != "" {
+				fmt.Fprintf(&buf, "            [CompletionResult]::new(%s, %s, [CompletionResultType]::ParameterName, %s)\n",
+					powerShellQuote("-"+f.Shorthand), powerShellQuote(f.Shorthand), powerShellQuote(f.Usage))
+			}
+			fmt.Fprintf(&buf, "            [CompletionResult]::new(%s, %s, [CompletionResultType]::ParameterName, %s)\n",
+				powerShellQuote("--"+f.Name), powerShellQuote(f.Name), powerShellQuote(f.Usage))
+		})
+		buf.WriteString("            break\n        }\n")
+	})
+
+	buf.WriteString(`    })
+    $completions.Where{ $_.CompletionText -like "$wordToComplete*" } |
+        Sort-Object -Property ListItemText
+}
+`)
+
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+// visitCommands calls fn for cmd and all of its available sub commands.
+func visitCommands(cmd *cobra.Command, fn func(cmd *cobra.Command)) {
+	fn(cmd)
+	for _, sub := range availableCommands(cmd) {
+		visitCommands(sub, fn)
+	}
+}
+
+func availableCommands(cmd *cobra.Command) []*cobra.Command {
+	var commands []*cobra.Command
+	for _, sub := range cmd.Commands() {
+		if sub.IsAvailableCommand() {
+			commands = append(commands, sub)
+		}
+	}
+	return commands
+}
+
+// visitCommandFlags calls fn for the visible local and inherited flags of cmd.
+func visitCommandFlags(cmd *cobra.Command, fn func(f *pflag.Flag)) {
+	visit := func(f *pflag.Flag) {
+		if !f.Hidden && f.Deprecated == "" {
+			fn(f)
+		}
+	}
+	cmd.LocalFlags().VisitAll(visit)
+	cmd.InheritedFlags().VisitAll(visit)
+}
+
+func fishQuote(s string) string {
+	return "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(s) + "'"
+}
+
+func powerShellQuote(s string) string {
+	return "'" + strings.Replace(s, "'", "''", -1) + "'"
+}
@@ -0,0 +1,90 @@
+// Copyright 2019 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestCompletion(t *testing.T) {
+	c := qt.New(t)
+
+	root := newCommandsBuilder().addAll().build().getCommand()
+
+	complete := func(shell string) string {
+		var buf bytes.Buffer
+		c.Assert(writeCompletion(root, shell, &buf), qt.IsNil)
+		return buf.String()
+	}
+
+	bash := complete("bash")
+	c.Assert(bash, qt.Contains, "__hugo_complete_words()")
+	c.Assert(bash, qt.Contains, `flags_completion+=("__hugo_complete_words csv json")`)
+
+	c.Assert(complete("zsh"), qt.Contains, "#compdef hugo")
+
+	fish := complete("fish")
+	c.Assert(fish, qt.Contains, `complete -c hugo -n '__hugo_using_command' -a server -d 'A high performance webserver'`)
+	c.Assert(fish, qt.Contains, `complete -c hugo -n '__hugo_using_command list all' -l format -x -a 'csv json' -d 'the output format, csv or json'`)
+	c.Assert(fish, qt.Contains, `complete -c hugo -n '__hugo_using_command server' -l port -s p -r -d 'port on which the server will listen'`)
+	c.Assert(fish, qt.Contains, `complete -c hugo -n '__hugo_using_command server' -l watch -s w -d 'watch filesystem for changes and recreate as needed'`)
+	c.Assert(fish, qt.Contains, `complete -c hugo -n '__hugo_using_command completion' -a 'bash fish powershell zsh'`)
+	c.Assert(fish, qt.Contains, `complete -c hugo -n '__hugo_using_command' -l disableKinds -x -a 'page home section taxonomy taxonomyTerm RSS sitemap robotsTXT 404'`)
+
+	powershell := complete("powershell")
+	c.Assert(powershell, qt.Contains, `'hugo;list' {`)
+	c.Assert(powershell, qt.Contains, `[CompletionResult]::new('drafts', 'drafts', [CompletionResultType]::ParameterValue, 'List all drafts')`)
+	c.Assert(powershell, qt.Contains, `[CompletionResult]::new('--format', 'format', [CompletionResultType]::ParameterName, 'the output format, csv or json')`)
+
+	c.Assert(writeCompletion(root, "tcsh", &bytes.Buffer{}), qt.Not(qt.IsNil))
+
+	root.SetArgs([]string{"completion", "tcsh"})
+	_, err := root.ExecuteC()
+	c.Assert(err, qt.Not(qt.IsNil))
+}
+
+func TestGenAutocompleteInvalidType(t *testing.T) {
+	c := qt.New(t)
+
+	dir, err := ioutil.TempDir("", "hugo-autocomplete")
+	c.Assert(err, qt.IsNil)
+	defer os.RemoveAll(dir)
+
+	filename := filepath.Join(dir, "hugo.sh")
+	c.Assert(ioutil.WriteFile(filename, []byte("existing"), 0666), qt.IsNil)
+
+	root := newCommandsBuilder().addAll().build().getCommand()
+
+	root.SetArgs([]string{"gen", "autocomplete", "--type=bogus", "--completionfile=" + filename})
+	_, err = root.ExecuteC()
+	c.Assert(err, qt.Not(qt.IsNil))
+
+	b, err := ioutil.ReadFile(filename)
+	c.Assert(err, qt.IsNil)
+	c.Assert(string(b), qt.Equals, "existing")
+
+	root.SetArgs([]string{"gen", "autocomplete", "--type=zsh", "--completionfile=" + filename})
+	_, err = root.ExecuteC()
+	c.Assert(err, qt.IsNil)
+
+	b, err = ioutil.ReadFile(filename)
+	c.Assert(err, qt.IsNil)
+	c.Assert(string(b), qt.Contains, "#compdef hugo")
+}
@@ -17,12 +17,14 @@ import (
 	"bytes"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"strings"
 	"time"
 
 	"github.com/gohugoio/hugo/resources/page"
 
 	"github.com/gohugoio/hugo/hugofs"
+	"github.com/gohugoio/hugo/hugofs/glob"
 
 	"github.com/gohugoio/hugo/helpers"
 
@@ -48,6 +50,10 @@ type convertCmd struct {
 
 	outputDir string
 	unsafe    bool
+	dryRun    bool
+	include   string
+
+	includeGlob func(s string) bool
 
 	*baseCmd
 }
@@ -97,16 +103,26 @@ to use YAML for the front matter.`,
 	cc.cmd.PersistentFlags().StringVarP(&cc.outputDir, "output", "o", "", "filesystem path to write files to")
 	cc.cmd.PersistentFlags().StringVarP(&cc.source, "source", "s", "", "filesystem path to read files relative from")
 	cc.cmd.PersistentFlags().BoolVar(&cc.unsafe, "unsafe", false, "enable less safe operations, please backup first")
+	cc.cmd.PersistentFlags().BoolVar(&cc.dryRun, "dryRun", false, "print the front matter changes without writing any files")
+	cc.cmd.PersistentFlags().StringVar(&cc.include, "include", "", "only convert the content files matching this Glob pattern, e.g. \"blog/**\"")
 	cc.cmd.PersistentFlags().SetAnnotation("source", cobra.BashCompSubdirsInDir, []string{})
 
 	return cc
 }
 
 func (cc *convertCmd) convertContents(format metadecoders.Format) error {
-	if cc.outputDir == "" && !cc.unsafe {
+	if cc.outputDir == "" && !cc.unsafe && !cc.dryRun {
 		return newUserError("Unsafe operation not allowed, use --unsafe or set a different output path")
 	}
 
+	if cc.include != "" {
+		g, err := glob.GetGlob(cc.include)
+		if err != nil {
+			return newUserError(fmt.Sprintf("invalid --include pattern %q: %s", cc.include, err))
+		}
+		cc.includeGlob = g.Match
+	}
+
 	c, err := initializeConfig(true, false, &cc.hugoBuilderCommon, cc, nil)
 	if err != nil {
 		return err
@@ -123,21 +139,24 @@ func (cc *convertCmd) convertContents(format metadecoders.Format) error {
 		return err
 	}
 
-	site := h.Sites[0]
+	// The content files may be shared between the languages.
+	seen := make(map[string]bool)
 
-	site.Log.FEEDBACK.Println("processing", len(site.AllPages()), "content files")
-	for _, p := range site.AllPages() {
-		if err := cc.convertAndSavePage(p, site, format); err != nil {
-			return err
+	for _, site := range h.Sites {
+		site.Log.FEEDBACK.Println("processing", len(site.AllPages()), "content files for language", site.Language().Lang)
+		for _, p := range site.AllPages() {
+			if err := cc.convertAndSavePage(p, site, format, seen); err != nil {
+				return err
+			}
 		}
 	}
 	return nil
 }
 
-func (cc *convertCmd) convertAndSavePage(p page.Page, site *hugolib.Site, targetFormat metadecoders.Format) error {
+func (cc *convertCmd) convertAndSavePage(p page.Page, site *hugolib.Site, targetFormat metadecoders.Format, seen map[string]bool) error {
 	// The resources are not in .Site.AllPages.
 	for _, r := range p.Resources().ByType("page") {
-		if err := cc.convertAndSavePage(r.(page.Page), site, targetFormat); err != nil {
+		if err := cc.convertAndSavePage(r.(page.Page), site, targetFormat, seen); err != nil {
 			return err
 		}
 	}
@@ -147,26 +166,66 @@ func (cc *convertCmd) convertAndSavePage(p page.Page, site *hugolib.Site, target
 		return nil
 	}
 
+	filename := p.File().Filename()
+	if seen[filename] {
+		return nil
+	}
+	seen[filename] = true
+
+	if cc.includeGlob != nil && !cc.includeGlob(strings.ToLower(filepath.ToSlash(p.File().Path()))) {
+		return nil
+	}
+
 	errMsg := fmt.Errorf("Error processing file %q", p.Path())
 
-	site.Log.INFO.Println("Attempting to convert", p.File().Filename())
+	site.Log.INFO.Println("Attempting to convert", filename)
 
 	f := p.File()
 	file, err := f.FileInfo().Meta().Open()
 	if err != nil {
 		site.Log.ERROR.Println(errMsg)
-		file.Close()
 		return nil
 	}
 
-	pf, err := parseContentFile(file)
+	source, err := ioutil.ReadAll(file)
+	file.Close()
 	if err != nil {
 		site.Log.ERROR.Println(errMsg)
-		file.Close()
 		return err
 	}
 
-	file.Close()
+	pf, err := parseContentFile(bytes.NewReader(source))
+	if err != nil {
+		site.Log.ERROR.Println(errMsg)
+		return err
+	}
+
+	newFilename := filename
+
+	if cc.outputDir != "" {
+		contentDir := strings.TrimSuffix(newFilename, p.Path())
+		contentDir = filepath.Base(contentDir)
+
+		newFilename = filepath.Join(cc.outputDir, contentDir, p.Path())
+	}
+
+	fs := hugofs.Os
+
+	if pf.frontMatterFormat == targetFormat {
+		// Leave the file as is, which keeps any comments.
+		if cc.outputDir == "" || cc.dryRun {
+			site.Log.INFO.Println("Skipping", filename, "already in", targetFormat)
+			return nil
+		}
+		if err := helpers.WriteToDisk(newFilename, bytes.NewReader(source), fs); err != nil {
+			return errors.Wrapf(err, "Failed to save file %q:", newFilename)
+		}
+		return nil
+	}
+
+	if hasFrontMatterComments(pf.frontMatterFormat, pf.frontMatterSource) {
+		site.Log.WARN.Printf("Any comments in the front matter of %q are not preserved", p.Path())
+	}
 
 	// better handling of dates in formats that don't have support for them
 	if pf.frontMatterFormat == metadecoders.JSON || pf.frontMatterFormat == metadecoders.YAML || pf.frontMatterFormat == metadecoders.TOML {
@@ -185,23 +244,120 @@ func (cc *convertCmd) convertAndSavePage(p page.Page, site *hugolib.Site, target
 		return err
 	}
 
+	if cc.dryRun {
+		oldFrontMatter := source[:len(source)-len(pf.content)]
+		site.Log.FEEDBACK.Print(diffFrontMatter(p.Path(), string(oldFrontMatter), newContent.String()))
+		return nil
+	}
+
 	newContent.Write(pf.content)
 
-	newFilename := p.File().Filename()
+	if err := helpers.WriteToDisk(newFilename, &newContent, fs); err != nil {
+		return errors.Wrapf(err, "Failed to save file %q:", newFilename)
+	}
 
-	if cc.outputDir != "" {
-		contentDir := strings.TrimSuffix(newFilename, p.Path())
-		contentDir = filepath.Base(contentDir)
+	return nil
+}
 
-		newFilename = filepath.Join(cc.outputDir, contentDir, p.Path())
+// hasFrontMatterComments reports whether the front matter source in the given
+// format has any comments, i.e. a "#" outside of a quoted string in TOML and
+// YAML, where it must also start the line or follow a space.
+func hasFrontMatterComments(format metadecoders.Format, source []byte) bool {
+	if format != metadecoders.TOML && format != metadecoders.YAML {
+		return false
 	}
 
-	fs := hugofs.Os
-	if err := helpers.WriteToDisk(newFilename, &newContent, fs); err != nil {
-		return errors.Wrapf(err, "Failed to save file %q:", newFilename)
+	for _, line := range strings.Split(string(source), "\n") {
+		var quote rune
+		var escaped bool
+		for i, r := range line {
+			switch {
+			case escaped:
+				escaped = false
+			case quote == '"' && r == '\\':
+				escaped = true
+			case quote != 0:
+				if r == quote {
+					quote = 0
+				}
+			case r == '"' || r == '\'':
+				quote = r
+			case r == '#':
+				if format == metadecoders.TOML || i == 0 || line[i-1] == ' ' || line[i-1] == '\t' {
+					return true
+				}
+			}
+		}
 	}
 
-	return nil
+	return false
+}
+
+// diffFrontMatter returns a unified diff like preview of the changes from
+// oldFrontMatter to newFrontMatter for the file with the given name.
+func diffFrontMatter(filename, oldFrontMatter, newFrontMatter string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "--- %s\n+++ %s\n", filename, filename)
+	for _, line := range diffLines(splitLines(oldFrontMatter), splitLines(newFrontMatter)) {
+		b.WriteString(line)
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+func splitLines(s string) []string {
+	s = strings.TrimSuffix(s, "\n")
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, "\n")
+}
+
+// diffLines returns the lines in a and b prefixed with "-" if only in a,
+// "+" if only in b, and " " if in both, based on the longest common
+// subsequence.
+func diffLines(a, b []string) []string {
+	// lcs[i][j] is the length of the longest common subsequence of a[i:] and b[j:].
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	var lines []string
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			lines = append(lines, " "+a[i])
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			lines = append(lines, "-"+a[i])
+			i++
+		default:
+			lines = append(lines, "+"+b[j])
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		lines = append(lines, "-"+a[i])
+	}
+	for ; j < len(b); j++ {
+		lines = append(lines, "+"+b[j])
+	}
+
+	return lines
 }
 
 type parsedFile struct {
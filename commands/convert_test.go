@@ -0,0 +1,146 @@
+// Copyright 2019 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gohugoio/hugo/parser/metadecoders"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestConvertDryRun(t *testing.T) {
+	c := qt.New(t)
+	dir, err := createSimpleTestSite(t, testSiteConfig{})
+	c.Assert(err, qt.IsNil)
+
+	defer func() {
+		os.RemoveAll(dir)
+	}()
+
+	bundleIndex := filepath.Join(dir, "content", "posts", "mybundle", "index.md")
+	bundleSource := `+++
+title = "My Bundle"
++++
+Bundle content.
+`
+	writeFile(t, bundleIndex, bundleSource)
+	writeFile(t, filepath.Join(dir, "content", "posts", "mybundle", "page.md"), `+++
+title = "Bundle Page"
++++
+`)
+	writeFile(t, filepath.Join(dir, "content", "posts", "yaml.md"), `---
+title: "Already YAML"
+---
+`)
+
+	hugoCmd := newCommandsBuilder().addAll().build()
+	cmd := hugoCmd.getCommand()
+	cmd.SetArgs([]string{"-s=" + dir, "convert", "toYAML", "--dryRun", "--include=posts/**"})
+
+	out, err := captureStdout(cmd.ExecuteC)
+	c.Assert(err, qt.IsNil)
+
+	c.Assert(out, qt.Contains, `--- `+filepath.Join("posts", "mybundle", "index.md")+`
++++ `+filepath.Join("posts", "mybundle", "index.md")+`
+-+++
+-title = "My Bundle"
+-+++
++---
++title: My Bundle
++---
+`)
+	c.Assert(out, qt.Contains, `-title = "Bundle Page"`)
+	c.Assert(out, qt.Not(qt.Contains), "Already YAML")
+	c.Assert(out, qt.Not(qt.Contains), "P1")
+
+	// Nothing is written.
+	b, err := ioutil.ReadFile(bundleIndex)
+	c.Assert(err, qt.IsNil)
+	c.Assert(string(b), qt.Equals, bundleSource)
+}
+
+func TestDiffLines(t *testing.T) {
+	c := qt.New(t)
+
+	c.Assert(diffLines([]string{"a", "b", "c"}, []string{"a", "x", "c", "d"}), qt.DeepEquals,
+		[]string{" a", "-b", "+x", " c", "+d"})
+	c.Assert(diffLines(nil, []string{"a"}), qt.DeepEquals, []string{"+a"})
+	c.Assert(diffLines([]string{"a"}, nil), qt.DeepEquals, []string{"-a"})
+}
+
+func TestConvertOutputDir(t *testing.T) {
+	c := qt.New(t)
+	dir, err := createSimpleTestSite(t, testSiteConfig{})
+	c.Assert(err, qt.IsNil)
+
+	defer func() {
+		os.RemoveAll(dir)
+	}()
+
+	yamlSource := `---
+title: "Already YAML"
+---
+`
+	writeFile(t, filepath.Join(dir, "content", "posts", "yaml.md"), yamlSource)
+	writeFile(t, filepath.Join(dir, "content", "posts", "toml.md"), `+++
+title = "TOML"
++++
+`)
+
+	outputDir := filepath.Join(dir, "converted")
+
+	hugoCmd := newCommandsBuilder().addAll().build()
+	cmd := hugoCmd.getCommand()
+	cmd.SetArgs([]string{"-s=" + dir, "convert", "toYAML", "--output=" + outputDir, "--include=posts/**"})
+
+	_, err = cmd.ExecuteC()
+	c.Assert(err, qt.IsNil)
+
+	// The files already in the target format are copied unchanged.
+	b, err := ioutil.ReadFile(filepath.Join(outputDir, "content", "posts", "yaml.md"))
+	c.Assert(err, qt.IsNil)
+	c.Assert(string(b), qt.Equals, yamlSource)
+
+	b, err = ioutil.ReadFile(filepath.Join(outputDir, "content", "posts", "toml.md"))
+	c.Assert(err, qt.IsNil)
+	c.Assert(string(b), qt.Contains, "title: TOML")
+}
+
+func TestHasFrontMatterComments(t *testing.T) {
+	c := qt.New(t)
+
+	for _, test := range []struct {
+		format metadecoders.Format
+		source string
+		expect bool
+	}{
+		{metadecoders.TOML, "# A comment\ntitle = \"T\"", true},
+		{metadecoders.TOML, "title = \"T\" # A comment", true},
+		{metadecoders.TOML, "color = \"#fff\"", false},
+		{metadecoders.TOML, "url = 'https://example.org/#top'", false},
+		{metadecoders.TOML, "title = \"A \\\" # quote\"", false},
+		{metadecoders.YAML, "# A comment\ntitle: T", true},
+		{metadecoders.YAML, "title: T # A comment", true},
+		{metadecoders.YAML, "url: https://example.org/#top", false},
+		{metadecoders.YAML, "color: \"#fff\"", false},
+		{metadecoders.JSON, "{\"title\": \"# T\"}", false},
+	} {
+		c.Assert(hasFrontMatterComments(test.format, []byte(test.source)), qt.Equals, test.expect, qt.Commentf(test.source))
+	}
+}
@@ -14,6 +14,9 @@
 package commands
 
 import (
+	"bytes"
+	"io/ioutil"
+
 	"github.com/spf13/cobra"
 	jww "github.com/spf13/jwalterweatherman"
 )
@@ -23,7 +26,7 @@ var _ cmder = (*genautocompleteCmd)(nil)
 type genautocompleteCmd struct {
 	autocompleteTarget string
 
-	// bash for now (zsh and others will come)
+	// One of bash, zsh, fish or powershell.
 	autocompleteType string
 
 	*baseCmd
@@ -37,10 +40,7 @@ func newGenautocompleteCmd() *genautocompleteCmd {
 		Short: "Generate shell autocompletion script for Hugo",
 		Long: `Generates a shell autocompletion script for Hugo.
 
-NOTE: The current version supports Bash only.
-      This should work for *nix systems with Bash installed.
-
-By default, the file is written directly to /etc/bash_completion.d
+By default, the Bash script is written directly to /etc/bash_completion.d
 for convenience, and the command may need superuser rights, e.g.:
 
 	$ sudo hugo gen autocomplete
@@ -54,27 +54,29 @@ or just source them in directly:
 	$ . /etc/bash_completion`,
 
 		RunE: func(cmd *cobra.Command, args []string) error {
-			if cc.autocompleteType != "bash" {
-				return newUserError("Only Bash is supported for now")
+			// Generate the script first, so an invalid --type leaves any
+			// existing file alone.
+			var buf bytes.Buffer
+			if err := writeCompletion(cmd.Root(), cc.autocompleteType, &buf); err != nil {
+				return err
 			}
 
-			err := cmd.Root().GenBashCompletionFile(cc.autocompleteTarget)
-
-			if err != nil {
+			if err := ioutil.WriteFile(cc.autocompleteTarget, buf.Bytes(), 0666); err != nil {
 				return err
 			}
 
-			jww.FEEDBACK.Println("Bash completion file for Hugo saved to", cc.autocompleteTarget)
+			jww.FEEDBACK.Println("Completion file for Hugo saved to", cc.autocompleteTarget)
 
 			return nil
 		},
 	})
 
 	cc.cmd.PersistentFlags().StringVarP(&cc.autocompleteTarget, "completionfile", "", "/etc/bash_completion.d/hugo.sh", "autocompletion file")
-	cc.cmd.PersistentFlags().StringVarP(&cc.autocompleteType, "type", "", "bash", "autocompletion type (currently only bash supported)")
+	cc.cmd.PersistentFlags().StringVarP(&cc.autocompleteType, "type", "", "bash", "autocompletion type, one of bash, zsh, fish or powershell")
 
 	// For bash-completion
 	cc.cmd.PersistentFlags().SetAnnotation("completionfile", cobra.BashCompFilenameExt, []string{})
+	setFlagCompletionValues(cc.cmd.PersistentFlags(), "type", completionShells...)
 
 	return cc
 }
@@ -16,6 +16,7 @@
 package commands
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"io/ioutil"
@@ -166,10 +167,30 @@ func (c *commandeer) createLogger(cfg config.Provider, running bool) (*loggers.L
 		}
 	}
 
-	loggers.InitGlobalLogger(stdoutThreshold, logThreshold, outHandle, logHandle)
+	if level := cfg.GetString("logLevel"); level != "" {
+		// An explicit log level wins over --verbose and --debug.
+		threshold, err := loggers.ParseLevel(level)
+		if err != nil {
+			return nil, newSystemError(err)
+		}
+		stdoutThreshold = threshold
+		if c.h.verboseLog {
+			logThreshold = threshold
+		}
+	}
+
+	opts := loggers.Options{
+		Format:   cfg.GetString("logFormat"),
+		Suppress: cfg.GetStringSlice("logSuppress"),
+	}
+	if err := opts.Validate(); err != nil {
+		return nil, newSystemError(err)
+	}
+
+	loggers.InitGlobalLogger(stdoutThreshold, logThreshold, outHandle, logHandle, opts)
 	helpers.InitLoggers()
 
-	return loggers.NewLogger(stdoutThreshold, logThreshold, outHandle, logHandle, running), nil
+	return loggers.NewLoggerWithOptions(stdoutThreshold, logThreshold, outHandle, logHandle, running, opts), nil
 }
 
 func initializeFlags(cmd *cobra.Command, cfg config.Provider) {
@@ -177,6 +198,9 @@ func initializeFlags(cmd *cobra.Command, cfg config.Provider) {
 		"debug",
 		"verbose",
 		"logFile",
+		"logLevel",
+		"logFormat",
+		"logSuppress",
 		// Moved from vars
 	}
 	flagKeys := []string{
@@ -197,6 +221,9 @@ func initializeFlags(cmd *cobra.Command, cfg config.Provider) {
 		"ignoreVendor",
 		"templateMetrics",
 		"templateMetricsHints",
+		"templateMetricsFile",
+		"buildReport",
+		"imageAudit",
 
 		// Moved from vars.
 		"baseURL",
@@ -212,12 +239,21 @@ func initializeFlags(cmd *cobra.Command, cfg config.Provider) {
 		"force",
 		"gc",
 		"i18n-warnings",
+		"i18n-report",
+		"i18n-min-coverage",
 		"invalidateCDN",
 		"layoutDir",
 		"logFile",
+		"logLevel",
+		"logFormat",
+		"logSuppress",
 		"maxDeletes",
+		"memoryBudget",
+		"poll",
 		"quiet",
+		"renderSegments",
 		"renderToMemory",
+		"renderWorkers",
 		"source",
 		"target",
 		"theme",
@@ -227,11 +263,6 @@ func initializeFlags(cmd *cobra.Command, cfg config.Provider) {
 		"duplicateTargetPaths",
 	}
 
-	// Will set a value even if it is the default.
-	flagKeysForced := []string{
-		"minify",
-	}
-
 	for _, key := range persFlagKeys {
 		setValueFromFlag(cmd.PersistentFlags(), key, cfg, "", false)
 	}
@@ -239,14 +270,14 @@ func initializeFlags(cmd *cobra.Command, cfg config.Provider) {
 		setValueFromFlag(cmd.Flags(), key, cfg, "", false)
 	}
 
-	for _, key := range flagKeysForced {
-		setValueFromFlag(cmd.Flags(), key, cfg, "", true)
-	}
-
 	// Set some "config aliases"
 	setValueFromFlag(cmd.Flags(), "destination", cfg, "publishDir", false)
 	setValueFromFlag(cmd.Flags(), "i18n-warnings", cfg, "logI18nWarnings", false)
+	setValueFromFlag(cmd.Flags(), "i18n-report", cfg, "i18nReportFile", false)
+	setValueFromFlag(cmd.Flags(), "i18n-min-coverage", cfg, "i18nMinCoverage", false)
 	setValueFromFlag(cmd.Flags(), "path-warnings", cfg, "logPathWarnings", false)
+	// The minify config key may hold a map with minifier options.
+	setValueFromFlag(cmd.Flags(), "minify", cfg, "minifyOutput", false)
 
 }
 
@@ -289,6 +320,19 @@ func ifTerminal(s string) string {
 	return s
 }
 
+// logFormatJSON returns whether the log is written as JSON, in which case
+// nothing else should be written to stdout.
+func (c *commandeer) logFormatJSON() bool {
+	return c.Cfg.GetString("logFormat") == loggers.FormatJSON
+}
+
+// printProcessingStats prints the build statistics table.
+func (c *commandeer) printProcessingStats() {
+	var b bytes.Buffer
+	c.hugo().PrintProcessingStats(&b)
+	c.logger.FEEDBACK.Printf("\n%s\n", b.String())
+}
+
 func (c *commandeer) fullBuild() error {
 
 	var (
@@ -297,11 +341,15 @@ func (c *commandeer) fullBuild() error {
 	)
 
 	if !c.h.quiet {
-		fmt.Print(ifTerminal(hideCursor) + "Building sites … ")
-		if isTerminal() {
-			defer func() {
-				fmt.Print(showCursor + clearLine)
-			}()
+		if c.logFormatJSON() {
+			c.logger.FEEDBACK.Println("Building sites …")
+		} else {
+			fmt.Print(ifTerminal(hideCursor) + "Building sites … ")
+			if isTerminal() {
+				defer func() {
+					fmt.Print(showCursor + clearLine)
+				}()
+			}
 		}
 	}
 
@@ -480,11 +528,8 @@ func (c *commandeer) build() error {
 		return err
 	}
 
-	// TODO(bep) Feedback?
 	if !c.h.quiet {
-		fmt.Println()
-		c.hugo().PrintProcessingStats(os.Stdout)
-		fmt.Println()
+		c.printProcessingStats()
 
 		if createCounter, ok := c.destinationFs.(hugofs.DuplicatesReporter); ok {
 			dupes := createCounter.ReportDuplicates()
@@ -506,7 +551,9 @@ func (c *commandeer) build() error {
 		c.logger.FEEDBACK.Printf("Watching for changes in %s%s{%s}\n", baseWatchDir, helpers.FilePathSeparator, rootWatchDirs)
 		c.logger.FEEDBACK.Println("Press Ctrl+C to stop")
 		watcher, err := c.newWatcher(watchDirs...)
-		checkErr(c.Logger, err)
+		if err != nil {
+			return err
+		}
 		defer watcher.Close()
 
 		var sigs = make(chan os.Signal, 1)
@@ -536,11 +583,8 @@ func (c *commandeer) serverBuild() error {
 		return err
 	}
 
-	// TODO(bep) Feedback?
 	if !c.h.quiet {
-		fmt.Println()
-		c.hugo().PrintProcessingStats(os.Stdout)
-		fmt.Println()
+		c.printProcessingStats()
 	}
 
 	return nil
@@ -682,7 +726,12 @@ func (c *commandeer) getDirList() ([]string, error) {
 				return filepath.SkipDir
 			}
 
-			dirnames = append(dirnames, fi.Meta().Filename())
+			filename := fi.Meta().Filename()
+			if c.isIgnoredWatchFile(filename) {
+				return filepath.SkipDir
+			}
+
+			dirnames = append(dirnames, filename)
 		}
 
 		return nil
@@ -698,11 +747,49 @@ func (c *commandeer) getDirList() ([]string, error) {
 		}
 	}
 
+	for _, dir := range c.extraWatchDirs() {
+		fs := hugofs.NewBaseFileDecorator(c.Fs.Source)
+		w := hugofs.NewWalkway(hugofs.WalkwayConfig{Logger: c.logger, Fs: fs, Root: dir, WalkFn: walkFn})
+		if err := w.Walk(); err != nil {
+			c.logger.ERROR.Println("walker: ", err)
+		}
+	}
+
 	dirnames = helpers.UniqueStringsSorted(dirnames)
 
 	return dirnames, nil
 }
 
+// extraWatchDirs returns the absolute paths of the directories outside of the
+// project set in watchDirs. A change in any of these triggers a full rebuild.
+func (c *commandeer) extraWatchDirs() []string {
+	var dirs []string
+	for _, dir := range c.Cfg.GetStringSlice("watchDirs") {
+		dirs = append(dirs, c.hugo().PathSpec.AbsPathify(dir))
+	}
+	return dirs
+}
+
+func (c *commandeer) isExtraWatchFile(filename string) bool {
+	for _, dir := range c.extraWatchDirs() {
+		if filename == dir || strings.HasPrefix(filename, dir+helpers.FilePathSeparator) {
+			return true
+		}
+	}
+	return false
+}
+
+// isIgnoredWatchFile returns whether filename matches any of the patterns
+// in ignoreWatchFiles.
+func (c *commandeer) isIgnoredWatchFile(filename string) bool {
+	for _, re := range c.ignoreWatchFilesRe {
+		if re.MatchString(filename) {
+			return true
+		}
+	}
+	return false
+}
+
 func (c *commandeer) buildSites() (err error) {
 	return c.hugo().Build(hugolib.BuildCfg{})
 }
@@ -814,7 +901,20 @@ func (c *commandeer) newWatcher(dirList ...string) (*watcher.Batcher, error) {
 		return nil, err
 	}
 
-	watcher, err := watcher.New(1 * time.Second)
+	var pollInterval time.Duration
+	poll := c.Cfg.GetString("poll") != ""
+	if poll {
+		pollInterval, err = time.ParseDuration(c.Cfg.GetString("poll"))
+		if err != nil {
+			return nil, newUserError("invalid value for flag poll:", err)
+		}
+		if pollInterval <= 0 {
+			return nil, newUserError("invalid value for flag poll: the interval must be positive, got", pollInterval)
+		}
+		c.logger.FEEDBACK.Printf("Polling for file system changes every %s\n", pollInterval)
+	}
+
+	watcher, err := watcher.New(1*time.Second, pollInterval, poll)
 
 	if err != nil {
 		return nil, err
@@ -835,16 +935,30 @@ func (c *commandeer) newWatcher(dirList ...string) (*watcher.Batcher, error) {
 		configSet[configFile] = true
 	}
 
+	// Record the state of the watched files in the background so we can
+	// skip the events for files that are touched but not changed.
+	changes := newSourceChangeDetector(c.Fs.Source)
+	go func() {
+		for _, d := range dirList {
+			if d != "" {
+				changes.addDir(d)
+			}
+		}
+		for _, configFile := range c.configFiles {
+			changes.addDir(configFile)
+		}
+	}()
+
 	go func() {
 		for {
 			select {
 			case evs := <-watcher.Events:
-				c.handleEvents(watcher, staticSyncer, evs, configSet)
+				c.handleEvents(watcher, staticSyncer, changes, evs, configSet)
 				if c.showErrorInBrowser && c.errCount() > 0 {
 					// Need to reload browser to show the error
 					livereload.ForceRefresh()
 				}
-			case err := <-watcher.Errors:
+			case err := <-watcher.Errors():
 				if err != nil {
 					c.logger.ERROR.Println("Error while watching:", err)
 				}
@@ -874,9 +988,15 @@ const (
 
 func (c *commandeer) handleEvents(watcher *watcher.Batcher,
 	staticSyncer *staticSyncer,
+	changes *sourceChangeDetector,
 	evs []fsnotify.Event,
 	configSet map[string]bool) {
 
+	evs = changes.filter(evs)
+	if len(evs) == 0 {
+		return
+	}
+
 	var isHandled bool
 
 	for _, ev := range evs {
@@ -946,6 +1066,7 @@ func (c *commandeer) handleEvents(watcher *watcher.Batcher,
 
 	staticEvents := []fsnotify.Event{}
 	dynamicEvents := []fsnotify.Event{}
+	extraEvents := []fsnotify.Event{}
 
 	// Special handling for symbolic links inside /content.
 	filtered := []fsnotify.Event{}
@@ -997,7 +1118,7 @@ func (c *commandeer) handleEvents(watcher *watcher.Batcher,
 		if istemp {
 			continue
 		}
-		if c.hugo().Deps.SourceSpec.IgnoreFile(ev.Name) {
+		if c.hugo().Deps.SourceSpec.IgnoreFile(ev.Name) || c.isIgnoredWatchFile(ev.Name) {
 			continue
 		}
 		// Sometimes during rm -rf operations a '"": REMOVE' is triggered. Just ignore these
@@ -1043,13 +1164,22 @@ func (c *commandeer) handleEvents(watcher *watcher.Batcher,
 			}
 		}
 
-		if staticSyncer.isStatic(ev.Name) {
+		if c.isExtraWatchFile(ev.Name) {
+			extraEvents = append(extraEvents, ev)
+		} else if staticSyncer.isStatic(ev.Name) {
 			staticEvents = append(staticEvents, ev)
 		} else {
 			dynamicEvents = append(dynamicEvents, ev)
 		}
 	}
 
+	if len(extraEvents) > 0 {
+		// Hugo cannot tell what depends on files outside of the project,
+		// so rebuild everything.
+		c.fullRebuild("")
+		return
+	}
+
 	if len(staticEvents) > 0 {
 		c.printChangeDetected("Static files")
 
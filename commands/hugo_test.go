@@ -14,7 +14,10 @@
 package commands
 
 import (
+	"fmt"
+	"io/ioutil"
 	"os"
+	"path/filepath"
 	"testing"
 
 	qt "github.com/frankban/quicktest"
@@ -50,3 +53,47 @@ contentDir = "thisdoesnotexist"
 	c.Assert(err, qt.IsNil)
 
 }
+
+func TestWatchDirsConfig(t *testing.T) {
+	c := qt.New(t)
+
+	extraDir, err := ioutil.TempDir("", "hugo-watch")
+	c.Assert(err, qt.IsNil)
+	defer os.RemoveAll(extraDir)
+
+	writeFile(t, filepath.Join(extraDir, "tokens", "colors.json"), "{}")
+	writeFile(t, filepath.Join(extraDir, "vendor", "lib.js"), "")
+
+	cfgStr := fmt.Sprintf(`
+baseURL = "https://example.org"
+title = "Hugo Commands"
+watchDirs = [%q]
+ignoreWatchFiles = ["vendor$", "_default$"]
+`, extraDir)
+
+	dir, err := createSimpleTestSite(t, testSiteConfig{configTOML: cfgStr})
+	c.Assert(err, qt.IsNil)
+	defer os.RemoveAll(dir)
+
+	b := newCommandsBuilder().addAll().build()
+	com, err := initializeConfig(true, false, &hugoBuilderCommon{source: dir}, b, nil)
+	c.Assert(err, qt.IsNil)
+
+	dirs, err := com.getDirList()
+	c.Assert(err, qt.IsNil)
+
+	dirsSet := make(map[string]bool)
+	for _, d := range dirs {
+		dirsSet[d] = true
+	}
+
+	c.Assert(dirsSet[filepath.Join(dir, "content")], qt.Equals, true)
+	c.Assert(dirsSet[filepath.Join(dir, "layouts")], qt.Equals, true)
+	c.Assert(dirsSet[filepath.Join(dir, "layouts", "_default")], qt.Equals, false)
+	c.Assert(dirsSet[filepath.Join(extraDir, "tokens")], qt.Equals, true)
+	c.Assert(dirsSet[filepath.Join(extraDir, "vendor")], qt.Equals, false)
+
+	c.Assert(com.isExtraWatchFile(filepath.Join(extraDir, "tokens", "colors.json")), qt.Equals, true)
+	c.Assert(com.isExtraWatchFile(filepath.Join(dir, "content", "p1.md")), qt.Equals, false)
+	c.Assert(com.isIgnoredWatchFile(filepath.Join(extraDir, "vendor")), qt.Equals, true)
+}
@@ -15,15 +15,20 @@ package commands
 
 import (
 	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
 	"os"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/gohugoio/hugo/hugolib"
+	"github.com/gohugoio/hugo/resources/page"
 	"github.com/gohugoio/hugo/resources/resource"
+	"github.com/pkg/errors"
+	"github.com/spf13/cast"
 	"github.com/spf13/cobra"
-	jww "github.com/spf13/jwalterweatherman"
 )
 
 var _ cmder = (*listCmd)(nil)
@@ -31,6 +36,63 @@ var _ cmder = (*listCmd)(nil)
 type listCmd struct {
 	hugoBuilderCommon
 	*baseCmd
+
+	format  string
+	section string
+	after   string
+	before  string
+}
+
+// listRecord is a page in the output of the list commands.
+type listRecord struct {
+	Path        string    `json:"path"`
+	Slug        string    `json:"slug"`
+	Title       string    `json:"title"`
+	Section     string    `json:"section"`
+	Date        time.Time `json:"date"`
+	ExpiryDate  time.Time `json:"expiryDate"`
+	PublishDate time.Time `json:"publishDate"`
+	Draft       bool      `json:"draft"`
+	Permalink   string    `json:"permalink"`
+}
+
+func newListRecord(p page.Page, workingDir string) listRecord {
+	return listRecord{
+		Path:        strings.TrimPrefix(p.File().Filename(), workingDir+string(os.PathSeparator)),
+		Slug:        p.Slug(),
+		Title:       p.Title(),
+		Section:     p.Section(),
+		Date:        p.Date(),
+		ExpiryDate:  p.ExpiryDate(),
+		PublishDate: p.PublishDate(),
+		Draft:       p.Draft(),
+		Permalink:   p.Permalink(),
+	}
+}
+
+func (r listRecord) csvValue(column string) (string, error) {
+	switch column {
+	case "path":
+		return r.Path, nil
+	case "slug":
+		return r.Slug, nil
+	case "title":
+		return r.Title, nil
+	case "section":
+		return r.Section, nil
+	case "date":
+		return r.Date.Format(time.RFC3339), nil
+	case "expiryDate":
+		return r.ExpiryDate.Format(time.RFC3339), nil
+	case "publishDate":
+		return r.PublishDate.Format(time.RFC3339), nil
+	case "draft":
+		return strconv.FormatBool(r.Draft), nil
+	case "permalink":
+		return r.Permalink, nil
+	default:
+		return "", errors.Errorf("unknown list column %q", column)
+	}
 }
 
 func (lc *listCmd) buildSites(config map[string]interface{}) (*hugolib.HugoSites, error) {
@@ -59,6 +121,125 @@ func (lc *listCmd) buildSites(config map[string]interface{}) (*hugolib.HugoSites
 	return sites, nil
 }
 
+// filter returns a func that reports whether a page matches the --section,
+// --after and --before flags, the last two applied to the page date
+// returned by date.
+func (lc *listCmd) filter(date func(p page.Page) time.Time) (func(p page.Page) bool, error) {
+	var after, before time.Time
+	var err error
+
+	if lc.after != "" {
+		if after, err = cast.ToTimeE(lc.after); err != nil {
+			return nil, errors.Wrapf(err, "invalid --after date %q", lc.after)
+		}
+	}
+	if lc.before != "" {
+		if before, err = cast.ToTimeE(lc.before); err != nil {
+			return nil, errors.Wrapf(err, "invalid --before date %q", lc.before)
+		}
+	}
+
+	return func(p page.Page) bool {
+		if lc.section != "" && !strings.EqualFold(p.Section(), lc.section) {
+			return false
+		}
+		if !after.IsZero() && date(p).Before(after) {
+			return false
+		}
+		if !before.IsZero() && !date(p).Before(before) {
+			return false
+		}
+		return true
+	}, nil
+}
+
+// list builds the sites with the given config and writes the pages matching
+// include and the filter flags to stdout, with --after and --before applied
+// to the page date returned by date. The CSV output has the given columns,
+// the JSON output all of them. With no columns the CSV output is the page
+// paths, one per line, as hugo list drafts has always printed them.
+func (lc *listCmd) list(config map[string]interface{}, include func(p page.Page) bool, date func(p page.Page) time.Time, columns []string, header bool) error {
+	format := strings.ToLower(lc.format)
+	if format != "csv" && format != "json" {
+		return newUserError(fmt.Sprintf("unsupported --format %q, must be one of csv or json", lc.format))
+	}
+
+	filter, err := lc.filter(date)
+	if err != nil {
+		return newUserError(err)
+	}
+
+	sites, err := lc.buildSites(config)
+
+	if err != nil {
+		return newSystemError("Error building sites", err)
+	}
+
+	records := []listRecord{}
+	for _, p := range sites.Pages() {
+		if include(p) && filter(p) {
+			records = append(records, newListRecord(p, sites.WorkingDir))
+		}
+	}
+
+	if format == "json" {
+		err = writeListJSON(os.Stdout, records)
+	} else if len(columns) == 0 {
+		err = writeListPaths(os.Stdout, records)
+	} else {
+		err = writeListCSV(os.Stdout, records, columns, header)
+	}
+
+	if err != nil {
+		return newSystemError("Error writing pages to stdout", err)
+	}
+
+	return nil
+}
+
+func writeListCSV(w io.Writer, records []listRecord, columns []string, header bool) error {
+	writer := csv.NewWriter(w)
+
+	if header {
+		if err := writer.Write(columns); err != nil {
+			return err
+		}
+	}
+
+	for _, r := range records {
+		values := make([]string, len(columns))
+		for i, column := range columns {
+			v, err := r.csvValue(column)
+			if err != nil {
+				return err
+			}
+			values[i] = v
+		}
+		if err := writer.Write(values); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+
+	return writer.Error()
+}
+
+func writeListPaths(w io.Writer, records []listRecord) error {
+	for _, r := range records {
+		if _, err := fmt.Fprintln(w, r.Path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeListJSON(w io.Writer, records []listRecord) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(records)
+}
+
 func newListCmd() *listCmd {
 	cc := &listCmd{}
 
@@ -75,21 +256,16 @@ List requires a subcommand, e.g. ` + "`hugo list drafts`.",
 		&cobra.Command{
 			Use:   "drafts",
 			Short: "List all drafts",
-			Long:  `List all of the drafts in your content directory.`,
+			Long: `List all of the drafts in your content directory, one path per line, or
+all of their fields with --format json.`,
 			RunE: func(cmd *cobra.Command, args []string) error {
-				sites, err := cc.buildSites(map[string]interface{}{"buildDrafts": true})
-
-				if err != nil {
-					return newSystemError("Error building sites", err)
-				}
-
-				for _, p := range sites.Pages() {
-					if p.Draft() {
-						jww.FEEDBACK.Println(strings.TrimPrefix(p.File().Filename(), sites.WorkingDir+string(os.PathSeparator)))
-					}
-				}
-
-				return nil
+				return cc.list(
+					map[string]interface{}{"buildDrafts": true},
+					func(p page.Page) bool { return p.Draft() },
+					page.Page.Date,
+					nil,
+					false,
+				)
 			},
 		},
 		&cobra.Command{
@@ -97,28 +273,13 @@ List requires a subcommand, e.g. ` + "`hugo list drafts`.",
 			Short: "List all posts dated in the future",
 			Long:  `List all of the posts in your content directory which will be posted in the future.`,
 			RunE: func(cmd *cobra.Command, args []string) error {
-				sites, err := cc.buildSites(map[string]interface{}{"buildFuture": true})
-
-				if err != nil {
-					return newSystemError("Error building sites", err)
-				}
-
-				writer := csv.NewWriter(os.Stdout)
-				defer writer.Flush()
-
-				for _, p := range sites.Pages() {
-					if resource.IsFuture(p) {
-						err := writer.Write([]string{
-							strings.TrimPrefix(p.File().Filename(), sites.WorkingDir+string(os.PathSeparator)),
-							p.PublishDate().Format(time.RFC3339),
-						})
-						if err != nil {
-							return newSystemError("Error writing future posts to stdout", err)
-						}
-					}
-				}
-
-				return nil
+				return cc.list(
+					map[string]interface{}{"buildFuture": true},
+					func(p page.Page) bool { return resource.IsFuture(p) },
+					page.Page.Date,
+					[]string{"path", "publishDate"},
+					false,
+				)
 			},
 		},
 		&cobra.Command{
@@ -126,28 +287,32 @@ List requires a subcommand, e.g. ` + "`hugo list drafts`.",
 			Short: "List all posts already expired",
 			Long:  `List all of the posts in your content directory which has already expired.`,
 			RunE: func(cmd *cobra.Command, args []string) error {
-				sites, err := cc.buildSites(map[string]interface{}{"buildExpired": true})
-
-				if err != nil {
-					return newSystemError("Error building sites", err)
-				}
-
-				writer := csv.NewWriter(os.Stdout)
-				defer writer.Flush()
-
-				for _, p := range sites.Pages() {
-					if resource.IsExpired(p) {
-						err := writer.Write([]string{
-							strings.TrimPrefix(p.File().Filename(), sites.WorkingDir+string(os.PathSeparator)),
-							p.ExpiryDate().Format(time.RFC3339),
-						})
-						if err != nil {
-							return newSystemError("Error writing expired posts to stdout", err)
-						}
-					}
-				}
-
-				return nil
+				return cc.list(
+					map[string]interface{}{"buildExpired": true},
+					func(p page.Page) bool { return resource.IsExpired(p) },
+					page.Page.Date,
+					[]string{"path", "expiryDate"},
+					false,
+				)
+			},
+		},
+		&cobra.Command{
+			Use:   "scheduled",
+			Short: "List all posts to be published or expired",
+			Long: `List all of the posts in your content directory which will be published or
+expire in the future, with --after and --before applied to the date of that
+change, e.g. "hugo list scheduled --before 2020-01-01".`,
+			RunE: func(cmd *cobra.Command, args []string) error {
+				return cc.list(
+					map[string]interface{}{
+						"buildExpired": true,
+						"buildFuture":  true,
+					},
+					func(p page.Page) bool { return !resource.ScheduledDate(p).IsZero() },
+					func(p page.Page) time.Time { return resource.ScheduledDate(p) },
+					[]string{"path", "publishDate", "expiryDate"},
+					false,
+				)
 			},
 		},
 		&cobra.Command{
@@ -155,55 +320,28 @@ List requires a subcommand, e.g. ` + "`hugo list drafts`.",
 			Short: "List all posts",
 			Long:  `List all of the posts in your content directory, include drafts, future and expired pages.`,
 			RunE: func(cmd *cobra.Command, args []string) error {
-				sites, err := cc.buildSites(map[string]interface{}{
-					"buildExpired": true,
-					"buildDrafts":  true,
-					"buildFuture":  true,
-				})
-
-				if err != nil {
-					return newSystemError("Error building sites", err)
-				}
-
-				writer := csv.NewWriter(os.Stdout)
-				defer writer.Flush()
-
-				writer.Write([]string{
-					"path",
-					"slug",
-					"title",
-					"date",
-					"expiryDate",
-					"publishDate",
-					"draft",
-					"permalink",
-				})
-				for _, p := range sites.Pages() {
-					if !p.IsPage() {
-						continue
-					}
-					err := writer.Write([]string{
-						strings.TrimPrefix(p.File().Filename(), sites.WorkingDir+string(os.PathSeparator)),
-						p.Slug(),
-						p.Title(),
-						p.Date().Format(time.RFC3339),
-						p.ExpiryDate().Format(time.RFC3339),
-						p.PublishDate().Format(time.RFC3339),
-						strconv.FormatBool(p.Draft()),
-						p.Permalink(),
-					})
-					if err != nil {
-						return newSystemError("Error writing posts to stdout", err)
-					}
-				}
-
-				return nil
+				return cc.list(
+					map[string]interface{}{
+						"buildExpired": true,
+						"buildDrafts":  true,
+						"buildFuture":  true,
+					},
+					func(p page.Page) bool { return p.IsPage() },
+					page.Page.Date,
+					[]string{"path", "slug", "title", "date", "expiryDate", "publishDate", "draft", "permalink"},
+					true,
+				)
 			},
 		},
 	)
 
 	cc.cmd.PersistentFlags().StringVarP(&cc.source, "source", "s", "", "filesystem path to read files relative from")
 	cc.cmd.PersistentFlags().SetAnnotation("source", cobra.BashCompSubdirsInDir, []string{})
+	cc.cmd.PersistentFlags().StringVar(&cc.format, "format", "csv", "the output format, csv or json")
+	setFlagCompletionValues(cc.cmd.PersistentFlags(), "format", "csv", "json")
+	cc.cmd.PersistentFlags().StringVar(&cc.section, "section", "", "only list the pages in this section")
+	cc.cmd.PersistentFlags().StringVar(&cc.after, "after", "", "only list the pages dated on or after this date, e.g. 2019-01-31")
+	cc.cmd.PersistentFlags().StringVar(&cc.before, "before", "", "only list the pages dated before this date, e.g. 2019-12-31")
 
 	return cc
 }
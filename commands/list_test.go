@@ -3,6 +3,7 @@ package commands
 import (
 	"bytes"
 	"encoding/csv"
+	"encoding/json"
 	"io"
 	"os"
 	"path/filepath"
@@ -70,3 +71,140 @@ func TestListAll(t *testing.T) {
 		"false", "https://example.org/p1/",
 	})
 }
+
+func TestListJSON(t *testing.T) {
+	c := qt.New(t)
+	dir, err := createSimpleTestSite(t, testSiteConfig{})
+
+	c.Assert(err, qt.IsNil)
+
+	defer func() {
+		os.RemoveAll(dir)
+	}()
+
+	writeFile(t, filepath.Join(dir, "content", "posts", "p2.md"), `---
+title: "P2"
+date: 2019-03-01
+draft: true
+---
+`)
+	writeFile(t, filepath.Join(dir, "content", "posts", "p3.md"), `---
+title: "P3"
+date: 2019-06-01
+---
+`)
+
+	list := func(args ...string) []listRecord {
+		hugoCmd := newCommandsBuilder().addAll().build()
+		cmd := hugoCmd.getCommand()
+		cmd.SetArgs(append([]string{"-s=" + dir, "list", "all", "--format=json"}, args...))
+
+		out, err := captureStdout(cmd.ExecuteC)
+		c.Assert(err, qt.IsNil)
+
+		var records []listRecord
+		c.Assert(json.Unmarshal([]byte(out), &records), qt.IsNil)
+		return records
+	}
+
+	titles := func(records []listRecord) []string {
+		var s []string
+		for _, r := range records {
+			s = append(s, r.Title)
+		}
+		return s
+	}
+
+	records := list("--section=posts")
+	c.Assert(titles(records), qt.DeepEquals, []string{"P3", "P2"})
+	c.Assert(records[1].Path, qt.Equals, filepath.Join("content", "posts", "p2.md"))
+	c.Assert(records[1].Section, qt.Equals, "posts")
+	c.Assert(records[1].Draft, qt.Equals, true)
+	c.Assert(records[1].Permalink, qt.Equals, "https://example.org/posts/p2/")
+
+	c.Assert(titles(list("--after=2019-05-01")), qt.DeepEquals, []string{"P3"})
+	c.Assert(titles(list("--after=2019-01-01", "--before=2019-05-01")), qt.DeepEquals, []string{"P2"})
+	c.Assert(list("--section=blog"), qt.HasLen, 0)
+}
+
+func TestListScheduled(t *testing.T) {
+	c := qt.New(t)
+	dir, err := createSimpleTestSite(t, testSiteConfig{})
+
+	c.Assert(err, qt.IsNil)
+
+	defer func() {
+		os.RemoveAll(dir)
+	}()
+
+	writeFile(t, filepath.Join(dir, "content", "p2.md"), `---
+title: "P2"
+publishDate: 2100-03-01
+---
+`)
+	writeFile(t, filepath.Join(dir, "content", "p3.md"), `---
+title: "P3"
+expiryDate: 2100-06-01
+---
+`)
+	writeFile(t, filepath.Join(dir, "content", "p4.md"), `---
+title: "P4"
+expiryDate: 2000-06-01
+---
+`)
+
+	list := func(args ...string) string {
+		hugoCmd := newCommandsBuilder().addAll().build()
+		cmd := hugoCmd.getCommand()
+		cmd.SetArgs(append([]string{"-s=" + dir, "list", "scheduled"}, args...))
+
+		out, err := captureStdout(cmd.ExecuteC)
+		c.Assert(err, qt.IsNil)
+		return out
+	}
+
+	out := list()
+	c.Assert(out, qt.Contains, filepath.Join("content", "p2.md")+",2100-03-01T00:00:00Z,0001-01-01T00:00:00Z")
+	c.Assert(out, qt.Contains, filepath.Join("content", "p3.md")+",0001-01-01T00:00:00Z,2100-06-01T00:00:00Z")
+	c.Assert(out, qt.Not(qt.Contains), "p1.md")
+	c.Assert(out, qt.Not(qt.Contains), "p4.md")
+
+	out = list("--before=2100-04-01")
+	c.Assert(out, qt.Contains, "p2.md")
+	c.Assert(out, qt.Not(qt.Contains), "p3.md")
+}
+
+func TestListDrafts(t *testing.T) {
+	c := qt.New(t)
+	dir, err := createSimpleTestSite(t, testSiteConfig{})
+
+	c.Assert(err, qt.IsNil)
+
+	defer func() {
+		os.RemoveAll(dir)
+	}()
+
+	writeFile(t, filepath.Join(dir, "content", "p2, draft.md"), `---
+title: "P2"
+draft: true
+---
+`)
+
+	hugoCmd := newCommandsBuilder().addAll().build()
+	cmd := hugoCmd.getCommand()
+	cmd.SetArgs([]string{"-s=" + dir, "list", "drafts"})
+
+	out, err := captureStdout(cmd.ExecuteC)
+	c.Assert(err, qt.IsNil)
+
+	// The plain paths, not CSV quoted.
+	c.Assert(out, qt.Equals, filepath.Join("content", "p2, draft.md")+"\n")
+}
+
+func TestWriteListCSVUnknownColumn(t *testing.T) {
+	c := qt.New(t)
+
+	var buf bytes.Buffer
+	err := writeListCSV(&buf, []listRecord{{Path: "p1.md"}}, []string{"path", "weight"}, false)
+	c.Assert(err, qt.ErrorMatches, `unknown list column "weight"`)
+}
@@ -14,7 +14,6 @@
 package commands
 
 import (
-	"bytes"
 	"os"
 	"path/filepath"
 	"strings"
@@ -22,7 +21,6 @@ import (
 	"github.com/gohugoio/hugo/create"
 	"github.com/gohugoio/hugo/helpers"
 	"github.com/gohugoio/hugo/hugolib"
-	"github.com/spf13/afero"
 	"github.com/spf13/cobra"
 	jww "github.com/spf13/jwalterweatherman"
 )
@@ -103,15 +101,6 @@ func mkdir(x ...string) {
 	}
 }
 
-func touchFile(fs afero.Fs, x ...string) {
-	inpath := filepath.Join(x...)
-	mkdir(filepath.Dir(inpath))
-	err := helpers.WriteToDisk(inpath, bytes.NewReader([]byte{}), fs)
-	if err != nil {
-		jww.FATAL.Fatalln(err)
-	}
-}
-
 func newContentPathSection(h *hugolib.HugoSites, path string) (string, string) {
 	// Forward slashes is used in all examples. Convert if needed.
 	// Issue #1133
@@ -40,9 +40,10 @@ func newNewThemeCmd() *newThemeCmd {
 		Use:   "theme [name]",
 		Short: "Create a new theme",
 		Long: `Create a new theme (skeleton) called [name] in the current directory.
-New theme is a skeleton. Please add content to the touched files. Add your
-name to the copyright line in the license and adjust the theme.toml file
-as you see fit.`,
+New theme is a skeleton with working templates and an example site in
+exampleSite, which can be built with "hugo server -s exampleSite --themesDir=../..".
+Add your name to the copyright line in the license and adjust the theme.toml
+file as you see fit.`,
 		RunE: ccmd.newTheme,
 	}
 
@@ -72,47 +73,19 @@ func (n *newThemeCmd) newTheme(cmd *cobra.Command, args []string) error {
 		return errors.New(createpath + " already exists")
 	}
 
-	mkdir(createpath, "layouts", "_default")
-	mkdir(createpath, "layouts", "partials")
-
-	touchFile(cfg.Fs.Source, createpath, "layouts", "index.html")
-	touchFile(cfg.Fs.Source, createpath, "layouts", "404.html")
-	touchFile(cfg.Fs.Source, createpath, "layouts", "_default", "list.html")
-	touchFile(cfg.Fs.Source, createpath, "layouts", "_default", "single.html")
-
-	baseofDefault := []byte(`<!DOCTYPE html>
-<html>
-    {{- partial "head.html" . -}}
-    <body>
-        {{- partial "header.html" . -}}
-        <div id="content">
-        {{- block "main" . }}{{- end }}
-        </div>
-        {{- partial "footer.html" . -}}
-    </body>
-</html>
-`)
-	err = helpers.WriteToDisk(filepath.Join(createpath, "layouts", "_default", "baseof.html"), bytes.NewReader(baseofDefault), cfg.Fs.Source)
-	if err != nil {
-		return err
+	for _, f := range themeSkeletonFiles {
+		err = helpers.WriteToDisk(filepath.Join(createpath, filepath.FromSlash(f.name)), strings.NewReader(f.content), cfg.Fs.Source)
+		if err != nil {
+			return err
+		}
 	}
 
-	touchFile(cfg.Fs.Source, createpath, "layouts", "partials", "head.html")
-	touchFile(cfg.Fs.Source, createpath, "layouts", "partials", "header.html")
-	touchFile(cfg.Fs.Source, createpath, "layouts", "partials", "footer.html")
-
-	mkdir(createpath, "archetypes")
-
-	archDefault := []byte("+++\n+++\n")
-
-	err = helpers.WriteToDisk(filepath.Join(createpath, "archetypes", "default.md"), bytes.NewReader(archDefault), cfg.Fs.Source)
+	exampleConfig := strings.Replace(themeExampleSiteConfig, "THEME_NAME", filepath.Base(createpath), 1)
+	err = helpers.WriteToDisk(filepath.Join(createpath, "exampleSite", "config.toml"), strings.NewReader(exampleConfig), cfg.Fs.Source)
 	if err != nil {
 		return err
 	}
 
-	mkdir(createpath, "static", "js")
-	mkdir(createpath, "static", "css")
-
 	by := []byte(`The MIT License (MIT)
 
 Copyright (c) ` + time.Now().Format("2006") + ` YOUR_NAME_HERE
@@ -177,3 +150,167 @@ min_version = "0.41"
 
 	return nil
 }
+
+type themeSkeletonFile struct {
+	name    string
+	content string
+}
+
+// themeSkeletonFiles are the files created by "hugo new theme", relative to
+// the theme's root.
+var themeSkeletonFiles = []themeSkeletonFile{
+	{"layouts/_default/baseof.html", `<!DOCTYPE html>
+<html lang="{{ .Site.LanguageCode | default "en" }}">
+    {{- partial "head.html" . -}}
+    <body>
+        {{- partial "header.html" . -}}
+        <div id="content">
+        {{- block "main" . }}{{- end }}
+        </div>
+        {{- partial "footer.html" . -}}
+    </body>
+</html>
+`},
+	{"layouts/index.html", `{{ define "main" }}
+{{ .Content }}
+{{ range first 10 (where .Site.RegularPages "Type" "in" .Site.Params.mainSections) }}
+<article>
+  <h2><a href="{{ .RelPermalink }}">{{ .Title }}</a></h2>
+  {{ .Summary }}
+</article>
+{{ end }}
+{{ end }}
+`},
+	{"layouts/_default/list.html", `{{ define "main" }}
+<h1>{{ .Title }}</h1>
+{{ .Content }}
+{{ range .Paginator.Pages }}
+<article>
+  <h2><a href="{{ .RelPermalink }}">{{ .Title }}</a></h2>
+  {{ .Summary }}
+</article>
+{{ end }}
+{{ template "_internal/pagination.html" . }}
+{{ end }}
+`},
+	{"layouts/_default/single.html", `{{ define "main" }}
+<article>
+  <h1>{{ .Title }}</h1>
+  {{ with .Date }}{{ if not .IsZero }}<time datetime="{{ .Format "2006-01-02" }}">{{ .Format "January 2, 2006" }}</time>{{ end }}{{ end }}
+  {{ .Content }}
+  {{ with .Params.tags }}
+  <ul class="tags">
+    {{ range . }}<li><a href="{{ "tags/" | relLangURL }}{{ . | urlize }}/">{{ . }}</a></li>{{ end }}
+  </ul>
+  {{ end }}
+</article>
+{{ end }}
+`},
+	{"layouts/404.html", `{{ define "main" }}
+<h1>404</h1>
+<p>Page not found. Go to the <a href="{{ .Site.Home.RelPermalink }}">home page</a>.</p>
+{{ end }}
+`},
+	{"layouts/partials/head.html", `<head>
+    <meta charset="utf-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1">
+    <title>{{ if not .IsHome }}{{ .Title }} | {{ end }}{{ .Site.Title }}</title>
+    {{ $style := resources.Get "css/main.css" | minify | fingerprint }}
+    <link rel="stylesheet" href="{{ $style.RelPermalink }}" integrity="{{ $style.Data.Integrity }}">
+    {{ range .AlternativeOutputFormats -}}
+    {{ printf "<link rel=%q type=%q href=%q title=%q>" .Rel .MediaType.Type .Permalink $.Site.Title | safeHTML }}
+    {{ end -}}
+</head>
+`},
+	{"layouts/partials/header.html", `<header>
+    <a href="{{ .Site.Home.RelPermalink }}">{{ .Site.Title }}</a>
+    <nav>
+        <ul>
+        {{ $currentPage := . }}
+        {{ range .Site.Menus.main }}
+            <li{{ if $currentPage.IsMenuCurrent "main" . }} class="active"{{ end }}><a href="{{ .URL }}">{{ .Name }}</a></li>
+        {{ end }}
+        </ul>
+    </nav>
+</header>
+`},
+	{"layouts/partials/footer.html", `<footer>
+    <p>{{ with .Site.Copyright }}{{ . }}{{ else }}&copy; {{ now.Year }} {{ .Site.Title }}{{ end }}</p>
+</footer>
+`},
+	{"assets/css/main.css", `body {
+  margin: 0 auto;
+  max-width: 50rem;
+  padding: 1rem;
+  font-family: sans-serif;
+  line-height: 1.5;
+}
+
+nav ul {
+  list-style: none;
+  padding: 0;
+}
+
+nav li {
+  display: inline-block;
+  margin-right: 1rem;
+}
+
+nav li.active a {
+  font-weight: bold;
+}
+`},
+	{"archetypes/default.md", "+++\n+++\n"},
+	{"exampleSite/content/_index.md", `---
+title: "Home"
+---
+
+This is the example site for the theme.
+`},
+	{"exampleSite/content/about.md", `---
+title: "About"
+menu: main
+---
+
+An about page.
+`},
+	{"exampleSite/content/posts/_index.md", `---
+title: "Posts"
+menu: main
+---
+`},
+	{"exampleSite/content/posts/first-post.md", `---
+title: "First Post"
+date: 2019-01-01
+tags: ["hugo", "theme"]
+---
+
+The first post.
+
+<!--more-->
+
+The rest of the first post.
+`},
+	{"exampleSite/content/posts/second-post.md", `---
+title: "Second Post"
+date: 2019-01-02
+tags: ["hugo"]
+---
+
+The second post.
+`},
+}
+
+// THEME_NAME is replaced with the name of the theme.
+const themeExampleSiteConfig = `baseURL = "http://example.org/"
+languageCode = "en-us"
+title = "Example Site"
+
+# The example site lives in the theme's exampleSite folder, so the
+# theme is found two levels up.
+theme = "THEME_NAME"
+themesDir = "../.."
+
+[params]
+mainSections = ["posts"]
+`
@@ -0,0 +1,67 @@
+// Copyright 2019 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestNewThemeExampleSite(t *testing.T) {
+	c := qt.New(t)
+
+	dir, err := createSimpleTestSite(t, testSiteConfig{})
+	c.Assert(err, qt.IsNil)
+	defer os.RemoveAll(dir)
+
+	// The -s flag is registered on the "new" command, so set the source
+	// directly.
+	n := newNewThemeCmd()
+	n.source = dir
+	c.Assert(n.newTheme(n.getCommand(), []string{"mytheme"}), qt.IsNil)
+
+	themeDir := filepath.Join(dir, "themes", "mytheme")
+	exampleSite := filepath.Join(themeDir, "exampleSite")
+
+	archetype, err := ioutil.ReadFile(filepath.Join(themeDir, "archetypes", "default.md"))
+	c.Assert(err, qt.IsNil)
+	c.Assert(string(archetype), qt.Equals, "+++\n+++\n")
+	_, err = os.Stat(filepath.Join(themeDir, "static"))
+	c.Assert(os.IsNotExist(err), qt.Equals, true)
+
+	b := newCommandsBuilder().addAll().build()
+	cmd := b.getCommand()
+	cmd.SetArgs([]string{"-s=" + exampleSite, "--quiet"})
+	_, err = cmd.ExecuteC()
+	c.Assert(err, qt.IsNil)
+
+	readPublic := func(name string) string {
+		b, err := ioutil.ReadFile(filepath.Join(exampleSite, "public", filepath.FromSlash(name)))
+		c.Assert(err, qt.IsNil)
+		return string(b)
+	}
+
+	home := readPublic("index.html")
+	c.Assert(home, qt.Contains, "<title>Example Site</title>")
+	c.Assert(home, qt.Contains, `<a href="/posts/first-post/">First Post</a>`)
+	c.Assert(home, qt.Contains, `integrity="sha256-`)
+	c.Assert(readPublic("posts/first-post/index.html"), qt.Contains, "<h1>First Post</h1>")
+	c.Assert(readPublic("posts/index.html"), qt.Contains, "Second Post")
+	c.Assert(readPublic("tags/hugo/index.html"), qt.Contains, "First Post")
+	c.Assert(readPublic("404.html"), qt.Contains, "Page not found")
+}
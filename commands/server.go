@@ -16,11 +16,13 @@ package commands
 import (
 	"bytes"
 	"fmt"
+	"io"
 	"net"
 	"net/http"
 	"net/url"
 	"os"
 	"os/signal"
+	"path"
 	"path/filepath"
 	"regexp"
 	"runtime"
@@ -32,6 +34,7 @@ import (
 
 	"github.com/pkg/errors"
 
+	"github.com/gohugoio/hugo/common/hugo"
 	"github.com/gohugoio/hugo/livereload"
 	"github.com/gohugoio/hugo/tpl"
 
@@ -59,6 +62,10 @@ type serverCmd struct {
 	disableFastRender   bool
 	disableBrowserError bool
 
+	tlsAuto     bool
+	tlsCertFile string
+	tlsKeyFile  string
+
 	*baseBuilderCmd
 }
 
@@ -99,6 +106,9 @@ of a second, you will be able to save and see your changes nearly instantly.`,
 	cc.cmd.Flags().BoolVar(&cc.renderToDisk, "renderToDisk", false, "render to Destination path (default is render to memory & serve from there)")
 	cc.cmd.Flags().BoolVar(&cc.disableFastRender, "disableFastRender", false, "enables full re-renders on changes")
 	cc.cmd.Flags().BoolVar(&cc.disableBrowserError, "disableBrowserError", false, "do not show build errors in the browser")
+	cc.cmd.Flags().BoolVar(&cc.tlsAuto, "tlsAuto", false, "serve over HTTPS using a generated certificate for localhost, stored in the user cache dir")
+	cc.cmd.Flags().StringVar(&cc.tlsCertFile, "tlsCertFile", "", "path to a TLS certificate file to serve over HTTPS (requires --tlsKeyFile)")
+	cc.cmd.Flags().StringVar(&cc.tlsKeyFile, "tlsKeyFile", "", "path to a TLS key file to serve over HTTPS (requires --tlsCertFile)")
 
 	cc.cmd.Flags().String("memstats", "", "log memory usage to this file")
 	cc.cmd.Flags().String("meminterval", "100ms", "interval to poll memory usage (requires --memstats), valid time units are \"ns\", \"us\" (or \"µs\"), \"ms\", \"s\", \"m\", \"h\".")
@@ -231,6 +241,14 @@ func (sc *serverCmd) server(cmd *cobra.Command, args []string) error {
 
 	}
 
+	if (sc.tlsCertFile == "") != (sc.tlsKeyFile == "") {
+		return newUserError("--tlsCertFile and --tlsKeyFile must be set together")
+	}
+
+	if sc.tlsAuto && sc.tlsCertFile != "" {
+		return newUserError("--tlsAuto cannot be combined with --tlsCertFile and --tlsKeyFile")
+	}
+
 	if err := memStats(); err != nil {
 		jww.WARN.Println("memstats error:", err)
 	}
@@ -288,6 +306,7 @@ type fileServer struct {
 	baseURLs      []string
 	roots         []string
 	errorTemplate tpl.Template
+	serverConfig  *config.Server
 	c             *commandeer
 	s             *serverCmd
 }
@@ -350,6 +369,20 @@ func (f *fileServer) createEndpoint(i int) (*http.ServeMux, string, string, erro
 				}
 			}
 
+			for _, header := range f.serverConfig.MatchHeaders(r.URL.Path) {
+				w.Header().Set(header.Key, header.Value)
+			}
+
+			if redirect := f.serverConfig.MatchRedirect(r.URL.Path); redirect.To != "" && redirect.To != r.URL.Path {
+				http.Redirect(w, r, redirect.To, redirect.Status)
+				return
+			}
+
+			if f.c.Cfg.GetString("environment") != hugo.EnvironmentProduction {
+				// Make sure preview servers are never indexed.
+				w.Header().Set("X-Robots-Tag", "noindex, nofollow")
+			}
+
 			if f.s.noHTTPCache {
 				w.Header().Set("Cache-Control", "no-store, no-cache, must-revalidate, max-age=0")
 				w.Header().Set("Pragma", "no-cache")
@@ -373,6 +406,11 @@ func (f *fileServer) createEndpoint(i int) (*http.ServeMux, string, string, erro
 
 				}
 			}
+
+			if f.serveNotFound(i, fs, w, r) {
+				return
+			}
+
 			h.ServeHTTP(w, r)
 		})
 	}
@@ -391,6 +429,52 @@ func (f *fileServer) createEndpoint(i int) (*http.ServeMux, string, string, erro
 	return mu, u.String(), endpoint, nil
 }
 
+// serveNotFound writes the site's 404 page with a 404 status if the file
+// requested does not exist. It returns whether it did.
+func (f *fileServer) serveNotFound(i int, fs http.FileSystem, w http.ResponseWriter, r *http.Request) bool {
+	requestPath := path.Clean("/" + r.URL.Path)
+
+	if file, err := fs.Open(requestPath); err == nil {
+		file.Close()
+		return false
+	} else if !os.IsNotExist(err) {
+		return false
+	}
+
+	notFound, err := fs.Open(f.notFoundPath(i, requestPath))
+	if err != nil {
+		return false
+	}
+	defer notFound.Close()
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(http.StatusNotFound)
+	io.Copy(w, notFound)
+
+	return true
+}
+
+// notFoundPath returns the path to the 404 page to use for the given request
+// path in the endpoint i.
+func (f *fileServer) notFoundPath(i int, requestPath string) string {
+	h := f.c.hugo()
+
+	if h.IsMultihost() {
+		return "/" + h.Sites[i].Language().GetString("notFoundFilename")
+	}
+
+	site := h.Sites[0]
+	for _, s := range h.Sites {
+		prefix := s.Info.LanguagePrefix
+		if prefix != "" && strings.HasPrefix(requestPath, prefix+"/") {
+			site = s
+			break
+		}
+	}
+
+	return path.Join("/", site.Info.LanguagePrefix, site.Language().GetString("notFoundFilename"))
+}
+
 var logErrorRe = regexp.MustCompile(`(?s)ERROR \d{4}/\d{2}/\d{2} \d{2}:\d{2}:\d{2} `)
 
 func removeErrorPrefixFromLog(content string) string {
@@ -421,12 +505,18 @@ func (c *commandeer) serve(s *serverCmd) error {
 		return err
 	}
 
+	serverConfig, err := config.DecodeServer(c.Cfg)
+	if err != nil {
+		return err
+	}
+
 	srv := &fileServer{
 		baseURLs:      baseURLs,
 		roots:         roots,
 		c:             c,
 		s:             s,
 		errorTemplate: templ,
+		serverConfig:  serverConfig,
 	}
 
 	doLiveReload := !c.Cfg.GetBool("disableLiveReload")
@@ -435,6 +525,19 @@ func (c *commandeer) serve(s *serverCmd) error {
 		livereload.Initialize()
 	}
 
+	certFile, keyFile := s.tlsCertFile, s.tlsKeyFile
+	if s.tlsAuto {
+		dir, err := localhostCertDir()
+		if err != nil {
+			return errors.Wrap(err, "failed to create TLS certificate")
+		}
+		certFile, keyFile, err = createLocalhostCert(dir, s.serverInterface)
+		if err != nil {
+			return errors.Wrap(err, "failed to create TLS certificate")
+		}
+		jww.FEEDBACK.Printf("Using the generated TLS certificate %s. Add it to your trust store to avoid browser warnings.\n", certFile)
+	}
+
 	var sigs = make(chan os.Signal, 1)
 	signal.Notify(sigs, syscall.SIGINT, syscall.SIGTERM)
 
@@ -447,7 +550,11 @@ func (c *commandeer) serve(s *serverCmd) error {
 		}
 		jww.FEEDBACK.Printf("Web Server is available at %s (bind address %s)\n", serverURL, s.serverInterface)
 		go func() {
-			err = http.ListenAndServe(endpoint, mu)
+			if s.useTLS() {
+				err = http.ListenAndServeTLS(endpoint, certFile, keyFile, mu)
+			} else {
+				err = http.ListenAndServe(endpoint, mu)
+			}
 			if err != nil {
 				c.logger.ERROR.Printf("Error: %s\n", err.Error())
 				os.Exit(1)
@@ -469,6 +576,11 @@ func (c *commandeer) serve(s *serverCmd) error {
 	return nil
 }
 
+// useTLS returns whether the server should serve over HTTPS.
+func (sc *serverCmd) useTLS() bool {
+	return sc.tlsAuto || sc.tlsCertFile != ""
+}
+
 // fixURL massages the baseURL into a form needed for serving
 // all pages correctly.
 func (sc *serverCmd) fixURL(cfg config.Provider, s string, port int) (string, error) {
@@ -506,6 +618,10 @@ func (sc *serverCmd) fixURL(cfg config.Provider, s string, port int) (string, er
 		u.Host = "localhost"
 	}
 
+	if sc.useTLS() {
+		u.Scheme = "https"
+	}
+
 	if sc.serverAppend {
 		if strings.Contains(u.Host, ":") {
 			u.Host, _, err = net.SplitHostPort(u.Host)
@@ -14,9 +14,14 @@
 package commands
 
 import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
 	"fmt"
+	"io/ioutil"
 	"net/http"
 	"os"
+	"path/filepath"
 	"runtime"
 	"strings"
 	"testing"
@@ -37,6 +42,8 @@ func TestServer(t *testing.T) {
 	dir, err := createSimpleTestSite(t, testSiteConfig{})
 	c.Assert(err, qt.IsNil)
 
+	writeFile(t, filepath.Join(dir, "layouts", "404.html"), "Not found: {{ .Site.Title }}")
+
 	// Let us hope that this port is available on all systems ...
 	port := 1331
 
@@ -70,6 +77,12 @@ func TestServer(t *testing.T) {
 	c.Assert(homeContent, qt.Contains, "List: Hugo Commands")
 	c.Assert(homeContent, qt.Contains, "Environment: development")
 
+	resp, err = http.Get("http://localhost:1331/does-not-exist/")
+	c.Assert(err, qt.IsNil)
+	defer resp.Body.Close()
+	c.Assert(resp.StatusCode, qt.Equals, http.StatusNotFound)
+	c.Assert(helpers.ReaderToString(resp.Body), qt.Contains, "Not found: Hugo Commands")
+
 	// Stop the server.
 	stop <- true
 
@@ -117,6 +130,80 @@ func TestFixURL(t *testing.T) {
 	}
 }
 
+func TestFixURLTLS(t *testing.T) {
+	c := qt.New(t)
+
+	b := newCommandsBuilder()
+	s := b.newServerCmd()
+	s.serverAppend = true
+	s.tlsAuto = true
+	v := viper.New()
+	v.Set("baseURL", "http://foo.com/bar")
+
+	result, err := s.fixURL(v, "", 1313)
+	c.Assert(err, qt.IsNil)
+	c.Assert(result, qt.Equals, "https://localhost:1313/bar/")
+}
+
+func TestCreateLocalhostCert(t *testing.T) {
+	c := qt.New(t)
+
+	dir, err := ioutil.TempDir("", "hugo-tls")
+	c.Assert(err, qt.IsNil)
+	defer os.RemoveAll(dir)
+
+	certFile, keyFile, err := createLocalhostCert(dir, "192.168.1.2")
+	c.Assert(err, qt.IsNil)
+
+	_, err = tls.LoadX509KeyPair(certFile, keyFile)
+	c.Assert(err, qt.IsNil)
+
+	cert1, err := ioutil.ReadFile(certFile)
+	c.Assert(err, qt.IsNil)
+
+	// A leaf certificate that cannot be used to sign other certificates.
+	block, _ := pem.Decode(cert1)
+	cert, err := x509.ParseCertificate(block.Bytes)
+	c.Assert(err, qt.IsNil)
+	c.Assert(cert.IsCA, qt.Equals, false)
+	c.Assert(cert.KeyUsage&x509.KeyUsageCertSign, qt.Equals, x509.KeyUsage(0))
+
+	if runtime.GOOS != "windows" {
+		for _, filename := range []string{certFile, keyFile} {
+			fi, err := os.Stat(filename)
+			c.Assert(err, qt.IsNil)
+			c.Assert(fi.Mode().Perm(), qt.Equals, os.FileMode(0600))
+		}
+	}
+
+	// Reused when still valid for the hosts.
+	_, _, err = createLocalhostCert(dir, "192.168.1.2")
+	c.Assert(err, qt.IsNil)
+	cert2, err := ioutil.ReadFile(certFile)
+	c.Assert(err, qt.IsNil)
+	c.Assert(string(cert2), qt.Equals, string(cert1))
+
+	// Recreated for a new bind address.
+	_, _, err = createLocalhostCert(dir, "192.168.1.3")
+	c.Assert(err, qt.IsNil)
+	cert3, err := ioutil.ReadFile(certFile)
+	c.Assert(err, qt.IsNil)
+	c.Assert(string(cert3), qt.Not(qt.Equals), string(cert1))
+
+	if runtime.GOOS != "windows" {
+		// Not reused when others can read the key.
+		c.Assert(os.Chmod(keyFile, 0644), qt.IsNil)
+		_, _, err = createLocalhostCert(dir, "192.168.1.3")
+		c.Assert(err, qt.IsNil)
+		cert4, err := ioutil.ReadFile(certFile)
+		c.Assert(err, qt.IsNil)
+		c.Assert(string(cert4), qt.Not(qt.Equals), string(cert3))
+		fi, err := os.Stat(keyFile)
+		c.Assert(err, qt.IsNil)
+		c.Assert(fi.Mode().Perm(), qt.Equals, os.FileMode(0600))
+	}
+}
+
 func TestRemoveErrorPrefixFromLog(t *testing.T) {
 	c := qt.New(t)
 	content := `ERROR 2018/10/07 13:11:12 Error while rendering "home": template: _default/baseof.html:4:3: executing "main" at <partial "logo" .>: error calling partial: template: partials/logo.html:5:84: executing "partials/logo.html" at <$resized.AHeight>: can't evaluate field AHeight in type *resource.Image
@@ -0,0 +1,203 @@
+// Copyright 2019 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"runtime"
+	"time"
+)
+
+const (
+	localhostCertFilename = "localhost.pem"
+	localhostKeyFilename  = "localhost-key.pem"
+)
+
+// localhostCertDir returns the user private directory where the generated
+// localhost certificate is stored.
+func localhostCertDir() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "hugo", "tls"), nil
+}
+
+// createLocalhostCert creates a self-signed leaf certificate for localhost and
+// the given bind address in dir, and returns the certificate and key filenames.
+// The certificate cannot sign other certificates, so trusting it only trusts
+// this server. A valid certificate created earlier by the current user is
+// reused, so it only needs to be added to the trust store once.
+func createLocalhostCert(dir, bindAddr string) (certFile, keyFile string, err error) {
+	certFile = filepath.Join(dir, localhostCertFilename)
+	keyFile = filepath.Join(dir, localhostKeyFilename)
+
+	hosts := []string{"localhost", "127.0.0.1", "::1"}
+	if bindAddr != "" && bindAddr != "0.0.0.0" && bindAddr != "::" {
+		hosts = append(hosts, bindAddr)
+	}
+
+	if err = os.MkdirAll(dir, 0700); err != nil {
+		return
+	}
+
+	if fi, err := os.Lstat(dir); err == nil && fi.IsDir() && isOwnedByCurrentUser(fi) {
+		// Tighten the permissions of a directory created by an earlier version.
+		os.Chmod(dir, 0700)
+	}
+
+	if isPrivateFile(dir, 0700) && isPrivateFile(certFile, 0600) && isPrivateFile(keyFile, 0600) && localhostCertIsValid(certFile, hosts) {
+		return
+	}
+
+	if !isPrivateFile(dir, 0700) {
+		err = fmt.Errorf("the TLS certificate directory %q must only be accessible by the current user", dir)
+		return
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return
+	}
+
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return
+	}
+
+	now := time.Now()
+	template := x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{Organization: []string{"Hugo development server"}, CommonName: "localhost"},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.AddDate(1, 0, 0),
+		KeyUsage:              x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  false,
+	}
+
+	for _, h := range hosts {
+		if ip := net.ParseIP(h); ip != nil {
+			template.IPAddresses = append(template.IPAddresses, ip)
+		} else {
+			template.DNSNames = append(template.DNSNames, h)
+		}
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, &template, &template, &key.PublicKey, key)
+	if err != nil {
+		return
+	}
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return
+	}
+
+	if err = writeNewPrivateFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})); err != nil {
+		return
+	}
+
+	err = writeNewPrivateFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+
+	return
+}
+
+// writeNewPrivateFile replaces filename with a new file with the given
+// content that only the current user can read and write. Any existing file is
+// removed first, so we never write to a file created by someone else.
+func writeNewPrivateFile(filename string, b []byte) error {
+	if err := os.Remove(filename); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	f, err := os.OpenFile(filename, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return err
+	}
+
+	if _, err := f.Write(b); err != nil {
+		f.Close()
+		return err
+	}
+
+	return f.Close()
+}
+
+// isPrivateFile returns whether filename exists, is owned by the current user
+// and has no permissions beyond perm.
+func isPrivateFile(filename string, perm os.FileMode) bool {
+	fi, err := os.Lstat(filename)
+	if err != nil {
+		return false
+	}
+
+	if fi.Mode()&os.ModeSymlink != 0 {
+		return false
+	}
+
+	if runtime.GOOS != "windows" && fi.Mode().Perm()&^perm != 0 {
+		return false
+	}
+
+	return isOwnedByCurrentUser(fi)
+}
+
+// localhostCertIsValid returns whether the certificate in certFile exists, is
+// a leaf certificate valid for at least another day and covers all of the
+// given hosts.
+func localhostCertIsValid(certFile string, hosts []string) bool {
+	b, err := ioutil.ReadFile(certFile)
+	if err != nil {
+		return false
+	}
+
+	block, _ := pem.Decode(b)
+	if block == nil {
+		return false
+	}
+
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return false
+	}
+
+	if cert.IsCA || cert.KeyUsage&x509.KeyUsageCertSign != 0 {
+		return false
+	}
+
+	if time.Now().Add(24 * time.Hour).After(cert.NotAfter) {
+		return false
+	}
+
+	for _, h := range hosts {
+		if cert.VerifyHostname(h) != nil {
+			return false
+		}
+	}
+
+	return true
+}
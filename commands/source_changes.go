@@ -0,0 +1,132 @@
+// Copyright 2019 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"os"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/gohugoio/hugo/helpers"
+	"github.com/spf13/afero"
+)
+
+// sourceChangeDetector keeps track of the content of the watched files so
+// we can skip file system events for files that did not change, e.g.
+// when a git checkout, a CI cache restore or an editor touches a file
+// without changing it.
+type sourceChangeDetector struct {
+	fs afero.Fs
+
+	mu    sync.Mutex
+	files map[string]fileState
+}
+
+type fileState struct {
+	modTime time.Time
+	size    int64
+	hash    string
+}
+
+func newSourceChangeDetector(fs afero.Fs) *sourceChangeDetector {
+	return &sourceChangeDetector{fs: fs, files: make(map[string]fileState)}
+}
+
+// addDir records the current state of all the files below dir. Files
+// that are not recorded will be reported as changed on the first event.
+func (d *sourceChangeDetector) addDir(dir string) {
+	_ = afero.Walk(d.fs, dir, func(path string, fi os.FileInfo, err error) error {
+		if err != nil || fi.IsDir() {
+			return nil
+		}
+		d.addFile(path, fi)
+		return nil
+	})
+}
+
+func (d *sourceChangeDetector) addFile(filename string, fi os.FileInfo) {
+	hash, err := d.hashFile(filename)
+	if err != nil {
+		return
+	}
+
+	// The file may have been written to while we read it.
+	fi2, err := d.fs.Stat(filename)
+	if err != nil || !fi2.ModTime().Equal(fi.ModTime()) || fi2.Size() != fi.Size() {
+		return
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if _, found := d.files[filename]; !found {
+		d.files[filename] = fileState{modTime: fi.ModTime(), size: fi.Size(), hash: hash}
+	}
+}
+
+// changed reports whether the file with the given name has changed since
+// it was last seen. The content is only hashed if the modification time
+// or the size has changed. Files not seen before, directories and files
+// that cannot be read are always reported as changed.
+func (d *sourceChangeDetector) changed(filename string) bool {
+	fi, err := d.fs.Stat(filename)
+	if err != nil || fi.IsDir() {
+		d.mu.Lock()
+		delete(d.files, filename)
+		d.mu.Unlock()
+		return true
+	}
+
+	d.mu.Lock()
+	prev, found := d.files[filename]
+	d.mu.Unlock()
+
+	if found && prev.modTime.Equal(fi.ModTime()) && prev.size == fi.Size() {
+		return false
+	}
+
+	hash, err := d.hashFile(filename)
+	if err != nil {
+		return true
+	}
+
+	d.mu.Lock()
+	d.files[filename] = fileState{modTime: fi.ModTime(), size: fi.Size(), hash: hash}
+	d.mu.Unlock()
+
+	return !found || prev.hash != hash
+}
+
+// filter removes the events for files that did not change. Note that
+// editors saving files by renaming a temporary file may send remove or
+// rename events for files that still exist, which are also removed if
+// the content is the same.
+func (d *sourceChangeDetector) filter(evs []fsnotify.Event) []fsnotify.Event {
+	var filtered []fsnotify.Event
+	for _, ev := range evs {
+		if ev.Name == "" || d.changed(ev.Name) {
+			filtered = append(filtered, ev)
+		}
+	}
+	return filtered
+}
+
+func (d *sourceChangeDetector) hashFile(filename string) (string, error) {
+	f, err := d.fs.Open(filename)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	return helpers.MD5FromReader(f)
+}
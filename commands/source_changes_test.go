@@ -0,0 +1,86 @@
+// Copyright 2019 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/fsnotify/fsnotify"
+	"github.com/spf13/afero"
+)
+
+func TestSourceChangeDetector(t *testing.T) {
+	c := qt.New(t)
+
+	fs := afero.NewMemMapFs()
+	p1 := filepath.FromSlash("content/p1.md")
+	p2 := filepath.FromSlash("content/p2.md")
+	p3 := filepath.FromSlash("content/p3.md")
+
+	c.Assert(afero.WriteFile(fs, p1, []byte("p1"), 0755), qt.IsNil)
+	c.Assert(afero.WriteFile(fs, p2, []byte("p2"), 0755), qt.IsNil)
+
+	d := newSourceChangeDetector(fs)
+	d.addDir("content")
+
+	touch := func(filename string) {
+		later := time.Now().Add(time.Hour)
+		c.Assert(fs.Chtimes(filename, later, later), qt.IsNil)
+	}
+
+	// Not touched.
+	c.Assert(d.changed(p1), qt.Equals, false)
+
+	// Touched, but not changed.
+	touch(p1)
+	c.Assert(d.changed(p1), qt.Equals, false)
+
+	// Changed, with the same size.
+	c.Assert(afero.WriteFile(fs, p2, []byte("P2"), 0755), qt.IsNil)
+	touch(p2)
+	c.Assert(d.changed(p2), qt.Equals, true)
+	c.Assert(d.changed(p2), qt.Equals, false)
+
+	// New file.
+	c.Assert(afero.WriteFile(fs, p3, []byte("p3"), 0755), qt.IsNil)
+	c.Assert(d.changed(p3), qt.Equals, true)
+	c.Assert(d.changed(p3), qt.Equals, false)
+
+	// Directories.
+	c.Assert(d.changed("content"), qt.Equals, true)
+
+	// Removed and then recreated with the same content.
+	c.Assert(fs.Remove(p3), qt.IsNil)
+	c.Assert(d.changed(p3), qt.Equals, true)
+	c.Assert(afero.WriteFile(fs, p3, []byte("p3"), 0755), qt.IsNil)
+	c.Assert(d.changed(p3), qt.Equals, true)
+
+	touch(p1)
+	c.Assert(afero.WriteFile(fs, p2, []byte("p2 changed"), 0755), qt.IsNil)
+	c.Assert(fs.Remove(p3), qt.IsNil)
+
+	c.Assert(d.filter([]fsnotify.Event{
+		{Name: p1, Op: fsnotify.Write},
+		{Name: p2, Op: fsnotify.Write},
+		{Name: p3, Op: fsnotify.Remove},
+		{Name: "", Op: fsnotify.Remove},
+	}), qt.DeepEquals, []fsnotify.Event{
+		{Name: p2, Op: fsnotify.Write},
+		{Name: p3, Op: fsnotify.Remove},
+		{Name: "", Op: fsnotify.Remove},
+	})
+}
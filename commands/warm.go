@@ -0,0 +1,117 @@
+// Copyright 2019 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"os"
+
+	"github.com/gohugoio/hugo/cache/filecache"
+	"github.com/pkg/errors"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+	jww "github.com/spf13/jwalterweatherman"
+)
+
+var _ cmder = (*warmCmd)(nil)
+
+type warmCmd struct {
+	hugoBuilderCommon
+	*baseCmd
+
+	// The number of files in the image and assets caches after warming,
+	// and how many of them were added.
+	images, newImages int
+	assets, newAssets int
+}
+
+func newWarmCmd() *warmCmd {
+	cc := &warmCmd{}
+
+	cc.baseCmd = newBaseCmd(&cobra.Command{
+		Use:   "warm",
+		Short: "Populate the caches for processed images and assets",
+		Long: `Build the site in memory to populate the caches for processed images and
+transformed assets (resources/_gen by default) without writing the site to disk.
+
+Use it to prime the caches in a separate CI step and share them between
+parallel build jobs.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cc.warm()
+		},
+	})
+
+	cc.hugoBuilderCommon.handleFlags(cc.cmd)
+
+	return cc
+}
+
+func (cc *warmCmd) warm() error {
+	cfgInit := func(c *commandeer) error {
+		c.Set("renderToMemory", true)
+		return nil
+	}
+
+	c, err := initializeConfig(true, false, &cc.hugoBuilderCommon, cc, cfgInit)
+	if err != nil {
+		return err
+	}
+
+	caches := c.hugo().ResourceSpec.FileCaches
+
+	imagesBefore, err := countCacheFiles(caches.ImageCache())
+	if err != nil {
+		return err
+	}
+	assetsBefore, err := countCacheFiles(caches.AssetsCache())
+	if err != nil {
+		return err
+	}
+
+	// Static files are not cached, so only build the sites.
+	if err := c.buildSites(); err != nil {
+		return errors.Wrap(err, "Error building site")
+	}
+
+	if cc.images, err = countCacheFiles(caches.ImageCache()); err != nil {
+		return err
+	}
+	if cc.assets, err = countCacheFiles(caches.AssetsCache()); err != nil {
+		return err
+	}
+	cc.newImages = cc.images - imagesBefore
+	cc.newAssets = cc.assets - assetsBefore
+
+	jww.FEEDBACK.Printf("Processed images: %d cached (%d new)\n", cc.images, cc.newImages)
+	jww.FEEDBACK.Printf("Assets:           %d cached (%d new)\n", cc.assets, cc.newAssets)
+
+	return nil
+}
+
+// countCacheFiles returns the number of files in the file cache c.
+func countCacheFiles(c *filecache.Cache) (int, error) {
+	var n int
+	err := afero.Walk(c.Fs, "", func(path string, fi os.FileInfo, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if !fi.IsDir() {
+			n++
+		}
+		return nil
+	})
+	return n, err
+}
@@ -0,0 +1,74 @@
+// Copyright 2019 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestWarm(t *testing.T) {
+	c := qt.New(t)
+	dir, err := createSimpleTestSite(t, testSiteConfig{})
+	c.Assert(err, qt.IsNil)
+
+	defer func() {
+		os.RemoveAll(dir)
+	}()
+
+	sunset, err := ioutil.ReadFile(filepath.FromSlash("../resources/testdata/sunset.jpg"))
+	c.Assert(err, qt.IsNil)
+
+	writeFile(t, filepath.Join(dir, "content", "mybundle", "index.md"), `---
+title: "My Bundle"
+---
+`)
+	writeFile(t, filepath.Join(dir, "content", "mybundle", "sunset.jpg"), string(sunset))
+	writeFile(t, filepath.Join(dir, "assets", "css", "main.css"), "body { color: red; }")
+	writeFile(t, filepath.Join(dir, "layouts", "_default", "single.html"), `
+{{ with .Resources.GetMatch "sunset.jpg" }}{{ (.Resize "100x").RelPermalink }}{{ end }}
+{{ (resources.Get "css/main.css" | minify | fingerprint).RelPermalink }}
+`)
+
+	warm := func() *warmCmd {
+		cc := newWarmCmd()
+		cmd := cc.getCommand()
+		cmd.SetArgs([]string{"-s=" + dir})
+		_, err := cmd.ExecuteC()
+		c.Assert(err, qt.IsNil)
+		return cc
+	}
+
+	cc := warm()
+	c.Assert(cc.images, qt.Equals, 1)
+	c.Assert(cc.newImages, qt.Equals, 1)
+	// Only the transformations depending on external tools, e.g. SCSS and
+	// PostCSS, are cached on disk.
+	c.Assert(cc.assets, qt.Equals, 0)
+
+	// Nothing is published.
+	_, err = os.Stat(filepath.Join(dir, "public"))
+	c.Assert(os.IsNotExist(err), qt.Equals, true)
+
+	_, err = os.Stat(filepath.Join(dir, "resources", "_gen", "images", "mybundle"))
+	c.Assert(err, qt.IsNil)
+
+	cc = warm()
+	c.Assert(cc.images, qt.Equals, 1)
+	c.Assert(cc.newImages, qt.Equals, 0)
+}
@@ -0,0 +1,125 @@
+// Copyright 2020 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package htime provides localized time formatting.
+package htime
+
+import (
+	"strings"
+	"time"
+
+	"github.com/go-playground/locales"
+)
+
+var (
+	longMonthNames = []string{
+		"January", "February", "March", "April", "May", "June",
+		"July", "August", "September", "October", "November", "December",
+	}
+
+	shortMonthNames = []string{
+		"Jan", "Feb", "Mar", "Apr", "May", "Jun",
+		"Jul", "Aug", "Sep", "Oct", "Nov", "Dec",
+	}
+
+	longDayNames = []string{
+		"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday",
+	}
+
+	shortDayNames = []string{
+		"Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat",
+	}
+)
+
+// GetTranslator returns the locale data for the given language code,
+// e.g. "nn", "en-US" or "pt_BR". The region is ignored. It falls back to
+// English if no locale data could be found for lang.
+func GetTranslator(lang string) locales.Translator {
+	lang = strings.ToLower(lang)
+	if i := strings.IndexAny(lang, "-_"); i != -1 {
+		lang = lang[:i]
+	}
+
+	if create, found := translators[lang]; found {
+		return create()
+	}
+
+	return translators["en"]()
+}
+
+// TimeFormatter formats time.Time values using the month and day names
+// of a language.
+type TimeFormatter struct {
+	ltr locales.Translator
+}
+
+// NewTimeFormatter creates a new TimeFormatter for the given locale data.
+func NewTimeFormatter(ltr locales.Translator) TimeFormatter {
+	if ltr == nil {
+		panic("must provide a locales.Translator")
+	}
+	return TimeFormatter{ltr: ltr}
+}
+
+// Format formats t with the given layout, which is either a Go time layout
+// or one of the localized layout aliases:
+//
+//	:date_full, :date_long, :date_medium, :date_short
+//	:time_full, :time_long, :time_medium, :time_short
+//
+// Any month or weekday names in a Go time layout are translated.
+func (f TimeFormatter) Format(t time.Time, layout string) string {
+	if layout == "" {
+		return ""
+	}
+
+	if layout[0] == ':' {
+		switch strings.ToLower(layout[1:]) {
+		case "date_full":
+			return f.ltr.FmtDateFull(t)
+		case "date_long":
+			return f.ltr.FmtDateLong(t)
+		case "date_medium":
+			return f.ltr.FmtDateMedium(t)
+		case "date_short":
+			return f.ltr.FmtDateShort(t)
+		case "time_full":
+			return f.ltr.FmtTimeFull(t)
+		case "time_long":
+			return f.ltr.FmtTimeLong(t)
+		case "time_medium":
+			return f.ltr.FmtTimeMedium(t)
+		case "time_short":
+			return f.ltr.FmtTimeShort(t)
+		}
+	}
+
+	s := t.Format(layout)
+
+	monthIdx := t.Month() - 1 // Month() starts at 1.
+	dayIdx := t.Weekday()
+
+	if strings.Contains(layout, "January") {
+		s = strings.Replace(s, longMonthNames[monthIdx], f.ltr.MonthWide(t.Month()), -1)
+	} else if strings.Contains(layout, "Jan") {
+		s = strings.Replace(s, shortMonthNames[monthIdx], f.ltr.MonthAbbreviated(t.Month()), -1)
+	}
+
+	if strings.Contains(layout, "Monday") {
+		s = strings.Replace(s, longDayNames[dayIdx], f.ltr.WeekdayWide(t.Weekday()), -1)
+	} else if strings.Contains(layout, "Mon") {
+		s = strings.Replace(s, shortDayNames[dayIdx], f.ltr.WeekdayAbbreviated(t.Weekday()), -1)
+	}
+
+	return s
+}
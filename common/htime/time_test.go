@@ -0,0 +1,67 @@
+// Copyright 2020 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package htime
+
+import (
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestTimeFormatter(t *testing.T) {
+	c := qt.New(t)
+
+	june06, _ := time.Parse("2006-Jan-02", "2018-Jun-06")
+	june06 = june06.Add(7777 * time.Second)
+
+	c.Run("Norsk nynorsk", func(c *qt.C) {
+		f := NewTimeFormatter(GetTranslator("nn"))
+
+		c.Assert(f.Format(june06, "Monday Jan 2 2006"), qt.Equals, "onsdag juni 6 2018")
+		c.Assert(f.Format(june06, "Mon January 2 2006"), qt.Equals, "on. juni 6 2018")
+		c.Assert(f.Format(june06, "Mon Mon"), qt.Equals, "on. on.")
+	})
+
+	c.Run("English", func(c *qt.C) {
+		f := NewTimeFormatter(GetTranslator("en-US"))
+
+		c.Assert(f.Format(june06, "Monday Jan 2 2006"), qt.Equals, "Wednesday Jun 6 2018")
+		c.Assert(f.Format(june06, "Mon January 2 2006"), qt.Equals, "Wed June 6 2018")
+		c.Assert(f.Format(june06, ":date_long"), qt.Equals, "June 6, 2018")
+		c.Assert(f.Format(june06, ":date_medium"), qt.Equals, "Jun 6, 2018")
+		c.Assert(f.Format(june06, ":time_short"), qt.Equals, "2:09 am")
+	})
+
+	c.Run("Weekdays", func(c *qt.C) {
+		f := NewTimeFormatter(GetTranslator("nb_NO"))
+
+		for i, weekday := range []string{"søndag", "mandag", "tirsdag", "onsdag", "torsdag", "fredag", "lørdag"} {
+			d := june06.Add(time.Duration(i-3) * 24 * time.Hour)
+			c.Assert(f.Format(d, "Monday"), qt.Equals, weekday)
+		}
+	})
+
+	c.Run("Unknown language", func(c *qt.C) {
+		f := NewTimeFormatter(GetTranslator("xx"))
+
+		c.Assert(f.Format(june06, "January"), qt.Equals, "June")
+	})
+
+	c.Run("Empty layout", func(c *qt.C) {
+		f := NewTimeFormatter(GetTranslator("en"))
+
+		c.Assert(f.Format(june06, ""), qt.Equals, "")
+	})
+}
@@ -42,6 +42,9 @@ type Info struct {
 	// This can also be set by the user.
 	// It can be any string, but it will be all lower case.
 	Environment string
+
+	// Whether this is running in the dev server (hugo server).
+	running bool
 }
 
 // Version returns the current version as a comparable version string.
@@ -54,8 +57,19 @@ func (i Info) Generator() template.HTML {
 	return template.HTML(fmt.Sprintf(`<meta name="generator" content="Hugo %s" />`, CurrentVersion.String()))
 }
 
+// IsProduction reports whether this is a production build, i.e. the
+// environment is "production".
+func (i Info) IsProduction() bool {
+	return i.Environment == EnvironmentProduction
+}
+
+// IsServer reports whether the site is being built by the dev server.
+func (i Info) IsServer() bool {
+	return i.running
+}
+
 // NewInfo creates a new Hugo Info object.
-func NewInfo(environment string) Info {
+func NewInfo(environment string, running bool) Info {
 	if environment == "" {
 		environment = EnvironmentProduction
 	}
@@ -63,5 +77,6 @@ func NewInfo(environment string) Info {
 		CommitHash:  commitHash,
 		BuildDate:   buildDate,
 		Environment: environment,
+		running:     running,
 	}
 }
@@ -23,7 +23,7 @@ import (
 func TestHugoInfo(t *testing.T) {
 	c := qt.New(t)
 
-	hugoInfo := NewInfo("")
+	hugoInfo := NewInfo("", false)
 
 	c.Assert(hugoInfo.Version(), qt.Equals, CurrentVersion.Version())
 	c.Assert(fmt.Sprintf("%T", VersionString("")), qt.Equals, fmt.Sprintf("%T", hugoInfo.Version()))
@@ -31,5 +31,11 @@ func TestHugoInfo(t *testing.T) {
 	c.Assert(hugoInfo.BuildDate, qt.Equals, buildDate)
 	c.Assert(hugoInfo.Environment, qt.Equals, "production")
 	c.Assert(string(hugoInfo.Generator()), qt.Contains, fmt.Sprintf("Hugo %s", hugoInfo.Version()))
+	c.Assert(hugoInfo.IsProduction(), qt.Equals, true)
+	c.Assert(hugoInfo.IsServer(), qt.Equals, false)
+
+	devInfo := NewInfo(EnvironmentDevelopment, true)
+	c.Assert(devInfo.IsProduction(), qt.Equals, false)
+	c.Assert(devInfo.IsServer(), qt.Equals, true)
 
 }
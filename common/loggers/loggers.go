@@ -15,11 +15,17 @@ package loggers
 
 import (
 	"bytes"
+	"encoding/json"
+	"fmt"
 	"io"
 	"io/ioutil"
 	"log"
 	"os"
 	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/gohugoio/hugo/common/terminal"
 
@@ -44,6 +50,15 @@ type Logger struct {
 
 	// This is only set in server mode.
 	errors *bytes.Buffer
+
+	// Used to create the category loggers.
+	cfg        notepadConfig
+	categories *categoryNotepads
+}
+
+type categoryNotepads struct {
+	mu sync.Mutex
+	m  map[string]*jww.Notepad
 }
 
 func (l *Logger) Errors() string {
@@ -61,9 +76,110 @@ func (l *Logger) Reset() {
 	}
 }
 
+// Category returns a Notepad that logs its messages in the given category,
+// e.g. "i18n". The messages are counted as any other, but only printed if
+// the category is not suppressed in the logger Options. The messages in an
+// ignored category, see WithIgnored, are dropped.
+func (l *Logger) Category(category string) *jww.Notepad {
+	l.categories.mu.Lock()
+	defer l.categories.mu.Unlock()
+
+	n, found := l.categories.m[category]
+	if !found {
+		cfg := l.cfg
+		cfg.category = category
+		n = cfg.newNotepad()
+		l.categories.m[category] = n
+	}
+
+	return n
+}
+
+// WithIgnored returns a Logger that drops the messages in the given
+// categories. Unlike the suppressed categories, the ignored messages are not
+// counted, so ignored errors do not fail the build. It shares the counters
+// with l.
+func (l *Logger) WithIgnored(categories ...string) *Logger {
+	if len(categories) == 0 {
+		return l
+	}
+	ll := *l
+	ll.cfg.ignore = append(append([]string(nil), l.cfg.ignore...), categories...)
+	ll.categories = newCategoryNotepads()
+	return &ll
+}
+
+func newCategoryNotepads() *categoryNotepads {
+	return &categoryNotepads{m: make(map[string]*jww.Notepad)}
+}
+
+// Supported log formats.
+const (
+	FormatText = "text"
+	FormatJSON = "json"
+)
+
+// Options configures how a Logger writes its messages.
+type Options struct {
+	// The format of the log lines, FormatText (default) or FormatJSON.
+	Format string
+
+	// Message categories to drop, e.g. "i18n". See Logger.Category.
+	Suppress []string
+}
+
+// Validate returns an error if the options are not valid.
+func (o Options) Validate() error {
+	switch o.Format {
+	case "", FormatText, FormatJSON:
+		return nil
+	default:
+		return fmt.Errorf("invalid log format %q, must be one of %q or %q", o.Format, FormatText, FormatJSON)
+	}
+}
+
+func (o Options) isSuppressed(category string) bool {
+	return containsCategory(o.Suppress, category)
+}
+
+func containsCategory(categories []string, category string) bool {
+	if category == "" {
+		return false
+	}
+	for _, c := range categories {
+		if strings.EqualFold(c, category) {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseLevel parses a log level name, one of debug, info, warn or error,
+// into a threshold.
+func ParseLevel(level string) (jww.Threshold, error) {
+	switch strings.ToLower(level) {
+	case "debug":
+		return jww.LevelDebug, nil
+	case "info":
+		return jww.LevelInfo, nil
+	case "warn", "warning":
+		return jww.LevelWarn, nil
+	case "error":
+		return jww.LevelError, nil
+	default:
+		return jww.LevelWarn, fmt.Errorf("invalid log level %q, must be one of debug, info, warn or error", level)
+	}
+}
+
 //  NewLogger creates a new Logger for the given thresholds
 func NewLogger(stdoutThreshold, logThreshold jww.Threshold, outHandle, logHandle io.Writer, saveErrors bool) *Logger {
-	return newLogger(stdoutThreshold, logThreshold, outHandle, logHandle, saveErrors)
+	return newLogger(stdoutThreshold, logThreshold, outHandle, logHandle, saveErrors, Options{})
+}
+
+// NewLoggerWithOptions creates a new Logger for the given thresholds that
+// formats and filters its messages as configured in opts.
+func NewLoggerWithOptions(stdoutThreshold, logThreshold jww.Threshold, outHandle, logHandle io.Writer, saveErrors bool, opts Options) *Logger {
+	return newLogger(stdoutThreshold, logThreshold, outHandle, logHandle, saveErrors, opts)
 }
 
 // NewDebugLogger is a convenience function to create a debug logger.
@@ -84,6 +200,12 @@ func NewErrorLogger() *Logger {
 var (
 	ansiColorRe = regexp.MustCompile("(?s)\\033\\[\\d*(;\\d*)*m")
 	errorRe     = regexp.MustCompile("^(ERROR|FATAL|WARN)")
+
+	// Matches the level and the timestamp written by the jww loggers.
+	logHeaderRe = regexp.MustCompile(`^(?:[A-Z]+ )?(?:\d{4}/\d{2}/\d{2} \d{2}:\d{2}:\d{2} )?`)
+
+	// Matches the file position in Hugo's error messages.
+	positionRe = regexp.MustCompile(`"([^"]+):(\d+):(\d+)"`)
 )
 
 type ansiCleaner struct {
@@ -116,19 +238,174 @@ func (a labelColorizer) Write(p []byte) (n int, err error) {
 
 }
 
+// logEntry is a log line in the JSON format.
+type logEntry struct {
+	Level    string `json:"level,omitempty"`
+	Time     string `json:"time"`
+	Category string `json:"category,omitempty"`
+	File     string `json:"file,omitempty"`
+	Line     int    `json:"line,omitempty"`
+	Column   int    `json:"column,omitempty"`
+	Message  string `json:"message"`
+}
+
+// entryWriter writes the log messages it receives as JSON objects, one per
+// line. The log package writes every message, including any newlines in it,
+// in one Write, so every Write is one entry.
+type entryWriter struct {
+	w        io.Writer
+	level    string
+	category string
+}
+
+func (e entryWriter) Write(p []byte) (n int, err error) {
+	entry := logEntry{
+		Level:    e.level,
+		Time:     time.Now().Format(time.RFC3339),
+		Category: e.category,
+		Message:  strings.Trim(ansiColorRe.ReplaceAllString(string(p), ""), "\r\n"),
+	}
+	if strings.TrimSpace(entry.Message) == "" {
+		// Blank lines used as separators in the text format.
+		return len(p), nil
+	}
+	if pm := positionRe.FindStringSubmatch(entry.Message); pm != nil {
+		entry.File = pm[1]
+		entry.Line, _ = strconv.Atoi(pm[2])
+		entry.Column, _ = strconv.Atoi(pm[3])
+	}
+
+	b, err := json.Marshal(entry)
+	if err != nil {
+		return 0, err
+	}
+	if _, err = e.w.Write(append(b, '\n')); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// categoryWriter writes the log lines in the text format with the category
+// as a prefix to the message, e.g.
+// "WARN 2019/10/18 09:12:04 i18n|MISSING_TRANSLATION|en|hello".
+// It relies on the log package writing every log line in one Write.
+type categoryWriter struct {
+	w        io.Writer
+	category string
+}
+
+func newCategoryWriter(w io.Writer, category string) io.Writer {
+	if w == ioutil.Discard {
+		return w
+	}
+	return categoryWriter{w: w, category: category}
+}
+
+func (c categoryWriter) Write(p []byte) (n int, err error) {
+	header := logHeaderRe.Find(p)
+	b := make([]byte, 0, len(p)+len(c.category)+1)
+	b = append(b, header...)
+	b = append(b, c.category...)
+	b = append(b, '|')
+	b = append(b, p[len(header):]...)
+	if _, err = c.w.Write(b); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// notepadConfig holds what's needed to create a Notepad for a category.
+type notepadConfig struct {
+	stdoutThreshold jww.Threshold
+	logThreshold    jww.Threshold
+	outHandle       io.Writer
+	logHandle       io.Writer
+	listeners       []jww.LogListener
+	opts            Options
+	ignore          []string
+	category        string
+}
+
+func (cfg notepadConfig) newNotepad() *jww.Notepad {
+	outHandle, logHandle := cfg.outHandle, cfg.logHandle
+	if containsCategory(cfg.ignore, cfg.category) {
+		return jww.NewNotepad(jww.LevelFatal+1, jww.LevelFatal+1, ioutil.Discard, ioutil.Discard, "", 0)
+	}
+	if cfg.opts.isSuppressed(cfg.category) {
+		// Still pass them on to the listeners, so they get counted.
+		outHandle, logHandle = ioutil.Discard, ioutil.Discard
+	}
+
+	if cfg.opts.Format != FormatJSON {
+		if cfg.category != "" {
+			outHandle, logHandle = newCategoryWriter(outHandle, cfg.category), newCategoryWriter(logHandle, cfg.category)
+		}
+		return jww.NewNotepad(cfg.stdoutThreshold, cfg.logThreshold, outHandle, logHandle, "", log.Ldate|log.Ltime, cfg.listeners...)
+	}
+
+	// Let the jww loggers write nothing but the message to the entry
+	// writers, which know the level and the category of the messages.
+	entryListener := func(t jww.Threshold) io.Writer {
+		var writers []io.Writer
+		level := strings.ToLower(t.String())
+		if t >= cfg.stdoutThreshold && outHandle != ioutil.Discard {
+			writers = append(writers, entryWriter{w: outHandle, level: level, category: cfg.category})
+		}
+		if t >= cfg.logThreshold && logHandle != ioutil.Discard {
+			writers = append(writers, entryWriter{w: logHandle, level: level, category: cfg.category})
+		}
+		switch len(writers) {
+		case 0:
+			return nil
+		case 1:
+			return writers[0]
+		default:
+			return io.MultiWriter(writers...)
+		}
+	}
+
+	listeners := append(cfg.listeners[:len(cfg.listeners):len(cfg.listeners)], entryListener)
+	n := jww.NewNotepad(jww.LevelFatal+1, jww.LevelFatal+1, ioutil.Discard, ioutil.Discard, "", 0, listeners...)
+	for _, l := range []**log.Logger{&n.TRACE, &n.DEBUG, &n.INFO, &n.WARN, &n.ERROR, &n.CRITICAL, &n.FATAL} {
+		*l = log.New((*l).Writer(), "", 0)
+	}
+
+	var feedbackHandle io.Writer = ioutil.Discard
+	if outHandle != ioutil.Discard {
+		feedbackHandle = entryWriter{w: outHandle, category: cfg.category}
+	}
+	n.FEEDBACK = jww.NewNotepad(jww.LevelFatal, jww.LevelFatal, feedbackHandle, ioutil.Discard, "", 0).FEEDBACK
+
+	return n
+}
+
 // InitGlobalLogger initializes the global logger, used in some rare cases.
-func InitGlobalLogger(stdoutThreshold, logThreshold jww.Threshold, outHandle, logHandle io.Writer) {
-	outHandle, logHandle = getLogWriters(outHandle, logHandle)
+func InitGlobalLogger(stdoutThreshold, logThreshold jww.Threshold, outHandle, logHandle io.Writer, opts Options) {
+	outHandle, logHandle = getLogWriters(outHandle, logHandle, opts)
 
 	jww.SetStdoutOutput(outHandle)
 	jww.SetLogOutput(logHandle)
 	jww.SetLogThreshold(logThreshold)
 	jww.SetStdoutThreshold(stdoutThreshold)
 
+	if opts.Format == FormatJSON {
+		cfg := notepadConfig{
+			stdoutThreshold: stdoutThreshold,
+			logThreshold:    logThreshold,
+			outHandle:       outHandle,
+			logHandle:       logHandle,
+			listeners:       []jww.LogListener{jww.LogCounter(GlobalErrorCounter, jww.LevelError)},
+			opts:            opts,
+		}
+		n := cfg.newNotepad()
+		jww.TRACE, jww.DEBUG, jww.INFO, jww.WARN = n.TRACE, n.DEBUG, n.INFO, n.WARN
+		jww.ERROR, jww.CRITICAL, jww.FATAL = n.ERROR, n.CRITICAL, n.FATAL
+		jww.FEEDBACK = n.FEEDBACK
+	}
 }
 
-func getLogWriters(outHandle, logHandle io.Writer) (io.Writer, io.Writer) {
-	isTerm := terminal.IsTerminal(os.Stdout)
+func getLogWriters(outHandle, logHandle io.Writer, opts Options) (io.Writer, io.Writer) {
+	isTerm := terminal.IsTerminal(os.Stdout) && opts.Format != FormatJSON
 	if logHandle != ioutil.Discard && isTerm {
 		// Remove any Ansi coloring from log output
 		logHandle = ansiCleaner{w: logHandle}
@@ -142,10 +419,10 @@ func getLogWriters(outHandle, logHandle io.Writer) (io.Writer, io.Writer) {
 
 }
 
-func newLogger(stdoutThreshold, logThreshold jww.Threshold, outHandle, logHandle io.Writer, saveErrors bool) *Logger {
+func newLogger(stdoutThreshold, logThreshold jww.Threshold, outHandle, logHandle io.Writer, saveErrors bool, opts Options) *Logger {
 	errorCounter := &jww.Counter{}
 	warnCounter := &jww.Counter{}
-	outHandle, logHandle = getLogWriters(outHandle, logHandle)
+	outHandle, logHandle = getLogWriters(outHandle, logHandle, opts)
 
 	listeners := []jww.LogListener{jww.LogCounter(errorCounter, jww.LevelError), jww.LogCounter(warnCounter, jww.LevelWarn)}
 	var errorBuff *bytes.Buffer
@@ -163,14 +440,25 @@ func newLogger(stdoutThreshold, logThreshold jww.Threshold, outHandle, logHandle
 		listeners = append(listeners, errorCapture)
 	}
 
+	cfg := notepadConfig{
+		stdoutThreshold: stdoutThreshold,
+		logThreshold:    logThreshold,
+		outHandle:       outHandle,
+		logHandle:       logHandle,
+		listeners:       listeners,
+		opts:            opts,
+	}
+
 	return &Logger{
-		Notepad:      jww.NewNotepad(stdoutThreshold, logThreshold, outHandle, logHandle, "", log.Ldate|log.Ltime, listeners...),
+		Notepad:      cfg.newNotepad(),
 		ErrorCounter: errorCounter,
 		WarnCounter:  warnCounter,
 		errors:       errorBuff,
+		cfg:          cfg,
+		categories:   newCategoryNotepads(),
 	}
 }
 
 func newBasicLogger(t jww.Threshold) *Logger {
-	return newLogger(t, jww.LevelError, os.Stdout, ioutil.Discard, false)
+	return newLogger(t, jww.LevelError, os.Stdout, ioutil.Discard, false, Options{})
 }
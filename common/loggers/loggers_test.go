@@ -14,8 +14,14 @@
 package loggers
 
 import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"strings"
 	"testing"
 
+	jww "github.com/spf13/jwalterweatherman"
+
 	qt "github.com/frankban/quicktest"
 )
 
@@ -30,3 +36,102 @@ func TestLogger(t *testing.T) {
 	c.Assert(l.ErrorCounter.Count(), qt.Equals, uint64(2))
 
 }
+
+func TestLoggerJSON(t *testing.T) {
+	c := qt.New(t)
+	var buf bytes.Buffer
+	l := NewLoggerWithOptions(jww.LevelInfo, jww.LevelError, &buf, ioutil.Discard, false, Options{Format: FormatJSON})
+
+	l.DEBUG.Println("Some debug")
+	l.INFO.Println("Some info")
+	l.Category("i18n").WARN.Println("MISSING_TRANSLATION|en|hello")
+	l.ERROR.Println(`render failed: "/layouts/index.html:5:3": unexpected EOF`)
+	l.WARN.Printf("Line 1\nLine 2")
+	l.FEEDBACK.Println()
+	l.FEEDBACK.Println("Total in 10 ms")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	c.Assert(lines, qt.HasLen, 5)
+
+	var entries []logEntry
+	for _, line := range lines {
+		var entry logEntry
+		c.Assert(json.Unmarshal([]byte(line), &entry), qt.IsNil)
+		c.Assert(entry.Time, qt.Not(qt.Equals), "")
+		entry.Time = ""
+		entries = append(entries, entry)
+	}
+
+	c.Assert(entries, qt.DeepEquals, []logEntry{
+		{Level: "info", Message: "Some info"},
+		{Level: "warn", Category: "i18n", Message: "MISSING_TRANSLATION|en|hello"},
+		{Level: "error", File: "/layouts/index.html", Line: 5, Column: 3, Message: `render failed: "/layouts/index.html:5:3": unexpected EOF`},
+		{Level: "warn", Message: "Line 1\nLine 2"},
+		{Message: "Total in 10 ms"},
+	})
+
+	c.Assert(l.ErrorCounter.Count(), qt.Equals, uint64(1))
+	c.Assert(l.WarnCounter.Count(), qt.Equals, uint64(3))
+}
+
+func TestLoggerSuppress(t *testing.T) {
+	c := qt.New(t)
+	var buf bytes.Buffer
+	l := NewLoggerWithOptions(jww.LevelWarn, jww.LevelError, &buf, ioutil.Discard, false, Options{Suppress: []string{"I18N"}})
+
+	l.Category("i18n").WARN.Println("MISSING_TRANSLATION|en|hello")
+	l.Category("deprecated").WARN.Println("Old param")
+	l.WARN.Println("A warning")
+
+	c.Assert(buf.String(), qt.Not(qt.Contains), "MISSING_TRANSLATION")
+	c.Assert(buf.String(), qt.Matches, `(?s).*WARN \d{4}/\d{2}/\d{2} \d{2}:\d{2}:\d{2} deprecated\|Old param\n.*`)
+	c.Assert(buf.String(), qt.Contains, "A warning")
+
+	// Suppressed messages are still counted.
+	c.Assert(l.WarnCounter.Count(), qt.Equals, uint64(3))
+}
+
+func TestLoggerIgnore(t *testing.T) {
+	c := qt.New(t)
+	var buf bytes.Buffer
+	l := NewLoggerWithOptions(jww.LevelWarn, jww.LevelError, &buf, ioutil.Discard, false, Options{})
+
+	ll := l.WithIgnored("Deprecated")
+	ll.Category("deprecated").WARN.Println("Old param")
+	ll.Category("deprecated").ERROR.Println("Removed param")
+	ll.Category("i18n").WARN.Println("MISSING_TRANSLATION|en|hello")
+	ll.ERROR.Println("An error")
+
+	c.Assert(buf.String(), qt.Not(qt.Contains), "param")
+	c.Assert(buf.String(), qt.Contains, "MISSING_TRANSLATION")
+	c.Assert(buf.String(), qt.Contains, "An error")
+
+	// Ignored messages are not counted, and the counters are shared.
+	c.Assert(l.WarnCounter.Count(), qt.Equals, uint64(2))
+	c.Assert(l.ErrorCounter.Count(), qt.Equals, uint64(1))
+}
+
+func TestParseLevel(t *testing.T) {
+	c := qt.New(t)
+
+	for _, test := range []struct {
+		level  string
+		expect jww.Threshold
+	}{
+		{"debug", jww.LevelDebug},
+		{"INFO", jww.LevelInfo},
+		{"warn", jww.LevelWarn},
+		{"warning", jww.LevelWarn},
+		{"error", jww.LevelError},
+	} {
+		threshold, err := ParseLevel(test.level)
+		c.Assert(err, qt.IsNil)
+		c.Assert(threshold, qt.Equals, test.expect)
+	}
+
+	_, err := ParseLevel("loud")
+	c.Assert(err, qt.Not(qt.IsNil))
+
+	c.Assert(Options{Format: "xml"}.Validate(), qt.Not(qt.IsNil))
+	c.Assert(Options{Format: FormatJSON}.Validate(), qt.IsNil)
+}
@@ -45,6 +45,15 @@ func ToLower(m map[string]interface{}) {
 	}
 }
 
+// ToStringMap converts in, e.g. a map[interface{}]interface{} from YAML, to a
+// map[string]interface{}. A map[string]interface{} is returned as is.
+func ToStringMap(in interface{}) map[string]interface{} {
+	if m, ok := in.(map[string]interface{}); ok {
+		return m
+	}
+	return cast.ToStringMap(in)
+}
+
 type keyRename struct {
 	pattern glob.Glob
 	newKey  string
@@ -73,6 +73,15 @@ func TestToLower(t *testing.T) {
 	}
 }
 
+func TestToStringMap(t *testing.T) {
+	c := qt.New(t)
+
+	m := map[string]interface{}{"a": 1}
+	c.Assert(ToStringMap(m), qt.DeepEquals, m)
+	c.Assert(ToStringMap(map[interface{}]interface{}{"a": 1}), qt.DeepEquals, m)
+	c.Assert(ToStringMap("a"), qt.DeepEquals, map[string]interface{}{})
+}
+
 func TestRenameKeys(t *testing.T) {
 	c := qt.New(t)
 
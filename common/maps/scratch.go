@@ -51,11 +51,13 @@ func NewScratcher() Scratcher {
 //
 // If the first add for a key is an array or slice, then the next value(s) will be appended.
 func (c *Scratch) Add(key string, newAddend interface{}) (string, error) {
+	// Hold the lock for the whole read-modify-write so concurrent adds
+	// to the same key from parallel rendering don't get lost.
+	c.mu.Lock()
+	defer c.mu.Unlock()
 
 	var newVal interface{}
-	c.mu.RLock()
 	existingAddend, found := c.values[key]
-	c.mu.RUnlock()
 	if found {
 		var err error
 
@@ -75,9 +77,7 @@ func (c *Scratch) Add(key string, newAddend interface{}) (string, error) {
 	} else {
 		newVal = newAddend
 	}
-	c.mu.Lock()
 	c.values[key] = newVal
-	c.mu.Unlock()
 	return "", nil // have to return something to make it work with the Go templates
 }
 
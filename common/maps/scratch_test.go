@@ -166,6 +166,27 @@ func TestScratchInParallel(t *testing.T) {
 	wg.Wait()
 }
 
+func TestScratchAddInParallel(t *testing.T) {
+	c := qt.New(t)
+	var wg sync.WaitGroup
+	scratch := NewScratch()
+
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for k := 0; k < 100; k++ {
+				scratch.Add("counter", 1)
+				scratch.Add("slice", []int{k})
+			}
+		}()
+	}
+	wg.Wait()
+
+	c.Assert(scratch.Get("counter"), qt.Equals, int64(1000))
+	c.Assert(scratch.Get("slice"), qt.HasLen, 1000)
+}
+
 func TestScratchGet(t *testing.T) {
 	t.Parallel()
 	scratch := NewScratch()
@@ -111,3 +111,70 @@ func compareFold(s, t string) int {
 func LessStrings(s, t string) bool {
 	return Strings(s, t) < 0
 }
+
+// StringsNatural returns an integer comparing two strings in natural order,
+// i.e. any embedded numbers are compared by their numeric value, so "p2"
+// sorts before "p10". Letters are compared case insensitively.
+func StringsNatural(s, t string) int {
+	s0, t0 := s, t
+
+	for s != "" && t != "" {
+		if isDigit(s[0]) && isDigit(t[0]) {
+			var sn, tn string
+			sn, s = splitLeading(s, true)
+			tn, t = splitLeading(t, true)
+			if c := compareNumeric(sn, tn); c != 0 {
+				return c
+			}
+			continue
+		}
+
+		var sp, tp string
+		sp, s = splitLeading(s, false)
+		tp, t = splitLeading(t, false)
+		if c := compareFold(sp, tp); c != 0 {
+			return c
+		}
+	}
+
+	if s == "" && t == "" {
+		// Equal in natural order, e.g. "a01" and "A1", so we need a tiebreaker.
+		return Strings(s0, t0)
+	}
+
+	if s == "" {
+		return -1
+	}
+
+	return 1
+}
+
+// LessStringsNatural returns whether s is less than t in natural order.
+func LessStringsNatural(s, t string) bool {
+	return StringsNatural(s, t) < 0
+}
+
+func isDigit(b byte) bool {
+	return b >= '0' && b <= '9'
+}
+
+// splitLeading splits s after its leading run of digits (or non-digits).
+func splitLeading(s string, digits bool) (string, string) {
+	i := 0
+	for i < len(s) && isDigit(s[i]) == digits {
+		i++
+	}
+	return s[:i], s[i:]
+}
+
+func compareNumeric(s, t string) int {
+	s = strings.TrimLeft(s, "0")
+	t = strings.TrimLeft(t, "0")
+	if len(s) != len(t) {
+		if len(s) < len(t) {
+			return -1
+		}
+		return 1
+	}
+	return strings.Compare(s, t)
+}
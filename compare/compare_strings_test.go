@@ -63,3 +63,20 @@ func TestLexicographicSort(t *testing.T) {
 	c.Assert(s, qt.DeepEquals, []string{"A", "b", "Ba", "ba", "ba", "Bz"})
 
 }
+
+func TestNaturalSort(t *testing.T) {
+	c := qt.New(t)
+
+	s := []string{"p10", "P2", "p1", "a", "p02", "p1b", "p1a", ""}
+
+	sort.Slice(s, func(i, j int) bool {
+		return LessStringsNatural(s[i], s[j])
+	})
+
+	c.Assert(s, qt.DeepEquals, []string{"", "a", "p1", "p1a", "p1b", "p02", "P2", "p10"})
+
+	c.Assert(StringsNatural("chapter 9", "chapter 10"), qt.Equals, -1)
+	c.Assert(StringsNatural("v1.10", "v1.9"), qt.Equals, 1)
+	c.Assert(StringsNatural("abc", "abc"), qt.Equals, 0)
+
+}
@@ -0,0 +1,144 @@
+// Copyright 2019 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/gohugoio/hugo/common/types"
+	"github.com/gohugoio/hugo/hugofs/glob"
+	"github.com/mitchellh/mapstructure"
+	"github.com/pkg/errors"
+)
+
+const serverConfigKey = "server"
+
+// Server configures the file server used in "hugo server".
+type Server struct {
+	Headers   []Headers
+	Redirects []Redirect
+
+	compiledHeaders   []headersMatcher
+	compiledRedirects []func(s string) bool
+}
+
+// Headers holds the HTTP headers to set for the request paths matching For.
+type Headers struct {
+	// A Glob pattern matched against the request path, e.g. "/**.html".
+	For    string
+	Values map[string]string
+}
+
+// Redirect redirects the request paths matching From to To.
+type Redirect struct {
+	// A Glob pattern matched against the request path, e.g. "/old/**".
+	From string
+	To   string
+
+	// The HTTP status code to redirect with, one of 301, 302, 307 or 308.
+	// Defaults to 301.
+	Status int
+}
+
+type headersMatcher struct {
+	match  func(s string) bool
+	values []types.KeyValueStr
+}
+
+// DecodeServer creates a Server config from the server section in cfg.
+func DecodeServer(cfg Provider) (*Server, error) {
+	s := &Server{}
+
+	if !cfg.IsSet(serverConfigKey) {
+		return s, nil
+	}
+
+	if err := mapstructure.WeakDecode(cfg.GetStringMap(serverConfigKey), s); err != nil {
+		return nil, errors.Wrap(err, "failed to decode server config")
+	}
+
+	for _, h := range s.Headers {
+		g, err := glob.GetGlob(h.For)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid server headers pattern %q", h.For)
+		}
+
+		var values []types.KeyValueStr
+		for k, v := range h.Values {
+			values = append(values, types.KeyValueStr{Key: http.CanonicalHeaderKey(k), Value: v})
+		}
+		sort.Slice(values, func(i, j int) bool {
+			return values[i].Key < values[j].Key
+		})
+
+		s.compiledHeaders = append(s.compiledHeaders, headersMatcher{match: g.Match, values: values})
+	}
+
+	for i, r := range s.Redirects {
+		g, err := glob.GetGlob(r.From)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid server redirects pattern %q", r.From)
+		}
+
+		switch r.Status {
+		case 0:
+			s.Redirects[i].Status = http.StatusMovedPermanently
+		case http.StatusMovedPermanently, http.StatusFound, http.StatusTemporaryRedirect, http.StatusPermanentRedirect:
+		default:
+			return nil, errors.Errorf("unsupported redirect status %d for %q, must be one of 301, 302, 307 or 308", r.Status, r.From)
+		}
+
+		s.compiledRedirects = append(s.compiledRedirects, g.Match)
+	}
+
+	return s, nil
+}
+
+// MatchRedirect returns the first redirect matching the given request path,
+// or a zero Redirect if none matches.
+func (s *Server) MatchRedirect(pattern string) Redirect {
+	if s == nil {
+		return Redirect{}
+	}
+
+	pattern = strings.ToLower(pattern)
+
+	for i, match := range s.compiledRedirects {
+		if match(pattern) {
+			return s.Redirects[i]
+		}
+	}
+
+	return Redirect{}
+}
+
+// MatchHeaders returns the headers to set for the given request path. When
+// more than one Headers entry matches, the first one wins.
+func (s *Server) MatchHeaders(pattern string) []types.KeyValueStr {
+	if s == nil {
+		return nil
+	}
+
+	pattern = strings.ToLower(pattern)
+
+	for _, h := range s.compiledHeaders {
+		if h.match(pattern) {
+			return h.values
+		}
+	}
+
+	return nil
+}
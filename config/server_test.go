@@ -0,0 +1,95 @@
+// Copyright 2019 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/gohugoio/hugo/common/types"
+	"github.com/spf13/viper"
+)
+
+func TestDecodeServer(t *testing.T) {
+	c := qt.New(t)
+
+	tomlConfig := `
+[[server.headers]]
+for = "/*.jpg"
+[server.headers.values]
+X-Frame-Options = "DENY"
+X-XSS-Protection = "1; mode=block"
+
+[[server.headers]]
+for = "/**.html"
+[server.headers.values]
+Content-Security-Policy = "script-src localhost:1313"
+`
+
+	cfg, err := FromConfigString(tomlConfig, "toml")
+	c.Assert(err, qt.IsNil)
+
+	s, err := DecodeServer(cfg)
+	c.Assert(err, qt.IsNil)
+
+	c.Assert(s.MatchHeaders("/foo.jpg"), qt.DeepEquals, []types.KeyValueStr{
+		{Key: "X-Frame-Options", Value: "DENY"},
+		{Key: "X-Xss-Protection", Value: "1; mode=block"},
+	})
+	c.Assert(s.MatchHeaders("/posts/Foo.html"), qt.DeepEquals, []types.KeyValueStr{
+		{Key: "Content-Security-Policy", Value: "script-src localhost:1313"},
+	})
+	c.Assert(s.MatchHeaders("/posts/foo.jpg"), qt.IsNil)
+
+	s, err = DecodeServer(viper.New())
+	c.Assert(err, qt.IsNil)
+	c.Assert(s.MatchHeaders("/foo.jpg"), qt.IsNil)
+}
+
+func TestDecodeServerRedirects(t *testing.T) {
+	c := qt.New(t)
+
+	tomlConfig := `
+[[server.redirects]]
+from = "/old/**"
+to = "/new/"
+
+[[server.redirects]]
+from = "/temp/**"
+to = "https://example.org/"
+status = 302
+`
+
+	cfg, err := FromConfigString(tomlConfig, "toml")
+	c.Assert(err, qt.IsNil)
+
+	s, err := DecodeServer(cfg)
+	c.Assert(err, qt.IsNil)
+
+	c.Assert(s.MatchRedirect("/old/posts/p1/"), qt.Equals, Redirect{From: "/old/**", To: "/new/", Status: 301})
+	c.Assert(s.MatchRedirect("/temp/"), qt.Equals, Redirect{From: "/temp/**", To: "https://example.org/", Status: 302})
+	c.Assert(s.MatchRedirect("/new/"), qt.Equals, Redirect{})
+
+	cfg, err = FromConfigString(`
+[[server.redirects]]
+from = "/old/**"
+to = "/new/"
+status = 200
+`, "toml")
+	c.Assert(err, qt.IsNil)
+
+	_, err = DecodeServer(cfg)
+	c.Assert(err, qt.Not(qt.IsNil))
+	c.Assert(err.Error(), qt.Contains, "unsupported redirect status 200")
+}
@@ -24,6 +24,7 @@ const (
 	disqusShortnameKey = "disqusshortname"
 	googleAnalyticsKey = "googleanalytics"
 	rssLimitKey        = "rssLimit"
+	rssConfigKey       = "rss"
 )
 
 // Config is a privacy configuration for all the relevant services in Hugo.
@@ -33,6 +34,7 @@ type Config struct {
 	Instagram       Instagram
 	Twitter         Twitter
 	RSS             RSS
+	SearchIndex     SearchIndex
 }
 
 // Disqus holds the functional configuration settings related to the Disqus template.
@@ -63,12 +65,39 @@ type Twitter struct {
 	DisableInlineCSS bool
 }
 
-// RSS holds the functional configuration settings related to the RSS feeds.
+// RSS holds the functional configuration settings related to the RSS and Atom feeds.
 type RSS struct {
 	// Limit the number of pages.
 	Limit int
+
+	// Include the full content of each page instead of the summary.
+	FullContent bool
+
+	// Disable the RSS feeds for taxonomies and taxonomy terms, which are
+	// rendered by default.
+	DisableTaxonomyFeeds bool
 }
 
+// SearchIndex holds the functional configuration settings related to the
+// built-in search index output format.
+type SearchIndex struct {
+	// The page kinds to include. Defaults to "page".
+	Kinds []string
+
+	// The fields to include for each page. Defaults to title, permalink,
+	// summary, tags and content.
+	Fields []string
+
+	// Truncate the plain text content to this number of characters.
+	// Zero means no truncation.
+	ContentLength int
+}
+
+var (
+	defaultSearchIndexKinds  = []string{"page"}
+	defaultSearchIndexFields = []string{"title", "permalink", "summary", "tags", "content"}
+)
+
 // DecodeConfig creates a services Config from a given Hugo configuration.
 func DecodeConfig(cfg config.Provider) (c Config, err error) {
 	m := cfg.GetStringMap(servicesConfigKey)
@@ -84,9 +113,25 @@ func DecodeConfig(cfg config.Provider) (c Config, err error) {
 		c.Disqus.Shortname = cfg.GetString(disqusShortnameKey)
 	}
 
+	// The RSS settings can also be set in a root level rss section. The
+	// settings given there override the ones in services.rss, so e.g.
+	// fullContent = false turns off a fullContent set in services.
+	if m := cfg.GetStringMap(rssConfigKey); len(m) > 0 {
+		if err = mapstructure.WeakDecode(m, &c.RSS); err != nil {
+			return
+		}
+	}
+
 	if c.RSS.Limit == 0 {
 		c.RSS.Limit = cfg.GetInt(rssLimitKey)
 	}
 
+	if len(c.SearchIndex.Kinds) == 0 {
+		c.SearchIndex.Kinds = defaultSearchIndexKinds
+	}
+	if len(c.SearchIndex.Fields) == 0 {
+		c.SearchIndex.Fields = defaultSearchIndexFields
+	}
+
 	return
 }
@@ -37,6 +37,9 @@ id = "ga_id"
 disableInlineCSS = true
 [services.twitter]
 disableInlineCSS = true
+[services.searchIndex]
+fields = ["title", "permalink"]
+contentLength = 100
 `
 	cfg, err := config.FromConfigString(tomlConfig, "toml")
 	c.Assert(err, qt.IsNil)
@@ -49,6 +52,10 @@ disableInlineCSS = true
 	c.Assert(config.GoogleAnalytics.ID, qt.Equals, "ga_id")
 
 	c.Assert(config.Instagram.DisableInlineCSS, qt.Equals, true)
+
+	c.Assert(config.SearchIndex.Kinds, qt.DeepEquals, []string{"page"})
+	c.Assert(config.SearchIndex.Fields, qt.DeepEquals, []string{"title", "permalink"})
+	c.Assert(config.SearchIndex.ContentLength, qt.Equals, 100)
 }
 
 // Support old root-level GA settings etc.
@@ -67,3 +74,59 @@ func TestUseSettingsFromRootIfSet(t *testing.T) {
 	c.Assert(config.GoogleAnalytics.ID, qt.Equals, "ga_root")
 
 }
+
+func TestDecodeRSSConfigFromRoot(t *testing.T) {
+	c := qt.New(t)
+
+	tomlConfig := `
+rssLimit = 42
+
+[rss]
+fullContent = true
+disableTaxonomyFeeds = true
+
+[services.rss]
+limit = 10
+`
+	cfg, err := config.FromConfigString(tomlConfig, "toml")
+	c.Assert(err, qt.IsNil)
+
+	rssConfig, err := DecodeConfig(cfg)
+	c.Assert(err, qt.IsNil)
+
+	c.Assert(rssConfig.RSS.Limit, qt.Equals, 10)
+	c.Assert(rssConfig.RSS.FullContent, qt.Equals, true)
+	c.Assert(rssConfig.RSS.DisableTaxonomyFeeds, qt.Equals, true)
+
+	cfg, err = config.FromConfigString(`
+rssLimit = 42
+[rss]
+limit = 5
+`, "toml")
+	c.Assert(err, qt.IsNil)
+
+	rssConfig, err = DecodeConfig(cfg)
+	c.Assert(err, qt.IsNil)
+	c.Assert(rssConfig.RSS.Limit, qt.Equals, 5)
+	c.Assert(rssConfig.RSS.DisableTaxonomyFeeds, qt.Equals, false)
+
+	// The root section overrides services.rss.
+	cfg, err = config.FromConfigString(`
+[rss]
+limit = 3
+fullContent = false
+disableTaxonomyFeeds = false
+
+[services.rss]
+limit = 10
+fullContent = true
+disableTaxonomyFeeds = true
+`, "toml")
+	c.Assert(err, qt.IsNil)
+
+	rssConfig, err = DecodeConfig(cfg)
+	c.Assert(err, qt.IsNil)
+	c.Assert(rssConfig.RSS.Limit, qt.Equals, 3)
+	c.Assert(rssConfig.RSS.FullContent, qt.Equals, false)
+	c.Assert(rssConfig.RSS.DisableTaxonomyFeeds, qt.Equals, false)
+}
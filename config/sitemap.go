@@ -23,6 +23,17 @@ type Sitemap struct {
 	ChangeFreq string
 	Priority   float64
 	Filename   string
+
+	// The maximum number of URLs in a sitemap file. Bigger sitemaps are
+	// split into several files listed in a sitemap index. Only used in the
+	// site config.
+	MaxURLs int
+
+	// Exclude the page from the sitemap.
+	Exclude bool
+
+	// Image URLs to list for the page.
+	Images []string
 }
 
 func DecodeSitemap(prototype Sitemap, input map[string]interface{}) Sitemap {
@@ -35,6 +46,12 @@ func DecodeSitemap(prototype Sitemap, input map[string]interface{}) Sitemap {
 			prototype.Priority = cast.ToFloat64(value)
 		case "filename":
 			prototype.Filename = cast.ToString(value)
+		case "maxurls":
+			prototype.MaxURLs = cast.ToInt(value)
+		case "exclude":
+			prototype.Exclude = cast.ToBool(value)
+		case "images":
+			prototype.Images = cast.ToStringSlice(value)
 		default:
 			jww.WARN.Printf("Unknown Sitemap field: %s\n", key)
 		}
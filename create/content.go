@@ -47,7 +47,12 @@ func NewContent(
 
 	jww.INFO.Printf("attempting to create %q of %q of ext %q", targetPath, kind, ext)
 
-	archetypeFilename, isDir := findArchetype(ps, kind, ext)
+	archetypeFilename, isDir := findArchetype(ps, kind, targetPath, ext)
+
+	if isDir {
+		targetPath = bundleTargetPath(targetPath, ext)
+	}
+
 	contentPath, s := resolveContentPath(sites, sourceFs, targetPath)
 
 	if isDir {
@@ -328,22 +333,91 @@ func resolveContentPath(sites *hugolib.HugoSites, fs afero.Fs, targetPath string
 
 // FindArchetype takes a given kind/archetype of content and returns the path
 // to the archetype in the archetype filesystem, blank if none found.
-func findArchetype(ps *helpers.PathSpec, kind, ext string) (outpath string, isDir bool) {
+// If kind is the section of targetPath, archetypes for its nested sections
+// are tried first, e.g. "blog/tech.md" before "blog.md" for
+// "blog/tech/my-post.md". A directory archetype is used to create a bundle.
+func findArchetype(ps *helpers.PathSpec, kind, targetPath, ext string) (outpath string, isDir bool) {
 	fs := ps.BaseFs.Archetypes.Fs
 
 	var pathsToCheck []string
 
-	if kind != "" {
-		pathsToCheck = append(pathsToCheck, kind+ext)
+	for _, k := range archetypeKinds(kind, targetPath) {
+		pathsToCheck = append(pathsToCheck, k+ext, k)
 	}
 	pathsToCheck = append(pathsToCheck, "default"+ext, "default")
 
 	for _, p := range pathsToCheck {
 		fi, err := fs.Stat(p)
-		if err == nil {
-			return p, fi.IsDir()
+		if err != nil {
+			continue
+		}
+		if !fi.IsDir() {
+			return p, false
+		}
+		// A directory may also just hold the archetypes for nested sections.
+		if isBundleArchetype(fs, p) {
+			return p, true
 		}
 	}
 
 	return "", false
 }
+
+// isBundleArchetype returns whether the directory dir in fs is a bundle
+// archetype, i.e. if it has an index content file.
+func isBundleArchetype(fs afero.Fs, dir string) bool {
+	fis, err := afero.ReadDir(fs, dir)
+	if err != nil {
+		return false
+	}
+
+	for _, fi := range fis {
+		if fi.IsDir() || !files.IsContentFile(fi.Name()) {
+			continue
+		}
+		name := strings.Split(fi.Name(), ".")[0]
+		if name == "index" || name == "_index" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// archetypeKinds returns the archetype names to try for kind and targetPath,
+// most specific first.
+func archetypeKinds(kind, targetPath string) []string {
+	if kind == "" {
+		return nil
+	}
+
+	kinds := []string{kind}
+
+	dir := filepath.Dir(strings.TrimPrefix(targetPath, helpers.FilePathSeparator))
+	if !strings.HasPrefix(dir, kind+helpers.FilePathSeparator) {
+		return kinds
+	}
+
+	var nested []string
+	for dir != kind && dir != "." {
+		nested = append(nested, dir)
+		dir = filepath.Dir(dir)
+	}
+
+	return append(nested, kinds...)
+}
+
+// bundleTargetPath returns the bundle directory to create from targetPath,
+// e.g. "posts/my-post" for "posts/my-post.md" and "posts/my-post/index.md".
+func bundleTargetPath(targetPath, ext string) string {
+	if ext == "" {
+		return targetPath
+	}
+
+	base := strings.TrimSuffix(filepath.Base(targetPath), ext)
+	if base == "index" || base == "_index" {
+		return filepath.Dir(targetPath)
+	}
+
+	return strings.TrimSuffix(targetPath, ext)
+}
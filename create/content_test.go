@@ -143,6 +143,35 @@ i18n: {{ T "hugo" }}
 
 }
 
+func TestNewContentSectionArchetypes(t *testing.T) {
+	mm := afero.NewMemMapFs()
+	c := qt.New(t)
+
+	c.Assert(afero.WriteFile(mm, filepath.Join("archetypes", "blog.md"), []byte("---\ntitle: Blog Arch\n---\n"), 0755), qt.IsNil)
+	c.Assert(afero.WriteFile(mm, filepath.Join("archetypes", "blog", "tech.md"), []byte("---\ntitle: Tech Arch\n---\n"), 0755), qt.IsNil)
+	c.Assert(afero.WriteFile(mm, filepath.Join("archetypes", "gallery", "index.md"), []byte("---\ntitle: {{ .Name | title }}\n---\nSite Lang: {{ .Site.Language.Lang }}\n"), 0755), qt.IsNil)
+	c.Assert(afero.WriteFile(mm, filepath.Join("archetypes", "gallery", "images", "placeholder.svg"), []byte("<svg></svg>"), 0755), qt.IsNil)
+
+	c.Assert(initFs(mm), qt.IsNil)
+	cfg, fs := newTestCfg(c, mm)
+
+	h, err := hugolib.NewHugoSites(deps.DepsCfg{Cfg: cfg, Fs: fs})
+	c.Assert(err, qt.IsNil)
+
+	c.Assert(create.NewContent(h, "blog", "blog/tech/my-post.md"), qt.IsNil)
+	cContains(c, readFileFromFs(t, fs.Source, filepath.Join("content", "blog", "tech", "my-post.md")), `title: Tech Arch`)
+
+	c.Assert(create.NewContent(h, "blog", "blog/travel/my-post.md"), qt.IsNil)
+	cContains(c, readFileFromFs(t, fs.Source, filepath.Join("content", "blog", "travel", "my-post.md")), `title: Blog Arch`)
+
+	c.Assert(create.NewContent(h, "gallery", "gallery/summer.md"), qt.IsNil)
+	cContains(c, readFileFromFs(t, fs.Source, filepath.Join("content", "gallery", "summer", "index.md")), `title: Summer`, `Site Lang: en`)
+	cContains(c, readFileFromFs(t, fs.Source, filepath.Join("content", "gallery", "summer", "images", "placeholder.svg")), `<svg></svg>`)
+
+	c.Assert(create.NewContent(h, "gallery", "gallery/winter/index.md"), qt.IsNil)
+	cContains(c, readFileFromFs(t, fs.Source, filepath.Join("content", "gallery", "winter", "index.md")), `title: Winter`)
+}
+
 func initFs(fs afero.Fs) error {
 	perm := os.FileMode(0755)
 	var err error
@@ -15,6 +15,7 @@ package deploy
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -22,9 +23,20 @@ import (
 	"github.com/aws/aws-sdk-go/service/cloudfront"
 )
 
-// InvalidateCloudFront invalidates the CloudFront cache for distributionID.
+const (
+	// CloudFront allows up to 3000 file paths per distribution in progress
+	// at the same time.
+	cloudFrontMaxPaths = 3000
+
+	// The number of paths sent in one invalidation request.
+	cloudFrontBatchSize = 1000
+)
+
+// InvalidateCloudFront invalidates the given paths in the CloudFront cache for
+// distributionID. If paths is empty or more than CloudFront allows in progress,
+// the entire cache is invalidated.
 // It uses the default AWS credentials from the environment.
-func InvalidateCloudFront(ctx context.Context, distributionID string) error {
+func InvalidateCloudFront(ctx context.Context, distributionID string, paths []string) error {
 	// SharedConfigEnable enables loading "shared config (~/.aws/config) and
 	// shared credentials (~/.aws/credentials) files".
 	// See https://docs.aws.amazon.com/sdk-for-go/api/aws/session/ for more
@@ -36,16 +48,29 @@ func InvalidateCloudFront(ctx context.Context, distributionID string) error {
 	if err != nil {
 		return err
 	}
-	req := &cloudfront.CreateInvalidationInput{
-		DistributionId: aws.String(distributionID),
-		InvalidationBatch: &cloudfront.InvalidationBatch{
-			CallerReference: aws.String(time.Now().Format("20060102150405")),
-			Paths: &cloudfront.Paths{
-				Items:    []*string{aws.String("/*")},
-				Quantity: aws.Int64(1),
+	if len(paths) == 0 || len(paths) > cloudFrontMaxPaths {
+		paths = []string{"/*"}
+	}
+
+	client := cloudfront.New(sess)
+	now := time.Now().Format("20060102150405")
+
+	for i, batch := range batchPaths(paths, cloudFrontBatchSize) {
+		req := &cloudfront.CreateInvalidationInput{
+			DistributionId: aws.String(distributionID),
+			InvalidationBatch: &cloudfront.InvalidationBatch{
+				// The caller reference must be unique per invalidation.
+				CallerReference: aws.String(fmt.Sprintf("%s-%d", now, i)),
+				Paths: &cloudfront.Paths{
+					Items:    aws.StringSlice(batch),
+					Quantity: aws.Int64(int64(len(batch))),
+				},
 			},
-		},
+		}
+		if _, err := client.CreateInvalidationWithContext(ctx, req); err != nil {
+			return err
+		}
 	}
-	_, err = cloudfront.New(sess).CreateInvalidationWithContext(ctx, req)
-	return err
+
+	return nil
 }
@@ -23,6 +23,7 @@ import (
 	"io/ioutil"
 	"mime"
 	"os"
+	"path"
 	"path/filepath"
 	"regexp"
 	"runtime"
@@ -126,7 +127,7 @@ func (d *Deployer) Deploy(ctx context.Context) error {
 	}
 
 	// Load local files from the source directory.
-	local, err := walkLocal(d.localFs, d.matchers)
+	local, err := walkLocal(d.localFs, d.matchers, d.target)
 	if err != nil {
 		return err
 	}
@@ -134,7 +135,7 @@ func (d *Deployer) Deploy(ctx context.Context) error {
 	d.summary.NumLocal = len(local)
 
 	// Load remote files from the target.
-	remote, err := walkRemote(ctx, bucket)
+	remote, err := walkRemote(ctx, bucket, d.target)
 	if err != nil {
 		return err
 	}
@@ -213,6 +214,7 @@ func (d *Deployer) Deploy(ctx context.Context) error {
 	if d.maxDeletes != -1 && len(deletes) > d.maxDeletes {
 		jww.WARN.Printf("Skipping %d deletes because it is more than --maxDeletes (%d). If this is expected, set --maxDeletes to a larger number, or -1 to disable this check.\n", len(deletes), d.maxDeletes)
 		d.summary.NumDeletes = 0
+		deletes = nil
 	} else {
 		// Apply deletes in parallel.
 		sort.Slice(deletes, func(i, j int) bool { return deletes[i] < deletes[j] })
@@ -250,17 +252,79 @@ func (d *Deployer) Deploy(ctx context.Context) error {
 		jww.FEEDBACK.Println("Success!")
 	}
 
-	if d.invalidateCDN && d.target.CloudFrontDistributionID != "" {
-		jww.FEEDBACK.Println("Invalidating CloudFront CDN...")
-		if err := InvalidateCloudFront(ctx, d.target.CloudFrontDistributionID); err != nil {
+	if !d.invalidateCDN || d.dryRun {
+		return nil
+	}
+
+	paths := invalidationPaths(uploads, deletes)
+
+	if d.target.CloudFrontDistributionID != "" {
+		jww.FEEDBACK.Printf("Invalidating %d path(s) in CloudFront CDN...\n", len(paths))
+		if err := InvalidateCloudFront(ctx, d.target.CloudFrontDistributionID, paths); err != nil {
 			jww.FEEDBACK.Printf("Failed to invalidate CloudFront CDN: %v\n", err)
 			return err
 		}
 		jww.FEEDBACK.Println("Success!")
 	}
+
+	if d.target.GoogleCloudCDNOrigin != "" {
+		jww.FEEDBACK.Printf("Invalidating %d path(s) in Google Cloud CDN...\n", len(paths))
+		if err := InvalidateGoogleCloudCDN(ctx, d.target.GoogleCloudCDNOrigin, paths); err != nil {
+			jww.FEEDBACK.Printf("Failed to invalidate Google Cloud CDN: %v\n", err)
+			return err
+		}
+		jww.FEEDBACK.Println("Success!")
+	}
+
 	return nil
 }
 
+// invalidationPaths returns the sorted CDN paths to invalidate for the given
+// uploads and deletes. An index.html file is also served as its directory,
+// so the directory path is invalidated as well.
+func invalidationPaths(uploads []*fileToUpload, deletes []string) []string {
+	seen := make(map[string]bool)
+	add := func(key string) {
+		p := "/" + key
+		seen[p] = true
+		if path.Base(p) == "index.html" {
+			dir := path.Dir(p)
+			if dir != "/" {
+				dir += "/"
+			}
+			seen[dir] = true
+		}
+	}
+
+	for _, u := range uploads {
+		add(u.Local.SlashPath)
+	}
+	for _, del := range deletes {
+		add(del)
+	}
+
+	paths := make([]string, 0, len(seen))
+	for p := range seen {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+
+	return paths
+}
+
+// batchPaths splits paths into batches of at most size paths.
+func batchPaths(paths []string, size int) [][]string {
+	var batches [][]string
+	for len(paths) > size {
+		batches = append(batches, paths[:size])
+		paths = paths[size:]
+	}
+	if len(paths) > 0 {
+		batches = append(batches, paths)
+	}
+	return batches
+}
+
 // summarizeChanges creates a text description of the proposed changes.
 func summarizeChanges(uploads []*fileToUpload, deletes []string) string {
 	uploadSize := int64(0)
@@ -428,8 +492,9 @@ func (lf *localFile) MD5() []byte {
 }
 
 // walkLocal walks the source directory and returns a flat list of files,
-// using localFile.SlashPath as the map keys.
-func walkLocal(fs afero.Fs, matchers []*matcher) (map[string]*localFile, error) {
+// using localFile.SlashPath as the map keys. Files not included in tgt
+// are skipped.
+func walkLocal(fs afero.Fs, matchers []*matcher, tgt *target) (map[string]*localFile, error) {
 	retval := map[string]*localFile{}
 	err := afero.Walk(fs, "", func(path string, info os.FileInfo, err error) error {
 		if err != nil {
@@ -453,8 +518,12 @@ func walkLocal(fs afero.Fs, matchers []*matcher) (map[string]*localFile, error)
 			path = norm.NFC.String(path)
 		}
 
-		// Find the first matching matcher (if any).
 		slashpath := filepath.ToSlash(path)
+		if !tgt.isIncluded(slashpath) {
+			return nil
+		}
+
+		// Find the first matching matcher (if any).
 		var m *matcher
 		for _, cur := range matchers {
 			if cur.Matches(slashpath) {
@@ -475,8 +544,9 @@ func walkLocal(fs afero.Fs, matchers []*matcher) (map[string]*localFile, error)
 	return retval, nil
 }
 
-// walkRemote walks the target bucket and returns a flat list.
-func walkRemote(ctx context.Context, bucket *blob.Bucket) (map[string]*blob.ListObject, error) {
+// walkRemote walks the target bucket and returns a flat list. Files not
+// included in tgt are skipped.
+func walkRemote(ctx context.Context, bucket *blob.Bucket, tgt *target) (map[string]*blob.ListObject, error) {
 	retval := map[string]*blob.ListObject{}
 	iter := bucket.List(nil)
 	for {
@@ -487,6 +557,9 @@ func walkRemote(ctx context.Context, bucket *blob.Bucket) (map[string]*blob.List
 		if err != nil {
 			return nil, err
 		}
+		if !tgt.isIncluded(obj.Key) {
+			continue
+		}
 		// If the remote didn't give us an MD5, compute one.
 		// This can happen for some providers (e.g., fileblob, which uses the
 		// local filesystem), but not for the most common Cloud providers
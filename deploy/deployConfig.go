@@ -16,8 +16,10 @@ package deploy
 import (
 	"fmt"
 	"regexp"
+	"strings"
 
 	"github.com/gohugoio/hugo/config"
+	"github.com/gohugoio/hugo/hugofs/glob"
 	"github.com/mitchellh/mapstructure"
 )
 
@@ -37,6 +39,55 @@ type target struct {
 	URL  string
 
 	CloudFrontDistributionID string
+
+	// GoogleCloudCDNOrigin is the Google Cloud CDN to invalidate, in the form
+	// "<project>/<URL map>".
+	GoogleCloudCDNOrigin string
+
+	// Optional Glob patterns matched against the file paths, e.g. "**.html".
+	// If Include is set, only the matching files are deployed. The files
+	// matching Exclude are not deployed. Files not deployed are also left
+	// untouched in the target.
+	Include string
+	Exclude string
+
+	// Include and Exclude compiled.
+	includeGlob func(s string) bool
+	excludeGlob func(s string) bool
+}
+
+func (tgt *target) parseIncludeExclude() error {
+	if tgt.Include != "" {
+		g, err := glob.GetGlob(tgt.Include)
+		if err != nil {
+			return fmt.Errorf("invalid deployment.target.include %q: %v", tgt.Include, err)
+		}
+		tgt.includeGlob = g.Match
+	}
+	if tgt.Exclude != "" {
+		g, err := glob.GetGlob(tgt.Exclude)
+		if err != nil {
+			return fmt.Errorf("invalid deployment.target.exclude %q: %v", tgt.Exclude, err)
+		}
+		tgt.excludeGlob = g.Match
+	}
+	return nil
+}
+
+// isIncluded returns whether the file with the given path (using / as the
+// path separator) should be deployed.
+func (tgt *target) isIncluded(slashpath string) bool {
+	if tgt == nil {
+		return true
+	}
+	slashpath = strings.ToLower(slashpath)
+	if tgt.includeGlob != nil && !tgt.includeGlob(slashpath) {
+		return false
+	}
+	if tgt.excludeGlob != nil && tgt.excludeGlob(slashpath) {
+		return false
+	}
+	return true
 }
 
 // matcher represents configuration to be applied to files whose paths match
@@ -84,6 +135,16 @@ func decodeConfig(cfg config.Provider) (deployConfig, error) {
 		return dcfg, err
 	}
 	var err error
+	for _, tgt := range dcfg.Targets {
+		if err := tgt.parseIncludeExclude(); err != nil {
+			return dcfg, err
+		}
+		if tgt.GoogleCloudCDNOrigin != "" {
+			if _, _, err := parseGoogleCloudCDNOrigin(tgt.GoogleCloudCDNOrigin); err != nil {
+				return dcfg, err
+			}
+		}
+	}
 	for _, m := range dcfg.Matchers {
 		m.re, err = regexp.Compile(m.Pattern)
 		if err != nil {
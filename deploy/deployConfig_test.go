@@ -50,6 +50,9 @@ CLOUDFRONTDISTRIBUTIONID = "cdn1"
 name = "name2"
 url = "url2"
 cloudFrontDistributionID = "cdn2"
+googleCloudCDNOrigin = "myproject/myurlmap"
+include = "**.html"
+exclude = "private/**"
 
 # All lowercase.
 [[deployment.matchers]]
@@ -97,6 +100,18 @@ force = true
 		c.Assert(tgt.CloudFrontDistributionID, qt.Equals, fmt.Sprintf("cdn%d", i))
 	}
 
+	c.Assert(dcfg.Targets[2].GoogleCloudCDNOrigin, qt.Equals, "myproject/myurlmap")
+
+	// Include and exclude.
+	c.Assert(dcfg.Targets[0].isIncluded("private/index.html"), qt.Equals, true)
+	tgt := dcfg.Targets[2]
+	c.Assert(tgt.Include, qt.Equals, "**.html")
+	c.Assert(tgt.Exclude, qt.Equals, "private/**")
+	c.Assert(tgt.isIncluded("index.html"), qt.Equals, true)
+	c.Assert(tgt.isIncluded("posts/Post.HTML"), qt.Equals, true)
+	c.Assert(tgt.isIncluded("main.css"), qt.Equals, false)
+	c.Assert(tgt.isIncluded("private/index.html"), qt.Equals, false)
+
 	// Matchers.
 	c.Assert(len(dcfg.Matchers), qt.Equals, 3)
 	for i := 0; i < 3; i++ {
@@ -128,6 +143,42 @@ order = ["["]  # invalid regular expression
 	c.Assert(err, qt.Not(qt.IsNil))
 }
 
+func TestInvalidTargetIncludePattern(t *testing.T) {
+	c := qt.New(t)
+
+	tomlConfig := `
+
+someOtherValue = "foo"
+
+[deployment]
+[[deployment.targets]]
+name = "name0"
+include = "[a"
+`
+	cfg, err := config.FromConfigString(tomlConfig, "toml")
+	c.Assert(err, qt.IsNil)
+	_, err = decodeConfig(cfg)
+	c.Assert(err, qt.Not(qt.IsNil))
+}
+
+func TestInvalidTargetGoogleCloudCDNOrigin(t *testing.T) {
+	c := qt.New(t)
+
+	tomlConfig := `
+
+someOtherValue = "foo"
+
+[deployment]
+[[deployment.targets]]
+name = "name0"
+googleCloudCDNOrigin = "myurlmap"
+`
+	cfg, err := config.FromConfigString(tomlConfig, "toml")
+	c.Assert(err, qt.IsNil)
+	_, err = decodeConfig(cfg)
+	c.Assert(err, qt.Not(qt.IsNil))
+}
+
 func TestInvalidMatcherPattern(t *testing.T) {
 	c := qt.New(t)
 
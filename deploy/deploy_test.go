@@ -752,6 +752,114 @@ func TestMatching(t *testing.T) {
 	}
 }
 
+// TestIncludeExclude verifies that the include and exclude patterns of the
+// target limit both the local and the remote files.
+func TestIncludeExclude(t *testing.T) {
+	ctx := context.Background()
+	tests, cleanup, err := initFsTests()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cleanup()
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := initLocalFs(ctx, test.fs)
+			if err != nil {
+				t.Fatal(err)
+			}
+			tgt := &target{Include: "**aaa", Exclude: "subdir/nested/**"}
+			if err := tgt.parseIncludeExclude(); err != nil {
+				t.Fatal(err)
+			}
+			deployer := &Deployer{
+				localFs:    test.fs,
+				maxDeletes: -1,
+				bucket:     test.bucket,
+				target:     tgt,
+			}
+
+			// Only aaa and subdir/aaa are deployed.
+			if err := deployer.Deploy(ctx); err != nil {
+				t.Errorf("initial deploy: failed: %v", err)
+			}
+			wantSummary := deploySummary{NumLocal: 2, NumRemote: 0, NumUploads: 2, NumDeletes: 0}
+			if !cmp.Equal(deployer.summary, wantSummary) {
+				t.Errorf("initial deploy: got %v, want %v", deployer.summary, wantSummary)
+			}
+
+			// Deploy everything.
+			deployer.target = nil
+			if err := deployer.Deploy(ctx); err != nil {
+				t.Errorf("deploy all: failed: %v", err)
+			}
+			wantSummary = deploySummary{NumLocal: 5, NumRemote: 2, NumUploads: 3, NumDeletes: 0}
+			if !cmp.Equal(deployer.summary, wantSummary) {
+				t.Errorf("deploy all: got %v, want %v", deployer.summary, wantSummary)
+			}
+
+			// Files not included are not deleted from the target.
+			if err := test.fs.Remove("bbb"); err != nil {
+				t.Fatal(err)
+			}
+			deployer.target = tgt
+			if err := deployer.Deploy(ctx); err != nil {
+				t.Errorf("deploy after delete: failed: %v", err)
+			}
+			wantSummary = deploySummary{NumLocal: 2, NumRemote: 2, NumUploads: 0, NumDeletes: 0}
+			if !cmp.Equal(deployer.summary, wantSummary) {
+				t.Errorf("deploy after delete: got %v, want %v", deployer.summary, wantSummary)
+			}
+		})
+	}
+}
+
+func TestInvalidationPaths(t *testing.T) {
+	uploads := []*fileToUpload{
+		{Local: &localFile{SlashPath: "index.html"}},
+		{Local: &localFile{SlashPath: "posts/p1/index.html"}},
+		{Local: &localFile{SlashPath: "css/main.css"}},
+	}
+	deletes := []string{"posts/p2/index.html", "css/main.css"}
+
+	got := invalidationPaths(uploads, deletes)
+	want := []string{
+		"/",
+		"/css/main.css",
+		"/index.html",
+		"/posts/p1/",
+		"/posts/p1/index.html",
+		"/posts/p2/",
+		"/posts/p2/index.html",
+	}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("invalidationPaths diff (-got +want):\n%s", diff)
+	}
+}
+
+func TestBatchPaths(t *testing.T) {
+	paths := []string{"/a", "/b", "/c", "/d", "/e"}
+
+	tests := []struct {
+		size int
+		want [][]string
+	}{
+		{1, [][]string{{"/a"}, {"/b"}, {"/c"}, {"/d"}, {"/e"}}},
+		{2, [][]string{{"/a", "/b"}, {"/c", "/d"}, {"/e"}}},
+		{5, [][]string{{"/a", "/b", "/c", "/d", "/e"}}},
+		{10, [][]string{{"/a", "/b", "/c", "/d", "/e"}}},
+	}
+
+	for _, test := range tests {
+		if diff := cmp.Diff(batchPaths(paths, test.size), test.want); diff != "" {
+			t.Errorf("batchPaths size %d diff (-got +want):\n%s", test.size, diff)
+		}
+	}
+
+	if got := batchPaths(nil, 10); got != nil {
+		t.Errorf("batchPaths of no paths: got %v, want nil", got)
+	}
+}
+
 // writeFiles writes the files in fds to fd.
 func writeFiles(fs afero.Fs, fds []*fileData) error {
 	for _, fd := range fds {
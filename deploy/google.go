@@ -0,0 +1,64 @@
+// Copyright 2019 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploy
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	compute "google.golang.org/api/compute/v1"
+)
+
+// Cloud CDN takes one path per invalidation request, and the number of
+// requests is rate limited, so above this many paths the entire cache is
+// invalidated instead.
+const googleCloudCDNMaxPaths = 50
+
+// InvalidateGoogleCloudCDN invalidates the given paths in the Google Cloud CDN
+// cache for origin, in the form "<project>/<URL map>". If paths is empty or
+// longer than googleCloudCDNMaxPaths, the entire cache is invalidated.
+// It uses the default Google credentials from the environment.
+func InvalidateGoogleCloudCDN(ctx context.Context, origin string, paths []string) error {
+	project, urlMap, err := parseGoogleCloudCDNOrigin(origin)
+	if err != nil {
+		return err
+	}
+
+	service, err := compute.NewService(ctx)
+	if err != nil {
+		return err
+	}
+
+	if len(paths) == 0 || len(paths) > googleCloudCDNMaxPaths {
+		paths = []string{"/*"}
+	}
+
+	for _, p := range paths {
+		rule := &compute.CacheInvalidationRule{Path: p}
+		if _, err := service.UrlMaps.InvalidateCache(project, urlMap, rule).Context(ctx).Do(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func parseGoogleCloudCDNOrigin(origin string) (project, urlMap string, err error) {
+	parts := strings.Split(origin, "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("invalid deployment.target.googleCloudCDNOrigin %q, must be in the form <project>/<URL map>", origin)
+	}
+	return parts[0], parts[1], nil
+}
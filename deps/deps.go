@@ -37,6 +37,10 @@ type Deps struct {
 	// Used to log warnings that may repeat itself many times.
 	DistinctWarningLog *helpers.DistinctLogger
 
+	// Used to log the messages with an ID, e.g. from warnidf, once per build
+	// for all languages.
+	DistinctIDLog *helpers.DistinctLogger
+
 	// The templates to use. This will usually implement the full tpl.TemplateHandler.
 	Tmpl tpl.TemplateFinder `json:"-"`
 
@@ -76,6 +80,10 @@ type Deps struct {
 	// All the output formats available for the current site.
 	OutputFormatsConfig output.Formats
 
+	// CurrentOutputFormat returns the output format currently being rendered.
+	// This may be nil.
+	CurrentOutputFormat func() output.Format `json:"-"`
+
 	templateProvider ResourceProvider
 	WithTemplate     func(templ tpl.TemplateHandler) error `json:"-"`
 
@@ -83,12 +91,25 @@ type Deps struct {
 
 	Metrics metrics.Provider
 
+	// Measures the build phases. This may be nil.
+	BuildTimer *metrics.BuildTimer
+
+	// Collects the fingerprinted resources. This may be nil.
+	AssetManifest *resources.AssetManifest
+
+	// Collects the requested image processing. This may be nil.
+	ImageAudit *resources.ImageAudit
+
 	// Timeout is configurable in site config.
 	Timeout time.Duration
 
 	// BuildStartListeners will be notified before a build starts.
 	BuildStartListeners *Listeners
 
+	// PartialCachedIncluded is called on every partialCached include, as the
+	// result may have been created for another page. This may be nil.
+	PartialCachedIncluded func() `json:"-"`
+
 	*globalErrHandler
 }
 
@@ -155,6 +176,11 @@ func (d *Deps) TemplateHandler() tpl.TemplateHandler {
 	return d.Tmpl.(tpl.TemplateHandler)
 }
 
+// TranslationProvider returns the provider of the i18n translations.
+func (d *Deps) TranslationProvider() ResourceProvider {
+	return d.translationProvider
+}
+
 // LoadResources loads translations and templates.
 func (d *Deps) LoadResources() error {
 	// Note that the translations need to be loaded before the templates.
@@ -194,6 +220,10 @@ func New(cfg DepsCfg) (*Deps, error) {
 		logger = loggers.NewErrorLogger()
 	}
 
+	// The IDs in ignoreLogs are the log categories of the messages
+	// logged with warnidf and erroridf.
+	logger = logger.WithIgnored(cfg.Cfg.GetStringSlice("ignoreLogs")...)
+
 	if fs == nil {
 		// Default to the production file system.
 		fs = hugofs.NewDefault(cfg.Language)
@@ -243,6 +273,7 @@ func New(cfg DepsCfg) (*Deps, error) {
 		Log:                 logger,
 		DistinctErrorLog:    distinctErrorLogger,
 		DistinctWarningLog:  distinctWarnLogger,
+		DistinctIDLog:       helpers.NewDistinctLogger(logger.WARN),
 		templateProvider:    cfg.TemplateProvider,
 		translationProvider: cfg.TranslationProvider,
 		WithTemplate:        cfg.WithTemplate,
@@ -259,10 +290,27 @@ func New(cfg DepsCfg) (*Deps, error) {
 		globalErrHandler:    &globalErrHandler{},
 	}
 
+	d.BuildStartListeners.Add(d.DistinctIDLog.Reset)
+
 	if cfg.Cfg.GetBool("templateMetrics") {
 		d.Metrics = metrics.NewProvider(cfg.Cfg.GetBool("templateMetricsHints"))
 	}
 
+	if cfg.Cfg.GetString("buildReport") != "" {
+		d.BuildTimer = metrics.NewBuildTimer()
+		d.ResourceSpec.BuildTimer = d.BuildTimer
+	}
+
+	if cfg.Cfg.GetBool("build.writeAssetManifest") {
+		d.AssetManifest = resources.NewAssetManifest()
+		d.ResourceSpec.AssetManifest = d.AssetManifest
+	}
+
+	if cfg.Cfg.GetBool("imageAudit") {
+		d.ImageAudit = resources.NewImageAudit()
+		d.ResourceSpec.ImageAudit = d.ImageAudit
+	}
+
 	return d, nil
 }
 
@@ -292,6 +340,9 @@ func (d Deps) ForLanguage(cfg DepsCfg, onCreated func(d *Deps) error) (*Deps, er
 		return nil, err
 	}
 	d.ResourceSpec.ResourceCache = resourceCache
+	d.ResourceSpec.BuildTimer = d.BuildTimer
+	d.ResourceSpec.AssetManifest = d.AssetManifest
+	d.ResourceSpec.ImageAudit = d.ImageAudit
 
 	d.Cfg = l
 	d.Language = l
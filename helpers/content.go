@@ -35,7 +35,9 @@ import (
 	"github.com/mitchellh/mapstructure"
 	"github.com/russross/blackfriday"
 	jww "github.com/spf13/jwalterweatherman"
+	"golang.org/x/text/unicode/norm"
 
+	"regexp"
 	"strings"
 )
 
@@ -48,6 +50,13 @@ var (
 	paragraphIndicator = []byte("<p")
 )
 
+// The units summaryLength can be measured in.
+const (
+	SummaryLengthUnitWords      = "words"
+	SummaryLengthUnitCharacters = "characters"
+	SummaryLengthUnitParagraphs = "paragraphs"
+)
+
 // ContentSpec provides functionality to render markdown content.
 type ContentSpec struct {
 	BlackFriday                *BlackFriday
@@ -55,6 +64,12 @@ type ContentSpec struct {
 	footnoteReturnLinkContents string
 	// SummaryLength is the length of the summary that Hugo extracts from a content.
 	summaryLength int
+	// summaryLengthUnit is the unit summaryLength is measured in, one of
+	// words, characters or paragraphs.
+	summaryLengthUnit string
+	// wordsPerMinute is the reading speed used to estimate the reading
+	// time. If not set, a default for CJK and non-CJK languages is used.
+	wordsPerMinute int
 
 	BuildFuture  bool
 	BuildExpired bool
@@ -75,6 +90,8 @@ func NewContentSpec(cfg config.Provider) (*ContentSpec, error) {
 		footnoteAnchorPrefix:       cfg.GetString("footnoteAnchorPrefix"),
 		footnoteReturnLinkContents: cfg.GetString("footnoteReturnLinkContents"),
 		summaryLength:              cfg.GetInt("summaryLength"),
+		summaryLengthUnit:          strings.ToLower(cfg.GetString("summaryLengthUnit")),
+		wordsPerMinute:             cfg.GetInt("wordsPerMinute"),
 		BuildFuture:                cfg.GetBool("buildFuture"),
 		BuildExpired:               cfg.GetBool("buildExpired"),
 		BuildDrafts:                cfg.GetBool("buildDrafts"),
@@ -82,6 +99,28 @@ func NewContentSpec(cfg config.Provider) (*ContentSpec, error) {
 		Cfg: cfg,
 	}
 
+	switch spec.summaryLengthUnit {
+	case "", "word":
+		spec.summaryLengthUnit = SummaryLengthUnitWords
+	case "character", "chars":
+		spec.summaryLengthUnit = SummaryLengthUnitCharacters
+	case "paragraph":
+		spec.summaryLengthUnit = SummaryLengthUnitParagraphs
+	case SummaryLengthUnitWords, SummaryLengthUnitCharacters, SummaryLengthUnitParagraphs:
+	default:
+		return nil, fmt.Errorf("invalid summaryLengthUnit %q, must be one of %q, %q or %q", spec.summaryLengthUnit, SummaryLengthUnitWords, SummaryLengthUnitCharacters, SummaryLengthUnitParagraphs)
+	}
+
+	if spec.wordsPerMinute < 0 {
+		return nil, fmt.Errorf("invalid wordsPerMinute %d, must be a positive number", spec.wordsPerMinute)
+	}
+
+	switch bf.HeadingIDType {
+	case HeadingIDTypeUnicode, HeadingIDTypeASCII:
+	default:
+		return nil, fmt.Errorf("invalid blackfriday headingIDType %q, must be one of %q or %q", bf.HeadingIDType, HeadingIDTypeUnicode, HeadingIDTypeASCII)
+	}
+
 	// Highlighting setup
 	options, err := parseDefaultPygmentsOpts(cfg)
 	if err != nil {
@@ -123,11 +162,63 @@ type BlackFriday struct {
 	LatexDashes           bool
 	TaskLists             bool
 	PlainIDAnchors        bool
+	HeadingIDType         string
 	Extensions            []string
 	ExtensionsMask        []string
 	SkipHTML              bool
 }
 
+// The algorithms used to create heading IDs, set with headingIDType in
+// the Blackfriday config.
+const (
+	// Keep Unicode letters and numbers, the Blackfriday default.
+	HeadingIDTypeUnicode = "unicode"
+
+	// Remove accents and any other non-ASCII characters.
+	HeadingIDTypeASCII = "ascii"
+)
+
+// SanitizeAnchorName creates an anchor name from the text s, e.g. a
+// heading, the same way as the heading IDs are created when rendering
+// Markdown with the given heading ID type.
+func SanitizeAnchorName(s, headingIDType string) string {
+	id := blackfriday.SanitizedAnchorName(s)
+	if headingIDType == HeadingIDTypeASCII {
+		id = ASCIIAnchorName(id)
+	}
+	return id
+}
+
+// ASCIIAnchorName removes the accents from the characters in id and
+// replaces any remaining runs of non-ASCII characters with a "-". The id
+// is kept as is if nothing would be left of it.
+func ASCIIAnchorName(id string) string {
+	var b strings.Builder
+	var dropped bool
+	for _, r := range norm.NFD.String(id) {
+		if unicode.Is(unicode.Mn, r) {
+			continue
+		}
+		if r >= utf8.RuneSelf {
+			dropped = true
+			continue
+		}
+		if dropped {
+			if b.Len() > 0 && r != '-' && !strings.HasSuffix(b.String(), "-") {
+				b.WriteRune('-')
+			}
+			dropped = false
+		}
+		b.WriteRune(r)
+	}
+
+	ascii := strings.Trim(b.String(), "-")
+	if ascii == "" {
+		return id
+	}
+	return ascii
+}
+
 // NewBlackfriday creates a new Blackfriday filled with site config or some sane defaults.
 func newBlackfriday(config map[string]interface{}) *BlackFriday {
 	defaultParam := map[string]interface{}{
@@ -141,6 +232,7 @@ func newBlackfriday(config map[string]interface{}) *BlackFriday {
 		"smartDashes":           true,
 		"latexDashes":           true,
 		"plainIDAnchors":        true,
+		"headingIDType":         HeadingIDTypeUnicode,
 		"taskLists":             true,
 		"skipHTML":              false,
 	}
@@ -314,6 +406,7 @@ func (c *ContentSpec) getHTMLRenderer(defaultFlags int, ctx *RenderingContext) b
 		cs:               c,
 		RenderingContext: ctx,
 		Renderer:         blackfriday.HtmlRendererWithParameters(htmlFlags, "", "", renderParameters),
+		autoHeadingIDs:   getMarkdownExtensions(ctx)&blackfriday.EXTENSION_AUTO_HEADER_IDS != 0,
 	}
 }
 
@@ -353,13 +446,19 @@ func getMarkdownExtensions(ctx *RenderingContext) int {
 }
 
 func (c ContentSpec) markdownRender(ctx *RenderingContext) []byte {
+	extensions := getMarkdownExtensions(ctx)
+	if ctx.Config.HeadingIDType == HeadingIDTypeASCII {
+		// The ASCII heading IDs are created by HugoHTMLRenderer.
+		extensions &^= blackfriday.EXTENSION_AUTO_HEADER_IDS
+	}
+
 	if ctx.RenderTOC {
 		return blackfriday.Markdown(ctx.Content,
 			c.getHTMLRenderer(blackfriday.HTML_TOC, ctx),
-			getMarkdownExtensions(ctx))
+			extensions)
 	}
 	return blackfriday.Markdown(ctx.Content, c.getHTMLRenderer(0, ctx),
-		getMarkdownExtensions(ctx))
+		extensions)
 }
 
 // getMmarkHTMLRenderer creates a new mmark HTML Renderer with the given configuration.
@@ -495,6 +594,57 @@ func (c ContentSpec) RenderBytes(ctx *RenderingContext) []byte {
 	}
 }
 
+// TotalCharacters counts the characters in s, not counting whitespace.
+func TotalCharacters(s string) int {
+	n := 0
+	for _, r := range s {
+		if !unicode.IsSpace(r) {
+			n++
+		}
+	}
+	return n
+}
+
+// codeBlockTagRe matches the opening and closing div and pre tags in the
+// rendered content.
+var codeBlockTagRe = regexp.MustCompile(`(?i)<(/?)(div|pre)\b([^>]*)>`)
+
+// highlightClassRe matches the class of the div Chroma wraps the highlighted
+// code blocks in.
+var highlightClassRe = regexp.MustCompile(`class\s*=\s*["']?highlight\b`)
+
+// TotalCodeBlocks counts the code blocks in the rendered content in s. A
+// block highlighted by Chroma counts once, even with lineNos=table, where
+// it is rendered as two pre elements.
+func TotalCodeBlocks(s string) int {
+	n := 0
+	// The div depth, and the depth of the highlight wrapper we are in, if any.
+	depth, highlightDepth := 0, -1
+
+	for _, m := range codeBlockTagRe.FindAllStringSubmatch(s, -1) {
+		closing, tag := m[1] != "", strings.ToLower(m[2])
+		switch {
+		case tag == "pre":
+			if !closing && highlightDepth == -1 {
+				n++
+			}
+		case closing:
+			depth--
+			if depth <= highlightDepth {
+				highlightDepth = -1
+			}
+		default:
+			if highlightDepth == -1 && highlightClassRe.MatchString(m[3]) {
+				highlightDepth = depth
+				n++
+			}
+			depth++
+		}
+	}
+
+	return n
+}
+
 // TotalWords counts instance of one or more consecutive white space
 // characters, as defined by unicode.IsSpace, in s.
 // This is a cheaper way of word counting than the obvious len(strings.Fields(s)).
@@ -580,6 +730,111 @@ func (c *ContentSpec) TruncateWordsToWholeSentence(s string) (string, bool) {
 	return strings.TrimSpace(s[:endIndex]), endIndex < len(s)
 }
 
+// SummaryLengthUnit returns the unit the summary length is measured in,
+// one of words, characters or paragraphs.
+func (c *ContentSpec) SummaryLengthUnit() string {
+	return c.summaryLengthUnit
+}
+
+// ReadingTime returns the estimated time in minutes to read the given
+// number of words, rounded up, using the configured wordsPerMinute.
+func (c *ContentSpec) ReadingTime(wordCount int, isCJKLanguage bool) int {
+	wpm := c.wordsPerMinute
+	if wpm == 0 {
+		if isCJKLanguage {
+			wpm = 501
+		} else {
+			wpm = 213
+		}
+	}
+	return (wordCount + wpm - 1) / wpm
+}
+
+// TruncateCharacters truncates s to the configured summary length
+// measured in characters. Unless isCJKLanguage is set, we will try to
+// avoid splitting words. It also returns whether it is truncated.
+func (c *ContentSpec) TruncateCharacters(s string, isCJKLanguage bool) (string, bool) {
+	s = strings.TrimSpace(s)
+
+	count := 0
+	endIndex := -1
+	lastSpaceIndex := -1
+
+	for i, r := range s {
+		if count >= c.summaryLength {
+			endIndex = i
+			break
+		}
+		if unicode.IsSpace(r) {
+			lastSpaceIndex = i
+		}
+		count++
+	}
+
+	if endIndex == -1 {
+		return s, false
+	}
+
+	if !isCJKLanguage && lastSpaceIndex != -1 {
+		if r, _ := utf8.DecodeRuneInString(s[endIndex:]); !unicode.IsSpace(r) {
+			// Do not split the last word.
+			endIndex = lastSpaceIndex
+		}
+	}
+
+	return strings.TrimSpace(s[:endIndex]), true
+}
+
+// TruncateParagraphs truncates the given HTML after the configured summary
+// length measured in paragraphs. Paragraphs inside container elements such as
+// lists and block quotes are not counted, so we never cut inside them.
+// It also returns whether it is truncated.
+func (c *ContentSpec) TruncateParagraphs(html string) (string, bool) {
+	if c.summaryLength <= 0 {
+		return "", strings.TrimSpace(html) != ""
+	}
+
+	count := 0
+	depth := 0
+
+	for i := 0; i < len(html); i++ {
+		if html[i] != '<' {
+			continue
+		}
+		rest := html[i:]
+		if strings.HasPrefix(rest, "</p>") && depth == 0 {
+			count++
+			if count >= c.summaryLength {
+				end := i + len("</p>")
+				return html[:end], strings.TrimSpace(html[end:]) != ""
+			}
+			continue
+		}
+		for _, tag := range paragraphContainers {
+			if hasTagPrefix(rest[1:], tag) {
+				depth++
+				break
+			}
+			if len(rest) > 2 && rest[1] == '/' && hasTagPrefix(rest[2:], tag) {
+				depth--
+				break
+			}
+		}
+	}
+
+	return html, false
+}
+
+var paragraphContainers = []string{"blockquote", "ul", "ol", "dl", "table", "div", "figure", "details", "aside", "section"}
+
+func hasTagPrefix(s, tag string) bool {
+	if !strings.HasPrefix(s, tag) || len(s) == len(tag) {
+		return false
+	}
+	c := s[len(tag)]
+	return c == '>' || c == ' ' || c == '\n' || c == '\t'
+}
+
 // TrimShortHTML removes the <p>/</p> tags from HTML input in the situation
 // where said tags are the only <p> tags in the input and enclose the content
 // of the input (whitespace excluded).
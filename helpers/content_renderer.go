@@ -15,6 +15,7 @@ package helpers
 
 import (
 	"bytes"
+	"html"
 	"strings"
 
 	"github.com/gohugoio/hugo/config"
@@ -28,6 +29,36 @@ type HugoHTMLRenderer struct {
 	cs *ContentSpec
 	*RenderingContext
 	blackfriday.Renderer
+
+	// Whether to create the heading IDs not set with {#id}.
+	autoHeadingIDs bool
+}
+
+// Header renders a heading. With the ASCII heading ID type, the automatic
+// heading IDs are created here from the heading text instead of by
+// Blackfriday, so the IDs set with {#id} are kept as is.
+func (r *HugoHTMLRenderer) Header(out *bytes.Buffer, text func() bool, level int, id string) {
+	if id != "" || !r.autoHeadingIDs || r.Config.HeadingIDType != HeadingIDTypeASCII {
+		r.Renderer.Header(out, text, level, id)
+		return
+	}
+
+	marker := out.Len()
+	if !text() {
+		out.Truncate(marker)
+		return
+	}
+	content := append([]byte(nil), out.Bytes()[marker:]...)
+	out.Truncate(marker)
+
+	// Blackfriday makes the IDs unique, e.g. "title" and "title-1" for
+	// "日本語 Title" and "Title".
+	id = SanitizeAnchorName(html.UnescapeString(StripHTML(string(content))), HeadingIDTypeASCII)
+
+	r.Renderer.Header(out, func() bool {
+		out.Write(content)
+		return true
+	}, level, id)
 }
 
 // BlockCode renders a given text as a block of code.
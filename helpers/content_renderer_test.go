@@ -139,3 +139,41 @@ END`, true, `<ul class="task-list">
 		}
 	}
 }
+
+func TestBlackfridayHeadingIDType(t *testing.T) {
+	c := qt.New(t)
+	spec := newTestContentSpec()
+
+	markdown := `# Crème Brûlée
+
+## 日本語 Title
+
+## 日本語
+
+## Title
+
+## Custom {#my--id}
+
+## Custom {#café}
+
+## Link to [Hugo](https://gohugo.io) &amp; *More*
+`
+
+	for _, test := range []struct {
+		headingIDType string
+		expect        []string
+	}{
+		{HeadingIDTypeUnicode, []string{`<h1 id="crème-brûlée">`, `<h2 id="日本語-title">`, `<h2 id="日本語">`, `<h2 id="title">`, `<h2 id="my--id">`, `<h2 id="café">`}},
+		{HeadingIDTypeASCII, []string{`<h1 id="creme-brulee">`, `<h2 id="title">`, `<h2 id="日本語">`, `<h2 id="title-1">Title</h2>`, `<h2 id="my--id">`, `<h2 id="café">`, `<h2 id="link-to-hugo-more">Link to <a href="https://gohugo.io">Hugo</a> &amp; <em>More</em></h2>`}},
+	} {
+		bf := *spec.BlackFriday
+		bf.HeadingIDType = test.headingIDType
+		ctx := &RenderingContext{Content: []byte(markdown), PageFmt: "markdown", Config: &bf, Cfg: spec.Cfg}
+
+		result := string(spec.RenderBytes(ctx))
+
+		for _, expect := range test.expect {
+			c.Assert(result, qt.Contains, expect)
+		}
+	}
+}
@@ -118,6 +118,58 @@ func TestNewContentSpec(t *testing.T) {
 
 }
 
+func TestNewContentSpecInvalidHeadingIDType(t *testing.T) {
+	cfg := viper.New()
+	c := qt.New(t)
+
+	cfg.Set("blackfriday", map[string]interface{}{"headingIDType": "emoji"})
+
+	_, err := NewContentSpec(cfg)
+	c.Assert(err, qt.Not(qt.IsNil))
+}
+
+func TestContentSpecReadingTime(t *testing.T) {
+	c := qt.New(t)
+
+	spec, err := NewContentSpec(viper.New())
+	c.Assert(err, qt.IsNil)
+	c.Assert(spec.ReadingTime(0, false), qt.Equals, 0)
+	c.Assert(spec.ReadingTime(213, false), qt.Equals, 1)
+	c.Assert(spec.ReadingTime(214, false), qt.Equals, 2)
+	c.Assert(spec.ReadingTime(501, true), qt.Equals, 1)
+	c.Assert(spec.ReadingTime(502, true), qt.Equals, 2)
+
+	cfg := viper.New()
+	cfg.Set("wordsPerMinute", 100)
+	spec, err = NewContentSpec(cfg)
+	c.Assert(err, qt.IsNil)
+	c.Assert(spec.ReadingTime(100, false), qt.Equals, 1)
+	c.Assert(spec.ReadingTime(101, true), qt.Equals, 2)
+
+	cfg.Set("wordsPerMinute", -1)
+	_, err = NewContentSpec(cfg)
+	c.Assert(err, qt.Not(qt.IsNil))
+}
+
+func TestSanitizeAnchorName(t *testing.T) {
+	c := qt.New(t)
+
+	for _, test := range []struct {
+		in      string
+		unicode string
+		ascii   string
+	}{
+		{"Hello World", "hello-world", "hello-world"},
+		{"Crème Brûlée", "crème-brûlée", "creme-brulee"},
+		{"Ελληνικά και English", "ελληνικά-και-english", "english"},
+		{"a日b", "a日b", "a-b"},
+		{"日本語", "日本語", "日本語"},
+	} {
+		c.Assert(SanitizeAnchorName(test.in, HeadingIDTypeUnicode), qt.Equals, test.unicode)
+		c.Assert(SanitizeAnchorName(test.in, HeadingIDTypeASCII), qt.Equals, test.ascii)
+	}
+}
+
 var benchmarkTruncateString = strings.Repeat("This is a sentence about nothing.", 20)
 
 func BenchmarkTestTruncateWordsToWholeSentence(b *testing.B) {
@@ -202,6 +254,66 @@ func TestTruncateWordsByRune(t *testing.T) {
 	}
 }
 
+func TestTruncateCharacters(t *testing.T) {
+	c := newTestContentSpec()
+	type test struct {
+		input, expected string
+		max             int
+		cjk             bool
+		truncated       bool
+	}
+	data := []test{
+		{"", "", 10, false, false},
+		{"a b c", "a b c", 5, false, false},
+		{"This is a sentence.", "This is a", 12, false, true},
+		{"This is a sentence.", "This is", 8, false, true},
+		{"Supercalifragilistic", "Super", 5, false, true},
+		{" \nThis is a sentence\n ", "This is a", 10, false, true},
+		{"这是中文，全中文。", "这是中文，", 5, true, true},
+		{"这是中文", "这是中文", 5, true, false},
+	}
+	for i, d := range data {
+		c.summaryLength = d.max
+		output, truncated := c.TruncateCharacters(d.input, d.cjk)
+		if d.expected != output {
+			t.Errorf("Test %d failed. Expected %q got %q", i, d.expected, output)
+		}
+
+		if d.truncated != truncated {
+			t.Errorf("Test %d failed. Expected truncated=%t got %t", i, d.truncated, truncated)
+		}
+	}
+}
+
+func TestTruncateParagraphs(t *testing.T) {
+	c := newTestContentSpec()
+	type test struct {
+		input, expected string
+		max             int
+		truncated       bool
+	}
+	data := []test{
+		{"", "", 1, false},
+		{"<p>a</p>", "<p>a</p>", 1, false},
+		{"<p>a</p>\n<p>b</p>", "<p>a</p>", 1, true},
+		{"<p>a</p>\n<p>b</p>\n", "<p>a</p>\n<p>b</p>", 2, false},
+		{"<h2>Title</h2>\n<p class=\"x\">a</p><p>b</p>", "<h2>Title</h2>\n<p class=\"x\">a</p>", 1, true},
+		{"<blockquote>\n<p>a</p>\n<p>b</p>\n</blockquote>\n<p>c</p><p>d</p>", "<blockquote>\n<p>a</p>\n<p>b</p>\n</blockquote>\n<p>c</p>", 1, true},
+		{"<ul><li><p>a</p></li></ul>", "<ul><li><p>a</p></li></ul>", 1, false},
+	}
+	for i, d := range data {
+		c.summaryLength = d.max
+		output, truncated := c.TruncateParagraphs(d.input)
+		if d.expected != output {
+			t.Errorf("Test %d failed. Expected %q got %q", i, d.expected, output)
+		}
+
+		if d.truncated != truncated {
+			t.Errorf("Test %d failed. Expected truncated=%t got %t", i, d.truncated, truncated)
+		}
+	}
+}
+
 func TestGetHTMLRendererFlags(t *testing.T) {
 	c := newTestContentSpec()
 	ctx := &RenderingContext{Cfg: c.Cfg, Config: c.BlackFriday}
@@ -498,6 +610,34 @@ func TestTotalWords(t *testing.T) {
 	}
 }
 
+func TestTotalCharacters(t *testing.T) {
+	c := qt.New(t)
+
+	c.Assert(TotalCharacters(""), qt.Equals, 0)
+	c.Assert(TotalCharacters("Two, Words!"), qt.Equals, 10)
+	c.Assert(TotalCharacters(" 好 means\tgood.\n"), qt.Equals, 11)
+}
+
+func TestTotalCodeBlocks(t *testing.T) {
+	c := qt.New(t)
+
+	c.Assert(TotalCodeBlocks(""), qt.Equals, 0)
+	c.Assert(TotalCodeBlocks(`<p>Some <code>code</code>.</p><pre><code class="language-go">a</code></pre>`), qt.Equals, 1)
+	c.Assert(TotalCodeBlocks(`<div class="highlight"><pre class="chroma"><code>a</code></pre></div><PRE>b</PRE>`), qt.Equals, 2)
+
+	// lineNos=table
+	table := `<div class="highlight"><div class="chroma">
+<table class="lntable"><tr><td class="lntd">
+<pre class="chroma"><code><span class="lnt">1
+</span></code></pre></td>
+<td class="lntd">
+<pre class="chroma"><code class="language-go" data-lang="go">a
+</code></pre></td></tr></table>
+</div>
+</div>`
+	c.Assert(TotalCodeBlocks(table+table+"<div><pre>c</pre></div>"), qt.Equals, 3)
+}
+
 func BenchmarkTotalWords(b *testing.B) {
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
@@ -299,7 +299,18 @@ func (l *DistinctLogger) Printf(format string, v ...interface{}) {
 	l.print(logStatement)
 }
 
+// PrintfTo is like Printf, but logs to the given logger. The statements
+// logged to all loggers are checked for duplicates.
+func (l *DistinctLogger) PrintfTo(logger LogPrinter, format string, v ...interface{}) {
+	logStatement := fmt.Sprintf(format, v...)
+	l.printTo(logger, logStatement)
+}
+
 func (l *DistinctLogger) print(logStatement string) {
+	l.printTo(l.logger, logStatement)
+}
+
+func (l *DistinctLogger) printTo(logger LogPrinter, logStatement string) {
 	l.RLock()
 	if l.m[logStatement] {
 		l.RUnlock()
@@ -309,12 +320,19 @@ func (l *DistinctLogger) print(logStatement string) {
 
 	l.Lock()
 	if !l.m[logStatement] {
-		l.logger.Println(logStatement)
+		logger.Println(logStatement)
 		l.m[logStatement] = true
 	}
 	l.Unlock()
 }
 
+// Reset forgets the statements logged so far, so they will be logged again.
+func (l *DistinctLogger) Reset() {
+	l.Lock()
+	l.m = make(map[string]bool)
+	l.Unlock()
+}
+
 // NewDistinctErrorLogger creates a new DistinctLogger that logs ERRORs
 func NewDistinctErrorLogger() *DistinctLogger {
 	return &DistinctLogger{m: make(map[string]bool), logger: jww.ERROR}
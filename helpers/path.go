@@ -157,9 +157,7 @@ func (p *PathSpec) UnicodeSanitize(s string) string {
 	var result string
 
 	if p.RemovePathAccents {
-		// remove accents - see https://blog.golang.org/normalization
-		t := transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
-		result, _, _ = transform.String(t, string(target))
+		result = RemoveAccentsString(string(target))
 	} else {
 		result = string(target)
 	}
@@ -167,6 +165,15 @@ func (p *PathSpec) UnicodeSanitize(s string) string {
 	return result
 }
 
+// RemoveAccentsString removes the accents from the characters in s, e.g.
+// "é" becomes "e".
+func RemoveAccentsString(s string) string {
+	// remove accents - see https://blog.golang.org/normalization
+	t := transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+	result, _, _ := transform.String(t, s)
+	return result
+}
+
 // ReplaceExtension takes a path and an extension, strips the old extension
 // and returns the path with the new extension.
 func ReplaceExtension(path string, newExt string) string {
@@ -30,3 +30,32 @@ func Test404(t *testing.T) {
 	b.AssertFileContent("public/404.html", "Not Found")
 
 }
+
+func Test404Multilingual(t *testing.T) {
+	t.Parallel()
+
+	b := newTestSitesBuilder(t)
+	b.WithConfigFile("toml", `
+baseURL = "http://example.com/"
+defaultContentLanguage = "en"
+notFoundFilename = "not_found.html"
+
+[languages]
+[languages.en]
+weight = 1
+[languages.fr]
+weight = 2
+[languages.de]
+weight = 3
+notFoundFilename = "404.html"
+`)
+	b.WithTemplatesAdded(
+		"404.html", "<html><body>Not Found: {{ .Lang }}</body></html>",
+		"404.fr.html", "<html><body>Introuvable: {{ .Lang }}</body></html>",
+	)
+	b.Build(BuildCfg{})
+
+	b.AssertFileContent("public/not_found.html", "Not Found: en")
+	b.AssertFileContent("public/fr/not_found.html", "Introuvable: fr")
+	b.AssertFileContent("public/de/404.html", "Not Found: de")
+}
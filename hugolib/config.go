@@ -38,6 +38,7 @@ import (
 	"github.com/gohugoio/hugo/config/services"
 	"github.com/gohugoio/hugo/helpers"
 	"github.com/spf13/afero"
+	"github.com/spf13/cast"
 	"github.com/spf13/viper"
 )
 
@@ -431,13 +432,19 @@ func (l configLoader) collectModules(modConfig modules.Config, v1 *viper.Viper,
 
 	var configFilenames []string
 
+	// The project's _merge settings apply to all themes.
+	mergeStrategies, err := l.takeMergeStrategies(v1)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	hook := func(m *modules.ModulesConfig) error {
 		for _, tc := range m.ActiveModules {
 			if tc.ConfigFilename() != "" {
 				if tc.Watch() {
 					configFilenames = append(configFilenames, tc.ConfigFilename())
 				}
-				if err := l.applyThemeConfig(v1, tc); err != nil {
+				if err := l.applyThemeConfig(v1, tc, mergeStrategies); err != nil {
 					return err
 				}
 			}
@@ -482,7 +489,30 @@ func (l configLoader) collectModules(modConfig modules.Config, v1 *viper.Viper,
 
 }
 
-func (l configLoader) applyThemeConfig(v1 *viper.Viper, theme modules.Module) error {
+const (
+	// mergeStrategyKey can be set in a config section, e.g. params, in the
+	// project or in a theme to control how the theme's section is merged into
+	// the project's. The project's setting wins.
+	mergeStrategyKey = "_merge"
+
+	// Do not merge the theme's section.
+	mergeStrategyNone = "none"
+	// Add the top level keys in the theme's section missing in the project.
+	mergeStrategyShallow = "shallow"
+	// Add the keys in the theme's section missing in the project at any depth.
+	mergeStrategyDeep = "deep"
+)
+
+// The config sections merged from themes, with their default merge strategy.
+var themeConfigMergeStrategies = map[string]string{
+	"params":        mergeStrategyShallow,
+	"outputformats": mergeStrategyShallow,
+	"mediatypes":    mergeStrategyShallow,
+	"menus":         mergeStrategyShallow,
+	"blackfriday":   mergeStrategyNone,
+}
+
+func (l configLoader) applyThemeConfig(v1 *viper.Viper, theme modules.Module, projectStrategies map[string]string) error {
 
 	const (
 		paramsKey    = "params"
@@ -491,9 +521,22 @@ func (l configLoader) applyThemeConfig(v1 *viper.Viper, theme modules.Module) er
 	)
 
 	v2 := theme.Cfg()
+	themeStrategies, err := l.takeMergeStrategies(v2)
+	if err != nil {
+		return errors.Wrapf(err, "theme %q", theme.Path())
+	}
 
-	for _, key := range []string{paramsKey, "outputformats", "mediatypes"} {
-		l.mergeStringMapKeepLeft("", key, v1, v2)
+	for _, key := range []string{paramsKey, "outputformats", "mediatypes", "blackfriday"} {
+		strategy, err := mergeStrategy(key, projectStrategies, themeStrategies)
+		if err != nil {
+			return errors.Wrapf(err, "theme %q", theme.Path())
+		}
+		switch strategy {
+		case mergeStrategyShallow:
+			l.mergeStringMapKeepLeft("", key, v1, v2)
+		case mergeStrategyDeep:
+			l.mergeStringMapDeepKeepLeft(key, v1, v2)
+		}
 	}
 
 	// Only add params and new menu entries, we do not add language definitions.
@@ -524,13 +567,21 @@ func (l configLoader) applyThemeConfig(v1 *viper.Viper, theme modules.Module) er
 		}
 	}
 
+	menuStrategy, err := mergeStrategy(menuKey, projectStrategies, themeStrategies)
+	if err != nil {
+		return errors.Wrapf(err, "theme %q", theme.Path())
+	}
+
 	// Add menu definitions from theme not found in project
-	if v2.IsSet(menuKey) {
+	if menuStrategy != mergeStrategyNone && v2.IsSet(menuKey) {
 		v2menus := v2.GetStringMap(menuKey)
 		for k, v := range v2menus {
 			menuEntry := menuKey + "." + k
 			if !v1.IsSet(menuEntry) {
 				v1.SetDefault(menuEntry, v)
+			} else if menuStrategy == mergeStrategyDeep {
+				// Add the theme's entries missing in the project's menu.
+				v1.Set(menuEntry, mergeMenuEntries(v1.Get(menuEntry), v))
 			}
 		}
 	}
@@ -539,6 +590,62 @@ func (l configLoader) applyThemeConfig(v1 *viper.Viper, theme modules.Module) er
 
 }
 
+// mergeStrategy returns the merge strategy for the config section key, set
+// with _merge in the project or the theme, or the default.
+func mergeStrategy(key string, projectStrategies, themeStrategies map[string]string) (string, error) {
+	strategy := themeConfigMergeStrategies[key]
+	for _, strategies := range []map[string]string{themeStrategies, projectStrategies} {
+		if s, found := strategies[key]; found {
+			strategy = s
+		}
+	}
+
+	switch strategy {
+	case mergeStrategyNone, mergeStrategyShallow, mergeStrategyDeep:
+		return strategy, nil
+	default:
+		return "", errors.Errorf("invalid %s %q in %q, must be one of %q, %q or %q", mergeStrategyKey, strategy, key, mergeStrategyNone, mergeStrategyShallow, mergeStrategyDeep)
+	}
+}
+
+// takeMergeStrategies returns the _merge settings in the config sections
+// merged from themes and removes them from cfg. The language sections are
+// always merged the same way, so _merge is not allowed in these.
+func (configLoader) takeMergeStrategies(cfg config.Provider) (map[string]string, error) {
+	strategies := make(map[string]string)
+	for key := range themeConfigMergeStrategies {
+		if !cfg.IsSet(key) {
+			continue
+		}
+		section := cfg.Get(key)
+		m := maps.ToStringMap(section)
+		if v, found := m[mergeStrategyKey]; found {
+			strategies[key] = strings.ToLower(cast.ToString(v))
+			delete(m, mergeStrategyKey)
+			if _, ok := section.(map[string]interface{}); !ok {
+				// A map[interface{}]interface{}, e.g. from YAML, is copied.
+				cfg.Set(key, m)
+			}
+		}
+	}
+
+	if cfg.IsSet("languages") {
+		for lang := range cfg.GetStringMap("languages") {
+			for _, key := range []string{"params", "menus"} {
+				langKey := "languages." + lang + "." + key
+				if !cfg.IsSet(langKey) {
+					continue
+				}
+				if _, found := maps.ToStringMap(cfg.Get(langKey))[mergeStrategyKey]; found {
+					return nil, errors.Errorf("%s is not supported in %q, set it in %q", mergeStrategyKey, langKey, key)
+				}
+			}
+		}
+	}
+
+	return strategies, nil
+}
+
 func (configLoader) mergeStringMapKeepLeft(rootKey, key string, v1, v2 config.Provider) {
 	if !v2.IsSet(key) {
 		return
@@ -562,6 +669,74 @@ func (configLoader) mergeStringMapKeepLeft(rootKey, key string, v1, v2 config.Pr
 	}
 }
 
+func (configLoader) mergeStringMapDeepKeepLeft(key string, v1, v2 config.Provider) {
+	if !v2.IsSet(key) {
+		return
+	}
+
+	if !v1.IsSet(key) {
+		v1.Set(key, make(map[string]interface{}))
+	}
+
+	mergeMapsDeepKeepLeft(v1.GetStringMap(key), v2.GetStringMap(key))
+}
+
+// mergeMapsDeepKeepLeft adds the keys in m2 missing in m1, recursing into
+// nested maps. Values already in m1 are kept.
+func mergeMapsDeepKeepLeft(m1, m2 map[string]interface{}) {
+	for k, v2 := range m2 {
+		v1, found := m1[k]
+		if !found {
+			if m, ok := v2.(map[string]interface{}); ok {
+				// Copy it so the theme's config is left untouched.
+				mm := make(map[string]interface{})
+				mergeMapsDeepKeepLeft(mm, m)
+				v2 = mm
+			}
+			m1[k] = v2
+			continue
+		}
+
+		mm1, ok1 := v1.(map[string]interface{})
+		mm2, ok2 := v2.(map[string]interface{})
+		if ok1 && ok2 {
+			mergeMapsDeepKeepLeft(mm1, mm2)
+		}
+	}
+}
+
+// mergeMenuEntries appends the menu entries in theme not in project,
+// matched by identifier or name.
+func mergeMenuEntries(project, theme interface{}) interface{} {
+	entries1, err1 := cast.ToSliceE(project)
+	entries2, err2 := cast.ToSliceE(theme)
+	if err1 != nil || err2 != nil {
+		return project
+	}
+
+	menuEntryKey := func(entry interface{}) string {
+		m := cast.ToStringMap(entry)
+		if id := cast.ToString(m["identifier"]); id != "" {
+			return id
+		}
+		return cast.ToString(m["name"])
+	}
+
+	seen := make(map[string]bool)
+	for _, entry := range entries1 {
+		seen[menuEntryKey(entry)] = true
+	}
+
+	merged := append([]interface{}{}, entries1...)
+	for _, entry := range entries2 {
+		if !seen[menuEntryKey(entry)] {
+			merged = append(merged, entry)
+		}
+	}
+
+	return merged
+}
+
 func loadDefaultSettingsFor(v *viper.Viper) error {
 
 	c, err := helpers.NewContentSpec(v)
@@ -602,7 +777,8 @@ func loadDefaultSettingsFor(v *viper.Viper) error {
 	v.SetDefault("titleCaseStyle", "AP")
 	v.SetDefault("taxonomies", map[string]string{"tag": "tags", "category": "categories"})
 	v.SetDefault("permalinks", make(map[string]string))
-	v.SetDefault("sitemap", config.Sitemap{Priority: -1, Filename: "sitemap.xml"})
+	v.SetDefault("sitemap", config.Sitemap{Priority: -1, Filename: "sitemap.xml", MaxURLs: sitemapMaxURLs})
+	v.SetDefault("notFoundFilename", "404.html")
 	v.SetDefault("pygmentsStyle", "monokai")
 	v.SetDefault("pygmentsUseClasses", false)
 	v.SetDefault("pygmentsCodeFences", false)
@@ -617,6 +793,7 @@ func loadDefaultSettingsFor(v *viper.Viper) error {
 	v.SetDefault("paginate", 10)
 	v.SetDefault("paginatePath", "page")
 	v.SetDefault("summaryLength", 70)
+	v.SetDefault("summaryLengthUnit", "words")
 	v.SetDefault("blackfriday", c.BlackFriday)
 	v.SetDefault("rssLimit", -1)
 	v.SetDefault("sectionPagesMenu", "")
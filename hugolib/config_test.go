@@ -362,6 +362,152 @@ map[string]interface {}{
 
 }
 
+func TestLoadConfigFromThemeMergeStrategy(t *testing.T) {
+	t.Parallel()
+
+	c := qt.New(t)
+
+	mainConfig := `
+theme = "test-theme"
+baseURL = "https://example.com/"
+
+[params]
+_merge = "deep"
+p1 = "p1 main"
+[params.colors]
+primary = "red"
+
+[blackfriday]
+_merge = "deep"
+angledQuotes = true
+
+[mediaTypes]
+_merge = "none"
+
+[[menus.main]]
+name = "Home"
+identifier = "home"
+[menus]
+_merge = "deep"
+`
+
+	themeConfig := `
+[params]
+p1 = "p1 theme"
+p2 = "p2 theme"
+[params.colors]
+primary = "blue"
+secondary = "green"
+
+[blackfriday]
+hrefTargetBlank = true
+angledQuotes = false
+
+[mediaTypes]
+[mediaTypes."text/m1"]
+suffixes = ["m1theme"]
+
+[[menus.main]]
+name = "Home Theme"
+identifier = "home"
+[[menus.main]]
+name = "About"
+identifier = "about"
+`
+
+	b := newTestSitesBuilder(t)
+	b.WithConfigFile("toml", mainConfig).WithThemeConfigFile("toml", themeConfig)
+	b.CreateSites().Build(BuildCfg{})
+
+	got := b.Cfg.(*viper.Viper).AllSettings()
+
+	b.AssertObject(`map[string]interface {}{
+  "colors": map[string]interface {}{
+    "primary": "red",
+    "secondary": "green",
+  },
+  "p1": "p1 main",
+  "p2": "p2 theme",
+}`, got["params"])
+
+	b.AssertObject(`map[string]interface {}{
+  "angledquotes": true,
+  "hreftargetblank": true,
+}`, got["blackfriday"])
+
+	c.Assert(got["mediatypes"], qt.IsNil)
+
+	b.AssertObject(`map[string]interface {}{
+  "main": []interface {}{
+    map[string]interface {}{
+      "identifier": "home",
+      "name": "Home",
+    },
+    map[string]interface {}{
+      "identifier": "about",
+      "name": "About",
+    },
+  },
+}`, got["menus"])
+
+	// The default strategy for params is shallow.
+	b = newTestSitesBuilder(t)
+	b.WithConfigFile("toml", `
+theme = "test-theme"
+baseURL = "https://example.com/"
+[params]
+[params.colors]
+primary = "red"
+`).WithThemeConfigFile("toml", themeConfig)
+	b.CreateSites().Build(BuildCfg{})
+
+	got = b.Cfg.(*viper.Viper).AllSettings()
+	b.AssertObject(`map[string]interface {}{
+  "colors": map[string]interface {}{
+    "primary": "red",
+  },
+  "p1": "p1 theme",
+  "p2": "p2 theme",
+}`, got["params"])
+
+	b = newTestSitesBuilder(t)
+	b.WithConfigFile("toml", `
+theme = "test-theme"
+baseURL = "https://example.com/"
+[params]
+_merge = "deepest"
+`).WithThemeConfigFile("toml", themeConfig)
+	err := b.CreateSitesE()
+	c.Assert(err, qt.Not(qt.IsNil))
+	c.Assert(err.Error(), qt.Contains, `invalid _merge "deepest" in "params"`)
+}
+
+func TestTakeMergeStrategies(t *testing.T) {
+	t.Parallel()
+
+	c := qt.New(t)
+
+	// As decoded from YAML.
+	v := viper.New()
+	v.Set("params", map[interface{}]interface{}{"_merge": "Deep", "p1": "p1"})
+
+	strategies, err := configLoader{}.takeMergeStrategies(v)
+	c.Assert(err, qt.IsNil)
+	c.Assert(strategies, qt.DeepEquals, map[string]string{"params": "deep"})
+	c.Assert(v.GetStringMap("params"), qt.DeepEquals, map[string]interface{}{"p1": "p1"})
+
+	v = viper.New()
+	v.Set("languages", map[string]interface{}{
+		"en": map[string]interface{}{
+			"params": map[string]interface{}{"_merge": "deep"},
+		},
+	})
+
+	_, err = configLoader{}.takeMergeStrategies(v)
+	c.Assert(err, qt.Not(qt.IsNil))
+	c.Assert(err.Error(), qt.Contains, `_merge is not supported in "languages.en.params"`)
+}
+
 func TestPrivacyConfig(t *testing.T) {
 	t.Parallel()
 
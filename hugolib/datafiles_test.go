@@ -30,10 +30,11 @@ import (
 
 func TestDataDir(t *testing.T) {
 	t.Parallel()
-	equivDataDirs := make([]dataDir, 3)
+	equivDataDirs := make([]dataDir, 4)
 	equivDataDirs[0].addSource("data/test/a.json", `{ "b" : { "c1": "red" , "c2": "blue" } }`)
 	equivDataDirs[1].addSource("data/test/a.yaml", "b:\n  c1: red\n  c2: blue")
 	equivDataDirs[2].addSource("data/test/a.toml", "[b]\nc1 = \"red\"\nc2 = \"blue\"\n")
+	equivDataDirs[3].addSource("data/test/a.xml", "<a><b><c1>red</c1><c2>blue</c2></b></a>")
 	expected := map[string]interface{}{
 		"test": map[string]interface{}{
 			"a": map[string]interface{}{
@@ -47,6 +48,22 @@ func TestDataDir(t *testing.T) {
 	doTestEquivalentDataDirs(t, equivDataDirs, expected)
 }
 
+func TestDataDirCSV(t *testing.T) {
+	t.Parallel()
+	var dd dataDir
+	dd.addSource("data/test/players.csv", "name,instrument\nBrecker,saxophone\nBlake,piano\n")
+	dd.addSource("data/test/empty.csv", "")
+	doTestDataDir(t, dd, map[string]interface{}{
+		"test": map[string]interface{}{
+			"players": []interface{}{
+				map[string]interface{}{"name": "Brecker", "instrument": "saxophone"},
+				map[string]interface{}{"name": "Blake", "instrument": "piano"},
+			},
+			"empty": []interface{}{},
+		},
+	})
+}
+
 // Unable to enforce equivalency for int values as
 // the JSON, YAML and TOML parsers return
 // float64, int, int64 respectively. They all return
@@ -211,7 +228,7 @@ func TestDataDirMultipleSourcesCommingled(t *testing.T) {
 	dd.addSource("themes/mytheme/data/a/b1.json", `{ "c1": "mytheme/data/a/b1", "c2": "mytheme/data/a/b1" }`)
 	dd.addSource("data/a/b1.json", `{ "c1": "data/a/b1" }`)
 
-	// Per handleDataFile() comment:
+	// Per mergeData() comment:
 	// 1. A theme uses the same key; the main data folder wins
 	// 2. A sub folder uses the same key: the sub folder wins
 	expected :=
@@ -229,6 +246,35 @@ func TestDataDirMultipleSourcesCommingled(t *testing.T) {
 	doTestDataDir(t, dd, expected, "theme", "mytheme")
 }
 
+func TestDataDirDeepMergedAcrossThemes(t *testing.T) {
+	t.Parallel()
+
+	var dd dataDir
+	dd.addSource("data/social.toml", "[icons]\ngithub = \"project-github.svg\"\n[icons.extra]\nmastodon = \"project-mastodon.svg\"\n")
+	dd.addSource("themes/mycomponent/data/social.toml", "[icons]\ngithub = \"component-github.svg\"\ntwitter = \"component-twitter.svg\"\n[icons.extra]\nmastodon = \"component-mastodon.svg\"\nrss = \"component-rss.svg\"\n")
+	dd.addSource("themes/mytheme/data/social.toml", "size = 24\n[icons]\ntwitter = \"theme-twitter.svg\"\nlinkedin = \"theme-linkedin.svg\"\n[icons.extra]\nrss = \"theme-rss.svg\"\n")
+
+	// The project wins over the theme components, and the theme
+	// components are merged in the order they are listed in theme.
+	expected :=
+		map[string]interface{}{
+			"social": map[string]interface{}{
+				"size": int64(24),
+				"icons": map[string]interface{}{
+					"github":   "project-github.svg",
+					"twitter":  "component-twitter.svg",
+					"linkedin": "theme-linkedin.svg",
+					"extra": map[string]interface{}{
+						"mastodon": "project-mastodon.svg",
+						"rss":      "component-rss.svg",
+					},
+				},
+			},
+		}
+
+	doTestDataDir(t, dd, expected, "theme", []string{"mycomponent", "mytheme"})
+}
+
 func TestDataDirCollidingChildArrays(t *testing.T) {
 	t.Parallel()
 
@@ -541,3 +541,69 @@ title: "My Page"
 
 	b.AssertFileContent("public/mypage/index.html", "Permalink: https://example.org/mypage/")
 }
+
+// Mount sources can live outside of the project, e.g. shared folders in a monorepo.
+func TestMountsOutsideProject(t *testing.T) {
+	c := qt.New(t)
+
+	config := `
+
+baseURL="https://example.org"
+workingDir="/my/project"
+
+[module]
+[[module.mounts]]
+source="content"
+target="content"
+[[module.mounts]]
+source="../shared/content"
+target="content/shared"
+[[module.mounts]]
+source="/common/layouts"
+target="layouts"
+
+`
+	b := newTestSitesBuilder(t).WithWorkingDir("/my/project").
+		WithConfigFile("toml", config).
+		WithContent("mypage.md", "---\ntitle: My Page\n---")
+
+	c.Assert(afero.WriteFile(b.Fs.Source, filepath.FromSlash("/my/shared/content/shared-page.md"), []byte("---\ntitle: Shared Page\n---"), 0755), qt.IsNil)
+	c.Assert(afero.WriteFile(b.Fs.Source, filepath.FromSlash("/common/layouts/_default/single.html"), []byte("Common: {{ .Title }}"), 0755), qt.IsNil)
+
+	b.Build(BuildCfg{})
+
+	b.AssertFileContent("public/mypage/index.html", "Common: My Page")
+	b.AssertFileContent("public/shared/shared-page/index.html", "Common: Shared Page")
+}
+
+func TestModulesOSFuncs(t *testing.T) {
+	b := newTestSitesBuilder(t).WithWorkingDir("/site").WithConfigFile("toml", `
+baseURL="https://example.org"
+
+workingDir="/site"
+
+[module]
+[[module.imports]]
+path="a"
+
+`)
+
+	b.WithSourceFile("files/project.txt", "Project")
+	b.WithSourceFile("files/shared.txt", "Shared Project")
+	b.WithSourceFile("themes/a/files/theme.txt", "Theme")
+	b.WithSourceFile("themes/a/files/shared.txt", "Shared Theme")
+
+	b.WithTemplatesAdded("index.html", `
+ReadDir: {{ range readDir "files" }}{{ .Name }}|{{ end }}
+ReadFile: {{ readFile "files/theme.txt" }}|{{ readFile "files/shared.txt" }}
+FileExists: {{ fileExists "files/theme.txt" }}|{{ fileExists "files/nope.txt" }}
+`)
+
+	b.Build(BuildCfg{})
+
+	b.AssertFileContent("public/index.html",
+		"ReadDir: project.txt|shared.txt|theme.txt|",
+		"ReadFile: Theme|Shared Project",
+		"FileExists: true|false",
+	)
+}
@@ -40,6 +40,7 @@ import (
 	"github.com/gohugoio/hugo/common/loggers"
 	"github.com/gohugoio/hugo/deps"
 	"github.com/gohugoio/hugo/helpers"
+	"github.com/gohugoio/hugo/hugolib/segments"
 	"github.com/gohugoio/hugo/langs"
 	"github.com/gohugoio/hugo/lazy"
 
@@ -67,6 +68,23 @@ type HugoSites struct {
 	// Render output formats for all sites.
 	renderFormats output.Formats
 
+	// The segments to render, set with renderSegments.
+	segments *segments.Segments
+
+	// Collects the HTML elements used in the site, set with build.writeStats.
+	htmlElementsCollector *publisher.HTMLElementsCollector
+
+	// Collects the links in the published HTML, set with build.checkLinks.
+	linksCollector *publisher.LinksCollector
+
+	// Tracks the list pages accessing the site wide page collections in
+	// server mode.
+	listPageDeps *listPageDeps
+
+	// Collects the pages skipped because of their publish or expiry date,
+	// set with build.writeSchedule.
+	scheduledPages *scheduledPages
+
 	*deps.Deps
 
 	gitInfo *gitInfo
@@ -260,12 +278,18 @@ func newHugoSites(cfg deps.DepsCfg, sites ...*Site) (*HugoSites, error) {
 		return nil, errors.Wrap(err, "failed to create language config")
 	}
 
+	segs, err := segments.DecodeSegments(cfg.Cfg)
+	if err != nil {
+		return nil, err
+	}
+
 	var contentChangeTracker *contentChangeMap
 
 	h := &HugoSites{
 		running:      cfg.Running,
 		multilingual: langConfig,
 		multihost:    cfg.Cfg.GetBool("multihost"),
+		segments:     segs,
 		Sites:        sites,
 		init: &hugoSitesInit{
 			data:         lazy.New(),
@@ -274,6 +298,22 @@ func newHugoSites(cfg deps.DepsCfg, sites ...*Site) (*HugoSites, error) {
 		},
 	}
 
+	if h.running {
+		h.listPageDeps = newListPageDeps()
+	}
+
+	if cfg.Cfg.GetBool("build.writeStats") {
+		h.htmlElementsCollector = publisher.NewHTMLElementsCollector()
+	}
+
+	if cfg.Cfg.GetBool("build.writeSchedule") {
+		h.scheduledPages = newScheduledPages()
+	}
+
+	if cfg.Cfg.GetBool("build.checkLinks") {
+		h.linksCollector = publisher.NewLinksCollector()
+	}
+
 	h.fatalErrorHandler = &fatalErrorHandler{
 		h:     h,
 		donec: make(chan bool),
@@ -363,14 +403,30 @@ func applyDeps(cfg deps.DepsCfg, sites ...*Site) error {
 		onCreated := func(d *deps.Deps) error {
 			s.Deps = d
 
+			var (
+				htmlElementsCollector *publisher.HTMLElementsCollector
+				linksCollector        *publisher.LinksCollector
+			)
+			if s.h != nil {
+				htmlElementsCollector = s.h.htmlElementsCollector
+				linksCollector = s.h.linksCollector
+			}
+
 			// Set up the main publishing chain.
-			s.publisher = publisher.NewDestinationPublisher(d.PathSpec.BaseFs.PublishFs, s.outputFormatsConfig, s.mediaTypesConfig, cfg.Cfg.GetBool("minify"))
+			pub, err := publisher.NewDestinationPublisher(d.PathSpec.BaseFs.PublishFs, s.outputFormatsConfig, s.mediaTypesConfig, cfg.Cfg, htmlElementsCollector, linksCollector)
+			if err != nil {
+				return err
+			}
+			s.publisher = pub
 
 			if err := s.initializeSiteInfo(); err != nil {
 				return err
 			}
 
 			d.Site = &s.Info
+			d.CurrentOutputFormat = s.currentOutputFormat
+			d.PartialCachedIncluded = s.Info.siteAccessed
+			d.ResourceSpec.CurrentOutputFormat = s.currentOutputFormat
 
 			siteConfig, err := loadSiteConfig(s.language)
 			if err != nil {
@@ -570,6 +626,9 @@ type BuildCfg struct {
 // shouldRender is used in the Fast Render Mode to determine if we need to re-render
 // a Page: If it is recently visited (the home pages will always be in this set) or changed.
 // Note that a page does not have to have a content page / file.
+// Outside of Fast Render Mode, rebuilds caused by content changes only re-render
+// the taxonomy term pages listing the changed pages or accessing the site wide
+// pages.
 // For regular builds, this will allways return true.
 // TODO(bep) rename/work this.
 func (cfg *BuildCfg) shouldRender(p *pageState) bool {
@@ -581,6 +640,10 @@ func (cfg *BuildCfg) shouldRender(p *pageState) bool {
 	}
 
 	if len(cfg.RecentlyVisited) == 0 {
+		if cfg.whatChanged != nil && cfg.whatChanged.listPages != nil && isMeasuredListPage(p) {
+			return cfg.whatChanged.listPages[listPageKey(p)] ||
+				p.s.h.listPageDeps.isSiteWide(listPageKey(p))
+		}
 		return true
 	}
 
@@ -595,6 +658,16 @@ func (cfg *BuildCfg) shouldRender(p *pageState) bool {
 	return false
 }
 
+// rendersAllPages reports whether all pages are rendered in this build, so
+// the state collected while rendering, e.g. the asset manifest, can be
+// collected from scratch.
+func (cfg *BuildCfg) rendersAllPages() bool {
+	if cfg.PartialReRender || len(cfg.RecentlyVisited) > 0 {
+		return false
+	}
+	return cfg.whatChanged == nil || cfg.whatChanged.listPages == nil
+}
+
 func (h *HugoSites) renderCrossSitesArtifacts() error {
 
 	if !h.multilingual.enabled() || h.IsMultihost() {
@@ -736,20 +809,9 @@ func (h *HugoSites) handleDataFile(r source.File) error {
 		case nil:
 			current[r.BaseFileName()] = data
 		case map[string]interface{}:
-			// merge maps: insert entries from data for keys that
-			// don't already exist in higherPrecedentData
-			higherPrecedentMap := higherPrecedentData.(map[string]interface{})
-			for key, value := range data.(map[string]interface{}) {
-				if _, exists := higherPrecedentMap[key]; exists {
-					// this warning could happen if
-					// 1. A theme uses the same key; the main data folder wins
-					// 2. A sub folder uses the same key: the sub folder wins
-					// TODO(bep) figure out a way to detect 2) above and make that a WARN
-					h.Log.INFO.Printf("Data for key '%s' in path '%s' is overridden by higher precedence data already in the data tree", key, r.Path())
-				} else {
-					higherPrecedentMap[key] = value
-				}
-			}
+			// Deep merge the maps: insert the entries from data missing in
+			// higherPrecedentData, recursing into nested maps.
+			h.mergeData(higherPrecedentData.(map[string]interface{}), data.(map[string]interface{}), "", r)
 		default:
 			// can't merge: higherPrecedentData is not a map
 			h.Log.WARN.Printf("The %T data from '%s' overridden by "+
@@ -772,6 +834,31 @@ func (h *HugoSites) handleDataFile(r source.File) error {
 	return nil
 }
 
+// mergeData adds the entries in m2 missing in m1, recursing into nested
+// maps. Values already in m1 have higher precedence and are kept.
+func (h *HugoSites) mergeData(m1, m2 map[string]interface{}, keyPrefix string, r source.File) {
+	for key, v2 := range m2 {
+		v1, exists := m1[key]
+		if !exists {
+			m1[key] = v2
+			continue
+		}
+
+		mm1, ok1 := v1.(map[string]interface{})
+		mm2, ok2 := v2.(map[string]interface{})
+		if ok1 && ok2 {
+			h.mergeData(mm1, mm2, keyPrefix+key+".", r)
+			continue
+		}
+
+		// this could happen if
+		// 1. A theme uses the same key; the main data folder wins
+		// 2. A sub folder uses the same key: the sub folder wins
+		// TODO(bep) figure out a way to detect 2) above and make that a WARN
+		h.Log.INFO.Printf("Data for key '%s' in path '%s' is overridden by higher precedence data already in the data tree", keyPrefix+key, r.Path())
+	}
+}
+
 func (h *HugoSites) errWithFileContext(err error, f source.File) error {
 	fim, ok := f.FileInfo().(hugofs.FileMetaInfo)
 	if !ok {
@@ -799,6 +886,11 @@ func (h *HugoSites) readData(f source.File) (interface{}, error) {
 	content := helpers.ReaderToBytes(file)
 
 	format := metadecoders.FormatFromString(f.Extension())
+	if format == metadecoders.CSV {
+		// Map the values in each row to the column names in the header row.
+		return metadecoders.Default.UnmarshalCSVWithHeader(content)
+	}
+
 	return metadecoders.Default.Unmarshal(content, format)
 }
 
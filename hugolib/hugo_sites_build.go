@@ -16,11 +16,26 @@ package hugolib
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
+	"net/url"
+	"path"
+	"path/filepath"
 	"runtime/trace"
+	"sort"
+	"strings"
+	"sync"
+	"time"
 
+	"github.com/gobwas/glob"
 	"github.com/gohugoio/hugo/config"
+	hglob "github.com/gohugoio/hugo/hugofs/glob"
+	"github.com/gohugoio/hugo/langs/i18n"
 	"github.com/gohugoio/hugo/output"
+	"github.com/gohugoio/hugo/publisher"
+	"github.com/gohugoio/hugo/resources/page"
+	"github.com/gohugoio/hugo/resources/resource"
+	"github.com/spf13/afero"
 	"golang.org/x/sync/errgroup"
 	"golang.org/x/sync/semaphore"
 
@@ -66,6 +81,13 @@ func (h *HugoSites) Build(config BuildCfg, events ...fsnotify.Event) error {
 		h.Metrics.Reset()
 	}
 
+	if !config.PartialReRender {
+		h.ImageAudit.Reset()
+	}
+
+	buildStart := time.Now()
+	h.BuildTimer.Reset()
+
 	// Need a pointer as this may be modified.
 	conf := &config
 
@@ -100,6 +122,7 @@ func (h *HugoSites) Build(config BuildCfg, events ...fsnotify.Event) error {
 			var err error
 
 			f := func() {
+				defer h.BuildTimer.MeasureSince("content/read", time.Now())
 				err = h.process(conf, init, events...)
 			}
 			trace.WithRegion(ctx, "process", f)
@@ -108,6 +131,7 @@ func (h *HugoSites) Build(config BuildCfg, events ...fsnotify.Event) error {
 			}
 
 			f = func() {
+				defer h.BuildTimer.MeasureSince("content/assemble", time.Now())
 				err = h.assemble(conf)
 			}
 			trace.WithRegion(ctx, "assemble", f)
@@ -129,8 +153,15 @@ func (h *HugoSites) Build(config BuildCfg, events ...fsnotify.Event) error {
 	}
 
 	if prepareErr == nil {
+		// The pages not rendered again keep their entries. The entries of
+		// changed assets are removed in processPartial.
+		if conf.rendersAllPages() {
+			h.AssetManifest.Reset()
+		}
+
 		var err error
 		f := func() {
+			defer h.BuildTimer.MeasureSince("render", time.Now())
 			err = h.render(conf)
 		}
 		trace.WithRegion(ctx, "render", f)
@@ -146,6 +177,34 @@ func (h *HugoSites) Build(config BuildCfg, events ...fsnotify.Event) error {
 		h.Log.FEEDBACK.Printf("\nTemplate Metrics:\n\n")
 		h.Log.FEEDBACK.Print(b.String())
 		h.Log.FEEDBACK.Println()
+
+		if filename := h.Cfg.GetString("templateMetricsFile"); filename != "" {
+			if err := h.writeMetricsJSON(filename); err != nil {
+				h.SendError(err)
+			}
+		}
+	}
+
+	if prepareErr == nil {
+		if err := h.writeI18nReport(); err != nil {
+			h.SendError(err)
+		}
+		if err := h.writeBuildStats(); err != nil {
+			h.SendError(err)
+		}
+		if err := h.writeAssetManifest(); err != nil {
+			h.SendError(err)
+		}
+		if err := h.writeSchedule(); err != nil {
+			h.SendError(err)
+		}
+		if err := h.checkPublishedLinks(conf); err != nil {
+			h.SendError(err)
+		}
+		if err := h.writeBuildReport(time.Since(buildStart)); err != nil {
+			h.SendError(err)
+		}
+		h.printImageAudit()
 	}
 
 	select {
@@ -244,6 +303,9 @@ func (h *HugoSites) assemble(bcfg *BuildCfg) error {
 		return nil
 	}
 
+	// The scheduled pages are collected again from all pages below.
+	h.scheduledPages.reset()
+
 	numWorkers := config.GetNumWorkerMultiplier()
 	sem := semaphore.NewWeighted(int64(numWorkers))
 	g, ctx := errgroup.WithContext(context.Background())
@@ -286,6 +348,16 @@ func (h *HugoSites) assemble(bcfg *BuildCfg) error {
 		return err
 	}
 
+	if changed := bcfg.whatChanged; changed.listPages != nil {
+		// Add the list pages listing the changed pages after the change.
+		for _, s := range h.Sites {
+			if !s.collectListPages(changed.files, changed.listPages) {
+				changed.listPages = nil
+				break
+			}
+		}
+	}
+
 	return nil
 
 }
@@ -293,6 +365,11 @@ func (h *HugoSites) assemble(bcfg *BuildCfg) error {
 func (h *HugoSites) render(config *BuildCfg) error {
 	siteRenderContext := &siteRenderContext{cfg: config, multihost: h.multihost}
 
+	if config.rendersAllPages() {
+		// All list pages are rendered.
+		h.listPageDeps.reset()
+	}
+
 	if !config.PartialReRender {
 		h.renderFormats = output.Formats{}
 		for _, s := range h.Sites {
@@ -353,3 +430,391 @@ func (h *HugoSites) render(config *BuildCfg) error {
 
 	return nil
 }
+
+// writeI18nReport writes the translation coverage report to i18nReportFile, if
+// set, and fails if any language has a coverage below i18nMinCoverage.
+func (h *HugoSites) writeI18nReport() error {
+	tp, ok := h.Deps.TranslationProvider().(*i18n.TranslationProvider)
+	if !ok {
+		return nil
+	}
+	report := tp.Report()
+	if report == nil {
+		return nil
+	}
+
+	if filename := h.Cfg.GetString("i18nReportFile"); filename != "" {
+		filename = h.PathSpec.AbsPathify(filename)
+		f, err := helpers.OpenFileForWriting(h.Fs.Source, filename)
+		if err != nil {
+			return errors.Wrap(err, "failed to create i18n report file")
+		}
+		defer f.Close()
+
+		if err := report.WriteJSON(f); err != nil {
+			return err
+		}
+	}
+
+	minCoverage := h.Cfg.GetInt("i18nMinCoverage")
+	for _, l := range report.Languages {
+		if l.Coverage < float64(minCoverage) {
+			return fmt.Errorf("i18n coverage for language %q is %.1f%%, below the required %d%% (missing: %s)", l.Lang, l.Coverage, minCoverage, strings.Join(l.Missing, ", "))
+		}
+	}
+
+	return nil
+}
+
+// writeBuildStats writes the HTML elements used in the site to hugo_stats.json
+// in the project directory, if build.writeStats is enabled.
+func (h *HugoSites) writeBuildStats() error {
+	if h.htmlElementsCollector == nil {
+		return nil
+	}
+
+	stats := struct {
+		HTMLElements publisher.HTMLElements `json:"htmlElements"`
+	}{
+		HTMLElements: h.htmlElementsCollector.HTMLElements(),
+	}
+
+	b, err := json.MarshalIndent(stats, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	filename := filepath.Join(h.Cfg.GetString("workingDir"), "hugo_stats.json")
+	if err := afero.WriteFile(h.Fs.Source, filename, b, 0666); err != nil {
+		return errors.Wrap(err, "failed to write build stats")
+	}
+
+	return nil
+}
+
+// writeAssetManifest writes the fingerprinted resources to
+// hugo_assets.json in the publish directory, if build.writeAssetManifest
+// is enabled. The file maps the target path of every resource to the
+// target path of its fingerprinted version.
+func (h *HugoSites) writeAssetManifest() error {
+	if h.AssetManifest == nil {
+		return nil
+	}
+
+	b, err := json.MarshalIndent(h.AssetManifest.Entries(), "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := afero.WriteFile(h.BaseFs.PublishFs, "hugo_assets.json", b, 0666); err != nil {
+		return errors.Wrap(err, "failed to write asset manifest")
+	}
+
+	return nil
+}
+
+// scheduledPage is a page skipped in the build because its publish date is
+// in the future or its expiry date in the past.
+type scheduledPage struct {
+	Path        string    `json:"path"`
+	Lang        string    `json:"lang"`
+	PublishDate time.Time `json:"publishDate"`
+	ExpiryDate  time.Time `json:"expiryDate"`
+	Reason      string    `json:"reason"`
+}
+
+// scheduledPages collects the scheduled pages of all sites, keyed by
+// language and filename. It is reset before the pages are assembled, so
+// deleted content files do not linger on rebuilds.
+type scheduledPages struct {
+	mu    sync.Mutex
+	pages map[string]scheduledPage
+}
+
+func newScheduledPages() *scheduledPages {
+	return &scheduledPages{pages: make(map[string]scheduledPage)}
+}
+
+func (c *scheduledPages) reset() {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	c.pages = make(map[string]scheduledPage)
+	c.mu.Unlock()
+}
+
+// update records p if it is skipped in the build because of its dates.
+func (c *scheduledPages) update(s *Site, p page.Page, shouldBuild bool) {
+	if c == nil || p.File().IsZero() {
+		return
+	}
+
+	key := s.Lang() + ":" + p.File().Filename()
+
+	var reason string
+	if !shouldBuild && (s.BuildDrafts || !p.Draft()) {
+		if !s.BuildFuture && resource.IsFuture(p) {
+			reason = "future"
+		} else if !s.BuildExpired && resource.IsExpired(p) {
+			reason = "expired"
+		}
+	}
+
+	if reason == "" {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.pages[key] = scheduledPage{
+		Path:        filepath.ToSlash(p.File().Path()),
+		Lang:        s.Lang(),
+		PublishDate: p.PublishDate(),
+		ExpiryDate:  p.ExpiryDate(),
+		Reason:      reason,
+	}
+}
+
+// Pages returns the collected pages sorted by language and path.
+func (c *scheduledPages) Pages() []scheduledPage {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	pages := make([]scheduledPage, 0, len(c.pages))
+	for _, p := range c.pages {
+		pages = append(pages, p)
+	}
+	sort.Slice(pages, func(i, j int) bool {
+		if pages[i].Lang != pages[j].Lang {
+			return pages[i].Lang < pages[j].Lang
+		}
+		return pages[i].Path < pages[j].Path
+	})
+
+	return pages
+}
+
+// writeSchedule writes the pages skipped because their publish date is in
+// the future or their expiry date in the past to hugo_schedule.json in the
+// project directory, if build.writeSchedule is enabled. The file also holds
+// the next date at which a page is published or expires, i.e. when the site
+// needs to be rebuilt.
+func (h *HugoSites) writeSchedule() error {
+	if h.scheduledPages == nil {
+		return nil
+	}
+
+	pages := h.scheduledPages.Pages()
+
+	var next time.Time
+	setNext := func(d time.Time) {
+		if !d.IsZero() && (next.IsZero() || d.Before(next)) {
+			next = d
+		}
+	}
+	for _, p := range pages {
+		if p.Reason == "future" {
+			setNext(p.PublishDate)
+		}
+	}
+	for _, p := range h.Pages() {
+		setNext(resource.ScheduledDate(p))
+	}
+
+	schedule := struct {
+		NextRebuild *time.Time      `json:"nextRebuild,omitempty"`
+		Pages       []scheduledPage `json:"pages"`
+	}{
+		Pages: pages,
+	}
+	if !next.IsZero() {
+		schedule.NextRebuild = &next
+	}
+
+	b, err := json.MarshalIndent(schedule, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	filename := filepath.Join(h.Cfg.GetString("workingDir"), "hugo_schedule.json")
+	if err := afero.WriteFile(h.Fs.Source, filename, b, 0666); err != nil {
+		return errors.Wrap(err, "failed to write schedule")
+	}
+
+	return nil
+}
+
+// checkPublishedLinks logs an error for every internal link in the
+// published HTML files pointing to a file that does not exist, if
+// build.checkLinks is enabled. Links matching any of the globs in
+// build.checkLinksIgnore are skipped.
+func (h *HugoSites) checkPublishedLinks(config *BuildCfg) error {
+	if h.linksCollector == nil {
+		return nil
+	}
+
+	var ignore []glob.Glob
+	for _, pattern := range h.Cfg.GetStringSlice("build.checkLinksIgnore") {
+		g, err := hglob.GetGlob(hglob.NormalizePath(pattern))
+		if err != nil {
+			return errors.Wrapf(err, "invalid build.checkLinksIgnore pattern %q", pattern)
+		}
+		ignore = append(ignore, g)
+	}
+
+	isIgnored := func(candidates ...string) bool {
+		for _, candidate := range candidates {
+			candidate = hglob.NormalizePath(candidate)
+			for _, g := range ignore {
+				if g.Match(candidate) {
+					return true
+				}
+			}
+		}
+		return false
+	}
+
+	baseURL := h.Cfg.GetString("baseURL")
+	basePath := "/"
+	if u, err := url.Parse(baseURL); err == nil && u.Path != "" {
+		basePath = strings.TrimSuffix(u.Path, "/") + "/"
+	}
+
+	// Published file => the content file of the page rendered to it.
+	sources := make(map[string]string)
+	targets := make(map[string]bool)
+	for _, p := range h.Pages() {
+		for _, f := range p.OutputFormats() {
+			if !f.Format.IsHTML {
+				continue
+			}
+			target, internal := publisher.ResolveLink(f.RelPermalink(), "/", basePath, "")
+			if !internal {
+				continue
+			}
+			if strings.HasSuffix(target, "/") {
+				target += "index.html"
+			}
+			targets[target] = true
+			if !p.File().IsZero() {
+				sources[target] = p.File().Path()
+			}
+		}
+	}
+
+	if !config.PartialReRender {
+		// Forget the links in the files of removed pages. The pages not
+		// published in this build, e.g. in fast render mode, keep theirs.
+		h.linksCollector.RemoveStale(func(target string) bool {
+			return targets[target]
+		})
+	}
+
+	links := h.linksCollector.Links()
+	filenames := make([]string, 0, len(links))
+	for filename := range links {
+		filenames = append(filenames, filename)
+	}
+	sort.Strings(filenames)
+
+	// Static files may not be copied to the publish dir yet, or at all
+	// when serving from memory, so look for them in the static dirs too.
+	fss := []afero.Fs{h.BaseFs.PublishFs}
+	for _, staticFs := range h.BaseFs.SourceFilesystems.Static {
+		fss = append(fss, staticFs.Fs)
+	}
+	exists := func(target string) bool {
+		for _, fs := range fss {
+			if publisher.LinkTargetExists(fs, target) {
+				return true
+			}
+		}
+		return false
+	}
+
+	for _, filename := range filenames {
+		dir := path.Dir(filename)
+		if dir != "/" {
+			dir += "/"
+		}
+		for _, link := range links[filename] {
+			target, internal := publisher.ResolveLink(link.URL, dir, basePath, baseURL)
+			if !internal || isIgnored(link.URL, target) || exists(target) {
+				continue
+			}
+			var source string
+			if s, found := sources[filename]; found {
+				source = fmt.Sprintf(" (content file %q)", s)
+			}
+			h.Log.ERROR.Printf("broken link %q in %s:%d%s", link.URL, strings.TrimPrefix(filename, "/"), link.Line, source)
+		}
+	}
+
+	return nil
+}
+
+// writeBuildReport prints the time spent in the build phases and writes them
+// as JSON to buildReport, if set.
+func (h *HugoSites) writeBuildReport(d time.Duration) error {
+	if h.BuildTimer == nil {
+		return nil
+	}
+
+	report := h.BuildTimer.Report(d)
+
+	var b bytes.Buffer
+	report.WriteTable(&b)
+
+	h.Log.FEEDBACK.Printf("\nBuild Report:\n\n")
+	h.Log.FEEDBACK.Print(b.String())
+	h.Log.FEEDBACK.Println()
+
+	filename := h.PathSpec.AbsPathify(h.Cfg.GetString("buildReport"))
+	f, err := helpers.OpenFileForWriting(h.Fs.Source, filename)
+	if err != nil {
+		return errors.Wrap(err, "failed to create build report file")
+	}
+	defer f.Close()
+
+	return report.WriteJSON(f)
+}
+
+// printImageAudit prints the image processing requested by the templates,
+// grouped by source image, if imageAudit is enabled.
+func (h *HugoSites) printImageAudit() {
+	if h.ImageAudit == nil {
+		return
+	}
+
+	sources := h.ImageAudit.Sources()
+
+	var b bytes.Buffer
+	var outputs int
+	for _, s := range sources {
+		outputs += len(s.Outputs)
+		source := strings.TrimPrefix(s.Source, h.WorkingDir+string(filepath.Separator))
+		fmt.Fprintf(&b, "%s: %d outputs, %d requests\n", filepath.ToSlash(source), len(s.Outputs), s.Requests)
+		for _, o := range s.Outputs {
+			fmt.Fprintf(&b, "  %-60s %d\n", o.Key, o.Requests)
+		}
+	}
+	fmt.Fprintf(&b, "\n%d source images, %d outputs\n", len(sources), outputs)
+
+	h.Log.FEEDBACK.Printf("\nImage Audit:\n\n")
+	h.Log.FEEDBACK.Print(b.String())
+	h.Log.FEEDBACK.Println()
+}
+
+func (h *HugoSites) writeMetricsJSON(filename string) error {
+	filename = h.PathSpec.AbsPathify(filename)
+
+	f, err := helpers.OpenFileForWriting(h.Fs.Source, filename)
+	if err != nil {
+		return errors.Wrap(err, "failed to create template metrics file")
+	}
+	defer f.Close()
+
+	return h.Metrics.WriteMetricsJSON(f)
+}
@@ -14,7 +14,10 @@
 package hugolib
 
 import (
+	"path/filepath"
 	"testing"
+
+	qt "github.com/frankban/quicktest"
 )
 
 func TestSitesRebuild(t *testing.T) {
@@ -81,3 +84,220 @@ Edited content.
 	b.AssertFileContent("public/index.html", "* Page Pages: Page 1 edit|Summary: Edited summary|Content: <p>Edited content.</p>")
 
 }
+
+func TestStoreRebuild(t *testing.T) {
+	b := newTestSitesBuilder(t)
+
+	b.WithContent("p1.md", `---
+title: "P1"
+---
+`)
+
+	b.WithTemplatesAdded("_default/single.html", `
+{{ .Scratch.Add "builds" 1 }}{{ .Store.Add "builds" 1 }}{{ site.Store.Add "builds" 1 }}
+Scratch: {{ .Scratch.Get "builds" }}|Store: {{ .Store.Get "builds" }}|Site: {{ site.Store.Get "builds" }}
+`)
+
+	b.Running().Build(BuildCfg{})
+
+	b.AssertFileContent("public/p1/index.html", "Scratch: 1|Store: 1|Site: 1")
+
+	b.EditFiles("layouts/_default/single.html", `
+{{ .Scratch.Add "builds" 1 }}{{ .Store.Add "builds" 1 }}{{ site.Store.Add "builds" 1 }}
+Edited Scratch: {{ .Scratch.Get "builds" }}|Store: {{ .Store.Get "builds" }}|Site: {{ site.Store.Get "builds" }}
+`)
+
+	b.Build(BuildCfg{})
+
+	b.AssertFileContent("public/p1/index.html", "Edited Scratch: 1|Store: 2|Site: 2")
+}
+
+func TestRebuildRendersAllListPages(t *testing.T) {
+	b := newTestSitesBuilder(t).WithConfigFile("toml", `
+baseURL = "https://example.com"
+disableKinds = ["RSS", "sitemap", "robotsTXT", "404"]
+`)
+
+	b.WithContent(
+		"blog/b1.md", "---\ntitle: B1\ntags: [a]\n---\n",
+		"blog/b2.md", "---\ntitle: B2\ntags: [b]\n---\n",
+		"docs/d1.md", "---\ntitle: D1\ntags: [c]\n---\n",
+	)
+
+	b.WithTemplates(
+		"_default/single.html", `Single: {{ .Title }}`,
+		"_default/list.html", `List: {{ .Title }}|{{ range site.RegularPages }}{{ .Title }}|{{ end }}`,
+	)
+
+	b.Running().Build(BuildCfg{})
+
+	b.AssertFileContent("public/docs/index.html", "List: Docs|B1|B2|D1|")
+
+	b.EditFiles("content/blog/b1.md", "---\ntitle: B1 Edit\ntags: [b]\n---\n")
+
+	b.Build(BuildCfg{})
+
+	// Every list page ranges over the site's pages, so all are rendered,
+	// also those not listing B1 in its section or taxonomies.
+	for _, filename := range []string{
+		"public/index.html",
+		"public/blog/index.html",
+		"public/docs/index.html",
+		"public/tags/index.html",
+		"public/tags/b/index.html",
+		"public/tags/c/index.html",
+	} {
+		b.AssertFileContent(filename, "B1 Edit|B2|D1|")
+	}
+
+	b.AssertFileContent("public/docs/d1/index.html", "Single: D1")
+}
+
+func TestRebuildAffectedListPages(t *testing.T) {
+	b := newTestSitesBuilder(t).WithConfigFile("toml", `
+baseURL = "https://example.com"
+disableKinds = ["RSS", "sitemap", "robotsTXT", "404"]
+`)
+
+	b.WithContent(
+		"blog/b1.md", "---\ntitle: B1\ntags: [a]\n---\n",
+		"blog/b2.md", "---\ntitle: B2\ntags: [b]\n---\n",
+		"docs/d1.md", "---\ntitle: D1\ntags: [c]\n---\n",
+		"news/n1.md", "---\ntitle: N1\ntags: [c]\n---\n",
+	)
+
+	b.WithTemplates(
+		"index.html", `Home: {{ range .Site.RegularPages }}{{ .Title }}|{{ end }}`,
+		"_default/single.html", `Single: {{ .Title }}`,
+		"_default/list.html", `List: {{ .Title }}|{{ range .Pages }}{{ .Title }}|{{ end }}`,
+		"news/list.html", `News: {{ range site.RegularPages }}{{ .Title }}|{{ end }}`,
+	)
+
+	b.Running().Build(BuildCfg{})
+
+	listPages := []string{
+		"public/index.html",
+		"public/blog/index.html",
+		"public/docs/index.html",
+		"public/news/index.html",
+		"public/tags/index.html",
+		"public/tags/b/index.html",
+		"public/tags/c/index.html",
+	}
+
+	removeListPages := func() {
+		for _, filename := range listPages {
+			b.Assert(b.Fs.Destination.Remove(filepath.FromSlash(filename)), qt.IsNil)
+		}
+	}
+
+	removeListPages()
+
+	b.EditFiles("content/blog/b1.md", "---\ntitle: B1 Edit\ntags: [b]\n---\n")
+
+	b.Build(BuildCfg{})
+
+	// The home page, the section and taxonomy list pages and the term pages
+	// listing B1 before and after the change.
+	b.AssertFileContent("public/index.html", "Home: B1 Edit|B2|D1|N1|")
+	b.AssertFileContent("public/blog/index.html", "List: Blogs|B1 Edit|B2|")
+	b.AssertFileContent("public/news/index.html", "News: B1 Edit|B2|D1|N1|")
+	b.AssertFileContent("public/tags/index.html", "List: Tags|")
+	b.AssertFileContent("public/tags/b/index.html", "List: b|B1 Edit|B2|")
+
+	// All regular, section and taxonomy list pages are rendered.
+	b.AssertFileContent("public/docs/d1/index.html", "Single: D1")
+	b.AssertFileContent("public/docs/index.html", "List: Docs|D1|")
+
+	// The taxonomy term pages not listing B1 are left alone.
+	b.Assert(b.CheckExists("public/tags/c/index.html"), qt.Equals, false)
+
+	// Menu entries may be listed anywhere.
+	b.EditFiles("content/docs/d1.md", "---\ntitle: D1 Edit\ntags: [c]\nmenu: main\n---\n")
+
+	b.Build(BuildCfg{})
+
+	b.AssertFileContent("public/docs/index.html", "List: Docs|D1 Edit|")
+	b.AssertFileContent("public/tags/c/index.html", "List: c|D1 Edit|N1|")
+
+	removeListPages()
+
+	// A template change renders everything.
+	b.EditFiles("layouts/_default/list.html", `List Edit: {{ .Title }}|{{ range .Pages }}{{ .Title }}|{{ end }}`)
+
+	b.Build(BuildCfg{})
+
+	b.AssertFileContent("public/docs/index.html", "List Edit: Docs|D1 Edit|")
+	b.AssertFileContent("public/tags/c/index.html", "List Edit: c|D1 Edit|N1|")
+}
+
+func TestRebuildListPagesUsingCachedPartialsAndTree(t *testing.T) {
+	b := newTestSitesBuilder(t).WithConfigFile("toml", `
+baseURL = "https://example.com"
+disableKinds = ["RSS", "sitemap", "robotsTXT", "404"]
+`)
+
+	b.WithContent(
+		"blog/b1.md", "---\ntitle: B1\ntags: [a]\n---\n",
+		"blog/b2.md", "---\ntitle: B2\ntags: [b]\n---\n",
+		"docs/d1.md", "---\ntitle: D1\ntags: [c]\n---\n",
+	)
+
+	b.WithTemplates(
+		"index.html", `Home`,
+		"_default/single.html", `Single: {{ .Title }}|{{ partialCached "menu.html" . }}`,
+		"_default/list.html", `List: {{ .Title }}|{{ range .Pages }}{{ .Title }}|{{ end }}`,
+		"_default/taxonomy.html", `Term: {{ .Title }}|{{ range .CurrentSection.Pages }}{{ .Title }}|{{ end }}`,
+		"docs/list.html", `Docs: {{ partialCached "menu.html" . }}`,
+		"partials/menu.html", `Menu: {{ range site.RegularPages }}{{ .Title }}|{{ end }}`,
+	)
+
+	b.Running().Build(BuildCfg{})
+
+	b.AssertFileContent("public/docs/index.html", "Docs: Menu: B1|B2|D1|")
+	b.AssertFileContent("public/tags/c/index.html", "Term: c|a|b|c|")
+
+	b.EditFiles("content/blog/b1.md", "---\ntitle: B1 Edit\ntags: [x]\n---\n")
+
+	b.Build(BuildCfg{})
+
+	// The menu was created while rendering the regular pages.
+	b.AssertFileContent("public/docs/index.html", "Docs: Menu: B1 Edit|B2|D1|")
+	b.AssertFileContent("public/tags/c/index.html", "Term: c|b|c|x|")
+}
+
+func TestRebuildTermPagesUsingRefsAndStore(t *testing.T) {
+	b := newTestSitesBuilder(t).WithConfigFile("toml", `
+baseURL = "https://example.com"
+disableKinds = ["RSS", "sitemap", "robotsTXT", "404"]
+`)
+
+	b.WithContent(
+		"blog/b1.md", "---\ntitle: B1\ntags: [a]\n---\n",
+		"docs/d1.md", "---\ntitle: D1\ntags: [c]\n---\n",
+		"docs/d2.md", "---\ntitle: D2\ncategories: [k]\n---\n",
+	)
+
+	b.WithTemplates(
+		"index.html", `Home`,
+		"_default/single.html", `{{ site.Store.Set (printf "t-%s" .File.BaseFileName) .Title }}Single: {{ .Title }}`,
+		"_default/list.html", `List: {{ .Title }}`,
+		"docs/list.html", `Docs: {{ site.Store.Get "t-b1" }}|{{ relref . "/blog/b1.md" }}`,
+		"taxonomy/tag.html", `Term: {{ .Title }}|{{ relref . "/blog/b1.md" }}`,
+		"taxonomy/category.html", `Term: {{ .Title }}|{{ site.Store.Get "t-b1" }}`,
+	)
+
+	b.Running().Build(BuildCfg{})
+
+	b.AssertFileContent("public/docs/index.html", "Docs: B1|/blog/b1/")
+	b.AssertFileContent("public/tags/c/index.html", "Term: c|/blog/b1/")
+	b.AssertFileContent("public/categories/k/index.html", "Term: k|B1")
+
+	b.EditFiles("content/blog/b1.md", "---\ntitle: B1 Edit\nslug: moved\ntags: [a]\n---\n")
+
+	b.Build(BuildCfg{})
+
+	b.AssertFileContent("public/docs/index.html", "Docs: B1 Edit|/blog/moved/")
+	b.AssertFileContent("public/tags/c/index.html", "Term: c|/blog/moved/")
+	b.AssertFileContent("public/categories/k/index.html", "Term: k|B1 Edit")
+}
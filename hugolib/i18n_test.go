@@ -0,0 +1,135 @@
+// Copyright 2019 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hugolib
+
+import (
+	"fmt"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestI18nReport(t *testing.T) {
+	t.Parallel()
+
+	newBuilder := func(extraConfig string) *sitesBuilder {
+		b := newTestSitesBuilder(t)
+		b.WithConfigFile("toml", fmt.Sprintf(`
+baseURL = "https://example.org/"
+defaultContentLanguage = "en"
+%s
+[languages]
+[languages.en]
+weight = 1
+[languages.fr]
+weight = 2
+`, extraConfig))
+		b.WithContent("_index.md", "---\ntitle: Home\n---")
+		b.WithTemplates("index.html", `{{ i18n "hello" }}|{{ i18n "bye" }}`)
+		b.WithI18n(
+			"en.toml", "[hello]\nother = \"Hello\"\n[bye]\nother = \"Bye\"",
+			"fr.toml", "[hello]\nother = \"Bonjour\"",
+		)
+		return b
+	}
+
+	b := newBuilder(`i18nReportFile = "i18n-report.json"`)
+	b.Build(BuildCfg{})
+
+	b.AssertFileContent("public/fr/index.html", "Bonjour|Bye")
+	b.AssertFileContent("i18n-report.json", `
+"lang": "en",
+"used": 2,
+"missing": [],
+"coverage": 100
+"lang": "fr",
+"used": 2,
+"missing": [
+"bye"
+],
+"coverage": 50
+`)
+
+	b = newBuilder(`i18nMinCoverage = 80`)
+	err := b.CreateSites().BuildE(BuildCfg{})
+	c := qt.New(t)
+	c.Assert(err, qt.Not(qt.IsNil))
+	c.Assert(err.Error(), qt.Contains, `i18n coverage for language "fr" is 50.0%, below the required 80% (missing: bye)`)
+
+	b = newBuilder(`i18nMinCoverage = 50`)
+	b.Build(BuildCfg{})
+}
+
+func TestLanguageFallbacks(t *testing.T) {
+	t.Parallel()
+
+	b := newTestSitesBuilder(t)
+	b.WithConfigFile("toml", `
+baseURL = "https://example.org/"
+defaultContentLanguage = "en"
+
+[languages]
+[languages.en]
+weight = 1
+[languages.pt]
+weight = 2
+[languages.pt-br]
+weight = 3
+fallbacks = ["pt", "en"]
+`)
+	b.WithContent(
+		"p1.md", "---\ntitle: P1 EN\nweight: 1\n---",
+		"p2.md", "---\ntitle: P2 EN\nweight: 2\n---",
+		"p3.md", "---\ntitle: P3 EN\nweight: 3\n---",
+		"p1.pt.md", "---\ntitle: P1 PT\nweight: 1\n---",
+		"p2.pt.md", "---\ntitle: P2 PT\nweight: 2\n---",
+		"p1.pt-br.md", "---\ntitle: P1 PT-BR\nweight: 1\n---",
+		"docs/_index.md", "---\ntitle: Docs EN\n---",
+		"docs/_index.pt-br.md", "---\ntitle: Docs PT-BR\n---",
+		"docs/d1.md", "---\ntitle: D1 EN\nweight: 1\n---",
+		"docs/d2.md", "---\ntitle: D2 EN\nweight: 2\n---",
+		"docs/d1.pt-br.md", "---\ntitle: D1 PT-BR\nweight: 1\n---",
+	)
+	b.WithTemplates(
+		"_default/single.html", "{{ .Title }}",
+		"_default/list.html", "List: {{ range .Pages }}{{ .Title }}|{{ end }}",
+		"index.html", `
+Fallbacks: {{ range .Site.Fallbacks }}{{ .Language.Lang }}|{{ end }}
+Pages: {{ range .Site.RegularPages }}{{ .Title }}|{{ end }}
+Home: {{ range .RegularPages }}{{ .Title }}|{{ end }}
+GetPage: {{ with .Site.GetPage "p3" }}{{ .Title }}{{ end }}|{{ with .Site.GetPage "p2" }}{{ .Title }}{{ end }}
+i18n: {{ i18n "hello" }}|{{ i18n "bye" }}
+`)
+	b.WithI18n(
+		"en.toml", "[hello]\nother = \"Hello\"\n[bye]\nother = \"Bye\"",
+		"pt.toml", "[bye]\nother = \"Tchau\"",
+		"pt-br.toml", "[hello]\nother = \"Oi\"",
+	)
+	b.Build(BuildCfg{})
+
+	b.AssertFileContent("public/pt-br/index.html",
+		"Fallbacks: pt|en|",
+		"Pages: D1 PT-BR|P1 PT-BR|D2 EN|P2 PT|P3 EN|",
+		"Home: D1 PT-BR|P1 PT-BR|D2 EN|P2 PT|P3 EN|",
+		"GetPage: P3 EN|P2 PT",
+		"i18n: Oi|Tchau",
+	)
+	b.AssertFileContent("public/pt-br/docs/index.html", "List: D1 PT-BR|D2 EN|")
+	b.AssertFileContent("public/pt/index.html",
+		"Fallbacks: \n",
+		"Pages: P1 PT|P2 PT|",
+		"GetPage: |P2 PT",
+		"i18n: Hello|Tchau",
+	)
+}
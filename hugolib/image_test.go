@@ -14,15 +14,20 @@
 package hugolib
 
 import (
+	"bytes"
 	"io"
+	"io/ioutil"
 	"os"
 	"path/filepath"
 	"testing"
 
+	"github.com/gohugoio/hugo/common/loggers"
 	"github.com/gohugoio/hugo/htesting"
 
 	qt "github.com/frankban/quicktest"
 	"github.com/gohugoio/hugo/hugofs"
+	"github.com/spf13/afero"
+	jww "github.com/spf13/jwalterweatherman"
 	"github.com/spf13/viper"
 )
 
@@ -115,3 +120,228 @@ title: "My bundle"
 	b.AssertFileContent(filepath.Join(workDir, "public/index.html"), imgExpect...)
 
 }
+
+func TestImageCard(t *testing.T) {
+	b := newTestSitesBuilder(t)
+	b.WithContent("mybundle/index.md", `
+---
+title: "My bundle"
+---
+
+`)
+	b.WithSunset("content/mybundle/sunset.jpg")
+	b.WithSunset("content/mybundle/logo.jpg")
+	b.WithSunset("content/mybundle/logo2.jpg")
+
+	b.WithTemplatesAdded("index.html", `
+{{ $p := .Site.GetPage "mybundle" }}
+{{ $bg := $p.Resources.GetMatch "sunset.jpg" }}
+{{ $logo := $p.Resources.GetMatch "logo.jpg" }}
+{{ $logo2 := $p.Resources.GetMatch "logo2.jpg" }}
+{{ $opts := dict "logo" $logo "fontSize" 48 }}
+{{ $card1 := $bg | images.Card $p.Title }}
+{{ $card2 := $bg | images.Card $p.Title $opts }}
+{{ $card3 := $bg | images.Card "Other title" }}
+{{ $card4 := $bg | images.Card $p.Title }}
+{{ $card5 := $bg | images.Card $p.Title (dict "logo" $logo2 "fontSize" 48) }}
+Card1: {{ $card1.Width }}x{{ $card1.Height }}
+SameTitle: {{ eq $card1.RelPermalink $card4.RelPermalink }}
+WithLogo: {{ ne $card1.RelPermalink $card2.RelPermalink }}
+OtherTitle: {{ ne $card1.RelPermalink $card3.RelPermalink }}
+SameLogoContent: {{ eq $card2.RelPermalink $card5.RelPermalink }}
+OptsUntouched: {{ isset $opts "logo" }}
+`)
+
+	b.Build(BuildCfg{})
+
+	b.AssertFileContent("public/index.html",
+		"Card1: 1200x630",
+		"SameTitle: true",
+		"WithLogo: true",
+		"OtherTitle: true",
+		"SameLogoContent: true",
+		"OptsUntouched: true",
+	)
+}
+
+func TestImageProcessedOnceForAllLanguages(t *testing.T) {
+	b := newTestSitesBuilder(t)
+	b.WithConfigFile("toml", `
+baseURL = "https://example.org"
+defaultContentLanguage = "en"
+
+[languages]
+[languages.en]
+weight = 1
+[languages.nn]
+weight = 2
+`)
+	b.WithContent("mybundle/index.en.md", `
+---
+title: "My bundle"
+slug: "my-bundle"
+---
+`, "mybundle/index.nn.md", `
+---
+title: "Mi bunt"
+slug: "mi-bunt"
+---
+`)
+	b.WithSunset("content/mybundle/sunset.jpg")
+
+	b.WithTemplatesAdded("_default/single.html", `
+{{ $img := .Resources.GetMatch "sunset.jpg" }}
+{{ $resized := $img.Resize "123x" }}
+Resized: {{ $resized.RelPermalink }}|{{ $resized.Width }}
+`)
+
+	b.Build(BuildCfg{})
+
+	b.AssertFileContent("public/my-bundle/index.html", "Resized: /my-bundle/sunset_hu59e56ffff1bc1d8d122b1403d34e039f_90587_123x0_resize_q75_box.jpg|123")
+	b.AssertFileContent("public/nn/mi-bunt/index.html", "Resized: /nn/mi-bunt/sunset_hu59e56ffff1bc1d8d122b1403d34e039f_90587_123x0_resize_q75_box.jpg|123")
+	b.Assert(b.CheckExists("public/my-bundle/sunset_hu59e56ffff1bc1d8d122b1403d34e039f_90587_123x0_resize_q75_box.jpg"), qt.Equals, true)
+	b.Assert(b.CheckExists("public/nn/mi-bunt/sunset_hu59e56ffff1bc1d8d122b1403d34e039f_90587_123x0_resize_q75_box.jpg"), qt.Equals, true)
+
+	// The image is processed and stored in the file cache once.
+	cacheFs := b.H.Sites[0].ResourceSpec.FileCaches.ImageCache().Fs
+	var processed []string
+	afero.Walk(cacheFs, "", func(path string, info os.FileInfo, err error) error {
+		if err == nil && !info.IsDir() {
+			processed = append(processed, filepath.ToSlash(path))
+		}
+		return nil
+	})
+	b.Assert(processed, qt.DeepEquals, []string{"mybundle/sunset_hu59e56ffff1bc1d8d122b1403d34e039f_90587_123x0_resize_q75_box.jpg"})
+}
+
+func TestImageOutputFormatDefaults(t *testing.T) {
+	b := newTestSitesBuilder(t)
+	b.WithConfigFile("toml", `
+baseURL = "https://example.org"
+
+[outputs]
+page = ["HTML", "AMP", "Email"]
+
+[outputFormats]
+[outputFormats.AMP.imaging]
+quality = 50
+[outputFormats.Email]
+mediaType = "text/html"
+baseName = "email"
+isHTML = true
+[outputFormats.Email.imaging]
+format = "png"
+`)
+	b.WithContent("mybundle/index.md", `
+---
+title: "My bundle"
+---
+`)
+	b.WithSunset("content/mybundle/sunset.jpg")
+
+	tpl := `
+{{ $img := .Resources.GetMatch "sunset.jpg" }}
+{{ $resized := $img.Resize "123x" }}
+{{ $explicit := $img.Resize "123x q80" }}
+Resized: {{ $resized.RelPermalink }}|{{ $resized.MediaType }}
+Explicit: {{ $explicit.RelPermalink }}
+`
+	b.WithTemplatesAdded(
+		"_default/single.html", tpl,
+		"_default/single.amp.html", tpl,
+		"_default/single.email.html", tpl,
+	)
+
+	b.Build(BuildCfg{})
+
+	b.AssertFileContent("public/mybundle/index.html",
+		"Resized: /mybundle/sunset_hu59e56ffff1bc1d8d122b1403d34e039f_90587_123x0_resize_q75_box.jpg|image/jpg",
+		"Explicit: /mybundle/sunset_hu59e56ffff1bc1d8d122b1403d34e039f_90587_123x0_resize_q80_box.jpg")
+	b.AssertFileContent("public/amp/mybundle/index.html",
+		"Resized: /amp/mybundle/sunset_hu59e56ffff1bc1d8d122b1403d34e039f_90587_123x0_resize_q50_box.jpg|image/jpg",
+		"Explicit: /amp/mybundle/sunset_hu59e56ffff1bc1d8d122b1403d34e039f_90587_123x0_resize_q80_box.jpg")
+	b.AssertFileContent("public/mybundle/email.html",
+		"Resized: /mybundle/sunset_hu59e56ffff1bc1d8d122b1403d34e039f_90587_123x0_resize_box_2.png|image/png",
+		"Explicit: /mybundle/sunset_hu59e56ffff1bc1d8d122b1403d34e039f_90587_123x0_resize_q80_box_2.png")
+
+	b.Assert(b.CheckExists("public/mybundle/sunset_hu59e56ffff1bc1d8d122b1403d34e039f_90587_123x0_resize_q75_box.jpg"), qt.Equals, true)
+	b.Assert(b.CheckExists("public/amp/mybundle/sunset_hu59e56ffff1bc1d8d122b1403d34e039f_90587_123x0_resize_q50_box.jpg"), qt.Equals, true)
+	b.Assert(b.CheckExists("public/mybundle/sunset_hu59e56ffff1bc1d8d122b1403d34e039f_90587_123x0_resize_box_2.png"), qt.Equals, true)
+}
+
+func TestImageOutputFormatDefaultsShortcode(t *testing.T) {
+	b := newTestSitesBuilder(t)
+	b.WithConfigFile("toml", `
+baseURL = "https://example.org"
+
+[outputs]
+page = ["HTML", "AMP"]
+
+[outputFormats]
+[outputFormats.AMP.imaging]
+quality = 50
+`)
+	b.WithContent("mybundle/index.md", `
+---
+title: "My bundle"
+---
+
+{{< img "sunset.jpg" >}}
+`)
+	b.WithSunset("content/mybundle/sunset.jpg")
+
+	b.WithTemplatesAdded(
+		"shortcodes/img.html", `{{ $img := .Page.Resources.GetMatch (.Get 0) }}{{ $resized := $img.Resize "123x" }}Resized: {{ $resized.RelPermalink }}`,
+		"_default/single.html", `{{ .Content }}`,
+		"_default/single.amp.html", `{{ .Content }}`,
+	)
+
+	b.Build(BuildCfg{})
+
+	b.AssertFileContent("public/mybundle/index.html",
+		"Resized: /mybundle/sunset_hu59e56ffff1bc1d8d122b1403d34e039f_90587_123x0_resize_q75_box.jpg")
+	b.AssertFileContent("public/amp/mybundle/index.html",
+		"Resized: /amp/mybundle/sunset_hu59e56ffff1bc1d8d122b1403d34e039f_90587_123x0_resize_q50_box.jpg")
+}
+
+func TestImageAudit(t *testing.T) {
+	var out bytes.Buffer
+	logger := loggers.NewLogger(jww.LevelWarn, jww.LevelError, &out, ioutil.Discard, false)
+
+	b := newTestSitesBuilder(t).WithLogger(logger).Running()
+	b.WithConfigFile("toml", `
+baseURL = "https://example.org"
+imageAudit = true
+`)
+	b.WithContent("mybundle/index.md", `
+---
+title: "My bundle"
+---
+`)
+	b.WithSunset("content/mybundle/sunset.jpg")
+
+	b.WithTemplatesAdded("_default/single.html", `
+{{ $img := .Resources.GetMatch "sunset.jpg" }}
+{{ $resized := $img.Resize "123x" }}
+{{ $resized2 := $img.Resize "123x" }}
+{{ $filled := $img.Fill "100x100" }}
+Resized: {{ $resized.RelPermalink }}|{{ $resized.Width }}x{{ $resized.Height }}
+`)
+
+	b.Build(BuildCfg{})
+
+	b.AssertFileContent("public/mybundle/index.html", "Resized: /mybundle/sunset_hu59e56ffff1bc1d8d122b1403d34e039f_90587_123x0_resize_q75_box.jpg|123x77")
+	b.Assert(b.CheckExists("public/mybundle/sunset_hu59e56ffff1bc1d8d122b1403d34e039f_90587_123x0_resize_q75_box.jpg"), qt.Equals, false)
+
+	report := out.String()
+	b.Assert(report, qt.Contains, "Image Audit:")
+	b.Assert(report, qt.Contains, "content/mybundle/sunset.jpg: 2 outputs, 3 requests")
+	b.Assert(report, qt.Contains, "  123x0_resize_q75_box")
+	b.Assert(report, qt.Contains, "1 source images, 2 outputs")
+
+	// The audit starts over on rebuilds.
+	out.Reset()
+	b.EditFiles("content/mybundle/index.md", "---\ntitle: My bundle edited\n---\n")
+	b.Build(BuildCfg{})
+	b.Assert(out.String(), qt.Contains, "content/mybundle/sunset.jpg: 2 outputs, 3 requests")
+}
@@ -319,3 +319,44 @@ Content.
 	c.Assert(nnHome.RelPermalink(), qt.Equals, "/nn/")
 
 }
+
+// Content in per language content dirs can be linked by the filename
+// language suffix, by having the same path or by setting translationKey.
+func TestLanguageContentDirTranslationLinking(t *testing.T) {
+	t.Parallel()
+
+	b := newTestSitesBuilder(t)
+	b.WithConfigFile("toml", `
+baseURL = "https://example.org/"
+defaultContentLanguage = "en"
+[languages]
+[languages.en]
+weight = 1
+contentDir = "content/en"
+[languages.fr]
+weight = 2
+contentDir = "content/fr"
+`)
+	b.WithContent(
+		"en/about.md", "---\ntitle: About\n---",
+		"fr/a-propos.md", "---\ntitle: A propos\ntranslationKey: about\n---",
+		"en/blog/_index.md", "---\ntitle: Blog\ntranslationKey: blog\n---",
+		"fr/articles/_index.md", "---\ntitle: Articles\ntranslationKey: blog\n---",
+		"en/contact.md", "---\ntitle: Contact\n---",
+		"en/contact.fr.md", "---\ntitle: Contact FR\n---",
+		"en/faq.md", "---\ntitle: FAQ\n---",
+		"fr/faq.md", "---\ntitle: FAQ FR\n---",
+	)
+
+	tpl := "{{ .Title }}|{{ range .Translations }}{{ .Lang }}: {{ .Title }}|{{ end }}"
+	b.WithTemplatesAdded("_default/single.html", tpl, "_default/list.html", tpl)
+	b.Build(BuildCfg{})
+
+	b.AssertFileContent("public/about/index.html", "About|fr: A propos|")
+	b.AssertFileContent("public/fr/a-propos/index.html", "A propos|en: About|")
+	b.AssertFileContent("public/blog/index.html", "Blog|fr: Articles|")
+	b.AssertFileContent("public/fr/articles/index.html", "Articles|en: Blog|")
+	b.AssertFileContent("public/contact/index.html", "Contact|fr: Contact FR|")
+	b.AssertFileContent("public/fr/contact/index.html", "Contact FR|en: Contact|")
+	b.AssertFileContent("public/faq/index.html", "FAQ|fr: FAQ FR|")
+}
@@ -267,3 +267,71 @@ menu:
 
 	b.AssertFileContent("public/index.html", "A|Children:C|B|")
 }
+
+func TestMenuConfigParamsPageRefAndCurrent(t *testing.T) {
+
+	config := `
+baseURL = "https://example.com"
+
+[[menu.main]]
+identifier = "blog"
+pageRef = "/blog"
+weight = 1
+[menu.main.params]
+Icon = "book"
+
+[[menu.main]]
+parent = "blog"
+pageRef = "/blog/post1"
+weight = 1
+
+[[menu.main]]
+name = "Post 2"
+parent = "blog"
+url = "/blog/post2/"
+weight = 2
+
+[[menu.main]]
+name = "Missing"
+pageRef = "/missing"
+weight = 3
+`
+
+	b := newTestSitesBuilder(t).WithConfigFile("toml", config)
+	b.WithContent("blog/_index.md", `
+---
+title: The Blog
+---
+`)
+	b.WithContent("blog/post1.md", `
+---
+title: Post 1
+---
+`)
+	b.WithContent("blog/post2.md", `
+---
+title: Post 2
+---
+`)
+
+	menuTemplate := `{{ $p := . }}{{ range .Site.Menus.main }}{{ .Name }}|{{ .URL }}|icon:{{ .Params.icon }}|current:{{ $p.IsMenuCurrent "main" . }}|has:{{ $p.HasMenuCurrent "main" . }}|Children:{{ range .Children }}{{ .Name }}|{{ .URL }}|current:{{ $p.IsMenuCurrent "main" . }}|{{ end }}END
+{{ end }}`
+
+	b.WithTemplatesAdded(
+		"_default/single.html", menuTemplate,
+		"_default/list.html", menuTemplate,
+	)
+
+	b.Build(BuildCfg{})
+
+	b.AssertFileContent("public/blog/index.html",
+		"The Blog|/blog/|icon:book|current:true|has:false|Children:Post 1|/blog/post1/|current:false|Post 2|/blog/post2/|current:false|END",
+		"Missing||icon:|current:false|has:false|Children:END",
+	)
+	b.AssertFileContent("public/blog/post1/index.html",
+		"The Blog|/blog/|icon:book|current:false|has:true|Children:Post 1|/blog/post1/|current:true|Post 2|/blog/post2/|current:false|END",
+	)
+	b.AssertFileContent("public/blog/post2/index.html",
+		"The Blog|/blog/|icon:book|current:false|has:true|Children:Post 1|/blog/post1/|current:false|Post 2|/blog/post2/|current:true|END",
+	)
+}
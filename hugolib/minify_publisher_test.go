@@ -61,3 +61,53 @@ func TestMinifyPublisher(t *testing.T) {
 	// Sitemap
 	b.AssertFileContent("public/sitemap.xml", "<?xml version=\"1.0\" encoding=\"utf-8\" standalone=\"yes\"?><urlset xmlns=\"http://www.sitemaps.org/schemas/sitemap/0.9\" xmlns:xhtml=\"http://www.w3.org/1999/xhtml\"><url><loc>h")
 }
+
+func TestMinifyPublisherConfig(t *testing.T) {
+	t.Parallel()
+
+	b := newTestSitesBuilder(t)
+	b.WithConfigFile("toml", `
+baseURL = "https://example.org/"
+
+[minify]
+minifyOutput = true
+disableXML = true
+[minify.html]
+keepWhitespace = true
+`)
+	b.WithTemplatesAdded("layouts/index.html", `
+<!DOCTYPE html>
+<html lang="en">
+<body>
+	<h1>{{ .Title }}</h1>    <p>Hello</p>
+</body>
+</html>
+`)
+	b.Build(BuildCfg{})
+
+	b.AssertFileContent("public/index.html", "<!doctype html><html lang=en>\n<body>\n<h1></h1> <p>Hello</p>")
+	b.AssertFileContent("public/sitemap.xml", "<urlset xmlns=\"http://www.sitemaps.org/schemas/sitemap/0.9\"\n")
+}
+
+func TestMinifyPublisherExclude(t *testing.T) {
+	t.Parallel()
+
+	b := newTestSitesBuilder(t)
+	b.WithConfigFile("toml", `
+baseURL = "https://example.org/"
+
+[minify]
+minifyOutput = true
+excludeOutputFormats = ["rss"]
+excludePaths = ["posts/**"]
+`)
+	b.WithTemplatesAdded("layouts/_default/single.html", `<p>  Single  </p>`,
+		"layouts/index.html", `<p>  Home  </p>`)
+	b.WithContent("posts/p1.md", "---\ntitle: P1\n---")
+	b.Build(BuildCfg{})
+
+	b.AssertFileContent("public/index.html", "<p>Home</p>")
+	b.AssertFileContent("public/posts/p1/index.html", "<p>  Single  </p>")
+	b.AssertFileContent("public/index.xml", "<rss version=\"2.0\" xmlns:atom=\"http://www.w3.org/2005/Atom\">\n")
+	b.AssertFileContent("public/sitemap.xml", "<urlset xmlns=\"http://www.sitemaps.org/schemas/sitemap/0.9\" xmlns:xhtml=\"http://www.w3.org/1999/xhtml\"><url>")
+}
@@ -20,6 +20,7 @@ import (
 	"os"
 	"path"
 	"path/filepath"
+	"regexp"
 	"sort"
 	"strings"
 
@@ -46,7 +47,9 @@ import (
 	"github.com/gohugoio/hugo/common/text"
 	"github.com/gohugoio/hugo/resources"
 	"github.com/gohugoio/hugo/resources/page"
+	"github.com/gohugoio/hugo/resources/page/pagemeta"
 	"github.com/gohugoio/hugo/resources/resource"
+	"github.com/spf13/cast"
 )
 
 var (
@@ -82,7 +85,14 @@ type pageSiteAdapter struct {
 }
 
 func (pa pageSiteAdapter) GetPage(ref string) (page.Page, error) {
+	pa.s.Info.siteAccessed()
 	p, err := pa.s.getPageNew(pa.p, ref)
+	for _, fs := range pa.s.fallbackSites() {
+		if p != nil || err != nil {
+			break
+		}
+		p, err = fs.getPageNew(pa.p, ref)
+	}
 	if p == nil {
 		// The nil struct has meaning in some situations, mostly to avoid breaking
 		// existing sites doing $nilpage.IsDescendant($p), which will always return
@@ -115,6 +125,12 @@ func (p *pageState) Eq(other interface{}) bool {
 	return p == pp
 }
 
+// Store returns a Scratch that, unlike Scratch, is not reset when
+// the site is rebuilt.
+func (p *pageState) Store() *maps.Scratch {
+	return p.store
+}
+
 func (p *pageState) GitInfo() *gitmap.GitInfo {
 	return p.gitInfo
 }
@@ -139,6 +155,21 @@ func (p *pageState) getPagesAndSections() page.Pages {
 	return b.getPagesAndSections()
 }
 
+// withFallbacks adds the pages returned by get for the translations of this
+// page in the fallback languages that are not translated to this page's
+// language.
+func (p *pageState) withFallbacks(pages page.Pages, get func(p *pageState) page.Pages) page.Pages {
+	for _, fs := range p.s.fallbackSites() {
+		for _, t := range p.Translations() {
+			if pt, ok := t.(*pageState); ok && pt.Language().Lang == fs.language.Lang {
+				pages = pages.MergeByLanguage(get(pt))
+				break
+			}
+		}
+	}
+	return pages
+}
+
 // TODO(bep) cm add a test
 func (p *pageState) RegularPages() page.Pages {
 	p.regularPagesInit.Do(func() {
@@ -147,8 +178,10 @@ func (p *pageState) RegularPages() page.Pages {
 		switch p.Kind() {
 		case page.KindPage:
 		case page.KindHome:
-			pages = p.s.RegularPages()
-		case page.KindSection, page.KindTaxonomyTerm:
+			pages = p.s.Info.RegularPages()
+		case page.KindSection:
+			pages = p.withFallbacks(p.getPages(), (*pageState).getPages)
+		case page.KindTaxonomyTerm:
 			pages = p.getPages()
 		case page.KindTaxonomy:
 			all := p.Pages()
@@ -178,9 +211,9 @@ func (p *pageState) Pages() page.Pages {
 			// See https://github.com/gohugoio/hugo/issues/6238
 			// Note: When making the change below, also remember RegularPages.
 			p.s.DistinctWarningLog.Println(`In the next Hugo version (0.58.0) we will change how $home.Pages behaves. If you want to list all regular pages, replace .Pages or .Data.Pages with .Site.RegularPages in your home page template.`)
-			pages = p.s.RegularPages()
+			pages = p.s.Info.RegularPages()
 		case page.KindSection:
-			pages = p.getPagesAndSections()
+			pages = p.withFallbacks(p.getPagesAndSections(), (*pageState).getPagesAndSections)
 		case page.KindTaxonomy:
 			termInfo := p.bucket
 			plural := maps.GetString(termInfo.meta, "plural")
@@ -277,6 +310,8 @@ func (p *pageState) IsTranslated() bool {
 // TranslationKey returns the key used to map language translations of this page.
 // It will use the translationKey set in front matter if set, or the content path and
 // filename (excluding any language code and extension), e.g. "about/index".
+// Taxonomy pages use the singular taxonomy name and the term key, so taxonomies
+// with a translated plural name, e.g. "tags" and "etiquetas", are linked.
 // The Page Kind is always prepended.
 func (p *pageState) TranslationKey() string {
 	p.translationKeyInit.Do(func() {
@@ -284,6 +319,8 @@ func (p *pageState) TranslationKey() string {
 			p.translationKey = p.Kind() + "/" + p.m.translationKey
 		} else if p.IsPage() && !p.File().IsZero() {
 			p.translationKey = path.Join(p.Kind(), filepath.ToSlash(p.File().Dir()), p.File().TranslationBaseName())
+		} else if singular := p.taxonomySingular(); singular != "" {
+			p.translationKey = path.Join(p.Kind(), singular, maps.GetString(p.bucket.meta, "termKey"))
 		} else if p.IsNode() {
 			p.translationKey = path.Join(p.Kind(), p.SectionsPath())
 		}
@@ -294,6 +331,24 @@ func (p *pageState) TranslationKey() string {
 
 }
 
+// GetTerms returns the taxonomy term pages of this page for the given
+// taxonomy, e.g. "tags".
+func (p *pageState) GetTerms(taxonomy string) page.Pages {
+	return p.terms[strings.ToLower(taxonomy)]
+}
+
+// taxonomySingular returns the singular taxonomy name, e.g. "tag", for
+// taxonomy and taxonomy term pages, else an empty string.
+func (p *pageState) taxonomySingular() string {
+	if p.Kind() != page.KindTaxonomy && p.Kind() != page.KindTaxonomyTerm {
+		return ""
+	}
+	if p.bucket == nil {
+		return ""
+	}
+	return maps.GetString(p.bucket.meta, "singular")
+}
+
 // AllTranslations returns all translations, including the current Page.
 func (p *pageState) AllTranslations() page.Pages {
 	p.s.h.init.translations.Do()
@@ -340,7 +395,7 @@ func (p *pageState) getLayoutDescriptor() output.LayoutDescriptor {
 				section = sections[0]
 			}
 		case page.KindTaxonomyTerm, page.KindTaxonomy:
-			section = maps.GetString(p.bucket.meta, "singular")
+			section = p.taxonomySingular()
 
 		default:
 		}
@@ -408,9 +463,15 @@ func (p *pageState) renderResources() (err error) {
 
 		for i, r := range p.Resources() {
 
-			if _, ok := r.(page.Page); ok {
+			if rp, ok := r.(page.Page); ok {
 				// Pages gets rendered with the owning page but we count them here.
 				p.s.PathSpec.ProcessingStats.Incr(&p.s.PathSpec.ProcessingStats.Pages)
+				if ps, ok := rp.(*pageState); ok && ps.m.headless {
+					// A headless bundle nested in this bundle, publish its resources.
+					if err = ps.renderResources(); err != nil {
+						return
+					}
+				}
 				continue
 			}
 
@@ -527,6 +588,32 @@ func (p *pageState) addResources(r ...resource.Resource) {
 	p.resources = append(p.resources, r...)
 }
 
+// headlessInFrontMatter reports whether the page is marked as headless in
+// its front matter. Unlike m.headless, this can be used before the page's
+// metadata is set. Any front matter error is reported later, in mapContent.
+func (p *pageState) headlessInFrontMatter() bool {
+	iter := p.source.parsed.Iterator()
+	for {
+		it := iter.Next()
+		switch {
+		case it.Type == pageparser.TypeIgnore:
+		case it.IsFrontMatter():
+			m, err := metadecoders.Default.UnmarshalToMap(it.Val, metadecoders.FormatFromFrontMatterType(it.Type))
+			if err != nil {
+				return false
+			}
+			for k, v := range m {
+				if strings.EqualFold(k, "headless") {
+					return cast.ToBool(v)
+				}
+			}
+			return false
+		default:
+			return false
+		}
+	}
+}
+
 func (p *pageState) mapContent(bucket *pagesMapBucket, meta *pageMeta) error {
 
 	s := p.shortcodeState
@@ -547,6 +634,7 @@ func (p *pageState) mapContent(bucket *pagesMapBucket, meta *pageMeta) error {
 	// … it's safe to keep some "global" state
 	var currShortcode shortcode
 	var ordinal int
+	var hasFrontMatter bool
 
 Loop:
 	for {
@@ -584,6 +672,9 @@ Loop:
 				return nil
 			}
 
+			hasFrontMatter = true
+			p.validateFrontMatter(iter.Input(), it.Pos, it.Val, m)
+
 		case it.Type == pageparser.TypeLeadSummaryDivider:
 			posBody := -1
 			f := func(item pageparser.Item) bool {
@@ -660,11 +751,44 @@ Loop:
 		}
 	}
 
+	if !hasFrontMatter {
+		p.validateFrontMatter(iter.Input(), 0, nil, nil)
+	}
+
 	p.cmap = rn
 
 	return nil
 }
 
+// validateFrontMatter logs the front matter fields that does not follow
+// the configured front matter schema, if any. The front matter is validated
+// after any cascade is applied and with the dates resolved from other
+// sources, e.g. the filename or Git. Fields of the wrong type
+// are logged with the position of the key, missing fields with the
+// position of the front matter.
+func (p *pageState) validateFrontMatter(input []byte, pos int, frontMatter []byte, m map[string]interface{}) {
+	violations := p.s.frontmatterSchema.Validate(p.Type(), p.m.frontMatterWithDates(m))
+	if len(violations) == 0 {
+		return
+	}
+
+	logger := p.s.Log.WARN
+	if p.s.frontmatterSchema.Severity() == pagemeta.FrontMatterSchemaSeverityError {
+		logger = p.s.Log.ERROR
+	}
+
+	for _, v := range violations {
+		offset := pos
+		if v.Present {
+			keyRe := regexp.MustCompile(`(?mi)^[ \t]*["']?` + regexp.QuoteMeta(v.Key) + `["']?[ \t]*[:=]`)
+			if loc := keyRe.FindIndex(frontMatter); loc != nil {
+				offset += loc[0] + bytes.IndexFunc(frontMatter[loc[0]:], func(r rune) bool { return r != ' ' && r != '\t' })
+			}
+		}
+		logger.Printf("%s: %s", p.posFromInput(input, offset), v.Message)
+	}
+}
+
 func (p *pageState) errorf(err error, format string, a ...interface{}) error {
 	if herrors.UnwrapErrorWithFileContext(err) != nil {
 		// More isn't always better.
@@ -38,6 +38,9 @@ type pageCommon struct {
 	metaInit   sync.Once
 	metaInitFn func(bucket *pagesMapBucket) error
 
+	// Lives for the whole build, see Store.
+	store *maps.Scratch
+
 	// All of these represents the common parts of a page.Page
 	maps.Scratcher
 	navigation.PageMenusProvider
@@ -109,6 +112,10 @@ type pageCommon struct {
 	// Will only be set for bundled pages.
 	parent *pageState
 
+	// The taxonomy term pages this page is assigned to, keyed by the
+	// plural taxonomy name. Set when the taxonomies are assembled.
+	terms map[string]page.Pages
+
 	// Set in fast render mode to force render a given page.
 	forceRender bool
 }
@@ -306,6 +306,29 @@ func (p *pageMeta) Weight() int {
 	return p.weight
 }
 
+// frontMatterWithDates returns a copy of the front matter, which
+// setMetadata has merged any cascade into, with the dates resolved from
+// other sources, e.g. the filename or Git, for the date keys not set in it.
+func (pm *pageMeta) frontMatterWithDates(frontmatter map[string]interface{}) map[string]interface{} {
+	m := make(map[string]interface{})
+	for k, v := range frontmatter {
+		m[k] = v
+	}
+
+	for k, d := range map[string]time.Time{
+		"date":        pm.Date(),
+		"lastmod":     pm.Lastmod(),
+		"publishdate": pm.PublishDate(),
+		"expirydate":  pm.ExpiryDate(),
+	} {
+		if _, found := m[k]; !found && !d.IsZero() {
+			m[k] = d
+		}
+	}
+
+	return m
+}
+
 func (pm *pageMeta) setMetadata(bucket *pagesMapBucket, p *pageState, frontmatter map[string]interface{}) error {
 	if frontmatter == nil && bucket.cascade == nil {
 		return errors.New("missing frontmatter data")
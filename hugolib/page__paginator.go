@@ -17,6 +17,7 @@ import (
 	"sync"
 
 	"github.com/gohugoio/hugo/resources/page"
+	"github.com/spf13/cast"
 )
 
 func newPagePaginator(source *pageState) *pagePaginator {
@@ -41,10 +42,21 @@ func (p *pagePaginator) reset() {
 	p.pagePaginatorInit = &pagePaginatorInit{}
 }
 
+// pagerSize returns the default pager size for the source page: paginate
+// set in front matter (or cascaded from a section) or in the site config.
+func (p *pagePaginator) pagerSize() int {
+	if v, found := p.source.Params()["paginate"]; found {
+		if size := cast.ToInt(v); size > 0 {
+			return size
+		}
+	}
+	return p.source.s.Cfg.GetInt("paginate")
+}
+
 func (p *pagePaginator) Paginate(seq interface{}, options ...interface{}) (*page.Pager, error) {
 	var initErr error
 	p.init.Do(func() {
-		pagerSize, err := page.ResolvePagerSize(p.source.s.Cfg, options...)
+		opts, err := page.ResolvePaginateOptions(p.pagerSize(), options...)
 		if err != nil {
 			initErr = err
 			return
@@ -52,7 +64,7 @@ func (p *pagePaginator) Paginate(seq interface{}, options ...interface{}) (*page
 
 		pd := p.source.targetPathDescriptor
 		pd.Type = p.source.outputFormat()
-		paginator, err := page.Paginate(pd, seq, pagerSize)
+		paginator, err := page.PaginateWithOptions(pd, seq, opts)
 		if err != nil {
 			initErr = err
 			return
@@ -72,7 +84,7 @@ func (p *pagePaginator) Paginate(seq interface{}, options ...interface{}) (*page
 func (p *pagePaginator) Paginator(options ...interface{}) (*page.Pager, error) {
 	var initErr error
 	p.init.Do(func() {
-		pagerSize, err := page.ResolvePagerSize(p.source.s.Cfg, options...)
+		opts, err := page.ResolvePaginateOptions(p.pagerSize(), options...)
 		if err != nil {
 			initErr = err
 			return
@@ -90,7 +102,7 @@ func (p *pagePaginator) Paginator(options ...interface{}) (*page.Pager, error) {
 		} else {
 			pages = p.source.RegularPages()
 		}
-		paginator, err := page.Paginate(pd, pages, pagerSize)
+		paginator, err := page.PaginateWithOptions(pd, pages, opts)
 		if err != nil {
 			initErr = err
 			return
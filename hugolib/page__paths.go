@@ -15,11 +15,13 @@ package hugolib
 
 import (
 	"net/url"
+	"path/filepath"
 	"strings"
 
 	"github.com/gohugoio/hugo/helpers"
 
 	"github.com/gohugoio/hugo/resources/page"
+	"github.com/spf13/cast"
 )
 
 func newPagePaths(
@@ -120,16 +122,25 @@ func createTargetPathDescriptor(s *Site, p page.Page, pm *pageMeta) (page.Target
 
 	alwaysInSubDir := p.Kind() == kindSitemap
 
+	sections := p.SectionsEntries()
+	if p.Kind() == page.KindPage {
+		dir = s.translateDir(dir, sections)
+	}
+
 	desc := page.TargetPathDescriptor{
 		PathSpec:    d.PathSpec,
 		Kind:        p.Kind(),
-		Sections:    p.SectionsEntries(),
+		Sections:    s.translateSections(p.Kind(), sections),
 		UglyURLs:    s.Info.uglyURLs(p),
 		ForcePrefix: s.h.IsMultihost() || alwaysInSubDir,
 		Dir:         dir,
 		URL:         pm.urlPaths.URL,
 	}
 
+	if v, found := pm.params["paginatepath"]; found {
+		desc.PaginatePath = cast.ToString(v)
+	}
+
 	if pm.Slug() != "" {
 		desc.BaseName = pm.Slug()
 	} else {
@@ -160,3 +171,66 @@ func createTargetPathDescriptor(s *Site, p page.Page, pm *pageMeta) (page.Target
 	return desc, nil
 
 }
+
+// decodeTermSlugs decodes the termSlugs config, e.g.
+// {"tag": {"red": "rojo"}}.
+func decodeTermSlugs(m map[string]interface{}) map[string]map[string]string {
+	slugs := make(map[string]map[string]string)
+	for singular, terms := range m {
+		slugs[strings.ToLower(singular)] = cast.ToStringMapString(terms)
+	}
+	return slugs
+}
+
+// translateSections returns the URL path segments of a list page with the
+// given sections, with the sections and taxonomy terms translated as
+// configured in sectionSlugs and termSlugs.
+func (s *Site) translateSections(kind string, sections []string) []string {
+	switch kind {
+	case page.KindSection:
+		if len(s.siteCfg.sectionSlugs) == 0 {
+			return sections
+		}
+		translated := make([]string, len(sections))
+		for i, section := range sections {
+			translated[i] = s.sectionSlug(section)
+		}
+		return translated
+	case page.KindTaxonomy:
+		if len(sections) != 2 {
+			return sections
+		}
+		for singular, plural := range s.siteCfg.taxonomiesConfig {
+			if plural != sections[0] {
+				continue
+			}
+			if slug, found := s.siteCfg.termSlugs[singular][strings.ToLower(sections[1])]; found {
+				return []string{sections[0], slug}
+			}
+		}
+	}
+	return sections
+}
+
+// translateDir translates the leading directories of a regular page's dir
+// that are the page's sections as configured in sectionSlugs.
+func (s *Site) translateDir(dir string, sections []string) string {
+	if len(s.siteCfg.sectionSlugs) == 0 || dir == "" {
+		return dir
+	}
+	parts := strings.Split(filepath.ToSlash(dir), "/")
+	for i := 0; i < len(sections) && i < len(parts); i++ {
+		if parts[i] != sections[i] {
+			break
+		}
+		parts[i] = s.sectionSlug(parts[i])
+	}
+	return filepath.FromSlash(strings.Join(parts, "/"))
+}
+
+func (s *Site) sectionSlug(section string) string {
+	if slug, found := s.siteCfg.sectionSlugs[strings.ToLower(section)]; found {
+		return slug
+	}
+	return section
+}
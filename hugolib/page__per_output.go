@@ -86,7 +86,7 @@ func newPageContentOutput(p *pageState) func(f output.Format) (*pageContentOutpu
 				return err
 			}
 
-			if p.render && !hasVariants {
+			if p.render && !hasVariants && !hasImageDefaults(p.m.outputFormats()) {
 				// We can reuse this for the other output formats
 				cp.enableReuse()
 			}
@@ -184,6 +184,8 @@ func newPageContentOutput(p *pageState) func(f output.Format) (*pageContentOutpu
 				return err, nil
 			}
 
+			cp.plainSummary = strings.TrimSpace(helpers.StripHTML(string(cp.summary)))
+
 			return nil, nil
 		})
 
@@ -222,6 +224,7 @@ type pageContentOutput struct {
 	// Content sections
 	content         template.HTML
 	summary         template.HTML
+	plainSummary    string
 	tableOfContents template.HTML
 
 	truncated bool
@@ -231,6 +234,7 @@ type pageContentOutput struct {
 	fuzzyWordCount int
 	wordCount      int
 	readingTime    int
+	stats          page.ContentStats
 }
 
 func (p *pageContentOutput) Content() (interface{}, error) {
@@ -263,6 +267,11 @@ func (p *pageContentOutput) ReadingTime() int {
 	return p.readingTime
 }
 
+func (p *pageContentOutput) Stats() page.ContentStats {
+	p.p.s.initInit(p.initPlain, p.p)
+	return p.stats
+}
+
 func (p *pageContentOutput) Summary() template.HTML {
 	p.p.s.initInit(p.initMain, p.p)
 	if !p.p.source.hasSummaryDivider {
@@ -271,6 +280,11 @@ func (p *pageContentOutput) Summary() template.HTML {
 	return p.summary
 }
 
+func (p *pageContentOutput) PlainSummary() string {
+	p.p.s.initInit(p.initPlain, p.p)
+	return p.plainSummary
+}
+
 func (p *pageContentOutput) TableOfContents() template.HTML {
 	p.p.s.initInit(p.initMain, p.p)
 	return p.tableOfContents
@@ -297,10 +311,17 @@ func (p *pageContentOutput) setAutoSummary() error {
 	var summary string
 	var truncated bool
 
-	if p.p.m.isCJKLanguage {
-		summary, truncated = p.p.s.ContentSpec.TruncateWordsByRune(p.plainWords)
-	} else {
-		summary, truncated = p.p.s.ContentSpec.TruncateWordsToWholeSentence(p.plain)
+	switch p.p.s.ContentSpec.SummaryLengthUnit() {
+	case helpers.SummaryLengthUnitParagraphs:
+		summary, truncated = p.p.s.ContentSpec.TruncateParagraphs(string(p.content))
+	case helpers.SummaryLengthUnitCharacters:
+		summary, truncated = p.p.s.ContentSpec.TruncateCharacters(p.plain, p.p.m.isCJKLanguage)
+	default:
+		if p.p.m.isCJKLanguage {
+			summary, truncated = p.p.s.ContentSpec.TruncateWordsByRune(p.plainWords)
+		} else {
+			summary, truncated = p.p.s.ContentSpec.TruncateWordsToWholeSentence(p.plain)
+		}
 	}
 	p.summary = template.HTML(summary)
 
@@ -338,10 +359,13 @@ func (p *pageContentOutput) setWordCounts(isCJKLanguage bool) {
 		p.fuzzyWordCount = (p.wordCount + 100) / 100 * 100
 	}
 
-	if isCJKLanguage {
-		p.readingTime = (p.wordCount + 500) / 501
-	} else {
-		p.readingTime = (p.wordCount + 212) / 213
+	p.readingTime = p.p.s.ContentSpec.ReadingTime(p.wordCount, isCJKLanguage)
+
+	p.stats = page.ContentStats{
+		Words:       p.wordCount,
+		Characters:  helpers.TotalCharacters(p.plain),
+		ReadingTime: p.readingTime,
+		CodeBlocks:  helpers.TotalCodeBlocks(string(p.content)),
 	}
 }
 
@@ -362,6 +386,34 @@ func (p *pageContentOutput) enablePlaceholders() {
 	})
 }
 
+// evict frees the rendered content. It will be rendered again on demand.
+// This must not be called while the page is being rendered.
+func (p *pageContentOutput) evict() {
+	p.initMain.Reset()
+	p.initPlain.Reset()
+
+	p.workContent = nil
+	p.contentPlaceholders = nil
+	p.content = ""
+	p.summary = ""
+	p.plainSummary = ""
+	p.tableOfContents = ""
+	p.plainWords = nil
+	p.plain = ""
+}
+
+// hasImageDefaults reports whether any of the given output formats sets
+// image processing defaults. Images processed in shortcodes then differ
+// per output format, so the content cannot be reused.
+func hasImageDefaults(formats output.Formats) bool {
+	for _, f := range formats {
+		if f.Imaging != (output.ImageDefaults{}) {
+			return true
+		}
+	}
+	return false
+}
+
 func (p *pageContentOutput) enableReuse() {
 	p.reuseInit.Do(func() {
 		p.reuse = true
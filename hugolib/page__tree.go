@@ -23,6 +23,19 @@ type pageTree struct {
 	p *pageState
 }
 
+func (pt pageTree) Ancestors() page.Pages {
+	if pt.p == nil {
+		return nil
+	}
+
+	var ancestors page.Pages
+	for parent := pt.Parent(); !types.IsNil(parent); parent = parent.Parent() {
+		ancestors = append(ancestors, parent)
+	}
+
+	return ancestors
+}
+
 func (pt pageTree) IsAncestor(other interface{}) (bool, error) {
 	if pt.p == nil {
 		return false, nil
@@ -33,6 +46,11 @@ func (pt pageTree) IsAncestor(other interface{}) (bool, error) {
 		return false, err
 	}
 
+	if pt.p.IsHome() {
+		// The home page is the ancestor of all the other pages.
+		return !pp.IsHome(), nil
+	}
+
 	if pt.p.Kind() == page.KindPage && len(pt.p.SectionsEntries()) == len(pp.SectionsEntries()) {
 		// A regular page is never its section's ancestor.
 		return false, nil
@@ -48,7 +66,7 @@ func (pt pageTree) CurrentSection() page.Page {
 		return p
 	}
 
-	return p.Parent()
+	return pt.Parent()
 }
 
 func (pt pageTree) IsDescendant(other interface{}) (bool, error) {
@@ -60,6 +78,10 @@ func (pt pageTree) IsDescendant(other interface{}) (bool, error) {
 		return false, err
 	}
 
+	if pp.IsHome() {
+		return !pt.p.IsHome(), nil
+	}
+
 	if pp.Kind() == page.KindPage && len(pt.p.SectionsEntries()) == len(pp.SectionsEntries()) {
 		// A regular page is never its section's descendant.
 		return false, nil
@@ -70,12 +92,14 @@ func (pt pageTree) IsDescendant(other interface{}) (bool, error) {
 func (pt pageTree) FirstSection() page.Page {
 	p := pt.p
 
-	parent := p.Parent()
+	parent := pt.treeParent()
 
 	if types.IsNil(parent) || parent.IsHome() {
 		return p
 	}
 
+	p.s.Info.siteAccessed()
+
 	for {
 		current := parent
 		parent = parent.Parent()
@@ -100,7 +124,7 @@ func (pt pageTree) InSection(other interface{}) (bool, error) {
 		return false, nil
 	}
 
-	return pp.CurrentSection().Eq(pt.p.CurrentSection()), nil
+	return currentSection(pp).Eq(currentSection(pt.p)), nil
 
 }
 
@@ -108,7 +132,17 @@ func (pt pageTree) Page() page.Page {
 	return pt.p
 }
 
+// Parent returns the page's parent section. Its pages are not the page's own,
+// so the access is counted as a site wide access, see listPageDeps.
 func (pt pageTree) Parent() page.Page {
+	parent := pt.treeParent()
+	if !types.IsNil(parent) {
+		pt.p.s.Info.siteAccessed()
+	}
+	return parent
+}
+
+func (pt pageTree) treeParent() page.Page {
 	if pt.p.parent != nil {
 		return pt.p.parent
 	}
@@ -127,3 +161,14 @@ func (pt pageTree) Sections() page.Pages {
 
 	return pt.p.bucket.getSections()
 }
+
+// currentSection is CurrentSection without counting any site wide access.
+func currentSection(p page.Page) page.Page {
+	if p.IsHome() || p.IsSection() {
+		return p
+	}
+	if ps, ok := p.(*pageState); ok {
+		return pageTree{p: ps}.treeParent()
+	}
+	return p.CurrentSection()
+}
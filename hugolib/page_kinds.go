@@ -41,6 +41,13 @@ const (
 	pageResourceType = "page"
 )
 
+// AllKinds returns all the page kinds that can be disabled with
+// disableKinds, including the kinds only used to render the RSS, sitemap,
+// robots.txt and 404 output formats.
+func AllKinds() []string {
+	return append([]string(nil), allKinds...)
+}
+
 var kindMap = map[string]string{
 	strings.ToLower(kindRSS):       kindRSS,
 	strings.ToLower(kindSitemap):   kindSitemap,
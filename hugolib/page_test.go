@@ -16,6 +16,7 @@ package hugolib
 import (
 	"fmt"
 	"html/template"
+	"io/ioutil"
 	"os"
 
 	"github.com/gohugoio/hugo/config"
@@ -32,6 +33,7 @@ import (
 	"github.com/gohugoio/hugo/resources/page"
 	"github.com/gohugoio/hugo/resources/resource"
 	"github.com/spf13/afero"
+	jww "github.com/spf13/jwalterweatherman"
 	"github.com/spf13/viper"
 
 	qt "github.com/frankban/quicktest"
@@ -1523,6 +1525,112 @@ Summary: In Chinese, 好 means good.
 
 }
 
+func TestPageStats(t *testing.T) {
+	t.Parallel()
+
+	b := newTestSitesBuilder(t).WithConfigFile("toml", `
+baseURL = "https://example.org"
+defaultContentLanguage = "en"
+
+[languages]
+[languages.en]
+weight = 1
+[languages.nn]
+weight = 2
+wordsPerMinute = 100
+`)
+
+	content := fmt.Sprintf(`---
+title: p1
+---
+
+%s
+
+`+"```go\nfmt.Println(42)\n```\n\n    indented code\n", strings.Repeat("word ", 250))
+
+	b.WithContent("p1.md", content, "p1.nn.md", content)
+
+	// Chroma renders a block with table line numbers as two pre elements.
+	b.WithContent("p2.md", `---
+title: p2
+---
+
+{{< highlight go "linenos=table" >}}
+fmt.Println(42)
+{{< /highlight >}}
+
+{{< highlight go >}}
+fmt.Println(43)
+{{< /highlight >}}
+`)
+
+	b.WithTemplatesAdded("_default/single.html", `
+Stats: {{ .Stats.Words }}|{{ .Stats.Characters }}|{{ .Stats.ReadingTime }}|{{ .Stats.CodeBlocks }}|
+ReadingTime: {{ .ReadingTime }}|
+`)
+
+	b.Build(BuildCfg{})
+
+	b.AssertFileContent("public/p1/index.html", "Stats: 253|1027|2|2|", "ReadingTime: 2|")
+	b.AssertFileContent("public/nn/p1/index.html", "Stats: 253|1027|3|2|", "ReadingTime: 3|")
+	b.AssertFileContent("public/p2/index.html", "|2|\nReadingTime")
+}
+
+func TestSummaryLengthUnit(t *testing.T) {
+	t.Parallel()
+
+	b := newTestSitesBuilder(t).WithConfigFile("toml", `
+baseURL = "https://example.org"
+defaultContentLanguage = "en"
+summaryLength = 2
+summaryLengthUnit = "paragraphs"
+
+[languages]
+[languages.en]
+weight = 1
+[languages.zh]
+weight = 2
+summaryLength = 5
+summaryLengthUnit = "characters"
+hasCJKLanguage = true
+`)
+
+	b.WithTemplatesAdded("_default/single.html", `
+Summary: {{ .Summary }}|
+PlainSummary: {{ .PlainSummary }}|
+Truncated: {{ .Truncated }}|
+`)
+
+	b.WithContent("p1.md", `---
+title: p1
+---
+
+First *paragraph*.
+
+Second paragraph.
+
+Third paragraph.
+`,
+		"p1.zh.md", `---
+title: p1
+---
+
+这是中文，全中文。
+`)
+
+	b.Build(BuildCfg{})
+
+	b.AssertFileContent("public/p1/index.html",
+		"Summary: <p>First <em>paragraph</em>.</p>\n\n<p>Second paragraph.</p>|",
+		"PlainSummary: First paragraph.\n\nSecond paragraph.|",
+		"Truncated: true|")
+
+	b.AssertFileContent("public/zh/p1/index.html",
+		"Summary: 这是中文，|",
+		"PlainSummary: 这是中文，|",
+		"Truncated: true|")
+}
+
 func TestScratchSite(t *testing.T) {
 	t.Parallel()
 
@@ -1604,3 +1712,119 @@ author = "Jo Nesbø"
 	b.AssertFileContent("public/index.html", "Author site config:  Kurt Vonnegut")
 
 }
+
+func TestHeadingIDTypeAndAnchorize(t *testing.T) {
+	t.Parallel()
+
+	for _, headingIDType := range []string{"unicode", "ascii"} {
+		b := newTestSitesBuilder(t).WithConfigFile("toml", fmt.Sprintf(`
+baseURL = "https://example.org"
+
+[blackfriday]
+headingIDType = %q
+`, headingIDType))
+
+		b.WithContent("p1.md", `---
+title: "P1"
+---
+
+## Crème Brûlée
+
+## Title 日本語 End
+`)
+		b.WithTemplatesAdded("_default/single.html", `{{ .Content }}|anchorize: {{ "Crème Brûlée" | anchorize }}|urlize: {{ "Crème Brûlée" | urlize }}|urlize CJK: {{ "Title 日本語 End" | urlize }}|`)
+
+		b.Build(BuildCfg{})
+
+		if headingIDType == "ascii" {
+			b.AssertFileContent("public/p1/index.html", `<h2 id="creme-brulee">`, "|anchorize: creme-brulee|", "|urlize: creme-brulee|", `<h2 id="title--end">`, "|urlize CJK: title--end|")
+		} else {
+			b.AssertFileContent("public/p1/index.html", `<h2 id="crème-brûlée">`, "|anchorize: crème-brûlée|", "|urlize: cr%C3%A8me-br%C3%BBl%C3%A9e|")
+		}
+	}
+}
+
+func TestPageFrontMatterSchema(t *testing.T) {
+	t.Parallel()
+
+	config := `
+baseURL = "http://example.com/"
+disableKinds = ["taxonomy", "taxonomyTerm", "RSS", "sitemap", "robotsTXT", "404"]
+
+[frontmatter]
+schemaSeverity = %q
+[frontmatter.schema._default]
+required = ["title"]
+[frontmatter.schema.posts]
+required = ["description", "date"]
+[frontmatter.schema.posts.types]
+tags = "slice"
+weight = "int"
+`
+
+	for _, severity := range []string{"error", "warning"} {
+		logger := loggers.NewLogger(jww.LevelError, jww.LevelError, ioutil.Discard, ioutil.Discard, true)
+		b := newTestSitesBuilder(t).WithLogger(logger).WithConfigFile("toml", fmt.Sprintf(config, severity))
+
+		b.WithContent(
+			"posts/p1.md", "---\ntitle: P1\ndescription: The P1\ndate: 2019-12-01\ntags: [\"a\"]\n---\n",
+			"posts/p2.md", "---\ntitle: P2\nweight: \"32\"\ntags: a\n---\n",
+			"docs/d1.md", "+++\ndraft = false\n+++\n",
+			"docs/d2.md", "No front matter.",
+		)
+		b.WithTemplates("_default/single.html", "{{ .Title }}", "_default/list.html", "{{ .Title }}", "index.html", "Home")
+
+		if severity == "warning" {
+			b.Build(BuildCfg{})
+			b.Assert(logger.Errors(), qt.Equals, "")
+			b.Assert(int(logger.WarnCounter.Count()), qt.Equals, 6)
+			continue
+		}
+
+		b.BuildFail(BuildCfg{})
+
+		errors := logger.Errors()
+		b.Assert(errors, qt.Not(qt.Contains), "p1.md")
+		b.Assert(errors, qt.Contains, filepath.FromSlash(`posts/p2.md:2:1": required front matter field "date" is missing`))
+		b.Assert(errors, qt.Contains, filepath.FromSlash(`posts/p2.md:2:1": required front matter field "description" is missing`))
+		b.Assert(errors, qt.Contains, filepath.FromSlash(`posts/p2.md:4:1": front matter field "tags" must be of type slice, got string`))
+		b.Assert(errors, qt.Contains, filepath.FromSlash(`posts/p2.md:3:1": front matter field "weight" must be of type int, got string`))
+		b.Assert(errors, qt.Contains, filepath.FromSlash(`docs/d1.md:2:1": required front matter field "title" is missing`))
+		b.Assert(errors, qt.Contains, filepath.FromSlash(`docs/d2.md:1:1": required front matter field "title" is missing`))
+	}
+}
+
+func TestPageFrontMatterSchemaResolved(t *testing.T) {
+	t.Parallel()
+
+	logger := loggers.NewLogger(jww.LevelError, jww.LevelError, ioutil.Discard, ioutil.Discard, true)
+	b := newTestSitesBuilder(t).WithLogger(logger).WithConfigFile("toml", `
+baseURL = "http://example.com/"
+disableKinds = ["taxonomy", "taxonomyTerm", "RSS", "sitemap", "robotsTXT", "404"]
+
+[frontmatter]
+date = [":filename", ":default"]
+schemaSeverity = "error"
+[frontmatter.schema._default]
+required = ["title"]
+[frontmatter.schema.posts]
+required = ["title", "description", "date"]
+`)
+
+	b.WithContent(
+		"_index.md", "---\ntitle: Home\ncascade:\n  description: Cascaded\n---\n",
+		"posts/2019-12-01-p1.md", "---\ntitle: P1\n---\n",
+		"posts/p2.md", "---\ntitle: P2\n---\n",
+		"posts/2019-12-03-p3.md", "---\ndraft: false\n---\n",
+	)
+	b.WithTemplates("_default/single.html", "{{ .Title }}", "_default/list.html", "{{ .Title }}", "index.html", "Home")
+
+	b.BuildFail(BuildCfg{})
+
+	errors := logger.Errors()
+	b.Assert(errors, qt.Not(qt.Contains), "p1.md")
+	b.Assert(errors, qt.Not(qt.Contains), `"description"`)
+	b.Assert(errors, qt.Contains, filepath.FromSlash(`posts/p2.md:2:1": required front matter field "date" is missing`))
+	b.Assert(strings.Count(errors, `required front matter field "title" is missing`), qt.Equals, 1)
+	b.Assert(errors, qt.Contains, filepath.FromSlash(`posts/2019-12-03-p3.md:2:1": required front matter field "title" is missing`))
+}
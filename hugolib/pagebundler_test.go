@@ -1216,3 +1216,43 @@ title: %q
 `)
 
 }
+
+func TestBundleNestedHeadlessComponents(t *testing.T) {
+	b := newTestSitesBuilder(t).WithSimpleConfigFile()
+
+	b.WithTemplates("_default/single.html", `{{ range .Resources }}{{ .ResourceType }}|{{ .Name }}|{{ .RelPermalink }}|
+{{ end }}
+{{ with .Resources.GetMatch "blocks/intro/index.md" }}Intro: {{ .Content }}{{ range .Resources }}Res: {{ .Name }}|{{ .RelPermalink }}|{{ end }}{{ end }}
+{{ with .Resources.GetMatch "blocks/outro/index.md" }}{{ with .Resources.GetMatch "nested/index.md" }}Nested: {{ .Content }}{{ range .Resources }}Res: {{ .Name }}|{{ end }}{{ end }}{{ end }}
+`)
+
+	b.WithContent(
+		"bundle/index.md", "---\ntitle: Bundle\n---",
+		"bundle/image.png", "PNG",
+		"bundle/blocks/intro/index.md", "---\ntitle: Intro\nheadless: true\n---\nIntro content.",
+		"bundle/blocks/intro/logo.png", "PNG",
+		"bundle/blocks/outro/index.md", "---\ntitle: Outro\nheadless: true\n---",
+		"bundle/blocks/outro/nested/index.md", "---\ntitle: Nested\nheadless: true\n---\nNested content.",
+		"bundle/blocks/outro/nested/data.json", "{}",
+		"bundle/blocks/plain/index.md", "---\ntitle: Plain\n---",
+		"bundle/blocks/plain/data.json", "{}",
+	)
+
+	b.Build(BuildCfg{})
+
+	b.AssertFileContent("public/bundle/index.html", `
+page|blocks/intro/index.md||
+page|blocks/outro/index.md||
+page|blocks/plain/index.md||
+json|blocks/plain/data.json|/bundle/blocks/plain/data.json|
+image|image.png|/bundle/image.png|
+Intro: <p>Intro content.</p>
+Res: logo.png|/bundle/blocks/intro/logo.png|
+Nested: <p>Nested content.</p>
+Res: data.json|
+`)
+
+	b.AssertFileContent("public/bundle/blocks/intro/logo.png", "PNG")
+	b.AssertFileContent("public/bundle/blocks/outro/nested/data.json", "{}")
+	b.Assert(b.CheckExists("public/bundle/blocks/intro/index.html"), qt.Equals, false)
+}
@@ -28,10 +28,38 @@ import (
 
 	"github.com/gohugoio/hugo/cache"
 	"github.com/gohugoio/hugo/resources/page"
+	"github.com/spf13/cast"
+)
+
+// Prefixes for page references resolved by the id front matter param and
+// by slug, e.g. "id:getting-started" and "slug:my-first-post".
+const (
+	refPrefixID   = "id:"
+	refPrefixSlug = "slug:"
 )
 
 // Used in the page cache to mark more than one hit for a given key.
-var ambiguityFlag = &pageState{}
+type ambiguousPages []*pageState
+
+func (a ambiguousPages) contains(p *pageState) bool {
+	for _, pp := range a {
+		if pp == p {
+			return true
+		}
+	}
+	return false
+}
+
+// String returns the sorted source references of the pages, to help the
+// user fix the reference.
+func (a ambiguousPages) String() string {
+	refs := make([]string, len(a))
+	for i, p := range a {
+		refs[i] = fmt.Sprintf("%q", p.sourceRef())
+	}
+	sort.Strings(refs)
+	return strings.Join(refs, ", ")
+}
 
 // PageCollections contains the page collections for a site.
 type PageCollections struct {
@@ -89,12 +117,11 @@ func (c *PageCollections) getFromCache(ref string) (page.Page, error) {
 		return nil, nil
 	}
 
-	p := v.(page.Page)
-
-	if p != ambiguityFlag {
-		return p, nil
+	if a, ok := v.(ambiguousPages); ok {
+		return nil, fmt.Errorf("page reference %q is ambiguous, it matches %s", ref, a)
 	}
-	return nil, fmt.Errorf("page reference %q is ambiguous", ref)
+
+	return v.(page.Page), nil
 }
 
 type lazyPagesFactory struct {
@@ -138,18 +165,32 @@ func newPageCollectionsFromPages(pages pageStatePages) *PageCollections {
 	c.pageIndex = cache.NewLazy(func() (map[string]interface{}, error) {
 		index := make(map[string]interface{})
 
-		add := func(ref string, p page.Page) {
+		add := func(ref string, p *pageState) {
 			ref = strings.ToLower(ref)
-			existing := index[ref]
-			if existing == nil {
+			switch existing := index[ref].(type) {
+			case nil:
 				index[ref] = p
-			} else if existing != ambiguityFlag && existing != p {
-				index[ref] = ambiguityFlag
+			case ambiguousPages:
+				if !existing.contains(p) {
+					index[ref] = append(existing, p)
+				}
+			case *pageState:
+				if existing != p {
+					index[ref] = ambiguousPages{existing, p}
+				}
 			}
 		}
 
 		for _, pageCollection := range []pageStatePages{c.workAllPages, c.headlessPages} {
 			for _, p := range pageCollection {
+				// Lookups that survive restructuring of the content and URLs.
+				if id := cast.ToString(p.Params()["id"]); id != "" {
+					add(refPrefixID+id, p)
+				}
+				if slug := p.Slug(); slug != "" {
+					add(refPrefixSlug+slug, p)
+				}
+
 				if p.IsPage() {
 					sourceRef := p.sourceRef()
 					if sourceRef != "" {
@@ -258,6 +299,14 @@ func (c *PageCollections) getPageNew(context page.Page, ref string) (page.Page,
 
 	ref = strings.ToLower(ref)
 
+	if strings.HasPrefix(ref, refPrefixID) || strings.HasPrefix(ref, refPrefixSlug) {
+		p, err := c.getFromCache(ref)
+		if err != nil {
+			return nil, wrapErr(errors.Wrap(err, "failed to resolve ref"), context)
+		}
+		return p, nil
+	}
+
 	// Absolute (content root relative) reference.
 	if strings.HasPrefix(ref, "/") {
 		p, err := c.getFromCache(ref)
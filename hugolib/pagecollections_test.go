@@ -244,3 +244,86 @@ func TestGetPage(t *testing.T) {
 	}
 
 }
+
+func TestGetPageByIDAndSlug(t *testing.T) {
+	b := newTestSitesBuilder(t).WithSimpleConfigFile()
+
+	b.WithContent(
+		"docs/intro.md", "---\ntitle: Intro\nid: Getting-Started\nslug: start\n---",
+		"blog/post1.md", "---\ntitle: Post 1\nslug: same\n---\nSee {{< ref \"id:getting-started#usage\" >}} and {{< relref \"slug:start\" >}}.",
+		"news/post2.md", "---\ntitle: Post 2\nslug: same\n---",
+	)
+	b.WithTemplatesAdded("index.html", `
+ID: {{ with .Site.GetPage "id:getting-started" }}{{ .Title }}|{{ .RelPermalink }}{{ end }}
+Slug: {{ with .Site.GetPage "slug:start" }}{{ .Title }}{{ end }}
+Path: {{ with .Site.GetPage "/docs/intro.md" }}{{ .Title }}{{ end }}
+`)
+
+	b.Build(BuildCfg{})
+
+	b.AssertFileContent("public/index.html",
+		"ID: Intro|/docs/start/",
+		"Slug: Intro",
+		"Path: Intro",
+	)
+	b.AssertFileContent("public/blog/same/index.html",
+		`See http://example.com/docs/start/#usage and /docs/start/.`,
+	)
+
+	s := b.H.Sites[0]
+	p, err := s.getPageNew(nil, "slug:same")
+	b.Assert(p, qt.IsNil)
+	b.Assert(err, qt.Not(qt.IsNil))
+	b.Assert(err.Error(), qt.Contains, `page reference "slug:same" is ambiguous, it matches "/blog/post1.md", "/news/post2.md"`)
+
+	p, err = s.getPageNew(nil, "id:missing")
+	b.Assert(err, qt.IsNil)
+	b.Assert(p, qt.IsNil)
+}
+
+func TestPagesWhereParam(t *testing.T) {
+	b := newTestSitesBuilder(t)
+	b.WithConfigFile("toml", `
+baseURL = "https://example.org"
+pageIndexes = ["category", "author.name"]
+`)
+	b.WithContent("p1.md", `---
+title: "P1"
+category: go
+author:
+  name: Jo
+---
+`, "p2.md", `---
+title: "P2"
+category: rust
+---
+`, "p3.md", `---
+title: "P3"
+category: go
+author:
+  name: Mo
+---
+`)
+
+	b.WithTemplatesAdded("_default/single.html", `
+{{ $same := site.RegularPages.WhereParam "category" .Params.category }}
+Same: {{ range $same }}{{ .Title }}|{{ end }}
+Mo: {{ range site.RegularPages.WhereParam "author.name" "Mo" }}{{ .Title }}|{{ end }}
+`)
+
+	b.Build(BuildCfg{})
+
+	b.AssertFileContent("public/p1/index.html", "Same: P1|P3|", "Mo: P3|")
+	b.AssertFileContent("public/p2/index.html", "Same: P2|")
+
+	b = newTestSitesBuilder(t)
+	b.WithContent("p1.md", `---
+title: "P1"
+series: go
+---
+`)
+	b.WithTemplatesAdded("_default/single.html", `{{ site.RegularPages.WhereParam "series" "go" }}`)
+	err := b.BuildE(BuildCfg{})
+	b.Assert(err, qt.Not(qt.IsNil))
+	b.Assert(err.Error(), qt.Contains, `param "series" is not indexed`)
+}
@@ -19,9 +19,11 @@ import (
 	"os"
 	pth "path"
 	"path/filepath"
+	"sort"
 	"strings"
 
 	"github.com/gohugoio/hugo/config"
+	"github.com/gohugoio/hugo/helpers"
 
 	"github.com/gohugoio/hugo/hugofs/files"
 
@@ -596,24 +598,36 @@ func (proc *pagesProcessor) newPageFromBundle(b *fileinfoBundle) (*pageState, er
 
 	if len(b.resources) > 0 {
 
-		resources := make(resource.Resources, len(b.resources))
+		// Headless leaf bundles nested inside this bundle are components
+		// owning the resources below their directory, see nestedBundles.
+		nested, err := proc.newNestedBundles(p, b.resources)
+		if err != nil {
+			return nil, err
+		}
 
-		for i, rfi := range b.resources {
+		resources := make(map[*pageState]resource.Resources)
+		var owners []*pageState
+
+		for _, rfi := range b.resources {
 			meta := rfi.Meta()
+			owner := nested.ownerOf(p, meta.Path())
 			classifier := meta.Classifier()
 			var r resource.Resource
 			switch classifier {
 			case files.ContentClassContent:
-				rp, err := proc.newPageFromFi(rfi, p)
-				if err != nil {
-					return nil, err
+				rp, found := nested.pages[meta.Path()]
+				if !found {
+					rp, err = proc.newPageFromFi(rfi, owner)
+					if err != nil {
+						return nil, err
+					}
 				}
-				rp.m.resourcePath = filepath.ToSlash(strings.TrimPrefix(rp.Path(), p.File().Dir()))
+				rp.m.resourcePath = filepath.ToSlash(strings.TrimPrefix(rp.Path(), owner.File().Dir()))
 
 				r = rp
 
 			case files.ContentClassFile:
-				r, err = proc.newResource(rfi, p)
+				r, err = proc.newResource(rfi, owner)
 				if err != nil {
 					return nil, err
 				}
@@ -621,16 +635,96 @@ func (proc *pagesProcessor) newPageFromBundle(b *fileinfoBundle) (*pageState, er
 				panic(fmt.Sprintf("invalid classifier: %q", classifier))
 			}
 
-			resources[i] = r
+			if _, found := resources[owner]; !found {
+				owners = append(owners, owner)
+			}
+			resources[owner] = append(resources[owner], r)
 
 		}
 
-		p.addResources(resources...)
+		for _, owner := range owners {
+			owner.addResources(resources[owner]...)
+		}
 	}
 
 	return p, nil
 }
 
+// nestedBundles holds the headless leaf bundles nested inside a bundle.
+type nestedBundles struct {
+	// Maps the path of the nested bundle's index file to its page.
+	pages map[string]*pageState
+
+	// The headless bundles' directories, with a trailing path separator,
+	// ordered by depth, deepest first.
+	dirs   []string
+	owners map[string]*pageState
+}
+
+// ownerOf returns the page owning the file with the given path, either
+// the deepest headless bundle containing it or the main bundle page p.
+func (n nestedBundles) ownerOf(p *pageState, path string) *pageState {
+	dir := filepath.Dir(path) + helpers.FilePathSeparator
+	for _, d := range n.dirs {
+		if strings.HasPrefix(dir, d) && n.pages[path] != n.owners[d] {
+			return n.owners[d]
+		}
+	}
+	return p
+}
+
+// newNestedBundles creates the pages for the leaf bundle headers (the
+// "index.md" files) in sub directories of the bundle owned by p. Those
+// marked as headless in front matter will own the resources in and below
+// their directory, so they can be used as components by p.
+func (proc *pagesProcessor) newNestedBundles(p *pageState, fis []hugofs.FileMetaInfo) (nestedBundles, error) {
+	n := nestedBundles{
+		pages:  make(map[string]*pageState),
+		owners: make(map[string]*pageState),
+	}
+
+	var headers []hugofs.FileMetaInfo
+	for _, fi := range fis {
+		meta := fi.Meta()
+		if meta.Classifier() == files.ContentClassContent && proc.isNestedBundleHeader(p, meta) {
+			headers = append(headers, fi)
+		}
+	}
+
+	if len(headers) == 0 {
+		return n, nil
+	}
+
+	// Create the outer bundles first, so they can own the inner ones.
+	sort.SliceStable(headers, func(i, j int) bool {
+		return strings.Count(headers[i].Meta().Path(), helpers.FilePathSeparator) < strings.Count(headers[j].Meta().Path(), helpers.FilePathSeparator)
+	})
+
+	for _, fi := range headers {
+		path := fi.Meta().Path()
+		rp, err := proc.newPageFromFi(fi, n.ownerOf(p, path))
+		if err != nil {
+			return n, err
+		}
+		n.pages[path] = rp
+
+		if rp.headlessInFrontMatter() {
+			dir := filepath.Dir(path) + helpers.FilePathSeparator
+			n.owners[dir] = rp
+			n.dirs = append([]string{dir}, n.dirs...)
+		}
+	}
+
+	return n, nil
+}
+
+func (proc *pagesProcessor) isNestedBundleHeader(p *pageState, meta hugofs.FileMeta) bool {
+	if meta.TranslationBaseName() != "index" {
+		return false
+	}
+	return filepath.Dir(meta.Path())+helpers.FilePathSeparator != p.File().Dir()
+}
+
 func (proc *pagesProcessor) newPageFromFi(fim hugofs.FileMetaInfo, owner *pageState) (*pageState, error) {
 	fi, err := newFileInfo(proc.sp, fim)
 	if err != nil {
@@ -78,6 +78,22 @@ func (m *pagesMap) initPageMeta(p *pageState, bucket *pagesMapBucket) error {
 	return err
 }
 
+// initPageMetaAndResources initializes the metadata of p and its page
+// resources, including the pages in any bundles nested in p.
+func (m *pagesMap) initPageMetaAndResources(p *pageState, bucket *pagesMapBucket) error {
+	if err := m.initPageMeta(p, bucket); err != nil {
+		return err
+	}
+
+	for _, r := range p.resources.ByType(pageResourceType) {
+		if err := m.initPageMetaAndResources(r.(*pageState), bucket); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (m *pagesMap) initPageMetaFor(prefix string, bucket *pagesMapBucket) error {
 	parentBucket := m.parentBucket(prefix)
 
@@ -89,16 +105,9 @@ func (m *pagesMap) initPageMetaFor(prefix string, bucket *pagesMapBucket) error
 
 	if !bucket.view {
 		for _, p := range bucket.pages {
-			ps := p.(*pageState)
-			if err := m.initPageMeta(ps, bucket); err != nil {
+			if err := m.initPageMetaAndResources(p.(*pageState), bucket); err != nil {
 				return err
 			}
-
-			for _, p := range ps.resources.ByType(pageResourceType) {
-				if err := m.initPageMeta(p.(*pageState), bucket); err != nil {
-					return err
-				}
-			}
 		}
 
 		// Now that the metadata is initialized (with dates, draft set etc.)
@@ -106,9 +115,11 @@ func (m *pagesMap) initPageMetaFor(prefix string, bucket *pagesMapBucket) error
 		// in this build.
 		tmp := bucket.pages[:0]
 		for _, x := range bucket.pages {
-			if m.s.shouldBuild(x) {
+			shouldBuild := m.s.shouldBuild(x)
+			if shouldBuild {
 				tmp = append(tmp, x)
 			}
+			m.s.h.scheduledPages.update(m.s, x, shouldBuild)
 		}
 		bucket.pages = tmp
 	}
@@ -292,6 +303,13 @@ func (m *pagesMap) assembleTaxonomies(s *Site) error {
 
 		s.Taxonomies[plural].add(termKey, w)
 
+		if ps, ok := p.(*pageState); ok {
+			if ps.terms == nil {
+				ps.terms = make(map[string]page.Pages)
+			}
+			ps.terms[plural] = appendTermIfNotFound(ps.terms[plural], b2.owner)
+		}
+
 		b1.owner.m.Dates.UpdateDateAndLastmodIfAfter(p)
 		b2.owner.m.Dates.UpdateDateAndLastmodIfAfter(p)
 
@@ -304,6 +322,10 @@ func (m *pagesMap) assembleTaxonomies(s *Site) error {
 			return false
 		}
 
+		for _, p := range b.pages {
+			p.(*pageState).terms = nil
+		}
+
 		for singular, plural := range s.siteCfg.taxonomiesConfig {
 			for _, p := range b.pages {
 
@@ -346,6 +368,15 @@ func (m *pagesMap) assembleTaxonomies(s *Site) error {
 	return nil
 }
 
+func appendTermIfNotFound(terms page.Pages, term page.Page) page.Pages {
+	for _, t := range terms {
+		if t == term {
+			return terms
+		}
+	}
+	return append(terms, term)
+}
+
 func (m *pagesMap) cleanKey(key string) string {
 	key = filepath.ToSlash(strings.ToLower(key))
 	key = strings.Trim(key, "/")
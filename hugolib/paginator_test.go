@@ -105,3 +105,66 @@ func TestPaginateWithSort(t *testing.T) {
 	b.Build(BuildCfg{}).AssertFileContent("public/index.html",
 		filepath.FromSlash("|content/sect/doc1.nn.md|content/sect/doc1.nb.md|content/sect/doc1.fr.md|content/sect/doc1.en.md"))
 }
+
+func TestPaginatorSectionOverrides(t *testing.T) {
+	config := `
+baseURL = "https://example.com/"
+paginate = 10
+`
+	b := newTestSitesBuilder(t).WithConfigFile("toml", config)
+
+	b.WithContent("blog/_index.md", `
+---
+title: Blog
+paginate: 2
+paginatePath: "side"
+---
+`)
+	b.WithContent("news/_index.md", `
+---
+title: News
+---
+`)
+	b.WithContent("archive/_index.md", `
+---
+title: Archive
+cascade:
+  paginate: 1
+---
+`)
+	b.WithContent("archive/2018/_index.md", `
+---
+title: Archive 2018
+---
+`)
+
+	for i, date := range []string{"2018-03-01", "2018-03-02", "2018-05-01", "2019-01-01"} {
+		b.WithContent(fmt.Sprintf("blog/post%d.md", i), fmt.Sprintf(`
+---
+title: Post %d
+date: %s
+---
+`, i, date))
+	}
+
+	b.WithTemplatesAdded("_default/list.html", `
+{{ $pag := "" }}
+{{ if eq .Section "archive" }}{{ $pag = .Paginate (where .Site.RegularPages "Section" "blog") "month" }}{{ else }}{{ $pag = .Paginator }}{{ end }}
+Size: {{ $pag.PageSize }}|Total: {{ $pag.TotalPages }}|Next: {{ with $pag.Next }}{{ .URL }}{{ end }}|
+{{ range $pag.PageGroups }}Group: {{ .Key }}:{{ range .Pages }}{{ .Title }}|{{ end }}{{ end }}
+{{ range $pag.Pages }}Page: {{ .Title }}|{{ end }}
+`)
+
+	b.Build(BuildCfg{})
+
+	b.AssertFileContent("public/blog/index.html", "Size: 2|Total: 2|Next: /blog/side/2/|")
+	b.AssertFileContent("public/blog/side/2/index.html", "Size: 2|Total: 2|Next: |")
+	b.AssertFileContent("public/archive/index.html", "Size: 1|Total: 4|Next: /archive/page/2/|", "Group: 2019-01:Post 3|")
+	b.AssertFileContent("public/archive/page/2/index.html", "Group: 2018-05:Post 2|")
+	b.AssertFileContent("public/archive/page/3/index.html", "Group: 2018-03:Post 1|")
+	b.AssertFileContent("public/archive/page/4/index.html", "Group: 2018-03:Post 0|")
+	b.AssertFileContent("public/archive/2018/index.html", "Size: 1|Total: 4|")
+
+	// The site default.
+	b.AssertFileContent("public/news/index.html", "Size: 10|")
+}
@@ -17,6 +17,7 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/gohugoio/hugo/htesting"
@@ -226,6 +227,85 @@ Edited content.
 	}
 }
 
+func TestResourceChainAssetManifest(t *testing.T) {
+	t.Parallel()
+
+	b := newTestSitesBuilder(t).Running()
+	b.WithConfigFile("toml", `
+baseURL = "https://example.org/"
+[build]
+writeAssetManifest = true
+`)
+
+	b.WithTemplatesAdded("index.html", `
+{{ $css := "body { color: red; }" | resources.FromString "css/main.css" | minify | fingerprint "md5" }}
+{{ $js := "var a = 1;" | resources.FromString "/js/main.js" | fingerprint "md5" }}
+{{ $plain := "plain" | resources.FromString "plain.txt" }}
+CSS: {{ $css.RelPermalink }}|JS: {{ $js.RelPermalink }}|PLAIN: {{ $plain.RelPermalink }}
+`)
+
+	b.Build(BuildCfg{})
+
+	b.AssertFileContent("public/index.html", "CSS: /css/main.min.6700e3e577966de91432a219303a54ce.css|JS: /js/main.cb6143ff70a133027139bbf27746a3c4.js|PLAIN: /plain.txt")
+	b.AssertFileContent("public/hugo_assets.json", `{
+  "css/main.css": "css/main.min.6700e3e577966de91432a219303a54ce.css",
+  "js/main.js": "js/main.cb6143ff70a133027139bbf27746a3c4.js"
+}`)
+
+	// Resources no longer in use should be removed on rebuild.
+	b.EditFiles("layouts/index.html", `
+{{ $css := "body { color: red; }" | resources.FromString "css/main.css" | minify | fingerprint "md5" }}
+CSS: {{ $css.RelPermalink }}
+`)
+
+	b.Build(BuildCfg{})
+
+	b.AssertFileContent("public/hugo_assets.json", `"css/main.css": "css/main.min.6700e3e577966de91432a219303a54ce.css"`)
+	b.AssertFileContentFn("public/hugo_assets.json", func(s string) bool {
+		return !strings.Contains(s, "js/main.js")
+	})
+}
+
+func TestResourceChainAssetManifestPartialRebuild(t *testing.T) {
+	t.Parallel()
+
+	b := newTestSitesBuilder(t).Running()
+	b.WithConfigFile("toml", `
+baseURL = "https://example.org/"
+[build]
+writeAssetManifest = true
+`)
+
+	b.WithContent("p1.md", "---\ntitle: P1\n---")
+	b.WithSourceFile("assets/js/main.js", "var a = 1;")
+	b.WithTemplatesAdded("index.html", `
+{{ $css := "body { color: red; }" | resources.FromString "css/main.css" | fingerprint "md5" }}
+CSS: {{ $css.RelPermalink }}
+`, "_default/single.html", `
+{{ $js := resources.Get "js/main.js" | fingerprint "md5" }}
+JS: {{ $js.RelPermalink }}
+`)
+
+	b.Build(BuildCfg{})
+
+	b.AssertFileContent("public/hugo_assets.json", `"css/main.css": "css/main.f2b804d3e3bd61d76922a667f90e66d8.css"`, `"js/main.js": "js/main.cb6143ff70a133027139bbf27746a3c4.js"`)
+
+	// Only the home page is rendered; the other pages keep their entries.
+	b.EditFiles("content/p1.md", "---\ntitle: P1 Edited\n---")
+	b.Build(BuildCfg{RecentlyVisited: map[string]bool{"/": true}})
+
+	b.AssertFileContent("public/hugo_assets.json", `"css/main.css"`, `"js/main.js": "js/main.cb6143ff70a133027139bbf27746a3c4.js"`)
+
+	// A changed asset gets its new target path.
+	b.EditFiles("assets/js/main.js", "var b = 2;")
+	b.Build(BuildCfg{RecentlyVisited: map[string]bool{"/p1/": true}})
+
+	b.AssertFileContent("public/hugo_assets.json", `"css/main.css"`, `"js/main.js": "js/main.`)
+	b.AssertFileContentFn("public/hugo_assets.json", func(s string) bool {
+		return !strings.Contains(s, "cb6143ff70a133027139bbf27746a3c4")
+	})
+}
+
 func TestResourceChain(t *testing.T) {
 	t.Parallel()
 
@@ -381,6 +461,19 @@ T4: {{ $r2.Data.Integrity }}|
 			b.AssertFileContent("public/index.html", `T3: ab|/rocks/hugo.187ef4436122d1cc2f40dc2b92f0eba0.txt|text/plain|md5-GH70Q2Ei0cwvQNwrkvDroA==|`)
 			b.AssertFileContent("public/index.html", `T4: sha256-Hgu9bGhroFC46wP/7txk/cnYCUf86CGrvl1tyNJSxaw=|`)
 
+		}},
+		{"inline", func() bool { return true }, func(b *sitesBuilder) {
+			b.WithTemplates("home.html", `
+{{ $css := "body { color: red; }" | resources.FromString "css/critical.css" | minify | resources.Inline }}
+{{ $js := "var a = 1;" | resources.FromString "js/critical.js" | resources.Inline "sha384" }}
+<meta http-equiv="Content-Security-Policy" content="style-src '{{ $css.Data.CSPHash }}'">
+T1: {{ $css.Data.Inline }}|{{ $css.Data.CSPHash }}|
+T2: {{ $js.Data.Inline }}|
+`)
+		}, func(b *sitesBuilder) {
+			b.AssertFileContent("public/index.html", `T1: <style>body{color:red}</style>|sha256-`, `T2: <script>var a = 1;</script>|`, `content="style-src 'sha256-`)
+			b.Assert(b.CheckExists("public/css/critical.min.css"), qt.Equals, false)
+
 		}},
 		// https://github.com/gohugoio/hugo/issues/5226
 		{"baseurl-path", func() bool { return true }, func(b *sitesBuilder) {
@@ -593,3 +686,73 @@ JSON: {{ $json.RelPermalink }}: {{ $json.Content }}
 		"JSON: /jsons/data1.json: json1 content",
 		"JSONS: 2", "/jsons/data1.json: json1 content")
 }
+
+func TestExecuteAsTemplateWithContext(t *testing.T) {
+	b := newTestSitesBuilder(t)
+	b.WithConfigFile("toml", `
+baseURL = "https://example.org"
+defaultContentLanguage = "en"
+
+[languages]
+[languages.en]
+title = "English Title"
+weight = 1
+[languages.nn]
+title = "Norsk tittel"
+weight = 2
+`)
+	b.WithI18n("en.toml", `[hello]
+other = "Hello"`, "nn.toml", `[hello]
+other = "Hei"`)
+	b.WithTemplatesAdded("partials/color.html", `{{ if eq .Lang "nn" }}red{{ else }}blue{{ end }}`)
+	b.WithTemplatesAdded("index.html", `
+{{ $css := resources.Get "css/main.css" | resources.ExecuteAsTemplate (printf "css/main.%s.css" .Lang) . }}
+CSS: {{ $css.Content | safeCSS }}|{{ $css.RelPermalink }}
+`)
+	b.WithSourceFile("assets/css/main.css", `body { color: {{ partial "color.html" . }}; } /* {{ i18n "hello" }}|{{ site.Title }}|{{ .Site.Language.Lang }} */`)
+
+	b.Build(BuildCfg{})
+
+	b.AssertFileContent("public/index.html", "CSS: body { color: blue; } /* Hello|English Title|en */|/css/main.en.css")
+	b.AssertFileContent("public/nn/index.html", "CSS: body { color: red; } /* Hei|Norsk tittel|nn */|/css/main.nn.css")
+}
+
+func TestResourceChainPerLanguage(t *testing.T) {
+	b := newTestSitesBuilder(t)
+	b.WithConfigFile("toml", `
+baseURL = "https://example.org"
+defaultContentLanguage = "en"
+
+[languages]
+[languages.en]
+weight = 1
+[languages.ar]
+weight = 2
+[languages.ar.params]
+direction = "rtl"
+`)
+	b.WithTemplatesAdded("index.html", `
+{{ $css := resources.Get "css/main.css" | resources.ExecuteAsTemplate "css/main.:lang.css" . }}
+{{ $js := resources.Get "js/i18n.js" | resources.ExecuteAsTemplate "js/i18n.:lang.js" . }}
+{{ $bundle := slice $js (resources.Get "js/main.js") | resources.Concat "js/bundle.:lang.js" }}
+CSS: {{ $css.Content | safeCSS }}|{{ $css.RelPermalink }}
+JS: {{ $bundle.RelPermalink }}
+`)
+	b.WithSourceFile(
+		"assets/css/main.css", `body { direction: {{ .Site.Params.direction | default "ltr" }}; }`,
+		"assets/js/i18n.js", "var lang = '{{ .Lang }}';",
+		"assets/js/main.js", `console.log(lang);`,
+	)
+
+	b.Build(BuildCfg{})
+
+	b.AssertFileContent("public/index.html",
+		"CSS: body { direction: ltr; }|/css/main.en.css",
+		"JS: /js/bundle.en.js")
+	b.AssertFileContent("public/ar/index.html",
+		"CSS: body { direction: rtl; }|/css/main.ar.css",
+		"JS: /js/bundle.ar.js")
+	b.AssertFileContent("public/css/main.ar.css", "direction: rtl")
+	b.AssertFileContent("public/js/bundle.en.js", "var lang = 'en';\n;\nconsole.log(lang);")
+	b.AssertFileContent("public/js/bundle.ar.js", "var lang = 'ar';\n;\nconsole.log(lang);")
+}
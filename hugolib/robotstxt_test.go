@@ -16,6 +16,7 @@ package hugolib
 import (
 	"testing"
 
+	qt "github.com/frankban/quicktest"
 	"github.com/spf13/viper"
 )
 
@@ -40,3 +41,59 @@ func TestRobotsTXTOutput(t *testing.T) {
 	b.AssertFileContent("public/robots.txt", "User-agent: Googlebot")
 
 }
+
+func TestRobotsTXTEnvironment(t *testing.T) {
+	t.Parallel()
+
+	newBuilderWithTemplate := func(env, single string) *sitesBuilder {
+		cfg := viper.New()
+		cfg.Set("baseURL", "http://auth/bub/")
+		cfg.Set("enableRobotsTXT", true)
+		cfg.Set("environment", env)
+
+		b := newTestSitesBuilder(t).WithViper(cfg)
+		b.WithContent(
+			"p1.md", "---\ntitle: P1\n---\n",
+			"p2.md", "---\ntitle: P2\nrobots: \"noindex, follow\"\n---\n",
+		)
+		b.WithTemplates("_default/single.html", single)
+		return b
+	}
+
+	newBuilder := func(env string) *sitesBuilder {
+		return newBuilderWithTemplate(env, `Robots:{{ template "_internal/robots.html" . }}|`)
+	}
+
+	b := newBuilder("production")
+	b.Build(BuildCfg{})
+	b.AssertFileContent("public/robots.txt", "User-agent: *")
+	b.Assert(b.FileContent("public/robots.txt"), qt.Not(qt.Contains), "Disallow")
+	b.AssertFileContent("public/p1/index.html", "Robots:|")
+	b.AssertFileContent("public/p2/index.html", `<meta name="robots" content="noindex, follow" />`)
+	b.Assert(b.FileContent("public/sitemap.xml"), qt.Not(qt.Contains), "/p2/")
+	b.AssertFileContent("public/sitemap.xml", "/p1/")
+
+	b = newBuilder("staging")
+	b.Build(BuildCfg{})
+	b.AssertFileContent("public/robots.txt", "User-agent: *\nDisallow: /")
+	b.AssertFileContent("public/p1/index.html", `<meta name="robots" content="noindex, nofollow" />`)
+
+	// The robots meta tag is injected into templates not including the
+	// internal template.
+	for _, env := range []string{"production", "staging"} {
+		b = newBuilderWithTemplate(env, `<html><head><title>{{ .Title }}</title></head></html>`)
+		b.Build(BuildCfg{})
+		if env == "production" {
+			b.Assert(b.FileContent("public/p1/index.html"), qt.Not(qt.Contains), "robots")
+			b.AssertFileContent("public/p2/index.html", "<head>\n\t<meta name=\"robots\" content=\"noindex, follow\" />")
+		} else {
+			b.AssertFileContent("public/p1/index.html", "<head>\n\t<meta name=\"robots\" content=\"noindex, nofollow\" />")
+			b.AssertFileContent("public/p2/index.html", "<head>\n\t<meta name=\"robots\" content=\"noindex, nofollow\" />")
+		}
+	}
+
+	b = newBuilder("staging")
+	b.WithTemplatesAdded("robots.staging.txt", "User-agent: Staging")
+	b.Build(BuildCfg{})
+	b.AssertFileContent("public/robots.txt", "User-agent: Staging")
+}
@@ -14,10 +14,13 @@
 package hugolib
 
 import (
+	"encoding/json"
+	"fmt"
 	"path/filepath"
 	"strings"
 	"testing"
 
+	qt "github.com/frankban/quicktest"
 	"github.com/gohugoio/hugo/deps"
 )
 
@@ -98,3 +101,175 @@ Figure:
 
 	b.AssertFileContent("public/index.xml", "img src=&#34;http://example.com/images/sunset.jpg")
 }
+
+func TestJSONFeedOutput(t *testing.T) {
+	t.Parallel()
+
+	b := newTestSitesBuilder(t).WithConfigFile("toml", `
+baseURL = "https://example.org/"
+title = "Feeds"
+languageCode = "en-us"
+rssLimit = 2
+
+[author]
+name = "Hugo Author"
+
+[outputs]
+home = ["HTML", "JSONFeed"]
+section = ["HTML", "JSONFeed"]
+`)
+
+	b.WithContent(
+		"posts/p1.md", "---\ntitle: P1\ndate: 2019-01-01\n---\nContent 1.",
+		"posts/p2.md", "---\ntitle: P2\ndate: 2019-01-02\n---\nContent 2.",
+		"posts/p3.md", "---\ntitle: P3\ndate: 2019-01-03\n---\nContent 3.",
+	)
+
+	b.Build(BuildCfg{})
+
+	type feed struct {
+		Version     string
+		Title       string
+		HomePageURL string `json:"home_page_url"`
+		FeedURL     string `json:"feed_url"`
+		Language    string
+		Authors     []map[string]string
+		Items       []struct {
+			ID    string
+			Title string
+		}
+	}
+
+	decode := func(filename string) feed {
+		var f feed
+		b.Assert(json.Unmarshal([]byte(b.FileContent(filename)), &f), qt.IsNil, qt.Commentf(b.FileContent(filename)))
+		return f
+	}
+
+	home := decode("public/feed.json")
+	b.Assert(home.Version, qt.Equals, "https://jsonfeed.org/version/1.1")
+	b.Assert(home.Title, qt.Equals, "Feeds")
+	b.Assert(home.HomePageURL, qt.Equals, "https://example.org/")
+	b.Assert(home.FeedURL, qt.Equals, "https://example.org/feed.json")
+	b.Assert(home.Language, qt.Equals, "en-us")
+	b.Assert(home.Authors, qt.DeepEquals, []map[string]string{{"name": "Hugo Author"}})
+	b.Assert(home.Items, qt.HasLen, 2)
+	b.Assert(home.Items[0].Title, qt.Equals, "P3")
+
+	posts := decode("public/posts/feed.json")
+	b.Assert(posts.Title, qt.Equals, "Posts on Feeds")
+	b.Assert(posts.FeedURL, qt.Equals, "https://example.org/posts/feed.json")
+	b.Assert(posts.Items[1].ID, qt.Equals, "https://example.org/posts/p2/")
+
+	b.Assert(posts.Items, qt.HasLen, 2)
+}
+
+func TestJSONFeedOutputCustomTemplate(t *testing.T) {
+	t.Parallel()
+
+	b := newTestSitesBuilder(t).WithConfigFile("toml", `
+baseURL = "https://example.org/"
+
+[outputs]
+home = ["HTML", "JSONFeed"]
+section = ["HTML", "JSONFeed"]
+`)
+
+	b.WithContent("posts/p1.md", "---\ntitle: P1\ntags: [\"a\", \"b\"]\n---\nContent <em>1</em>.")
+	b.WithTemplatesAdded("posts/jsonfeed.json", `{"custom": {{ len .RegularPages }}}`)
+
+	b.Build(BuildCfg{})
+
+	b.AssertFileContent("public/posts/feed.json", `{"custom": 1}`)
+	b.AssertFileContent("public/feed.json",
+		`"title": "P1"`,
+		`"content_html": "\u003cp\u003eContent \u003cem\u003e1\u003c/em\u003e.\u003c/p\u003e\n"`,
+		`"tags": ["a","b"]`,
+	)
+}
+
+func TestAtomOutput(t *testing.T) {
+	t.Parallel()
+
+	config := `
+baseURL = "https://example.org/"
+title = "Feeds"
+languageCode = "en-us"
+
+[author]
+name = "Hugo Author"
+
+[services.rss]
+limit = 2
+fullContent = %t
+
+[outputs]
+home = ["HTML", "RSS", "Atom"]
+section = ["HTML", "Atom"]
+`
+
+	for _, fullContent := range []bool{false, true} {
+		b := newTestSitesBuilder(t).WithConfigFile("toml", fmt.Sprintf(config, fullContent))
+
+		b.WithContent(
+			"posts/p1.md", "---\ntitle: P1\ndate: 2019-01-01\n---\nSummary 1.\n<!--more-->\nMore 1.",
+			"posts/p2.md", "---\ntitle: P2\ndate: 2019-01-02\n---\nSummary 2.\n<!--more-->\nMore 2.",
+			"posts/p3.md", "---\ntitle: P3\ndate: 2019-01-03\n---\nSummary 3.\n<!--more-->\nMore 3.",
+		)
+
+		b.Build(BuildCfg{})
+
+		b.AssertFileContent("public/atom.xml",
+			`<feed xmlns="http://www.w3.org/2005/Atom" xml:lang="en-us">`,
+			`<title>Feeds</title>`,
+			`<link href="https://example.org/atom.xml" rel="self" type="application/atom+xml" />`,
+			`<id>https://example.org/</id>`,
+			`<updated>2019-01-03T00:00:00Z</updated>`,
+			`<name>Hugo Author</name>`,
+			`<link href="https://example.org/posts/p3/" rel="alternate" type="text/html" />`,
+			`<published>2019-01-03T00:00:00Z</published>`,
+		)
+
+		b.AssertFileContent("public/posts/atom.xml", `<title>Posts on Feeds</title>`)
+
+		atom := b.FileContent("public/atom.xml")
+		rss := b.FileContent("public/index.xml")
+		b.Assert(strings.Count(atom, "<entry>"), qt.Equals, 2)
+		b.Assert(strings.Contains(atom, "P1"), qt.Equals, false)
+		b.Assert(strings.Contains(atom, "More 3."), qt.Equals, fullContent)
+		b.Assert(strings.Contains(rss, "More 3."), qt.Equals, fullContent)
+
+		if fullContent {
+			b.AssertFileContent("public/atom.xml", `<content type="html">`)
+		} else {
+			b.AssertFileContent("public/atom.xml", `<summary type="html">&lt;p&gt;Summary 3.&lt;/p&gt;</summary>`)
+		}
+	}
+}
+
+func TestRSSTaxonomyFeeds(t *testing.T) {
+	t.Parallel()
+
+	for _, disable := range []bool{false, true} {
+		b := newTestSitesBuilder(t).WithConfigFile("toml", fmt.Sprintf(`
+baseURL = "https://example.org/"
+
+[rss]
+limit = 1
+fullContent = true
+disableTaxonomyFeeds = %t
+`, disable))
+
+		b.WithContent(
+			"p1.md", "---\ntitle: P1\ndate: 2019-01-01\ntags: [\"podcast\"]\n---\nSummary 1.\n<!--more-->\nMore 1.",
+			"p2.md", "---\ntitle: P2\ndate: 2019-01-02\ntags: [\"podcast\"]\n---\nSummary 2.\n<!--more-->\nMore 2.",
+		)
+
+		b.Build(BuildCfg{})
+
+		b.AssertFileContent("public/index.xml", "More 2.")
+		b.Assert(strings.Count(b.FileContent("public/index.xml"), "<item>"), qt.Equals, 1)
+		b.Assert(b.CheckExists("public/tags/podcast/index.xml"), qt.Equals, !disable)
+		b.Assert(b.CheckExists("public/tags/index.xml"), qt.Equals, !disable)
+	}
+}
@@ -0,0 +1,210 @@
+// Copyright 2019 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package segments implements build segments, a way to render only a
+// subset of a site.
+package segments
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/gohugoio/hugo/config"
+	"github.com/gohugoio/hugo/hugofs/glob"
+	"github.com/mitchellh/mapstructure"
+	"github.com/pkg/errors"
+)
+
+const (
+	segmentsConfigKey       = "segments"
+	renderSegmentsConfigKey = "renderSegments"
+)
+
+// Segments holds the segments defined in the site config and the ones
+// selected for rendering.
+type Segments struct {
+	segments map[string]segment
+
+	// The segments to render. If empty, everything is rendered.
+	render []segment
+}
+
+// Segment defines a subset of the site.
+// A page belongs to the segment if it matches any of the Includes (or
+// Includes is empty) and none of the Excludes.
+type Segment struct {
+	Includes []Matcher
+	Excludes []Matcher
+}
+
+// Matcher matches pages. All of the non-empty fields must match.
+type Matcher struct {
+	// The page kind, e.g. "page" or "section".
+	Kind string
+
+	// The language code, e.g. "en".
+	Lang string
+
+	// The output format name, e.g. "html" or "rss".
+	Output string
+
+	// A Glob pattern matched against the relative permalink, e.g. "/docs/**".
+	Path string
+}
+
+// Query is the page to match against the segments. A Matcher with a field
+// set does not match a Query with the same field empty.
+type Query struct {
+	Kind   string
+	Lang   string
+	Output string
+	Path   string
+}
+
+type segment struct {
+	includes []matcher
+	excludes []matcher
+}
+
+type matcher struct {
+	kind   string
+	lang   string
+	output string
+	path   func(s string) bool
+}
+
+// DecodeSegments creates Segments from the segments and renderSegments
+// settings in cfg.
+func DecodeSegments(cfg config.Provider) (*Segments, error) {
+	s := &Segments{segments: make(map[string]segment)}
+
+	if cfg.IsSet(segmentsConfigKey) {
+		for name, v := range cfg.GetStringMap(segmentsConfigKey) {
+			var seg Segment
+			if err := mapstructure.WeakDecode(v, &seg); err != nil {
+				return nil, errors.Wrapf(err, "failed to decode segment %q", name)
+			}
+			compiled, err := compileSegment(seg)
+			if err != nil {
+				return nil, errors.Wrapf(err, "segment %q", name)
+			}
+			s.segments[strings.ToLower(name)] = compiled
+		}
+	}
+
+	for _, name := range cfg.GetStringSlice(renderSegmentsConfigKey) {
+		seg, found := s.segments[strings.ToLower(name)]
+		if !found {
+			return nil, errors.Errorf("segment %q not found in config, must be one of %v", name, s.names())
+		}
+		s.render = append(s.render, seg)
+	}
+
+	return s, nil
+}
+
+// ShouldRender returns whether a page matching q should be rendered.
+func (s *Segments) ShouldRender(q Query) bool {
+	if s == nil || len(s.render) == 0 {
+		return true
+	}
+
+	for _, seg := range s.render {
+		if seg.matches(q) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (s *Segments) names() []string {
+	var names []string
+	for name := range s.segments {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func compileSegment(seg Segment) (segment, error) {
+	var (
+		compiled segment
+		err      error
+	)
+
+	if compiled.includes, err = compileMatchers(seg.Includes); err != nil {
+		return compiled, err
+	}
+	if compiled.excludes, err = compileMatchers(seg.Excludes); err != nil {
+		return compiled, err
+	}
+
+	return compiled, nil
+}
+
+func compileMatchers(ms []Matcher) ([]matcher, error) {
+	var compiled []matcher
+	for _, m := range ms {
+		c := matcher{
+			kind:   strings.ToLower(m.Kind),
+			lang:   strings.ToLower(m.Lang),
+			output: strings.ToLower(m.Output),
+		}
+		if m.Path != "" {
+			g, err := glob.GetGlob(m.Path)
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid path %q", m.Path)
+			}
+			c.path = g.Match
+		}
+		compiled = append(compiled, c)
+	}
+	return compiled, nil
+}
+
+func (s segment) matches(q Query) bool {
+	for _, m := range s.excludes {
+		if m.matches(q) {
+			return false
+		}
+	}
+
+	if len(s.includes) == 0 {
+		return true
+	}
+
+	for _, m := range s.includes {
+		if m.matches(q) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (m matcher) matches(q Query) bool {
+	if m.kind != "" && m.kind != strings.ToLower(q.Kind) {
+		return false
+	}
+	if m.lang != "" && m.lang != strings.ToLower(q.Lang) {
+		return false
+	}
+	if m.output != "" && m.output != strings.ToLower(q.Output) {
+		return false
+	}
+	if m.path != nil && (q.Path == "" || !m.path(strings.ToLower(q.Path))) {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,72 @@
+// Copyright 2019 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package segments
+
+import (
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/gohugoio/hugo/config"
+)
+
+func TestDecodeSegments(t *testing.T) {
+	c := qt.New(t)
+
+	tomlConfig := `
+renderSegments = ["docs", "nn"]
+[segments]
+[segments.docs]
+[[segments.docs.includes]]
+path = "/docs/**"
+[[segments.docs.excludes]]
+output = "rss"
+[segments.nn]
+[[segments.nn.includes]]
+lang = "nn"
+kind = "page"
+[segments.unused]
+`
+
+	cfg, err := config.FromConfigString(tomlConfig, "toml")
+	c.Assert(err, qt.IsNil)
+
+	s, err := DecodeSegments(cfg)
+	c.Assert(err, qt.IsNil)
+
+	for _, test := range []struct {
+		q      Query
+		expect bool
+	}{
+		{Query{Kind: "page", Lang: "en", Output: "HTML", Path: "/docs/p1/"}, true},
+		{Query{Kind: "section", Lang: "en", Output: "HTML", Path: "/docs/"}, true},
+		{Query{Kind: "section", Lang: "en", Output: "RSS", Path: "/docs/index.xml"}, false},
+		{Query{Kind: "page", Lang: "en", Output: "HTML", Path: "/blog/p1/"}, false},
+		{Query{Kind: "page", Lang: "nn", Output: "HTML", Path: "/nn/blog/p1/"}, true},
+		{Query{Kind: "section", Lang: "nn", Output: "HTML", Path: "/nn/blog/"}, false},
+		{Query{Kind: "robotsTXT", Lang: "en", Output: "ROBOTS"}, false},
+	} {
+		c.Assert(s.ShouldRender(test.q), qt.Equals, test.expect, qt.Commentf("%+v", test.q))
+	}
+
+	// Without renderSegments everything is rendered.
+	cfg.Set("renderSegments", []string{})
+	s, err = DecodeSegments(cfg)
+	c.Assert(err, qt.IsNil)
+	c.Assert(s.ShouldRender(Query{Kind: "page", Path: "/blog/p1/"}), qt.Equals, true)
+
+	cfg.Set("renderSegments", []string{"doesnotexist"})
+	_, err = DecodeSegments(cfg)
+	c.Assert(err, qt.Not(qt.IsNil))
+	c.Assert(err.Error(), qt.Contains, `segment "doesnotexist" not found in config, must be one of [docs nn unused]`)
+}
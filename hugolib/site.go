@@ -40,6 +40,7 @@ import (
 	"github.com/gohugoio/hugo/publisher"
 	_errors "github.com/pkg/errors"
 
+	"github.com/gohugoio/hugo/hugolib/segments"
 	"github.com/gohugoio/hugo/langs"
 
 	"github.com/gohugoio/hugo/resources/page"
@@ -60,6 +61,7 @@ import (
 	"github.com/gohugoio/hugo/resources/page/pagemeta"
 	"github.com/gohugoio/hugo/source"
 	"github.com/gohugoio/hugo/tpl"
+	"github.com/gohugoio/hugo/tpl/partials"
 
 	"github.com/spf13/afero"
 	"github.com/spf13/cast"
@@ -106,6 +108,9 @@ type Site struct {
 
 	enableInlineShortcodes bool
 
+	// Lives for the whole build, see SiteInfo.Store.
+	store *maps.Scratch
+
 	// Output formats defined in site config per Page Kind, or some defaults
 	// if not set.
 	// Output formats defined in Page front matter will override these.
@@ -122,6 +127,10 @@ type Site struct {
 	// How to handle page front matter.
 	frontmatterHandler pagemeta.FrontMatterHandler
 
+	// Validates the page front matter, set with frontmatter.schema.
+	// This may be nil.
+	frontmatterSchema *pagemeta.FrontMatterSchema
+
 	// We render each site for all the relevant output formats in serial with
 	// this rendering context pointing to the current one.
 	rc *siteRenderingContext
@@ -139,12 +148,18 @@ type Site struct {
 	titleFunc func(s string) string
 
 	relatedDocsHandler *page.RelatedDocsHandler
+	pageIndexHandler   *page.PageIndexHandler
 	siteRefLinker
 
 	publisher publisher.Publisher
 
 	menus navigation.Menus
 
+	// Pages and RegularPages merged with the pages from the fallback
+	// languages not translated to this language.
+	pagesWithFallbacks        page.Pages
+	regularPagesWithFallbacks page.Pages
+
 	// Shortcut to the home page. Note that this may be nil if
 	// home page, for some odd reason, is disabled.
 	home *pageState
@@ -158,10 +173,18 @@ type Site struct {
 
 type siteConfigHolder struct {
 	sitemap          config.Sitemap
+	notFoundFilename string
 	taxonomiesConfig map[string]string
 	timeout          time.Duration
-	hasCJKLanguage   bool
-	enableEmoji      bool
+
+	// The URL path segments per section, set with sectionSlugs.
+	sectionSlugs map[string]string
+	// The URL path segments per singular taxonomy name and term, set with
+	// termSlugs.
+	termSlugs map[string]map[string]string
+
+	hasCJKLanguage bool
+	enableEmoji    bool
 }
 
 // Lazily loaded site dependencies.
@@ -169,12 +192,14 @@ type siteInit struct {
 	prevNext          *lazy.Init
 	prevNextInSection *lazy.Init
 	menus             *lazy.Init
+	fallbackPages     *lazy.Init
 }
 
 func (init *siteInit) Reset() {
 	init.prevNext.Reset()
 	init.prevNextInSection.Reset()
 	init.menus.Reset()
+	init.fallbackPages.Reset()
 }
 
 func (s *Site) initInit(init *lazy.Init, pctx pageContext) {
@@ -257,12 +282,30 @@ func (s *Site) prepareInits() {
 		return nil, nil
 	})
 
+	s.init.fallbackPages = init.Branch(func() (interface{}, error) {
+		s.pagesWithFallbacks = s.withFallbacks(s.Pages(), func(fs *Site) page.Pages {
+			return fs.Pages()
+		})
+		s.regularPagesWithFallbacks = s.withFallbacks(s.RegularPages(), func(fs *Site) page.Pages {
+			return fs.RegularPages()
+		})
+		return nil, nil
+	})
+
 }
 
 type siteRenderingContext struct {
 	output.Format
 }
 
+// currentOutputFormat returns the output format currently being rendered.
+func (s *Site) currentOutputFormat() output.Format {
+	if s.rc == nil {
+		return output.Format{}
+	}
+	return s.rc.Format
+}
+
 func (s *Site) Menus() navigation.Menus {
 	s.init.menus.Do()
 	return s.menus
@@ -300,6 +343,10 @@ func (s *Site) GetRelatedDocsHandler() *page.RelatedDocsHandler {
 	return s.relatedDocsHandler
 }
 
+func (s *Site) GetPageIndexHandler() *page.PageIndexHandler {
+	return s.pageIndexHandler
+}
+
 func (s *Site) Language() *langs.Language {
 	return s.language
 }
@@ -311,6 +358,17 @@ func (s *Site) isEnabled(kind string) bool {
 	return !s.disabledKinds[kind]
 }
 
+// shouldRenderSegment returns whether a page with the given kind, relative
+// permalink and output format belongs to the segments set in renderSegments.
+func (s *Site) shouldRenderSegment(kind, relPermalink, outputFormat string) bool {
+	return s.h.segments.ShouldRender(segments.Query{
+		Kind:   kind,
+		Lang:   s.language.Lang,
+		Output: outputFormat,
+		Path:   relPermalink,
+	})
+}
+
 // reset returns a new Site prepared for rebuild.
 func (s *Site) reset() *Site {
 	return &Site{Deps: s.Deps,
@@ -318,11 +376,13 @@ func (s *Site) reset() *Site {
 		disabledKinds:          s.disabledKinds,
 		titleFunc:              s.titleFunc,
 		relatedDocsHandler:     s.relatedDocsHandler.Clone(),
+		pageIndexHandler:       s.pageIndexHandler.Clone(),
 		siteRefLinker:          s.siteRefLinker,
 		outputFormats:          s.outputFormats,
 		rc:                     s.rc,
 		outputFormatsConfig:    s.outputFormatsConfig,
 		frontmatterHandler:     s.frontmatterHandler,
+		frontmatterSchema:      s.frontmatterSchema,
 		mediaTypesConfig:       s.mediaTypesConfig,
 		language:               s.language,
 		h:                      s.h,
@@ -378,11 +438,16 @@ func newSite(cfg deps.DepsCfg) (*Site, error) {
 		return nil, err
 	}
 
-	outputFormats, err := createSiteOutputFormats(siteOutputFormatsConfig, cfg.Language)
+	outputFormats, err := createSiteOutputFormats(siteOutputFormatsConfig, cfg.Language, cfg.Logger)
 	if err != nil {
 		return nil, err
 	}
 
+	// The template funcs cannot fail, so validate their config here.
+	if err := partials.ValidateCacheConfig(cfg.Language); err != nil {
+		return nil, err
+	}
+
 	taxonomies := cfg.Language.GetStringMapString("taxonomies")
 
 	var relatedContentConfig related.Config
@@ -399,6 +464,14 @@ func newSite(cfg deps.DepsCfg) (*Site, error) {
 		}
 	}
 
+	var pageIndexes []string
+	if cfg.Language.IsSet("pageIndexes") {
+		pageIndexes, err = cast.ToStringSliceE(cfg.Language.Get("pageIndexes"))
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to decode pageIndexes")
+		}
+	}
+
 	titleFunc := helpers.GetTitleFunc(cfg.Language.GetString("titleCaseStyle"))
 
 	frontMatterHandler, err := pagemeta.NewFrontmatterHandler(cfg.Logger, cfg.Cfg)
@@ -406,9 +479,17 @@ func newSite(cfg deps.DepsCfg) (*Site, error) {
 		return nil, err
 	}
 
+	frontMatterSchema, err := pagemeta.NewFrontMatterSchema(cfg.Cfg)
+	if err != nil {
+		return nil, err
+	}
+
 	siteConfig := siteConfigHolder{
-		sitemap:          config.DecodeSitemap(config.Sitemap{Priority: -1, Filename: "sitemap.xml"}, cfg.Language.GetStringMap("sitemap")),
+		sitemap:          config.DecodeSitemap(config.Sitemap{Priority: -1, Filename: "sitemap.xml", MaxURLs: sitemapMaxURLs}, cfg.Language.GetStringMap("sitemap")),
+		notFoundFilename: cfg.Language.GetString("notFoundFilename"),
 		taxonomiesConfig: taxonomies,
+		sectionSlugs:     cfg.Language.GetStringMapString("sectionSlugs"),
+		termSlugs:        decodeTermSlugs(cfg.Language.GetStringMap("termSlugs")),
 		timeout:          time.Duration(cfg.Language.GetInt("timeout")) * time.Millisecond,
 		hasCJKLanguage:   cfg.Language.GetBool("hasCJKLanguage"),
 		enableEmoji:      cfg.Language.Cfg.GetBool("enableEmoji"),
@@ -421,12 +502,15 @@ func newSite(cfg deps.DepsCfg) (*Site, error) {
 		disabledKinds:          disabledKinds,
 		titleFunc:              titleFunc,
 		relatedDocsHandler:     page.NewRelatedDocsHandler(relatedContentConfig),
+		pageIndexHandler:       page.NewPageIndexHandler(pageIndexes),
 		outputFormats:          outputFormats,
 		rc:                     &siteRenderingContext{output.HTMLFormat},
 		outputFormatsConfig:    siteOutputFormatsConfig,
 		mediaTypesConfig:       siteMediaTypesConfig,
 		frontmatterHandler:     frontMatterHandler,
+		frontmatterSchema:      frontMatterSchema,
 		enableInlineShortcodes: cfg.Language.GetBool("enableInlineShortcodes"),
+		store:                  maps.NewScratch(),
 		siteCfg:                siteConfig,
 	}
 
@@ -535,20 +619,24 @@ type SiteInfo struct {
 }
 
 func (s *SiteInfo) Pages() page.Pages {
-	return s.s.Pages()
-
+	s.siteAccessed()
+	s.s.init.fallbackPages.Do()
+	return s.s.pagesWithFallbacks
 }
 
 func (s *SiteInfo) RegularPages() page.Pages {
-	return s.s.RegularPages()
-
+	s.siteAccessed()
+	s.s.init.fallbackPages.Do()
+	return s.s.regularPagesWithFallbacks
 }
 
 func (s *SiteInfo) AllPages() page.Pages {
+	s.siteAccessed()
 	return s.s.AllPages()
 }
 
 func (s *SiteInfo) AllRegularPages() page.Pages {
+	s.siteAccessed()
 	return s.s.AllRegularPages()
 }
 
@@ -559,6 +647,7 @@ func (s *SiteInfo) Permalinks() map[string]string {
 }
 
 func (s *SiteInfo) LastChange() time.Time {
+	s.siteAccessed()
 	return s.s.lastmod
 }
 
@@ -571,11 +660,13 @@ func (s *SiteInfo) Site() page.Site {
 }
 
 func (s *SiteInfo) Menus() navigation.Menus {
+	s.siteAccessed()
 	return s.s.Menus()
 }
 
 // TODO(bep) type
 func (s *SiteInfo) Taxonomies() interface{} {
+	s.siteAccessed()
 	return s.s.Taxonomies
 }
 
@@ -599,11 +690,55 @@ func (s *SiteInfo) Hugo() hugo.Info {
 	return s.hugoInfo
 }
 
+// Store returns a Scratch that, unlike a page's Scratch, is not reset
+// when the site is rebuilt.
+func (s *SiteInfo) Store() *maps.Scratch {
+	s.siteAccessed()
+	return s.s.store
+}
+
 // Sites is a convenience method to get all the Hugo sites/languages configured.
 func (s *SiteInfo) Sites() page.Sites {
+	s.siteAccessed()
 	return s.s.h.siteInfos()
 }
 
+// fallbackSites returns the sites for the fallback languages configured for
+// this site's language, in order.
+func (s *Site) fallbackSites() []*Site {
+	var sites []*Site
+	for _, fallback := range s.language.Fallbacks {
+		for _, site := range s.h.Sites {
+			if site.language.Lang == fallback {
+				sites = append(sites, site)
+				break
+			}
+		}
+	}
+	return sites
+}
+
+// withFallbacks adds the pages returned by get for the fallback sites that
+// are not translated to this site's language.
+func (s *Site) withFallbacks(pages page.Pages, get func(fs *Site) page.Pages) page.Pages {
+	for _, fs := range s.fallbackSites() {
+		pages = pages.MergeByLanguage(get(fs))
+	}
+	return pages
+}
+
+// Fallbacks returns the sites for the fallback languages configured for this
+// site's language, in order. Content missing in this language is taken from
+// these in .Site.Pages, .Site.RegularPages, .Site.GetPage and in the home
+// and section page lists.
+func (s *SiteInfo) Fallbacks() page.Sites {
+	var sites page.Sites
+	for _, site := range s.s.fallbackSites() {
+		sites = append(sites, &site.Info)
+	}
+	return sites
+}
+
 func (s *SiteInfo) String() string {
 	return fmt.Sprintf("Site(%q)", s.title)
 }
@@ -699,6 +834,7 @@ func (s siteRefLinker) logNotFound(ref, what string, p page.Page, position text.
 }
 
 func (s *siteRefLinker) refLink(ref string, source interface{}, relative bool, outputFormat string) (string, error) {
+	s.s.Info.siteAccessed()
 
 	p, err := unwrapPage(source)
 	if err != nil {
@@ -715,6 +851,12 @@ func (s *siteRefLinker) refLink(ref string, source interface{}, relative bool, o
 		return s.notFoundURL, err
 	}
 
+	if refURL.Opaque != "" && (refURL.Scheme+":" == refPrefixID || refURL.Scheme+":" == refPrefixSlug) {
+		// A lookup by id or slug, e.g. "id:intro#usage".
+		refURL.Path = refURL.Scheme + ":" + refURL.Opaque
+		refURL.Scheme, refURL.Opaque = "", ""
+	}
+
 	var target page.Page
 	var link string
 
@@ -808,6 +950,12 @@ type whatChanged struct {
 	source bool
 	other  bool
 	files  map[string]bool
+
+	// The list pages (sections and taxonomies) listing the changed content
+	// files before and after the change, keyed by listPageKey. If set, the
+	// other list pages are only re-rendered if they access the site wide
+	// page collections.
+	listPages map[string]bool
 }
 
 // RegisterMediaTypes will register the Site's media types in the mime
@@ -922,6 +1070,8 @@ func (s *Site) processPartial(config *BuildCfg, init func(config *BuildCfg) erro
 	for _, ev := range events {
 		if assetsFilename := s.BaseFs.Assets.MakePathRelative(ev.Name); assetsFilename != "" {
 			cachePartitions = append(cachePartitions, resources.ResourceKeyPartitions(assetsFilename)...)
+			// Added back with the new target path if still in use.
+			s.h.AssetManifest.Remove(filepath.ToSlash(assetsFilename))
 		}
 
 		if s.isContentDirEvent(ev) {
@@ -954,6 +1104,38 @@ func (s *Site) processPartial(config *BuildCfg, init func(config *BuildCfg) erro
 		files:  sourceFilesChanged,
 	}
 
+	// When only regular content files have changed in a full render, limit
+	// the list pages to render. This must be done before the page tree is
+	// reset.
+	if h.listPageDeps != nil && len(config.RecentlyVisited) == 0 && changed.source && !changed.other && len(shortcodesChanged) == 0 {
+		contentFilenames := make(map[string]bool)
+		for _, ev := range sourceChanged {
+			if !files.IsContentFile(ev.Name) {
+				contentFilenames = nil
+				break
+			}
+			contentFilenames[ev.Name] = true
+		}
+
+		for _, s := range h.Sites {
+			if len(s.language.Fallbacks) > 0 {
+				// Sections list the pages of other languages.
+				contentFilenames = nil
+				break
+			}
+		}
+
+		if contentFilenames != nil {
+			changed.listPages = make(map[string]bool)
+			for _, s := range h.Sites {
+				if !s.collectListPages(contentFilenames, changed.listPages) {
+					changed.listPages = nil
+					break
+				}
+			}
+		}
+	}
+
 	config.whatChanged = changed
 
 	if err := init(config); err != nil {
@@ -986,6 +1168,9 @@ func (s *Site) processPartial(config *BuildCfg, init func(config *BuildCfg) erro
 			}
 			site.Deps, err = first.Deps.ForLanguage(depsCfg, func(d *deps.Deps) error {
 				d.Site = &site.Info
+				d.CurrentOutputFormat = site.currentOutputFormat
+				d.PartialCachedIncluded = site.Info.siteAccessed
+				d.ResourceSpec.CurrentOutputFormat = site.currentOutputFormat
 				return nil
 			})
 			if err != nil {
@@ -1211,7 +1396,7 @@ func (s *Site) initializeSiteInfo() error {
 		permalinks:                     permalinks,
 		owner:                          s.h,
 		s:                              s,
-		hugoInfo:                       hugo.NewInfo(s.Cfg.GetString("environment")),
+		hugoInfo:                       hugo.NewInfo(s.Cfg.GetString("environment"), s.running()),
 	}
 
 	rssOutputFormat, found := s.outputFormats[page.KindHome].GetByName(output.RSSFormat.Name)
@@ -1274,6 +1459,18 @@ func (s *Site) getMenusFromConfig() navigation.Menus {
 					// TODO(bep) clean up all of this
 					menuEntry.ConfiguredURL = s.Info.createNodeMenuEntryURL(menuEntry.ConfiguredURL)
 
+					if menuEntry.PageRef != "" {
+						p, err := s.getPageNew(nil, menuEntry.PageRef)
+						if err != nil || p == nil {
+							s.Log.WARN.Printf("page %q not found for entry in menu %q in site config\n", menuEntry.PageRef, name)
+						} else {
+							menuEntry.Page = p
+							if menuEntry.Name == "" {
+								menuEntry.Name = p.LinkTitle()
+							}
+						}
+					}
+
 					if ret[name] == nil {
 						ret[name] = navigation.Menu{}
 					}
@@ -1418,6 +1615,7 @@ func (s *Site) getTaxonomyKey(key string) string {
 // Prepare site for a new full build.
 func (s *Site) resetBuildState(sourceChanged bool) {
 	s.relatedDocsHandler = s.relatedDocsHandler.Clone()
+	s.pageIndexHandler = s.pageIndexHandler.Clone()
 	s.init.Reset()
 
 	if sourceChanged {
@@ -1453,8 +1651,16 @@ func (s *Site) errorCollator(results <-chan error, errs chan<- error) {
 // as possible for existing sites. Most sites will use {{ .Site.GetPage "section" "my/section" }},
 // i.e. 2 arguments, so we test for that.
 func (s *SiteInfo) GetPage(ref ...string) (page.Page, error) {
+	s.siteAccessed()
 	p, err := s.s.getPageOldVersion(ref...)
 
+	for _, fs := range s.s.fallbackSites() {
+		if p != nil || err != nil {
+			break
+		}
+		p, err = fs.getPageOldVersion(ref...)
+	}
+
 	if p == nil {
 		// The nil struct has meaning in some situations, mostly to avoid breaking
 		// existing sites doing $nilpage.IsDescendant($p), which will always return
@@ -1494,9 +1700,9 @@ func (s *Site) renderAndWriteXML(statCounter *uint64, name string, targetPath st
 		Src:         renderBuffer,
 		TargetPath:  targetPath,
 		StatCounter: statCounter,
-		// For the minification part of XML,
-		// we currently only use the MIME type.
-		OutputFormat: output.RSSFormat,
+		// This is currently only used for sitemaps. The output format
+		// picks the minifier and any minify exclusions.
+		OutputFormat: output.SitemapFormat,
 		AbsURLPath:   path,
 	}
 
@@ -1557,6 +1763,8 @@ func (s *Site) renderAndWritePage(statCounter *uint64, name string, targetPath s
 			pd.AddHugoGeneratorTag = !s.Cfg.GetBool("disableHugoGeneratorInject")
 		}
 
+		pd.Robots = s.robotsFor(p)
+
 	}
 
 	return s.publisher.Publish(pd)
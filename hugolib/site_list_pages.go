@@ -0,0 +1,144 @@
+// Copyright 2019 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hugolib
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"github.com/gohugoio/hugo/navigation"
+	"github.com/gohugoio/hugo/resources/page"
+)
+
+// listPageDeps is used in server mode to find the taxonomy term pages that
+// list more than their own pages, i.e. that access any of the site wide pages
+// when rendered, e.g. through .Site.RegularPages, .Site.Menus, .Site.Store,
+// ref and relref, the pages of another list page through .Parent,
+// .CurrentSection or .FirstSection or a partialCached result created for any
+// page. These are re-rendered on every content change.
+type listPageDeps struct {
+	// Incremented on every access to a site wide page collection.
+	// Must be first for 64-bit alignment.
+	accessed uint64
+
+	mu sync.RWMutex
+
+	// Keyed by listPageKey.
+	siteWide map[string]bool
+}
+
+func newListPageDeps() *listPageDeps {
+	return &listPageDeps{siteWide: make(map[string]bool)}
+}
+
+// siteAccessed marks an access to a site wide page collection.
+func (d *listPageDeps) siteAccessed() {
+	if d == nil {
+		return
+	}
+	atomic.AddUint64(&d.accessed, 1)
+}
+
+func (d *listPageDeps) accessCount() uint64 {
+	return atomic.LoadUint64(&d.accessed)
+}
+
+func (d *listPageDeps) setSiteWide(key string) {
+	d.mu.Lock()
+	d.siteWide[key] = true
+	d.mu.Unlock()
+}
+
+func (d *listPageDeps) isSiteWide(key string) bool {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return d.siteWide[key]
+}
+
+// reset must be called before all list pages are rendered.
+func (d *listPageDeps) reset() {
+	if d == nil {
+		return
+	}
+	d.mu.Lock()
+	d.siteWide = make(map[string]bool)
+	d.mu.Unlock()
+}
+
+// listPageKey identifies a list page across rebuilds. The permalinks are not
+// ready when the keys are collected.
+func listPageKey(p page.Page) string {
+	return p.Lang() + "|" + p.Kind() + "|" + p.SectionsPath()
+}
+
+// isMeasuredListPage reports whether p is a list page that is only rendered
+// on content rebuilds if it lists the changed content or has been found to
+// access the site wide pages. Only the taxonomy term pages, e.g. /tags/go/,
+// qualify; their templates usually list their own pages only. The home,
+// section and taxonomy list pages are always rendered.
+func isMeasuredListPage(p *pageState) bool {
+	return p.Kind() == page.KindTaxonomy
+}
+
+// collectListPages adds the keys of the list pages listing any of the pages
+// created from the given content files to keys. It returns false if any of
+// these pages may change more than the list pages found, i.e. if it is a
+// list page itself or has menu entries.
+func (s *Site) collectListPages(filenames map[string]bool, keys map[string]bool) bool {
+	addWithParents := func(p page.Page) {
+		for p != nil {
+			ps, ok := p.(*pageState)
+			if !ok || ps == nil {
+				return
+			}
+			keys[listPageKey(ps)] = true
+			p = pageTree{p: ps}.treeParent()
+		}
+	}
+
+	for _, p := range s.rawAllPages {
+		if p.File().IsZero() || !filenames[p.File().Filename()] {
+			continue
+		}
+
+		if p.IsNode() {
+			return false
+		}
+
+		if menus, err := navigation.PageMenusFromPage(p); err != nil || len(menus) > 0 {
+			return false
+		}
+
+		addWithParents(pageTree{p: p}.treeParent())
+
+		for _, taxonomy := range s.Taxonomies {
+			for _, wps := range taxonomy {
+				for _, wp := range wps {
+					if wp.Page == page.Page(p) {
+						addWithParents(wps.Page())
+						break
+					}
+				}
+			}
+		}
+	}
+
+	return true
+}
+
+func (s *SiteInfo) siteAccessed() {
+	if s.owner != nil {
+		s.owner.listPageDeps.siteAccessed()
+	}
+}
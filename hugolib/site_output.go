@@ -16,24 +16,31 @@ package hugolib
 import (
 	"fmt"
 
+	"github.com/gohugoio/hugo/common/loggers"
 	"github.com/gohugoio/hugo/config"
+	"github.com/gohugoio/hugo/config/services"
 	"github.com/gohugoio/hugo/output"
 	"github.com/gohugoio/hugo/resources/page"
 	"github.com/spf13/cast"
 )
 
-func createDefaultOutputFormats(allFormats output.Formats, cfg config.Provider) map[string]output.Formats {
+func createDefaultOutputFormats(allFormats output.Formats, rssConfig services.RSS) map[string]output.Formats {
 	rssOut, _ := allFormats.GetByName(output.RSSFormat.Name)
 	htmlOut, _ := allFormats.GetByName(output.HTMLFormat.Name)
 	robotsOut, _ := allFormats.GetByName(output.RobotsTxtFormat.Name)
 	sitemapOut, _ := allFormats.GetByName(output.SitemapFormat.Name)
 
+	taxonomyFormats := output.Formats{htmlOut, rssOut}
+	if rssConfig.DisableTaxonomyFeeds {
+		taxonomyFormats = output.Formats{htmlOut}
+	}
+
 	return map[string]output.Formats{
 		page.KindPage:         {htmlOut},
 		page.KindHome:         {htmlOut, rssOut},
 		page.KindSection:      {htmlOut, rssOut},
-		page.KindTaxonomy:     {htmlOut, rssOut},
-		page.KindTaxonomyTerm: {htmlOut, rssOut},
+		page.KindTaxonomy:     taxonomyFormats,
+		page.KindTaxonomyTerm: taxonomyFormats,
 		// Below are for consistency. They are currently not used during rendering.
 		kindRSS:       {rssOut},
 		kindSitemap:   {sitemapOut},
@@ -43,8 +50,17 @@ func createDefaultOutputFormats(allFormats output.Formats, cfg config.Provider)
 
 }
 
-func createSiteOutputFormats(allFormats output.Formats, cfg config.Provider) (map[string]output.Formats, error) {
-	defaultOutputFormats := createDefaultOutputFormats(allFormats, cfg)
+func createSiteOutputFormats(allFormats output.Formats, cfg config.Provider, logger *loggers.Logger) (map[string]output.Formats, error) {
+	if logger == nil {
+		logger = loggers.NewErrorLogger()
+	}
+
+	servicesConfig, err := services.DecodeConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	defaultOutputFormats := createDefaultOutputFormats(allFormats, servicesConfig.RSS)
 
 	if !cfg.IsSet("outputs") {
 		return defaultOutputFormats, nil
@@ -61,11 +77,12 @@ func createSiteOutputFormats(allFormats output.Formats, cfg config.Provider) (ma
 	seen := make(map[string]bool)
 
 	for k, v := range outputs {
-		k = getKind(k)
-		if k == "" {
-			// Invalid kind
+		kind := getKind(k)
+		if kind == "" {
+			logger.WARN.Printf("Invalid page kind %q in outputs config, skipping it.", k)
 			continue
 		}
+		k = kind
 		var formats output.Formats
 		vals := cast.ToStringSlice(v)
 		for _, format := range vals {
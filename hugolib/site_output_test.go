@@ -14,11 +14,15 @@
 package hugolib
 
 import (
+	"bytes"
+	"io/ioutil"
 	"strings"
 	"testing"
 
 	qt "github.com/frankban/quicktest"
+	"github.com/gohugoio/hugo/common/loggers"
 	"github.com/gohugoio/hugo/resources/page"
+	jww "github.com/spf13/jwalterweatherman"
 
 	"github.com/spf13/afero"
 
@@ -341,7 +345,7 @@ func TestCreateSiteOutputFormats(t *testing.T) {
 		cfg := viper.New()
 		cfg.Set("outputs", outputsConfig)
 
-		outputs, err := createSiteOutputFormats(output.DefaultFormats, cfg)
+		outputs, err := createSiteOutputFormats(output.DefaultFormats, cfg, nil)
 		c.Assert(err, qt.IsNil)
 		c.Assert(outputs[page.KindSection], deepEqualsOutputFormats, output.Formats{output.JSONFormat})
 		c.Assert(outputs[page.KindHome], deepEqualsOutputFormats, output.Formats{output.HTMLFormat, output.JSONFormat})
@@ -371,7 +375,7 @@ func TestCreateSiteOutputFormats(t *testing.T) {
 		}
 		cfg.Set("outputs", outputsConfig)
 
-		outputs, err := createSiteOutputFormats(output.DefaultFormats, cfg)
+		outputs, err := createSiteOutputFormats(output.DefaultFormats, cfg, nil)
 		c.Assert(err, qt.IsNil)
 		c.Assert(outputs[page.KindTaxonomyTerm], deepEqualsOutputFormats, output.Formats{output.JSONFormat})
 
@@ -389,10 +393,30 @@ func TestCreateSiteOutputFormatsInvalidConfig(t *testing.T) {
 	cfg := viper.New()
 	cfg.Set("outputs", outputsConfig)
 
-	_, err := createSiteOutputFormats(output.DefaultFormats, cfg)
+	_, err := createSiteOutputFormats(output.DefaultFormats, cfg, nil)
 	c.Assert(err, qt.Not(qt.IsNil))
 }
 
+func TestCreateSiteOutputFormatsInvalidKind(t *testing.T) {
+	c := qt.New(t)
+
+	outputsConfig := map[string]interface{}{
+		"pages": []string{"HTML"},
+	}
+
+	cfg := viper.New()
+	cfg.Set("outputs", outputsConfig)
+
+	var buf bytes.Buffer
+	logger := loggers.NewLogger(jww.LevelWarn, jww.LevelError, &buf, ioutil.Discard, false)
+
+	outputs, err := createSiteOutputFormats(output.DefaultFormats, cfg, logger)
+	c.Assert(err, qt.IsNil)
+	c.Assert(outputs[page.KindPage], deepEqualsOutputFormats, output.Formats{output.HTMLFormat})
+	c.Assert(buf.String(), qt.Contains, `Invalid page kind "pages" in outputs config`)
+	c.Assert(logger.WarnCounter.Count(), qt.Equals, uint64(1))
+}
+
 func TestCreateSiteOutputFormatsEmptyConfig(t *testing.T) {
 	c := qt.New(t)
 
@@ -403,7 +427,7 @@ func TestCreateSiteOutputFormatsEmptyConfig(t *testing.T) {
 	cfg := viper.New()
 	cfg.Set("outputs", outputsConfig)
 
-	outputs, err := createSiteOutputFormats(output.DefaultFormats, cfg)
+	outputs, err := createSiteOutputFormats(output.DefaultFormats, cfg, nil)
 	c.Assert(err, qt.IsNil)
 	c.Assert(outputs[page.KindHome], deepEqualsOutputFormats, output.Formats{output.HTMLFormat, output.RSSFormat})
 }
@@ -423,7 +447,7 @@ func TestCreateSiteOutputFormatsCustomFormats(t *testing.T) {
 		customHTML = output.Format{Name: "HTML", BaseName: "customHTML"}
 	)
 
-	outputs, err := createSiteOutputFormats(output.Formats{customRSS, customHTML}, cfg)
+	outputs, err := createSiteOutputFormats(output.Formats{customRSS, customHTML}, cfg, nil)
 	c.Assert(err, qt.IsNil)
 	c.Assert(outputs[page.KindHome], deepEqualsOutputFormats, output.Formats{customHTML, customRSS})
 }
@@ -625,3 +649,86 @@ WordCount: {{ .WordCount }}
 	b.AssertFileContent("public/outputs-string/index.html", "O1:", "Word1. Word2.")
 
 }
+
+func TestCustomOutputFormatsPerKindAndPage(t *testing.T) {
+	b := newTestSitesBuilder(t).WithConfigFile("toml", `
+baseURL = "https://example.org"
+
+[mediaTypes]
+[mediaTypes."text/plain"]
+suffixes = ["txt"]
+
+[outputFormats]
+[outputFormats.Text]
+mediaType = "text/plain"
+baseName = "page"
+isPlainText = true
+[outputFormats.Calendar]
+baseName = "calendar"
+
+[outputs]
+page = ["HTML", "Text"]
+`)
+
+	b.WithContent("p1.md", `---
+title: "P1"
+---
+`, "event.md", `---
+title: "Event"
+outputs: ["HTML", "Calendar", "JSON"]
+---
+`)
+
+	b.WithTemplatesAdded(
+		"_default/single.html", `HTML: {{ .Title }}|{{ range .OutputFormats }}{{ .Name }}:{{ .RelPermalink }}|{{ end }}`,
+		"_default/single.txt", `Text: {{ .Title }} & more`,
+		"_default/single.ics", `Calendar: {{ .Title }}`,
+		"_default/single.json", `{"title": {{ .Title | jsonify }}}`,
+	)
+
+	b.Build(BuildCfg{})
+
+	b.AssertFileContent("public/p1/index.html", "HTML: P1|HTML:/p1/|text:/p1/page.txt|")
+	b.AssertFileContent("public/p1/page.txt", "Text: P1 & more")
+	b.AssertFileContent("public/event/index.html", "HTML: Event|HTML:/event/|Calendar:/event/calendar.ics|JSON:/event/index.json|")
+	b.AssertFileContent("public/event/calendar.ics", "Calendar: Event")
+	b.AssertFileContent("public/event/index.json", `{"title": "Event"}`)
+	b.Assert(b.CheckExists("public/event/page.txt"), qt.Equals, false)
+}
+
+func TestSearchIndexOutputFormat(t *testing.T) {
+	b := newTestSitesBuilder(t).WithConfigFile("toml", `
+baseURL = "https://example.org/"
+
+[outputs]
+home = ["HTML", "SearchIndex"]
+section = ["HTML", "SearchIndex"]
+
+[services.searchIndex]
+fields = ["title", "permalink", "tags", "content"]
+contentLength = 12
+`)
+
+	b.WithContent(
+		"blog/p1.md", "---\ntitle: \"P1 & Co\"\ntags: [\"a\", \"b\"]\n---\nSome <em>long</em> content for P1.",
+		"docs/p2.md", "---\ntitle: P2\n---\nContent P2.",
+	)
+
+	// A site's JSON templates are not used, but the templates named after
+	// the format are.
+	b.WithTemplatesAdded(
+		"index.json", `{"json": true}`,
+		"_default/list.json", `{"json": true}`,
+		"docs/searchindex.json", `{"custom": {{ len .RegularPages }}}`,
+	)
+
+	b.Build(BuildCfg{})
+
+	b.AssertFileContent("public/blog/searchindex.json",
+		`[{"content":"Some long co","permalink":"https://example.org/blog/p1/","tags":["a","b"],"title":"P1 \u0026 Co"}]`)
+	b.AssertFileContent("public/docs/searchindex.json", `{"custom": 1}`)
+	b.AssertFileContent("public/searchindex.json", `"title":"P1 \u0026 Co"`, `{"content":"Content P2.","permalink":"https://example.org/docs/p2/","tags":[],"title":"P2"}`)
+	b.AssertFileContentFn("public/index.html", func(s string) bool {
+		return !strings.Contains(s, "searchindex")
+	})
+}
@@ -16,13 +16,17 @@ package hugolib
 import (
 	"fmt"
 	"path"
+	"runtime"
+	"runtime/debug"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/gohugoio/hugo/config"
 
 	"github.com/gohugoio/hugo/output"
 	"github.com/pkg/errors"
+	"github.com/spf13/cast"
 
 	"github.com/gohugoio/hugo/resources/page"
 	"github.com/gohugoio/hugo/resources/page/pagemeta"
@@ -58,21 +62,15 @@ func (s siteRenderContext) renderSingletonPages() bool {
 // TODO(bep np doc
 func (s *Site) renderPages(ctx *siteRenderContext) error {
 
-	numWorkers := config.GetNumWorkerMultiplier()
+	numWorkers := s.numRenderWorkers()
 
 	results := make(chan error)
-	pages := make(chan *pageState, numWorkers) // buffered for performance
 	errs := make(chan error)
 
 	go s.errorCollator(results, errs)
 
 	wg := &sync.WaitGroup{}
 
-	for i := 0; i < numWorkers; i++ {
-		wg.Add(1)
-		go pageRenderer(ctx, s, pages, results, wg)
-	}
-
 	cfg := ctx.cfg
 
 	if !cfg.PartialReRender && ctx.outIdx == 0 && len(s.headlessPages) > 0 {
@@ -80,19 +78,23 @@ func (s *Site) renderPages(ctx *siteRenderContext) error {
 		go headlessPagesPublisher(s, wg)
 	}
 
-L:
+	var pages, listPages []*pageState
 	for _, page := range s.workAllPages {
-		if cfg.shouldRender(page) {
-			select {
-			case <-s.h.Done():
-				break L
-			default:
-				pages <- page
-			}
+		if !cfg.shouldRender(page) {
+			continue
+		}
+		if s.h.listPageDeps != nil && isMeasuredListPage(page) {
+			// These are rendered last, so the accesses to the site wide
+			// pages while rendering them are not mixed up with the others.
+			listPages = append(listPages, page)
+		} else {
+			pages = append(pages, page)
 		}
 	}
 
-	close(pages)
+	if s.renderPagesInBatches(ctx, pages, numWorkers, results) {
+		s.renderPagesInBatches(ctx, listPages, numWorkers, results)
+	}
 
 	wg.Wait()
 
@@ -105,6 +107,114 @@ L:
 	return nil
 }
 
+// renderPagesInBatches renders pages in batches of memoryBudgetBatchSize if
+// memoryBudget is set. It returns false if the build was stopped.
+func (s *Site) renderPagesInBatches(ctx *siteRenderContext, pages []*pageState, numWorkers int, results chan<- error) bool {
+	budget := s.memoryBudget()
+	batchSize := len(pages)
+	if budget > 0 {
+		batchSize = memoryBudgetBatchSize
+	}
+
+	for len(pages) > 0 {
+		n := batchSize
+		if n > len(pages) {
+			n = len(pages)
+		}
+		batch := pages[:n]
+		pages = pages[n:]
+
+		if !s.renderPageBatch(ctx, batch, numWorkers, results) {
+			return false
+		}
+
+		if budget > 0 {
+			s.evictContentIfOverBudget(batch, budget)
+		}
+	}
+
+	return true
+}
+
+// renderPageBatch renders pages in parallel and waits for all of them to
+// finish. It returns false if the build was stopped.
+func (s *Site) renderPageBatch(ctx *siteRenderContext, pages []*pageState, numWorkers int, results chan<- error) bool {
+	pagesc := make(chan *pageState, numWorkers) // buffered for performance
+
+	wg := &sync.WaitGroup{}
+
+	for i := 0; i < numWorkers; i++ {
+		wg.Add(1)
+		go pageRenderer(ctx, s, pagesc, results, wg)
+	}
+
+	done := false
+
+L:
+	for _, page := range pages {
+		select {
+		case <-s.h.Done():
+			done = true
+			break L
+		default:
+			pagesc <- page
+		}
+	}
+
+	close(pagesc)
+
+	wg.Wait()
+
+	return !done
+}
+
+// memoryBudgetBatchSize is the number of pages rendered between the memory
+// checks when memoryBudget is set.
+const memoryBudgetBatchSize = 500
+
+// memoryBudget returns the memory budget in bytes set with memoryBudget
+// (in megabytes), or 0 if not set.
+func (s *Site) memoryBudget() uint64 {
+	if mb := s.Cfg.GetInt("memoryBudget"); mb > 0 {
+		return uint64(mb) * 1024 * 1024
+	}
+	return 0
+}
+
+// evictContentIfOverBudget frees the rendered content of the given, already
+// published, pages if the memory in use is above budget. Pages with
+// shortcodes are kept, as their shortcodes may have side effects when
+// executed again.
+func (s *Site) evictContentIfOverBudget(pages []*pageState, budget uint64) {
+	var stats runtime.MemStats
+	runtime.ReadMemStats(&stats)
+	if stats.HeapAlloc <= budget {
+		return
+	}
+
+	for _, p := range pages {
+		if !p.renderable || p.shortcodeState.hasShortcodes() {
+			continue
+		}
+		for _, po := range p.pageOutputs {
+			if po.cp != nil {
+				po.cp.evict()
+			}
+		}
+	}
+
+	debug.FreeOSMemory()
+}
+
+// numRenderWorkers returns the number of pages to render in parallel, set
+// with renderWorkers, or the number of logical CPUs.
+func (s *Site) numRenderWorkers() int {
+	if n := s.Cfg.GetInt("renderWorkers"); n > 0 {
+		return n
+	}
+	return config.GetNumWorkerMultiplier()
+}
+
 func headlessPagesPublisher(s *Site, wg *sync.WaitGroup) {
 	defer wg.Done()
 	for _, p := range s.headlessPages {
@@ -131,6 +241,10 @@ func pageRenderer(
 			continue
 		}
 
+		if !s.shouldRenderSegment(p.Kind(), p.RelPermalink(), f.Name) {
+			continue
+		}
+
 		if err := p.renderResources(); err != nil {
 			s.SendError(p.errorf(err, "failed to render page resources"))
 			continue
@@ -149,6 +263,14 @@ func pageRenderer(
 			continue
 		}
 
+		start := time.Now()
+
+		deps := s.h.listPageDeps
+		var accessed uint64
+		if deps != nil {
+			accessed = deps.accessCount()
+		}
+
 		if err := s.renderAndWritePage(&s.PathSpec.ProcessingStats.Pages, "page "+p.Title(), targetPath, p, layouts...); err != nil {
 			results <- err
 		}
@@ -158,15 +280,36 @@ func pageRenderer(
 				results <- err
 			}
 		}
+
+		if deps != nil && isMeasuredListPage(p) && deps.accessCount() != accessed {
+			// Any list page rendered at the same time may also have
+			// accessed the site wide pages, which is safe.
+			deps.setSiteWide(listPageKey(p))
+		}
+
+		s.BuildTimer.MeasureSince(renderPhase(p), start)
+	}
+}
+
+// renderPhase returns the build report phase for rendering p, e.g.
+// "render/page/blog".
+func renderPhase(p *pageState) string {
+	phase := "render/" + p.Kind()
+	if section := p.Section(); section != "" {
+		phase += "/" + section
 	}
+	return phase
 }
 
 // renderPaginator must be run after the owning Page has been rendered.
 func (s *Site) renderPaginator(p *pageState, layouts []string) error {
 
-	paginatePath := s.Cfg.GetString("paginatePath")
-
 	d := p.targetPathDescriptor
+
+	paginatePath := d.PaginatePath
+	if paginatePath == "" {
+		paginatePath = s.Cfg.GetString("paginatePath")
+	}
 	f := p.s.rc.Format
 	d.Type = f
 
@@ -210,7 +353,7 @@ func (s *Site) render404() error {
 		s:    s,
 		kind: kind404,
 		urlPaths: pagemeta.URLPath{
-			URL: "404.html",
+			URL: s.notFoundFilename(),
 		},
 	},
 		output.HTMLFormat,
@@ -220,7 +363,11 @@ func (s *Site) render404() error {
 		return err
 	}
 
-	nfLayouts := []string{"404.html"}
+	if !s.shouldRenderSegment(kind404, p.RelPermalink(), output.HTMLFormat.Name) {
+		return nil
+	}
+
+	nfLayouts := []string{fmt.Sprintf("404.%s.html", s.language.Lang), "404.html"}
 
 	targetPath := p.targetPaths().TargetFilename
 
@@ -231,33 +378,159 @@ func (s *Site) render404() error {
 	return s.renderAndWritePage(&s.PathSpec.ProcessingStats.Pages, "404 page", targetPath, p, nfLayouts...)
 }
 
+// notFoundFilename returns the filename of the 404 page, which may be set per
+// language to match what the hosting platform expects, e.g. "not_found.html".
+func (s *Site) notFoundFilename() string {
+	if s.siteCfg.notFoundFilename == "" {
+		return "404.html"
+	}
+	return s.siteCfg.notFoundFilename
+}
+
+// The maximum number of URLs in a sitemap file as defined by the protocol.
+const sitemapMaxURLs = 50000
+
+// sitemapIndexEntry represents one of the files in a split sitemap.
+type sitemapIndexEntry struct {
+	absURL     string
+	lastChange time.Time
+}
+
+func (e sitemapIndexEntry) SitemapAbsURL() string {
+	return e.absURL
+}
+
+func (e sitemapIndexEntry) LastChange() time.Time {
+	return e.lastChange
+}
+
 func (s *Site) renderSitemap() error {
 	if !s.isEnabled(kindSitemap) {
 		return nil
 	}
 
+	filename := s.siteCfg.sitemap.Filename
+
+	if !s.shouldRenderSegment(kindSitemap, path.Join("/", s.Info.LanguagePrefix, filename), output.SitemapFormat.Name) {
+		return nil
+	}
+
+	var pages page.Pages
+	for _, p := range s.Pages() {
+		if p.Sitemap().Exclude || isNoIndex(p) {
+			continue
+		}
+		pages = append(pages, p)
+	}
+
+	maxURLs := s.siteCfg.sitemap.MaxURLs
+	if maxURLs <= 0 || maxURLs > sitemapMaxURLs {
+		maxURLs = sitemapMaxURLs
+	}
+
+	if len(pages) <= maxURLs {
+		return s.renderSitemapFile(filename, pages)
+	}
+
+	// Split the sitemap into multiple files and render a sitemap index
+	// with the configured filename.
+	ext := path.Ext(filename)
+	base := strings.TrimSuffix(filename, ext)
+	homeURL := s.Info.HomeAbsURL()
+	if !strings.HasSuffix(homeURL, "/") {
+		homeURL += "/"
+	}
+
+	var entries []sitemapIndexEntry
+	for i := 0; i*maxURLs < len(pages); i++ {
+		end := (i + 1) * maxURLs
+		if end > len(pages) {
+			end = len(pages)
+		}
+		chunk := pages[i*maxURLs : end]
+		chunkFilename := fmt.Sprintf("%s-%d%s", base, i+1, ext)
+
+		if err := s.renderSitemapFile(chunkFilename, chunk); err != nil {
+			return err
+		}
+
+		var lastChange time.Time
+		for _, p := range chunk {
+			if p.Lastmod().After(lastChange) {
+				lastChange = p.Lastmod()
+			}
+		}
+
+		entries = append(entries, sitemapIndexEntry{absURL: homeURL + chunkFilename, lastChange: lastChange})
+	}
+
+	p, err := s.newSitemapPage(filename)
+	if err != nil {
+		return err
+	}
+
+	smLayouts := []string{"sitemapindex.xml", "_default/sitemapindex.xml", "_internal/_default/sitemapindex.xml"}
+
+	return s.renderAndWriteXML(&s.PathSpec.ProcessingStats.Sitemaps, "sitemapindex", p.targetPaths().TargetFilename, entries, smLayouts...)
+}
+
+// robotsFor returns the robots meta tag content to inject into the HTML
+// output of p: "noindex, nofollow" outside of the production environment,
+// else the robots front matter of p, if set.
+func (s *Site) robotsFor(p page.Page) string {
+	if !s.Info.hugoInfo.IsProduction() {
+		return "noindex, nofollow"
+	}
+	return cast.ToString(p.Params()["robots"])
+}
+
+// isNoIndex returns whether the robots front matter of p tells search engines
+// not to index it, e.g. "noindex, nofollow".
+func isNoIndex(p page.Page) bool {
+	robots := strings.ToLower(cast.ToString(p.Params()["robots"]))
+	for _, directive := range strings.Split(robots, ",") {
+		if d := strings.TrimSpace(directive); d == "noindex" || d == "none" {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Site) newSitemapPage(filename string) (*pageState, error) {
 	p, err := newPageStandalone(&pageMeta{
 		s:    s,
 		kind: kindSitemap,
 		urlPaths: pagemeta.URLPath{
-			URL: s.siteCfg.sitemap.Filename,
+			URL: filename,
 		}},
 		output.HTMLFormat,
 	)
 
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	targetPath := p.targetPaths().TargetFilename
+	if p.targetPaths().TargetFilename == "" {
+		return nil, errors.New("failed to create targetPath for sitemap")
+	}
 
-	if targetPath == "" {
-		return errors.New("failed to create targetPath for sitemap")
+	return p, nil
+}
+
+func (s *Site) renderSitemapFile(filename string, pages page.Pages) error {
+	p, err := s.newSitemapPage(filename)
+	if err != nil {
+		return err
 	}
 
+	// Make .Pages and .Data.Pages return the pages for this file.
+	p.pagesInit.Do(func() {
+		p.pages = pages
+	})
+
 	smLayouts := []string{"sitemap.xml", "_default/sitemap.xml", "_internal/_default/sitemap.xml"}
 
-	return s.renderAndWriteXML(&s.PathSpec.ProcessingStats.Sitemaps, "sitemap", targetPath, p, smLayouts...)
+	return s.renderAndWriteXML(&s.PathSpec.ProcessingStats.Sitemaps, "sitemap", p.targetPaths().TargetFilename, p, smLayouts...)
 }
 
 func (s *Site) renderRobotsTXT() error {
@@ -282,7 +555,16 @@ func (s *Site) renderRobotsTXT() error {
 		return err
 	}
 
-	rLayouts := []string{"robots.txt", "_default/robots.txt", "_internal/_default/robots.txt"}
+	if !s.shouldRenderSegment(kindRobotsTXT, p.RelPermalink(), output.RobotsTxtFormat.Name) {
+		return nil
+	}
+
+	// Environment specific templates, e.g. robots.staging.txt, take precedence.
+	env := s.Cfg.GetString("environment")
+	rLayouts := []string{
+		fmt.Sprintf("robots.%s.txt", env), fmt.Sprintf("_default/robots.%s.txt", env),
+		"robots.txt", "_default/robots.txt", "_internal/_default/robots.txt",
+	}
 
 	return s.renderAndWritePage(&s.PathSpec.ProcessingStats.Pages, "Robots Txt", p.targetPaths().TargetFilename, p, rLayouts...)
 
@@ -304,6 +586,10 @@ func (s *Site) renderAliases() error {
 			plink := of.Permalink()
 			f := of.Format
 
+			if !s.shouldRenderSegment(p.Kind(), of.RelPermalink(), f.Name) {
+				continue
+			}
+
 			for _, a := range p.Aliases() {
 				isRelative := !strings.HasPrefix(a, "/")
 
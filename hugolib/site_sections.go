@@ -19,6 +19,7 @@ import (
 
 // Sections returns the top level sections.
 func (s *SiteInfo) Sections() page.Pages {
+	s.siteAccessed()
 	home, err := s.Home()
 	if err == nil {
 		return home.Sections()
@@ -28,5 +29,6 @@ func (s *SiteInfo) Sections() page.Pages {
 
 // Home is a shortcut to the home page, equivalent to .Site.GetPage "home".
 func (s *SiteInfo) Home() (page.Page, error) {
+	s.siteAccessed()
 	return s.s.home, nil
 }
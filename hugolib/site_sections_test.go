@@ -279,6 +279,27 @@ PAG|{{ .Title }}|{{ $sect.InSection . }}
 			c.Assert(err, qt.IsNil)
 			c.Assert(isAncestor, qt.Equals, false)
 
+			ancestors := p.Ancestors()
+			c.Assert(len(ancestors), qt.Equals, 3)
+			c.Assert(ancestors[0], qt.Equals, p.Parent())
+			c.Assert(ancestors[1], qt.Equals, l1)
+			c.Assert(ancestors[2].IsHome(), qt.Equals, true)
+			for _, ancestor := range ancestors {
+				isAncestor, err = ancestor.IsAncestor(p)
+				c.Assert(err, qt.IsNil)
+				c.Assert(isAncestor, qt.Equals, true)
+				isDescendant, err = p.IsDescendant(ancestor)
+				c.Assert(err, qt.IsNil)
+				c.Assert(isDescendant, qt.Equals, true)
+			}
+			isAncestor, err = ancestors[2].IsAncestor(ancestors[2])
+			c.Assert(err, qt.IsNil)
+			c.Assert(isAncestor, qt.Equals, false)
+			c.Assert(len(p.Pages()[0].Ancestors()), qt.Equals, 4)
+			c.Assert(p.Pages()[0].Ancestors()[0], qt.Equals, p)
+			c.Assert(len(ancestors[2].Ancestors()), qt.Equals, 0)
+			c.Assert(len(nilp.Ancestors()), qt.Equals, 0)
+
 		}},
 		{"perm a,link", func(c *qt.C, p page.Page) {
 			c.Assert(p.Title(), qt.Equals, "T9_-1")
@@ -0,0 +1,59 @@
+// Copyright 2019 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hugolib
+
+import (
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestRenderSegments(t *testing.T) {
+	t.Parallel()
+
+	c := qt.New(t)
+
+	config := `
+baseURL = "https://example.org/"
+disableKinds = ["taxonomy", "taxonomyTerm"]
+renderSegments = ["docs"]
+
+[segments.docs]
+[[segments.docs.includes]]
+path = "/docs/**"
+[[segments.docs.includes]]
+kind = "home"
+`
+
+	b := newTestSitesBuilder(t).WithConfigFile("toml", config)
+	b.WithContent(
+		"docs/d1.md", "---\ntitle: D1\n---\n",
+		"blog/b1.md", "---\ntitle: B1\n---\n",
+	)
+	b.WithTemplates(
+		"index.html", "Home: {{ len .Site.RegularPages }}",
+		"_default/list.html", "List: {{ .Title }}",
+		"_default/single.html", `Single: {{ .Title }}|{{ with .Site.GetPage "/blog/b1" }}{{ .RelPermalink }}{{ end }}`,
+	)
+	b.Build(BuildCfg{})
+
+	b.AssertFileContent("public/index.html", "Home: 2")
+	b.AssertFileContent("public/docs/d1/index.html", "Single: D1|/blog/b1/")
+	b.AssertFileContent("public/docs/index.html", "List: Docs")
+
+	c.Assert(b.CheckExists("public/blog/b1/index.html"), qt.Equals, false)
+	c.Assert(b.CheckExists("public/blog/index.html"), qt.Equals, false)
+	c.Assert(b.CheckExists("public/sitemap.xml"), qt.Equals, false)
+	c.Assert(b.CheckExists("public/404.html"), qt.Equals, false)
+}
@@ -17,11 +17,17 @@ import (
 	"bytes"
 	"fmt"
 	"io/ioutil"
+	"path/filepath"
+	"strings"
 	"testing"
 
+	"github.com/gohugoio/hugo/common/loggers"
 	"github.com/gohugoio/hugo/helpers"
 
+	"github.com/fsnotify/fsnotify"
+
 	qt "github.com/frankban/quicktest"
+	jww "github.com/spf13/jwalterweatherman"
 )
 
 func TestSiteStats(t *testing.T) {
@@ -96,3 +102,217 @@ aliases: [/Ali%d]
 	c.Assert(buff.String(), qt.Contains, "Pages            | 19 |  6")
 
 }
+
+func TestWriteStats(t *testing.T) {
+	t.Parallel()
+
+	b := newTestSitesBuilder(t).WithConfigFile("toml", `
+baseURL = "http://example.com"
+disableKinds = ["taxonomy", "taxonomyTerm", "RSS", "sitemap", "robotsTXT", "404"]
+
+[build]
+writeStats = true
+`)
+
+	b.WithContent("p1.md", "---\ntitle: P1\n---\n")
+	b.WithTemplates(
+		"index.html", `<html><body><div id="home" class="a b">Home</div></body></html>`,
+		"_default/single.html", `<html><body><article class="b  c"><img src="i.png" id="img"/></article><script>var s = "<span class='not'>";</script></body></html>`,
+	)
+
+	b.Build(BuildCfg{})
+
+	b.AssertFileContent("hugo_stats.json", `
+"tags": [
+"article",
+"body",
+"div",
+"html",
+"img",
+"script"
+],
+"classes": [
+"a",
+"b",
+"c"
+],
+"ids": [
+"home",
+"img"
+]
+`)
+}
+
+func TestWriteSchedule(t *testing.T) {
+	t.Parallel()
+
+	b := newTestSitesBuilder(t).WithConfigFile("toml", `
+baseURL = "http://example.com"
+disableKinds = ["taxonomy", "taxonomyTerm", "RSS", "sitemap", "robotsTXT", "404"]
+
+[build]
+writeSchedule = true
+`)
+
+	b.WithContent(
+		"p1.md", "---\ntitle: P1\nexpiryDate: 2100-06-01\n---\n",
+		"p2.md", "---\ntitle: P2\npublishDate: 2100-09-01\n---\n",
+		"p3.md", "---\ntitle: P3\nexpiryDate: 2000-01-01\n---\n",
+		"p4.md", "---\ntitle: P4\npublishDate: 2100-09-01\ndraft: true\n---\n",
+	)
+	b.WithTemplates("_default/single.html", "{{ .Title }}", "index.html", "Home")
+
+	b.Build(BuildCfg{})
+
+	b.AssertFileContent("hugo_schedule.json", `
+"nextRebuild": "2100-06-01T00:00:00Z",
+"path": "p2.md",
+"publishDate": "2100-09-01T00:00:00Z",
+"reason": "future"
+"path": "p3.md",
+"expiryDate": "2000-01-01T00:00:00Z",
+"reason": "expired"
+`)
+	schedule := b.FileContent("hugo_schedule.json")
+	b.Assert(schedule, qt.Not(qt.Contains), "p1.md")
+	b.Assert(schedule, qt.Not(qt.Contains), "p4.md")
+}
+
+func TestWriteScheduleRebuild(t *testing.T) {
+	t.Parallel()
+
+	b := newTestSitesBuilder(t).Running().WithConfigFile("toml", `
+baseURL = "http://example.com"
+disableKinds = ["taxonomy", "taxonomyTerm", "RSS", "sitemap", "robotsTXT", "404"]
+
+[build]
+writeSchedule = true
+`)
+
+	b.WithContent(
+		"p1.md", "---\ntitle: P1\npublishDate: 2100-06-01\n---\n",
+		"p2.md", "---\ntitle: P2\npublishDate: 2100-09-01\n---\n",
+	)
+	b.WithTemplates("_default/single.html", "{{ .Title }}", "index.html", "Home")
+
+	b.Build(BuildCfg{})
+
+	b.AssertFileContent("hugo_schedule.json", `"path": "p1.md"`, `"path": "p2.md"`)
+
+	// Deleted content files must be removed from the schedule.
+	filename := filepath.FromSlash("content/p1.md")
+	b.Assert(b.Fs.Source.Remove(filename), qt.IsNil)
+	b.Assert(b.H.Build(BuildCfg{}, fsnotify.Event{Name: filename, Op: fsnotify.Remove}), qt.IsNil)
+
+	schedule := b.FileContent("hugo_schedule.json")
+	b.Assert(schedule, qt.Not(qt.Contains), "p1.md")
+	b.Assert(schedule, qt.Contains, `"path": "p2.md"`)
+	b.Assert(schedule, qt.Contains, `"nextRebuild": "2100-09-01T00:00:00Z"`)
+}
+
+func TestCheckLinks(t *testing.T) {
+	t.Parallel()
+
+	config := `
+baseURL = "http://example.com/docs/"
+disableKinds = ["taxonomy", "taxonomyTerm", "RSS", "sitemap", "robotsTXT", "404"]
+
+[build]
+checkLinks = %t
+checkLinksIgnore = ["/api/**", "/search/*"]
+`
+
+	for _, enabled := range []bool{true, false} {
+		logger := loggers.NewLogger(jww.LevelError, jww.LevelError, ioutil.Discard, ioutil.Discard, true)
+		b := newTestSitesBuilder(t).WithLogger(logger).WithConfigFile("toml", fmt.Sprintf(config, enabled))
+
+		b.WithContent("blog/p1.md", "---\ntitle: P1\n---\n", "blog/p2.md", "---\ntitle: P2\n---\n")
+		b.WithSourceFile("static/images/logo.png", "logo")
+		b.WithTemplates(
+			"index.html", `<html><body><a href="/docs/blog/p1/">P1</a><a href="https://gohugo.io/">Hugo</a><a href="/other/">Other</a></body></html>`,
+			"_default/list.html", `<html><body>{{ range .Pages }}<a href="{{ .RelPermalink }}">{{ .Title }}</a>{{ end }}</body></html>`,
+			"_default/single.html", `<html>
+<body>
+<img src="/docs/images/logo.png">
+<img src="../../images/nope.png">
+<a href="{{ .Site.BaseURL }}api/v1/users">API</a><a href="/docs/search/q">Search</a>
+<a href="/docs/blog/p3/">P3</a><a href="#top">Top</a><a href="/docs/blog/p2/#x">P2</a>
+</body>
+</html>`,
+		)
+
+		if !enabled {
+			b.Build(BuildCfg{})
+			b.Assert(logger.Errors(), qt.Equals, "")
+			continue
+		}
+
+		b.BuildFail(BuildCfg{})
+
+		errors := logger.Errors()
+		b.Assert(errors, qt.Contains, `broken link "../../images/nope.png" in blog/p1/index.html:4 (content file "blog/p1.md")`)
+		b.Assert(errors, qt.Contains, `broken link "/docs/blog/p3/" in blog/p1/index.html:6 (content file "blog/p1.md")`)
+		b.Assert(errors, qt.Contains, `broken link "/docs/blog/p3/" in blog/p2/index.html:6 (content file "blog/p2.md")`)
+		b.Assert(strings.Count(errors, "broken link"), qt.Equals, 4, qt.Commentf(errors))
+	}
+}
+
+func TestCheckLinksRebuild(t *testing.T) {
+	t.Parallel()
+
+	logger := loggers.NewLogger(jww.LevelError, jww.LevelError, ioutil.Discard, ioutil.Discard, true)
+	b := newTestSitesBuilder(t).Running().WithLogger(logger).WithConfigFile("toml", `
+baseURL = "http://example.com/"
+disableKinds = ["taxonomy", "taxonomyTerm", "RSS", "sitemap", "robotsTXT", "404"]
+
+[build]
+checkLinks = true
+`)
+
+	b.WithContent(
+		"p1.md", "---\ntitle: P1\nlink: /missing/\n---\n",
+		"p2.md", "---\ntitle: P2\nlink: /p2/\n---\n",
+		"p3.md", "---\ntitle: P3\nlink: /missing3/\n---\n",
+	)
+	b.WithTemplates(
+		"index.html", `<html><body>Home</body></html>`,
+		"_default/single.html", `<html><body><a href="{{ .Params.link }}">Link</a></body></html>`,
+	)
+
+	b.BuildFail(BuildCfg{})
+	b.Assert(logger.Errors(), qt.Contains, `broken link "/missing/" in p1/index.html:1`)
+
+	b.Assert(logger.Errors(), qt.Contains, `broken link "/missing3/" in p3/index.html:1`)
+
+	// The links of deleted pages must not be checked again, but the pages
+	// not rendered in fast render mode keep theirs.
+	filename := filepath.FromSlash("content/p1.md")
+	b.Assert(b.Fs.Source.Remove(filename), qt.IsNil)
+	b.Assert(b.H.Build(BuildCfg{RecentlyVisited: map[string]bool{"/": true}}, fsnotify.Event{Name: filename, Op: fsnotify.Remove}), qt.Not(qt.IsNil))
+	b.Assert(logger.Errors(), qt.Not(qt.Contains), "/missing/")
+	b.Assert(logger.Errors(), qt.Contains, `broken link "/missing3/" in p3/index.html:1`)
+}
+
+func TestBuildReport(t *testing.T) {
+	t.Parallel()
+
+	b := newTestSitesBuilder(t).WithConfigFile("toml", `
+baseURL = "http://example.com"
+buildReport = "build-report.json"
+`)
+
+	b.WithContent("blog/p1.md", "---\ntitle: P1\n---\n")
+	b.WithTemplatesAdded("index.html", `{{ $css := "body { color: red; }" | resources.FromString "main.css" | minify }}{{ $css.RelPermalink }}`)
+
+	b.Build(BuildCfg{})
+
+	b.AssertFileContent("build-report.json",
+		`"name": "content/read"`,
+		`"name": "content/assemble"`,
+		`"name": "render"`,
+		`"name": "render/home"`,
+		`"name": "render/page/blog"`,
+		`"name": "render/section/blog"`,
+		`"name": "assets/minify"`,
+	)
+}
@@ -27,6 +27,7 @@ import (
 	"github.com/gohugoio/hugo/helpers"
 
 	qt "github.com/frankban/quicktest"
+	"github.com/gohugoio/hugo/config"
 	"github.com/gohugoio/hugo/deps"
 	"github.com/gohugoio/hugo/resources/page"
 )
@@ -48,6 +49,61 @@ func TestRenderWithInvalidTemplate(t *testing.T) {
 
 }
 
+func TestRenderWorkers(t *testing.T) {
+	t.Parallel()
+	c := qt.New(t)
+
+	b := newTestSitesBuilder(t).WithSimpleConfigFile()
+	c.Assert(b.CreateSites().H.Sites[0].numRenderWorkers(), qt.Equals, config.GetNumWorkerMultiplier())
+
+	b = newTestSitesBuilder(t).WithConfigFile("toml", `
+baseURL = "https://example.org"
+renderWorkers = 1
+`)
+	for i := 1; i <= 10; i++ {
+		b.WithContent(fmt.Sprintf("p%d.md", i), fmt.Sprintf("---\ntitle: P%d\n---\n", i))
+	}
+	b.WithTemplatesAdded("_default/single.html", "Single: {{ .Title }}")
+	b.Build(BuildCfg{})
+
+	c.Assert(b.H.Sites[0].numRenderWorkers(), qt.Equals, 1)
+	for i := 1; i <= 10; i++ {
+		b.AssertFileContent(fmt.Sprintf("public/p%d/index.html", i), fmt.Sprintf("Single: P%d", i))
+	}
+}
+
+func TestRenderMemoryBudget(t *testing.T) {
+	t.Parallel()
+	c := qt.New(t)
+
+	b := newTestSitesBuilder(t).WithConfigFile("toml", `
+baseURL = "https://example.org"
+disableKinds = ["taxonomy", "taxonomyTerm", "section", "RSS", "sitemap", "robotsTXT", "404"]
+# Always over budget.
+memoryBudget = 1
+[outputs]
+home = ["HTML", "JSON"]
+`)
+	for i := 1; i <= 10; i++ {
+		b.WithContent(fmt.Sprintf("p%d.md", i), fmt.Sprintf("---\ntitle: P%d\n---\n**Content %d**", i, i))
+	}
+	b.WithTemplatesAdded(
+		"_default/single.html", "Single: {{ .Title }}|{{ .Content }}",
+		"index.json", "{{ range .Site.RegularPages }}JSON: {{ .Title }}|{{ .Plain | chomp }}|{{ .Content }}{{ end }}",
+	)
+	b.Build(BuildCfg{})
+
+	c.Assert(b.H.Sites[0].memoryBudget(), qt.Equals, uint64(1024*1024))
+
+	b.AssertFileContent("public/p1/index.html", "Single: P1|<p><strong>Content 1</strong></p>")
+
+	// Rendered again after the HTML pages were evicted.
+	b.AssertFileContent("public/index.json",
+		"JSON: P1|Content 1|<p><strong>Content 1</strong></p>",
+		"JSON: P10|Content 10|<p><strong>Content 10</strong></p>",
+	)
+}
+
 func TestDraftAndFutureRender(t *testing.T) {
 	t.Parallel()
 	sources := [][2]string{
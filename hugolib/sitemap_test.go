@@ -14,6 +14,8 @@
 package hugolib
 
 import (
+	"fmt"
+	"strings"
 	"testing"
 
 	"reflect"
@@ -87,11 +89,13 @@ func doTestSitemapOutput(t *testing.T, internal bool) {
 
 func TestParseSitemap(t *testing.T) {
 	t.Parallel()
-	expected := config.Sitemap{Priority: 3.0, Filename: "doo.xml", ChangeFreq: "3"}
+	expected := config.Sitemap{Priority: 3.0, Filename: "doo.xml", ChangeFreq: "3", Exclude: true, Images: []string{"a.jpg"}}
 	input := map[string]interface{}{
 		"changefreq": "3",
 		"priority":   3.0,
 		"filename":   "doo.xml",
+		"exclude":    true,
+		"images":     []interface{}{"a.jpg"},
 		"unknown":    "ignore",
 	}
 	result := config.DecodeSitemap(config.Sitemap{}, input)
@@ -120,3 +124,57 @@ outputs: [ "html", "amp" ]
 	// Should link to the HTML version.
 	b.AssertFileContent("public/sitemap.xml", " <loc>http://example.com/blog/html-amp/</loc>")
 }
+
+func TestSitemapFrontMatter(t *testing.T) {
+	t.Parallel()
+
+	b := newTestSitesBuilder(t).WithConfigFile("toml", `baseURL = "https://example.org/"`)
+
+	b.WithContent(
+		"p1.md", "---\ntitle: P1\nsitemap:\n  changefreq: weekly\n  priority: 0.8\n  images: [\"/images/p1.jpg\"]\n---\n",
+		"p2.md", "---\ntitle: P2\nsitemap:\n  exclude: true\n---\n",
+	)
+
+	b.Build(BuildCfg{})
+
+	b.AssertFileContent("public/sitemap.xml",
+		"<loc>https://example.org/p1/</loc>",
+		"<changefreq>weekly</changefreq>",
+		"<priority>0.8</priority>",
+		`<image:image xmlns:image="http://www.google.com/schemas/sitemap-image/1.1">`,
+		"<image:loc>https://example.org/images/p1.jpg</image:loc>",
+	)
+
+	b.Assert(strings.Contains(b.FileContent("public/sitemap.xml"), "/p2/"), qt.Equals, false)
+}
+
+func TestSitemapSplit(t *testing.T) {
+	t.Parallel()
+
+	b := newTestSitesBuilder(t).WithConfigFile("toml", `
+baseURL = "https://example.org/"
+disableKinds = ["taxonomy", "taxonomyTerm"]
+
+[sitemap]
+maxURLs = 3
+`)
+
+	for i := 1; i <= 4; i++ {
+		b.WithContent(fmt.Sprintf("p%d.md", i), fmt.Sprintf("---\ntitle: P%d\nlastmod: 2019-01-0%d\n---\n", i, i))
+	}
+
+	b.Build(BuildCfg{})
+
+	b.AssertFileContent("public/sitemap.xml",
+		"<sitemapindex",
+		"<loc>https://example.org/sitemap-1.xml</loc>",
+		"<loc>https://example.org/sitemap-2.xml</loc>",
+		"<lastmod>2019-01-04T00:00:00+00:00</lastmod>",
+	)
+
+	// Home page and 4 regular pages.
+	sm1, sm2 := b.FileContent("public/sitemap-1.xml"), b.FileContent("public/sitemap-2.xml")
+	b.Assert(strings.Count(sm1, "<url>"), qt.Equals, 3)
+	b.Assert(strings.Count(sm2, "<url>"), qt.Equals, 2)
+	b.Assert(b.CheckExists("public/sitemap-3.xml"), qt.Equals, false)
+}
@@ -55,6 +55,17 @@ func (i Taxonomy) Get(key string) page.WeightedPages {
 // Count the weighted pages for the given key.
 func (i Taxonomy) Count(key string) int { return len(i[key]) }
 
+// Page returns the taxonomy term page for the given key, or nil if
+// the key is not found. Use it to access the term's front matter, e.g.
+// {{ (.Site.Taxonomies.tags.Page "go").Params.color }}.
+func (i Taxonomy) Page(key string) page.Page {
+	wp, found := i[key]
+	if !found || len(wp) == 0 {
+		return nil
+	}
+	return wp.Page()
+}
+
 func (i Taxonomy) add(key string, w page.WeightedPage) {
 	i[key] = append(i[key], w)
 }
@@ -382,3 +382,140 @@ This is the invisible content.
 	b.AssertFileContent("public/index.html", " /categories/|The Categories|0||")
 
 }
+
+func TestTaxonomiesTranslatedPlural(t *testing.T) {
+	t.Parallel()
+
+	b := newTestSitesBuilder(t)
+	b.WithConfigFile("toml", `
+baseURL = "https://example.org/"
+defaultContentLanguage = "en"
+
+[languages]
+[languages.en]
+weight = 1
+[languages.en.taxonomies]
+tag = "tags"
+[languages.es]
+weight = 2
+[languages.es.taxonomies]
+tag = "etiquetas"
+`)
+	b.WithContent(
+		"p1.md", "---\ntitle: P1\ntags: [hugo]\n---",
+		"p1.es.md", "---\ntitle: P1 ES\netiquetas: [hugo, blog]\n---",
+		"p2.es.md", "---\ntitle: P2 ES\netiquetas: [rojo]\n---",
+		"p2.md", "---\ntitle: P2\ntags: [red]\n---",
+		// Terms with different names can be linked with translationKey.
+		"tags/red/_index.md", "---\ntitle: Red\ntranslationKey: red\n---",
+		"etiquetas/rojo/_index.es.md", "---\ntitle: Rojo\ntranslationKey: red\n---",
+	)
+	tpl := `{{ .Title }}|{{ .Data.Plural }}|{{ range .Translations }}{{ .Lang }}: {{ .RelPermalink }}|{{ end }}`
+	b.WithTemplates("_default/single.html", "{{ .Title }}", "_default/list.html", tpl)
+	b.Build(BuildCfg{})
+
+	b.AssertFileContent("public/tags/index.html", "Tags|tags|es: /es/etiquetas/|")
+	b.AssertFileContent("public/es/etiquetas/index.html", "Etiquetas|etiquetas|en: /tags/|")
+	b.AssertFileContent("public/tags/hugo/index.html", "hugo|tags|es: /es/etiquetas/hugo/|")
+	b.AssertFileContent("public/es/etiquetas/hugo/index.html", "hugo|etiquetas|en: /tags/hugo/|")
+	b.AssertFileContent("public/es/etiquetas/blog/index.html", "blog|etiquetas|")
+	b.AssertFileContent("public/tags/red/index.html", "Red|tags|es: /es/etiquetas/rojo/|")
+	b.AssertFileContent("public/es/etiquetas/rojo/index.html", "Rojo|etiquetas|en: /tags/red/|")
+}
+
+func TestTaxonomiesGetTermsAndTermPage(t *testing.T) {
+	t.Parallel()
+
+	b := newTestSitesBuilder(t)
+
+	b.WithContent("tags/go/_index.md", `---
+title: "Go"
+color: "blue"
+---
+`,
+		"p1.md", `---
+title: "P1"
+tags: ["Go", "Hugo", "go"]
+---
+`,
+		"p2.md", `---
+title: "P2"
+tags: ["hugo"]
+---
+`)
+
+	b.WithTemplatesAdded("index.html", `
+{{ $tags := .Site.Taxonomies.tags }}
+Go: {{ ($tags.Page "go").Title }}|{{ ($tags.Page "go").Params.color }}|{{ $tags.Count "go" }}|
+Missing: {{ with $tags.Page "nope" }}FOUND{{ else }}nil{{ end }}|
+{{ range $tags.ByCount }}Entry: {{ .Page.Title }}|{{ .Count }}|{{ len .WeightedPages }}|
+{{ end }}
+`,
+		"_default/single.html", `
+Terms: {{ range .GetTerms "tags" }}{{ .Title }}|{{ .RelPermalink }}|{{ end }}
+Terms upper: {{ len (.GetTerms "Tags") }}
+Categories: {{ len (.GetTerms "categories") }}
+`)
+
+	b.Build(BuildCfg{})
+
+	b.AssertFileContent("public/index.html",
+		"Go: Go|blue|2|",
+		"Missing: nil|",
+		"Entry: Go|2|2|\nEntry: Hugo|2|2|",
+	)
+
+	b.AssertFileContent("public/p1/index.html",
+		"Terms: Go|/tags/go/|Hugo|/tags/hugo/|",
+		"Terms upper: 2",
+		"Categories: 0",
+	)
+	b.AssertFileContent("public/p2/index.html", "Terms: Hugo|/tags/hugo/|")
+}
+
+func TestTaxonomiesTranslatedSlugs(t *testing.T) {
+	t.Parallel()
+
+	b := newTestSitesBuilder(t)
+	b.WithConfigFile("toml", `
+baseURL = "https://example.org/"
+defaultContentLanguage = "en"
+
+[languages]
+[languages.en]
+weight = 1
+[languages.en.taxonomies]
+tag = "tags"
+[languages.es]
+weight = 2
+[languages.es.taxonomies]
+tag = "etiquetas"
+[languages.es.sectionSlugs]
+posts = "articulos"
+news = "noticias"
+[languages.es.termSlugs.tag]
+red = "rojo"
+`)
+	b.WithContent(
+		"posts/_index.md", "---\ntitle: Posts\n---",
+		"posts/_index.es.md", "---\ntitle: Artículos\n---",
+		"posts/news/_index.md", "---\ntitle: News\n---",
+		"posts/news/_index.es.md", "---\ntitle: Noticias\n---",
+		"posts/news/p1.md", "---\ntitle: P1\ntags: [red]\n---",
+		"posts/news/p1.es.md", "---\ntitle: P1 ES\netiquetas: [red]\n---",
+		"posts/2020/p2.es.md", "---\ntitle: P2 ES\n---",
+	)
+	tpl := `{{ .Title }}|{{ range .Translations }}{{ .Lang }}: {{ .RelPermalink }}|{{ end }}`
+	b.WithTemplates("_default/single.html", tpl, "_default/list.html", tpl)
+	b.Build(BuildCfg{})
+
+	b.AssertFileContent("public/posts/index.html", "Posts|es: /es/articulos/|")
+	b.AssertFileContent("public/es/articulos/index.html", "Artículos|en: /posts/|")
+	b.AssertFileContent("public/es/articulos/noticias/index.html", "Noticias|en: /posts/news/|")
+	b.AssertFileContent("public/posts/news/p1/index.html", "P1|es: /es/articulos/noticias/p1/|")
+	b.AssertFileContent("public/es/articulos/noticias/p1/index.html", "P1 ES|en: /posts/news/p1/|")
+	// Only the directories that are sections are translated.
+	b.AssertFileContent("public/es/articulos/2020/p2/index.html", "P2 ES|")
+	b.AssertFileContent("public/tags/red/index.html", "red|es: /es/etiquetas/rojo/|")
+	b.AssertFileContent("public/es/etiquetas/rojo/index.html", "red|en: /tags/red/|")
+}
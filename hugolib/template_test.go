@@ -14,10 +14,17 @@
 package hugolib
 
 import (
+	"bytes"
 	"fmt"
+	"io/ioutil"
 	"path/filepath"
+	"strings"
 	"testing"
 
+	qt "github.com/frankban/quicktest"
+	"github.com/gohugoio/hugo/common/loggers"
+	jww "github.com/spf13/jwalterweatherman"
+
 	"github.com/gohugoio/hugo/deps"
 	"github.com/gohugoio/hugo/hugofs"
 
@@ -247,6 +254,7 @@ func TestTemplateFuncs(t *testing.T) {
 	homeTpl := `Site: {{ site.Language.Lang }} / {{ .Site.Language.Lang }} / {{ site.BaseURL }}
 Sites: {{ site.Sites.First.Home.Language.Lang }}
 Hugo: {{ hugo.Generator }}
+HugoInfo: {{ hugo.Environment }}|{{ hugo.IsProduction }}|{{ hugo.IsServer }}
 `
 
 	b.WithTemplatesAdded(
@@ -259,7 +267,8 @@ Hugo: {{ hugo.Generator }}
 	b.AssertFileContent("public/en/index.html",
 		"Site: en / en / http://example.com/blog",
 		"Sites: en",
-		"Hugo: <meta name=\"generator\" content=\"Hugo")
+		"Hugo: <meta name=\"generator\" content=\"Hugo",
+		"HugoInfo: production|true|false")
 	b.AssertFileContent("public/fr/index.html",
 		"Site: fr / fr / http://example.com/blog",
 		"Sites: en",
@@ -268,6 +277,19 @@ Hugo: {{ hugo.Generator }}
 
 }
 
+func TestTemplateFuncsHugoServer(t *testing.T) {
+	b := newTestSitesBuilder(t).Running()
+	b.WithConfigFile("toml", `
+baseURL = "http://example.com/"
+environment = "development"
+`)
+	b.WithTemplatesAdded("index.html", `HugoInfo: {{ hugo.Environment }}|{{ hugo.IsProduction }}|{{ hugo.IsServer }}`)
+
+	b.Build(BuildCfg{})
+
+	b.AssertFileContent("public/index.html", "HugoInfo: development|false|true")
+}
+
 func TestPartialWithReturn(t *testing.T) {
 
 	b := newTestSitesBuilder(t).WithSimpleConfigFile()
@@ -280,6 +302,10 @@ add42: 50: {{ partial "add42.tpl" 8 }}
 dollarContext: 60: {{ partial "dollarContext.tpl" 18 }}
 adder: 70: {{ partial "dict.tpl" (dict "adder" 28) }}
 complex: 80: {{ partial "complex.tpl" 38 }}
+conditional: {{ partial "conditional.tpl" 5 }}|{{ partial "conditional.tpl" 50 }}|{{ partial "conditional.tpl" 0 }}
+nil: {{ partial "nil.tpl" nil }}
+map: {{ (partial "map.tpl" "Hugo").name }}
+slice: {{ partial "slice.tpl" 3 }}
 `,
 		"partials/add42.tpl", `
 		{{ $v := add . 42 }}
@@ -295,6 +321,27 @@ complex: 80: {{ partial "complex.tpl" 38 }}
 `,
 		"partials/complex.tpl", `
 {{ return add . 42 }}
+`,
+		"partials/conditional.tpl", `
+{{ if eq . 0 }}
+{{ return "zero" }}
+{{ end }}
+{{ range seq 10 }}
+{{ if eq . $ }}
+{{ return "small" }}
+{{ end }}
+{{ end }}
+{{ $v := printf "large: %d" . }}
+{{ return $v }}
+`,
+		"partials/nil.tpl", `
+{{ with . }}{{ return "not nil" }}{{ else }}{{ return "is nil" }}{{ end }}
+`,
+		"partials/map.tpl", `
+{{ return dict "name" . }}
+`,
+		"partials/slice.tpl", `
+{{ return seq . }}
 `,
 	)
 
@@ -305,6 +352,137 @@ complex: 80: {{ partial "complex.tpl" 38 }}
 		"dollarContext: 60: 60",
 		"adder: 70: 70",
 		"complex: 80: 80",
+		"conditional: small|large: 50|zero",
+		"nil: is nil",
+		"map: Hugo",
+		"slice: [1 2 3]",
+	)
+
+}
+
+func TestPartialCached(t *testing.T) {
+	t.Parallel()
+
+	for _, scope := range []string{"", "outputFormat"} {
+		t.Run(scope, func(t *testing.T) {
+			config := `
+baseURL = "http://example.com/"
+[outputs]
+home = ["HTML", "JSON"]
+`
+			if scope != "" {
+				config += `
+[partialCache]
+scope = "` + scope + `"
+`
+			}
+
+			b := newTestSitesBuilder(t).WithConfigFile("toml", config)
+
+			b.WithTemplatesAdded(
+				"index.html", `
+HTML: {{ partialCached "format.html" . }}
+{{ $a := partialCached "counter.html" . }}{{ $b := partialCached "counter.html" . }}{{ partials.ClearCached "counter.html" }}{{ $c := partialCached "counter.html" . }}
+Counter: {{ $a }}|{{ $b }}|{{ $c }}
+`,
+				"index.json", `JSON: {{ partialCached "format.html" . }}`,
+				"partials/format.html", `{{ range .AlternativeOutputFormats }}{{ .Name }}{{ end }}`,
+				"partials/counter.html", `{{ .Scratch.Add "counter" 1 }}{{ .Scratch.Get "counter" }}`,
+			)
+
+			b.Build(BuildCfg{})
+
+			b.AssertFileContent("public/index.html", "HTML: JSON", "Counter: 1|1|2")
+			if scope == "" {
+				b.AssertFileContent("public/index.json", "JSON: JSON")
+			} else {
+				b.AssertFileContent("public/index.json", "JSON: HTML")
+			}
+		})
+	}
+}
+
+func TestPartialCachedInvalidConfig(t *testing.T) {
+	t.Parallel()
+
+	b := newTestSitesBuilder(t).WithConfigFile("toml", `
+baseURL = "http://example.com/"
+[partialCache]
+scope = "page"
+`)
+
+	err := b.CreateSitesE()
+	b.Assert(err, qt.Not(qt.IsNil))
+	b.Assert(err.Error(), qt.Contains, `invalid partialCache scope "page"`)
+}
+
+func TestTimeFormatLocalized(t *testing.T) {
+	t.Parallel()
+
+	config := `
+baseURL = "http://example.com/"
+defaultContentLanguage = "en"
+[languages]
+[languages.en]
+weight = 1
+[languages.nn]
+weight = 2
+`
+
+	b := newTestSitesBuilder(t).WithConfigFile("toml", config)
+
+	b.WithTemplatesAdded(
+		"index.html", `
+Long: {{ "2020-03-21" | time.Format ":date_long" }}
+Layout: {{ dateFormat "Monday 2 January 2006" "2020-03-21" }}
+`,
+	)
+
+	b.Build(BuildCfg{})
+
+	b.AssertFileContent("public/index.html", "Long: March 21, 2020", "Layout: Saturday 21 March 2020")
+	b.AssertFileContent("public/nn/index.html", "Long: 21. mars 2020", "Layout: laurdag 21 mars 2020")
+}
+
+func TestTemplateLogIDs(t *testing.T) {
+	var buf bytes.Buffer
+	logger := loggers.NewLogger(jww.LevelWarn, jww.LevelError, &buf, ioutil.Discard, false)
+
+	b := newTestSitesBuilder(t).WithLogger(logger).Running()
+	b.WithConfigFile("toml", `
+baseURL = "http://example.com/"
+ignoreLogs = ["Theme-Old-Param", "theme-broken"]
+defaultContentLanguage = "en"
+
+[languages]
+[languages.en]
+weight = 1
+[languages.nn]
+weight = 2
+`)
+	b.WithTemplatesAdded(
+		"_default/single.html", `{{ warnidf "theme-deprecated" "The %s param is deprecated" "author" }}{{ warnidf "theme-old-param" "Ignored" }}{{ erroridf "theme-broken" "Ignored" }}Single: {{ .Title }}`,
 	)
+	b.WithContent("p1.md", "---\ntitle: P1\n---", "p2.md", "---\ntitle: P2\n---", "p1.nn.md", "---\ntitle: P1 NN\n---")
+
+	b.Build(BuildCfg{})
+
+	b.AssertFileContent("public/p1/index.html", "Single: P1")
+
+	b.AssertFileContent("public/nn/p1/index.html", "Single: P1 NN")
+
+	// The warning is printed once for all languages.
+	out := buf.String()
+	b.Assert(strings.Count(out, "The author param is deprecated"), qt.Equals, 1)
+	b.Assert(out, qt.Contains, "theme-deprecated|The author param is deprecated")
+	b.Assert(out, qt.Contains, "ignoreLogs = ['theme-deprecated']")
+	b.Assert(out, qt.Not(qt.Contains), "Ignored")
+	b.Assert(logger.ErrorCounter.Count(), qt.Equals, uint64(0))
 
+	// The warning is printed once per build.
+	buf.Reset()
+	b.EditFiles("content/p1.md", "---\ntitle: P1 Edited\n---")
+	b.Build(BuildCfg{})
+	b.AssertFileContent("public/p1/index.html", "Single: P1 Edited")
+	b.Assert(strings.Count(buf.String(), "The author param is deprecated"), qt.Equals, 1)
 }
@@ -191,6 +191,10 @@ func toSortedLanguages(cfg config.Provider, l map[string]interface{}) (Languages
 				language.ContentDir = filepath.Clean(cast.ToString(v))
 			case "disabled":
 				language.Disabled = cast.ToBool(v)
+			case "fallbacks":
+				for _, fallback := range cast.ToStringSlice(v) {
+					language.Fallbacks = append(language.Fallbacks, strings.ToLower(fallback))
+				}
 			case "params":
 				m := cast.ToStringMap(v)
 				// Needed for case insensitive fetching of params values
@@ -14,28 +14,50 @@
 package i18n
 
 import (
+	"strconv"
+
+	"github.com/spf13/cast"
+
 	"github.com/gohugoio/hugo/common/loggers"
 	"github.com/gohugoio/hugo/config"
 	"github.com/gohugoio/hugo/helpers"
+	"github.com/gohugoio/hugo/langs"
 
 	"github.com/nicksnyder/go-i18n/i18n/bundle"
 	"github.com/nicksnyder/go-i18n/i18n/translation"
 )
 
-var (
-	i18nWarningLogger = helpers.NewDistinctFeedbackLogger()
-)
-
 // Translator handles i18n translations.
 type Translator struct {
 	translateFuncs map[string]bundle.TranslateFunc
 	cfg            config.Provider
 	logger         *loggers.Logger
+
+	// Logs every missing translation once, in the i18n log category.
+	missingLogger *helpers.DistinctLogger
+
+	// Set when a translation report is requested.
+	tracker *translationTracker
+
+	// Creates a translate func for lang that records its lookups as trackLang.
+	newFunc func(lang, trackLang string) bundle.TranslateFunc
+
+	// Maps a language to its configured fallback languages.
+	fallbacks map[string][]string
 }
 
 // NewTranslator creates a new Translator for the given language bundle and configuration.
 func NewTranslator(b *bundle.Bundle, cfg config.Provider, logger *loggers.Logger) Translator {
-	t := Translator{cfg: cfg, logger: logger, translateFuncs: make(map[string]bundle.TranslateFunc)}
+	t := Translator{cfg: cfg, logger: logger, translateFuncs: make(map[string]bundle.TranslateFunc), fallbacks: make(map[string][]string)}
+	t.missingLogger = helpers.NewDistinctLogger(logger.Category("i18n").WARN)
+	if languages, ok := cfg.Get("languagesSorted").(langs.Languages); ok {
+		for _, l := range languages {
+			t.fallbacks[l.Lang] = l.Fallbacks
+		}
+	}
+	if cfg.GetString("i18nReportFile") != "" || cfg.GetInt("i18nMinCoverage") > 0 {
+		t.tracker = newTranslationTracker()
+	}
 	t.initFuncs(b)
 	return t
 }
@@ -46,8 +68,23 @@ func (t Translator) Func(lang string) bundle.TranslateFunc {
 	if f, ok := t.translateFuncs[lang]; ok {
 		return f
 	}
+	for _, fallback := range t.fallbacks[lang] {
+		if f, ok := t.translateFuncs[fallback]; ok {
+			t.logger.INFO.Printf("Translation func for language %v not found, use fallback %v.", lang, fallback)
+			if t.tracker != nil {
+				// Record the lookups as missing for this language.
+				return t.newFunc(fallback, lang)
+			}
+			return f
+		}
+	}
 	t.logger.INFO.Printf("Translation func for language %v not found, use default.", lang)
-	if f, ok := t.translateFuncs[t.cfg.GetString("defaultContentLanguage")]; ok {
+	defaultContentLanguage := t.cfg.GetString("defaultContentLanguage")
+	if f, ok := t.translateFuncs[defaultContentLanguage]; ok {
+		if t.tracker != nil {
+			// Record the lookups as missing for this language.
+			return t.newFunc(defaultContentLanguage, lang)
+		}
 		return f
 	}
 	t.logger.INFO.Println("i18n not initialized; if you need string translations, check that you have a bundle in /i18n that matches the site language or the default language.")
@@ -57,10 +94,10 @@ func (t Translator) Func(lang string) bundle.TranslateFunc {
 
 }
 
-func (t Translator) initFuncs(bndl *bundle.Bundle) {
+func (t *Translator) initFuncs(bndl *bundle.Bundle) {
 	defaultContentLanguage := t.cfg.GetString("defaultContentLanguage")
 
-	defaultT, err := bndl.Tfunc(defaultContentLanguage)
+	defaultT, err := newTranslateFunc(bndl, defaultContentLanguage)
 	if err != nil {
 		t.logger.INFO.Printf("No translation bundle found for default language %q", defaultContentLanguage)
 	}
@@ -68,33 +105,44 @@ func (t Translator) initFuncs(bndl *bundle.Bundle) {
 	translations := bndl.Translations()
 
 	enableMissingTranslationPlaceholders := t.cfg.GetBool("enableMissingTranslationPlaceholders")
-	for _, lang := range bndl.LanguageTags() {
-		currentLang := lang
 
-		t.translateFuncs[currentLang] = func(translationID string, args ...interface{}) string {
-			tFunc, err := bndl.Tfunc(currentLang)
+	t.newFunc = func(currentLang, trackLang string) bundle.TranslateFunc {
+		return func(translationID string, args ...interface{}) string {
+			tFunc, err := newTranslateFunc(bndl, currentLang)
 			if err != nil {
-				t.logger.WARN.Printf("could not load translations for language %q (%s), will use default content language.\n", lang, err)
+				t.logger.WARN.Printf("could not load translations for language %q (%s), will use default content language.\n", currentLang, err)
 			}
 
 			translated := tFunc(translationID, args...)
-			if translated != translationID {
-				return translated
-			}
+
 			// If there is no translation for translationID,
 			// then Tfunc returns translationID itself.
 			// But if user set same translationID and translation, we should check
 			// if it really untranslated:
-			if isIDTranslated(translations, currentLang, translationID) {
+			found := translated != translationID || isIDTranslated(translations, currentLang, translationID)
+			if t.tracker != nil {
+				t.tracker.track(trackLang, translationID, found && trackLang == currentLang)
+			}
+			if found {
 				return translated
 			}
 
 			if t.cfg.GetBool("logI18nWarnings") {
-				i18nWarningLogger.Printf("i18n|MISSING_TRANSLATION|%s|%s", currentLang, translationID)
+				t.missingLogger.Printf("MISSING_TRANSLATION|%s|%s", currentLang, translationID)
 			}
 			if enableMissingTranslationPlaceholders {
 				return "[i18n] " + translationID
 			}
+			for _, fallback := range t.fallbacks[currentLang] {
+				fallbackT, err := newTranslateFunc(bndl, fallback)
+				if err != nil {
+					continue
+				}
+				translated := fallbackT(translationID, args...)
+				if translated != translationID || isIDTranslated(translations, fallback, translationID) {
+					return translated
+				}
+			}
 			if defaultT != nil {
 				translated := defaultT(translationID, args...)
 				if translated != translationID {
@@ -107,6 +155,97 @@ func (t Translator) initFuncs(bndl *bundle.Bundle) {
 			return ""
 		}
 	}
+
+	for _, lang := range bndl.LanguageTags() {
+		t.translateFuncs[lang] = t.newFunc(lang, lang)
+	}
+}
+
+// newTranslateFunc creates a translate func for lang that also selects the
+// CLDR plural form (zero, one, two, few, many, other) for counts go-i18n does
+// not accept. go-i18n only accepts signed integers and strings, so e.g. a float
+// of 1.5 would otherwise silently select the "other" form. The count is only
+// converted for the plural selection; .Count keeps its type in the template.
+func newTranslateFunc(bndl *bundle.Bundle, lang string) (bundle.TranslateFunc, error) {
+	tFunc, l, err := bndl.TfuncAndLanguage(lang)
+	if l == nil {
+		return tFunc, err
+	}
+	translations := bndl.Translations()[l.Tag]
+
+	return func(translationID string, args ...interface{}) string {
+		count, data, ok := pluralCountArgs(args)
+		if !ok {
+			return tFunc(translationID, args...)
+		}
+		tr, found := translations[translationID]
+		if !found {
+			return tFunc(translationID, args...)
+		}
+		p, _ := l.Plural(count)
+		tmpl := tr.Template(p)
+		if tmpl == nil {
+			return translationID
+		}
+		if s := tmpl.Execute(data); s != "" {
+			return s
+		}
+		return translationID
+	}, err
+}
+
+// pluralCountArgs returns the count to select the plural form with and the
+// template data for args if the count, given either as the first argument or
+// as the Count value in a map argument, needs to be converted for go-i18n.
+func pluralCountArgs(args []interface{}) (interface{}, map[string]interface{}, bool) {
+	if len(args) == 0 {
+		return nil, nil, false
+	}
+
+	if n, ok := toPluralCount(args[0]); ok {
+		data := make(map[string]interface{})
+		if len(args) > 1 {
+			if m, ok := args[1].(map[string]interface{}); ok {
+				for k, v := range m {
+					data[k] = v
+				}
+			}
+		}
+		data["Count"] = args[0]
+		return n, data, true
+	}
+
+	if m, ok := args[0].(map[string]interface{}); ok {
+		if c, found := m["Count"]; found {
+			if n, ok := toPluralCount(c); ok {
+				return n, m, true
+			}
+		}
+	}
+
+	return nil, nil, false
+}
+
+func toPluralCount(v interface{}) (interface{}, bool) {
+	switch vv := v.(type) {
+	case uint, uint8, uint16, uint32, uint64:
+		return cast.ToInt64(vv), true
+	case float32:
+		return strconv.FormatFloat(float64(vv), 'f', -1, 32), true
+	case float64:
+		return strconv.FormatFloat(vv, 'f', -1, 64), true
+	}
+	return nil, false
+}
+
+// Report returns the translation coverage per language for the lookups done
+// so far. It returns nil if no report was requested in the configuration.
+func (t Translator) Report() *Report {
+	if t.tracker == nil {
+		return nil
+	}
+	r := t.tracker.report()
+	return &r
 }
 
 // If the translation map contains translationID for specified currentLang,
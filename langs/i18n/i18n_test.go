@@ -14,6 +14,7 @@
 package i18n
 
 import (
+	"bytes"
 	"path/filepath"
 	"testing"
 
@@ -43,6 +44,23 @@ type i18nTest struct {
 	lang, id, expected, expectedFlag string
 }
 
+// Translations with all the CLDR plural categories used in Russian and Arabic.
+var (
+	ruPlurals = []byte(`[apples]
+one = "{{ .Count }} яблоко"
+few = "{{ .Count }} яблока"
+many = "{{ .Count }} яблок"
+other = "{{ .Count }} яблока (other)"`)
+
+	arPlurals = []byte(`[books]
+zero = "لا كتب"
+one = "كتاب واحد"
+two = "كتابان"
+few = "{{ .Count }} كتب"
+many = "{{ .Count }} كتابًا"
+other = "{{ .Count }} كتاب"`)
+)
+
 var i18nTests = []i18nTest{
 	// All translations present
 	{
@@ -169,6 +187,166 @@ other = "{{ .Count }} minuttar lesing"`),
 		expected:     "3 minuttar lesing",
 		expectedFlag: "3 minuttar lesing",
 	},
+	// CLDR plural categories
+	{
+		name: "plural-ru-one",
+		data: map[string][]byte{
+			"ru.toml": ruPlurals,
+		},
+		args:         21,
+		lang:         "ru",
+		id:           "apples",
+		expected:     "21 яблоко",
+		expectedFlag: "21 яблоко",
+	},
+	{
+		name: "plural-ru-few",
+		data: map[string][]byte{
+			"ru.toml": ruPlurals,
+		},
+		args:         3,
+		lang:         "ru",
+		id:           "apples",
+		expected:     "3 яблока",
+		expectedFlag: "3 яблока",
+	},
+	{
+		name: "plural-ru-many",
+		data: map[string][]byte{
+			"ru.toml": ruPlurals,
+		},
+		args:         11,
+		lang:         "ru",
+		id:           "apples",
+		expected:     "11 яблок",
+		expectedFlag: "11 яблок",
+	},
+	{
+		name: "plural-ru-uint",
+		data: map[string][]byte{
+			"ru.toml": ruPlurals,
+		},
+		args:         uint(5),
+		lang:         "ru",
+		id:           "apples",
+		expected:     "5 яблок",
+		expectedFlag: "5 яблок",
+	},
+	{
+		name: "plural-ru-float",
+		data: map[string][]byte{
+			"ru.toml": ruPlurals,
+		},
+		args:         1.5,
+		lang:         "ru",
+		id:           "apples",
+		expected:     "1.5 яблока (other)",
+		expectedFlag: "1.5 яблока (other)",
+	},
+	{
+		name: "plural-ru-float-whole",
+		data: map[string][]byte{
+			"ru.toml": ruPlurals,
+		},
+		args:         2.0,
+		lang:         "ru",
+		id:           "apples",
+		expected:     "2 яблока",
+		expectedFlag: "2 яблока",
+	},
+	{
+		name: "plural-ru-dict-count",
+		data: map[string][]byte{
+			"ru.toml": ruPlurals,
+		},
+		args:         map[string]interface{}{"Count": 25.0},
+		lang:         "ru",
+		id:           "apples",
+		expected:     "25 яблок",
+		expectedFlag: "25 яблок",
+	},
+	{
+		name: "plural-ar-zero",
+		data: map[string][]byte{
+			"ar.toml": arPlurals,
+		},
+		args:         0,
+		lang:         "ar",
+		id:           "books",
+		expected:     "لا كتب",
+		expectedFlag: "لا كتب",
+	},
+	{
+		name: "plural-ar-two",
+		data: map[string][]byte{
+			"ar.toml": arPlurals,
+		},
+		args:         2,
+		lang:         "ar",
+		id:           "books",
+		expected:     "كتابان",
+		expectedFlag: "كتابان",
+	},
+	{
+		name: "plural-ar-few",
+		data: map[string][]byte{
+			"ar.toml": arPlurals,
+		},
+		args:         3,
+		lang:         "ar",
+		id:           "books",
+		expected:     "3 كتب",
+		expectedFlag: "3 كتب",
+	},
+	{
+		name: "plural-ar-many",
+		data: map[string][]byte{
+			"ar.toml": arPlurals,
+		},
+		args:         11,
+		lang:         "ar",
+		id:           "books",
+		expected:     "11 كتابًا",
+		expectedFlag: "11 كتابًا",
+	},
+	{
+		name: "plural-ar-other",
+		data: map[string][]byte{
+			"ar.toml": arPlurals,
+		},
+		args:         100,
+		lang:         "ar",
+		id:           "books",
+		expected:     "100 كتاب",
+		expectedFlag: "100 كتاب",
+	},
+	// Count keeps its type in the template
+	{
+		name: "plural-count-numeric-float",
+		data: map[string][]byte{
+			"en.toml": []byte(`[items]
+one = "{{ if gt .Count 1.0 }}more{{ else }}one{{ end }}"
+other = "{{ if gt .Count 1.0 }}{{ .Count }} items{{ else }}less{{ end }}"`),
+		},
+		args:         2.5,
+		lang:         "en",
+		id:           "items",
+		expected:     "2.5 items",
+		expectedFlag: "2.5 items",
+	},
+	{
+		name: "plural-count-numeric-dict-uint",
+		data: map[string][]byte{
+			"en.toml": []byte(`[items]
+one = "one"
+other = "{{ if gt .Count 1 }}{{ .Count }} items{{ else }}less{{ end }}"`),
+		},
+		args:         map[string]interface{}{"Count": uint(3)},
+		lang:         "en",
+		id:           "items",
+		expected:     "3 items",
+		expectedFlag: "3 items",
+	},
 }
 
 func doTestI18nTranslate(t testing.TB, test i18nTest, cfg config.Provider) string {
@@ -270,3 +448,75 @@ func BenchmarkI18nTranslate(b *testing.B) {
 	}
 
 }
+
+func TestI18nReport(t *testing.T) {
+	c := qt.New(t)
+	v := getConfig()
+	v.Set("i18nReportFile", "report.json")
+
+	tp := prepareTranslationProvider(t, i18nTest{
+		data: map[string][]byte{
+			"en.toml": []byte("[hello]\nother = \"Hello\"\n[bye]\nother = \"Bye\""),
+			"fr.toml": []byte("[hello]\nother = \"Bonjour\""),
+		},
+	}, v)
+
+	for _, lang := range []string{"en", "fr", "de"} {
+		f := tp.t.Func(lang)
+		f("hello")
+		f("bye")
+		f("bye")
+	}
+
+	report := tp.Report()
+	c.Assert(report, qt.Not(qt.IsNil))
+	c.Assert(report.Languages, qt.DeepEquals, []LanguageReport{
+		{Lang: "de", Used: 2, Missing: []string{"bye", "hello"}, Coverage: 0},
+		{Lang: "en", Used: 2, Missing: []string{}, Coverage: 100},
+		{Lang: "fr", Used: 2, Missing: []string{"bye"}, Coverage: 50},
+	})
+
+	var b bytes.Buffer
+	c.Assert(report.WriteJSON(&b), qt.IsNil)
+	c.Assert(b.String(), qt.Contains, `"lang": "fr",
+      "used": 2,
+      "missing": [
+        "bye"
+      ],
+      "coverage": 50`)
+
+	v.Set("i18nReportFile", "")
+	tp = prepareTranslationProvider(t, i18nTest{}, v)
+	c.Assert(tp.Report(), qt.IsNil)
+}
+
+func TestI18nFallbacks(t *testing.T) {
+	c := qt.New(t)
+	v := getConfig()
+	v.Set("languages", map[string]interface{}{
+		"en":    map[string]interface{}{"weight": 1},
+		"pt":    map[string]interface{}{"weight": 2},
+		"pt-br": map[string]interface{}{"weight": 3, "fallbacks": []string{"pt", "en"}},
+		"pt-ao": map[string]interface{}{"weight": 4, "fallbacks": []string{"pt"}},
+	})
+	_, err := langs.LoadLanguageSettings(v, nil)
+	c.Assert(err, qt.IsNil)
+
+	tp := prepareTranslationProvider(t, i18nTest{
+		data: map[string][]byte{
+			"en.toml":    []byte("[hello]\nother = \"Hello\"\n[bye]\nother = \"Bye\"\n[thanks]\nother = \"Thanks\""),
+			"pt.toml":    []byte("[hello]\nother = \"Olá\"\n[bye]\nother = \"Tchau\""),
+			"pt-br.toml": []byte("[hello]\nother = \"Oi\""),
+		},
+	}, v)
+
+	ptbr := tp.t.Func("pt-br")
+	c.Assert(ptbr("hello"), qt.Equals, "Oi")
+	c.Assert(ptbr("bye"), qt.Equals, "Tchau")
+	c.Assert(ptbr("thanks"), qt.Equals, "Thanks")
+
+	// No translation file for pt-ao, use the first fallback with one.
+	ptao := tp.t.Func("pt-ao")
+	c.Assert(ptao("hello"), qt.Equals, "Olá")
+	c.Assert(ptao("thanks"), qt.Equals, "Thanks")
+}
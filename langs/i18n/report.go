@@ -0,0 +1,99 @@
+// Copyright 2019 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package i18n
+
+import (
+	"encoding/json"
+	"io"
+	"sort"
+	"sync"
+)
+
+// Report holds the translation coverage for the languages in a build.
+type Report struct {
+	Languages []LanguageReport `json:"languages"`
+}
+
+// LanguageReport holds the translation coverage for one language.
+type LanguageReport struct {
+	Lang string `json:"lang"`
+
+	// The number of distinct translation IDs looked up in this language.
+	Used int `json:"used"`
+
+	// The translation IDs not found in this language's translation files.
+	Missing []string `json:"missing"`
+
+	// The percentage of the used translation IDs found in this language.
+	Coverage float64 `json:"coverage"`
+}
+
+// WriteJSON writes the report as indented JSON to w.
+func (r Report) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r)
+}
+
+// translationTracker records the translation lookups per language.
+type translationTracker struct {
+	mu sync.Mutex
+
+	// lang => translation ID => found
+	lookups map[string]map[string]bool
+}
+
+func newTranslationTracker() *translationTracker {
+	return &translationTracker{lookups: make(map[string]map[string]bool)}
+}
+
+func (t *translationTracker) track(lang, translationID string, found bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	m, ok := t.lookups[lang]
+	if !ok {
+		m = make(map[string]bool)
+		t.lookups[lang] = m
+	}
+	m[translationID] = m[translationID] || found
+}
+
+func (t *translationTracker) report() Report {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var r Report
+
+	for lang, ids := range t.lookups {
+		lr := LanguageReport{Lang: lang, Used: len(ids), Missing: []string{}}
+		for id, found := range ids {
+			if !found {
+				lr.Missing = append(lr.Missing, id)
+			}
+		}
+		sort.Strings(lr.Missing)
+		lr.Coverage = 100
+		if lr.Used > 0 {
+			lr.Coverage = float64(lr.Used-len(lr.Missing)) / float64(lr.Used) * 100
+		}
+		r.Languages = append(r.Languages, lr)
+	}
+
+	sort.Slice(r.Languages, func(i, j int) bool {
+		return r.Languages[i].Lang < r.Languages[j].Lang
+	})
+
+	return r
+}
@@ -103,6 +103,12 @@ func addTranslationFile(bundle *bundle.Bundle, r source.File) error {
 	return nil
 }
 
+// Report returns the translation coverage report, or nil if not enabled
+// in the configuration (see i18nReportFile and i18nMinCoverage).
+func (tp *TranslationProvider) Report() *Report {
+	return tp.t.Report()
+}
+
 // Clone sets the language func for the new language.
 func (tp *TranslationProvider) Clone(d *deps.Deps) error {
 	d.Translate = tp.t.Func(d.Language.Lang)
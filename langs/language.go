@@ -52,6 +52,10 @@ type Language struct {
 	// absolute directory reference. It is what we get.
 	ContentDir string
 
+	// Languages to fall back to, in order, for untranslated strings and
+	// content, e.g. ["pt", "en"] for "pt-br".
+	Fallbacks []string
+
 	Cfg config.Provider
 
 	// These are params declared in the [params] section of the language merged with the
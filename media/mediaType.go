@@ -124,6 +124,9 @@ func (m Type) Suffix() string {
 // Definitions from https://developer.mozilla.org/en-US/docs/Web/HTTP/Basics_of_HTTP/MIME_types etc.
 // Note that from Hugo 0.44 we only set Suffix if it is part of the MIME type.
 var (
+	// AtomType is used by the built-in Atom output format. It is not part of
+	// DefaultTypes to keep RSS as the first match for the "xml" suffix.
+	AtomType       = Type{MainType: "application", SubType: "atom", mimeSuffix: "xml", Suffixes: []string{"xml"}, Delimiter: defaultDelimiter}
 	CalendarType   = Type{MainType: "text", SubType: "calendar", Suffixes: []string{"ics"}, Delimiter: defaultDelimiter}
 	CSSType        = Type{MainType: "text", SubType: "css", Suffixes: []string{"css"}, Delimiter: defaultDelimiter}
 	SCSSType       = Type{MainType: "text", SubType: "x-scss", Suffixes: []string{"scss"}, Delimiter: defaultDelimiter}
@@ -0,0 +1,132 @@
+// Copyright 2019 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"time"
+)
+
+// BuildTimer measures the time spent in the phases of a build, e.g.
+// "content/read" or "render/page/blog". It is safe for concurrent use.
+// A nil BuildTimer measures nothing.
+type BuildTimer struct {
+	mu     sync.Mutex
+	phases map[string]*BuildPhase
+}
+
+// BuildReport holds the time spent in a build.
+type BuildReport struct {
+	// The wall clock duration of the build.
+	Duration time.Duration `json:"duration"`
+
+	// The phases, sorted by cumulative duration.
+	Phases []BuildPhase `json:"phases"`
+}
+
+// BuildPhase holds the time spent in a build phase.
+type BuildPhase struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+
+	// The sum of all the measurements. For phases run in parallel, e.g.
+	// page rendering, this may be longer than the build.
+	Duration time.Duration `json:"duration"`
+}
+
+// NewBuildTimer creates a new BuildTimer.
+func NewBuildTimer() *BuildTimer {
+	return &BuildTimer{phases: make(map[string]*BuildPhase)}
+}
+
+// MeasureSince adds the time since start to phase.
+func (t *BuildTimer) MeasureSince(phase string, start time.Time) {
+	if t == nil {
+		return
+	}
+
+	d := time.Since(start)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	p, found := t.phases[phase]
+	if !found {
+		p = &BuildPhase{Name: phase}
+		t.phases[phase] = p
+	}
+	p.Count++
+	p.Duration += d
+}
+
+// Reset clears the measurements.
+func (t *BuildTimer) Reset() {
+	if t == nil {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.phases = make(map[string]*BuildPhase)
+}
+
+// Report creates a report of the measurements for a build that took d.
+func (t *BuildTimer) Report(d time.Duration) BuildReport {
+	r := BuildReport{Duration: d, Phases: []BuildPhase{}}
+
+	if t == nil {
+		return r
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for _, p := range t.phases {
+		r.Phases = append(r.Phases, *p)
+	}
+
+	sort.Slice(r.Phases, func(i, j int) bool {
+		if r.Phases[i].Duration == r.Phases[j].Duration {
+			return r.Phases[i].Name < r.Phases[j].Name
+		}
+		return r.Phases[i].Duration > r.Phases[j].Duration
+	})
+
+	return r
+}
+
+// WriteTable writes a summary of the report to w.
+func (r BuildReport) WriteTable(w io.Writer) {
+	fmt.Fprintf(w, "  %13s  %12s  %5s  %s\n", "cumulative", "average", "", "")
+	fmt.Fprintf(w, "  %13s  %12s  %5s  %s\n", "duration", "duration", "count", "phase")
+	fmt.Fprintf(w, "  %13s  %12s  %5s  %s\n", "----------", "--------", "-----", "-----")
+
+	for _, p := range r.Phases {
+		fmt.Fprintf(w, "  %13s  %12s  %5d  %s\n", p.Duration, p.Duration/time.Duration(p.Count), p.Count, p.Name)
+	}
+
+	fmt.Fprintf(w, "\n  Total build time: %s\n", r.Duration)
+}
+
+// WriteJSON writes the report to w as JSON. Durations are in nanoseconds.
+func (r BuildReport) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r)
+}
@@ -0,0 +1,58 @@
+// Copyright 2019 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestBuildTimer(t *testing.T) {
+	c := qt.New(t)
+
+	var nilTimer *BuildTimer
+	nilTimer.MeasureSince("render", time.Now())
+	c.Assert(nilTimer.Report(time.Second).Phases, qt.HasLen, 0)
+
+	timer := NewBuildTimer()
+	timer.MeasureSince("content/read", time.Now().Add(-time.Second))
+	timer.MeasureSince("render/page", time.Now().Add(-2*time.Second))
+	timer.MeasureSince("render/page", time.Now().Add(-2*time.Second))
+
+	r := timer.Report(5 * time.Second)
+	c.Assert(r.Duration, qt.Equals, 5*time.Second)
+	c.Assert(r.Phases, qt.HasLen, 2)
+	c.Assert(r.Phases[0].Name, qt.Equals, "render/page")
+	c.Assert(r.Phases[0].Count, qt.Equals, 2)
+	c.Assert(r.Phases[0].Duration >= 4*time.Second, qt.Equals, true)
+	c.Assert(r.Phases[1].Name, qt.Equals, "content/read")
+
+	var b bytes.Buffer
+	r.WriteTable(&b)
+	c.Assert(b.String(), qt.Contains, "render/page")
+	c.Assert(b.String(), qt.Contains, "Total build time: 5s")
+
+	b.Reset()
+	c.Assert(r.WriteJSON(&b), qt.IsNil)
+	var decoded BuildReport
+	c.Assert(json.Unmarshal(b.Bytes(), &decoded), qt.IsNil)
+	c.Assert(decoded.Phases[1].Name, qt.Equals, "content/read")
+
+	timer.Reset()
+	c.Assert(timer.Report(0).Phases, qt.HasLen, 0)
+}
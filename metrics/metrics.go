@@ -15,6 +15,7 @@
 package metrics
 
 import (
+	"encoding/json"
 	"fmt"
 	"io"
 	"math"
@@ -38,6 +39,9 @@ type Provider interface {
 	// WriteMetrics will write a summary of the metrics to w.
 	WriteMetrics(w io.Writer)
 
+	// WriteMetricsJSON will write the metrics to w as a JSON array.
+	WriteMetricsJSON(w io.Writer) error
+
 	// TrackValue tracks the value for diff calculations etc.
 	TrackValue(key string, value interface{})
 
@@ -125,7 +129,68 @@ func (s *Store) MeasureSince(key string, start time.Time) {
 
 // WriteMetrics writes a summary of the metrics to w.
 func (s *Store) WriteMetrics(w io.Writer) {
+	results := s.results()
+
+	if s.calculateHints {
+		fmt.Fprintf(w, "  %9s  %13s  %12s  %12s  %5s  %s\n", "cache", "cumulative", "average", "maximum", "", "")
+		fmt.Fprintf(w, "  %9s  %13s  %12s  %12s  %5s  %s\n", "potential", "duration", "duration", "duration", "count", "template")
+		fmt.Fprintf(w, "  %9s  %13s  %12s  %12s  %5s  %s\n", "-----", "----------", "--------", "--------", "-----", "--------")
+	} else {
+		fmt.Fprintf(w, "  %13s  %12s  %12s  %5s  %s\n", "cumulative", "average", "maximum", "", "")
+		fmt.Fprintf(w, "  %13s  %12s  %12s  %5s  %s\n", "duration", "duration", "duration", "count", "template")
+		fmt.Fprintf(w, "  %13s  %12s  %12s  %5s  %s\n", "----------", "--------", "--------", "-----", "--------")
+
+	}
+
+	for _, v := range results {
+		if s.calculateHints {
+			fmt.Fprintf(w, "  %9d %13s  %12s  %12s  %5d  %s\n", v.cacheFactor, v.sum, v.avg, v.max, v.count, v.key)
+		} else {
+			fmt.Fprintf(w, "  %13s  %12s  %12s  %5d  %s\n", v.sum, v.avg, v.max, v.count, v.key)
+		}
+	}
+
+}
+
+// WriteMetricsJSON writes the metrics to w as a JSON array sorted by
+// cumulative duration. Durations are in nanoseconds. The cache potential
+// is only included when hints are enabled.
+func (s *Store) WriteMetricsJSON(w io.Writer) error {
+	results := s.results()
+
+	type jsonResult struct {
+		Template       string        `json:"template"`
+		Count          int           `json:"count"`
+		Cumulative     time.Duration `json:"cumulativeDuration"`
+		Average        time.Duration `json:"averageDuration"`
+		Maximum        time.Duration `json:"maximumDuration"`
+		CachePotential *int          `json:"cachePotential,omitempty"`
+	}
+
+	jr := make([]jsonResult, len(results))
+	for i, v := range results {
+		jr[i] = jsonResult{
+			Template:   v.key,
+			Count:      v.count,
+			Cumulative: v.sum,
+			Average:    v.avg,
+			Maximum:    v.max,
+		}
+		if s.calculateHints {
+			cacheFactor := v.cacheFactor
+			jr[i].CachePotential = &cacheFactor
+		}
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(jr)
+}
+
+// results calculates the results sorted by cumulative duration.
+func (s *Store) results() []result {
 	s.mu.Lock()
+	s.diffmu.Lock()
 
 	results := make([]result, len(s.metrics))
 
@@ -153,28 +218,12 @@ func (s *Store) WriteMetrics(w io.Writer) {
 		i++
 	}
 
+	s.diffmu.Unlock()
 	s.mu.Unlock()
 
-	if s.calculateHints {
-		fmt.Fprintf(w, "  %9s  %13s  %12s  %12s  %5s  %s\n", "cache", "cumulative", "average", "maximum", "", "")
-		fmt.Fprintf(w, "  %9s  %13s  %12s  %12s  %5s  %s\n", "potential", "duration", "duration", "duration", "count", "template")
-		fmt.Fprintf(w, "  %9s  %13s  %12s  %12s  %5s  %s\n", "-----", "----------", "--------", "--------", "-----", "--------")
-	} else {
-		fmt.Fprintf(w, "  %13s  %12s  %12s  %5s  %s\n", "cumulative", "average", "maximum", "", "")
-		fmt.Fprintf(w, "  %13s  %12s  %12s  %5s  %s\n", "duration", "duration", "duration", "count", "template")
-		fmt.Fprintf(w, "  %13s  %12s  %12s  %5s  %s\n", "----------", "--------", "--------", "-----", "--------")
-
-	}
-
 	sort.Sort(bySum(results))
-	for _, v := range results {
-		if s.calculateHints {
-			fmt.Fprintf(w, "  %9d %13s  %12s  %12s  %5d  %s\n", v.cacheFactor, v.sum, v.avg, v.max, v.count, v.key)
-		} else {
-			fmt.Fprintf(w, "  %13s  %12s  %12s  %5d  %s\n", v.sum, v.avg, v.max, v.count, v.key)
-		}
-	}
 
+	return results
 }
 
 // A result represents the calculated results for a given metric.
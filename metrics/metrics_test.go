@@ -14,8 +14,11 @@
 package metrics
 
 import (
+	"bytes"
+	"encoding/json"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/gohugoio/hugo/resources/page"
 
@@ -48,6 +51,44 @@ func TestSimilarPercentageNonString(t *testing.T) {
 	c.Assert(howSimilar(page.Pages{}, page.Pages{}), qt.Equals, 90)
 }
 
+func TestWriteMetricsJSON(t *testing.T) {
+	c := qt.New(t)
+
+	type jsonResult struct {
+		Template       string
+		Count          int
+		CachePotential *int
+	}
+
+	write := func(hints bool) []jsonResult {
+		s := NewProvider(hints)
+		now := time.Now()
+		s.MeasureSince("partials/fast.html", now.Add(-time.Millisecond))
+		s.MeasureSince("partials/slow.html", now.Add(-time.Second))
+		s.MeasureSince("partials/slow.html", now.Add(-time.Second))
+		s.TrackValue("partials/slow.html", "Hugo Rules")
+
+		var b bytes.Buffer
+		c.Assert(s.WriteMetricsJSON(&b), qt.IsNil)
+
+		var results []jsonResult
+		c.Assert(json.Unmarshal(b.Bytes(), &results), qt.IsNil)
+		c.Assert(results, qt.HasLen, 2)
+		c.Assert(results[0].Template, qt.Equals, "partials/slow.html")
+		c.Assert(results[0].Count, qt.Equals, 2)
+		c.Assert(results[1].Template, qt.Equals, "partials/fast.html")
+
+		return results
+	}
+
+	results := write(false)
+	c.Assert(results[0].CachePotential, qt.IsNil)
+
+	results = write(true)
+	c.Assert(results[0].CachePotential, qt.Not(qt.IsNil))
+	c.Assert(results[1].CachePotential, qt.Not(qt.IsNil))
+}
+
 func BenchmarkHowSimilar(b *testing.B) {
 	s1 := "Hugo is cool and " + strings.Repeat("fun ", 10) + "!"
 	s2 := "Hugo is cool and " + strings.Repeat("cool ", 10) + "!"
@@ -0,0 +1,100 @@
+// Copyright 2019 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package minifiers
+
+import (
+	"github.com/gohugoio/hugo/config"
+	"github.com/mitchellh/mapstructure"
+	"github.com/pkg/errors"
+	"github.com/tdewolff/minify/v2/css"
+	"github.com/tdewolff/minify/v2/html"
+	"github.com/tdewolff/minify/v2/svg"
+	"github.com/tdewolff/minify/v2/xml"
+)
+
+const (
+	minifyConfigKey = "minify"
+
+	// minifyOutputConfigKey is where the --minify flag is stored. It takes
+	// precedence over the minifyOutput setting in the minify config section.
+	minifyOutputConfigKey = "minifyOutput"
+)
+
+var defaultConfig = Config{
+	HTML: html.Minifier{
+		KeepDocumentTags:        true,
+		KeepConditionalComments: true,
+		KeepEndTags:             true,
+		KeepDefaultAttrVals:     true,
+	},
+	CSS: css.Minifier{
+		Decimals: -1,
+		KeepCSS2: true,
+	},
+	SVG: svg.Minifier{
+		Decimals: -1,
+	},
+}
+
+// Config configures the minifiers, both when publishing and in resources.Minify.
+type Config struct {
+	// Whether to minify the published output (the HTML, XML etc. written to /public).
+	MinifyOutput bool
+
+	// Output formats (by name) to leave untouched when minifying the published output.
+	ExcludeOutputFormats []string
+
+	// Glob patterns matched against the target path (e.g. "js/vendor/**") of files
+	// to leave untouched when minifying the published output.
+	ExcludePaths []string
+
+	DisableHTML bool
+	DisableCSS  bool
+	DisableJS   bool
+	DisableJSON bool
+	DisableSVG  bool
+	DisableXML  bool
+
+	HTML html.Minifier
+	CSS  css.Minifier
+	SVG  svg.Minifier
+	XML  xml.Minifier
+}
+
+// DecodeConfig creates a minify config from the given config. The minify
+// config value can either be a bool (the old style) or a map with
+// the keys in Config.
+func DecodeConfig(cfg config.Provider) (conf Config, err error) {
+	conf = defaultConfig
+
+	if cfg == nil {
+		return
+	}
+
+	switch v := cfg.Get(minifyConfigKey).(type) {
+	case nil:
+	case bool:
+		conf.MinifyOutput = v
+	default:
+		if err = mapstructure.WeakDecode(v, &conf); err != nil {
+			return conf, errors.Wrap(err, "failed to decode minify config")
+		}
+	}
+
+	if cfg.IsSet(minifyOutputConfigKey) {
+		conf.MinifyOutput = cfg.GetBool(minifyOutputConfigKey)
+	}
+
+	return
+}
@@ -0,0 +1,64 @@
+// Copyright 2019 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package minifiers
+
+import (
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/spf13/viper"
+)
+
+func TestDecodeConfig(t *testing.T) {
+	c := qt.New(t)
+
+	v := viper.New()
+	conf, err := DecodeConfig(v)
+	c.Assert(err, qt.IsNil)
+	c.Assert(conf.MinifyOutput, qt.Equals, false)
+	c.Assert(conf.HTML.KeepEndTags, qt.Equals, true)
+	c.Assert(conf.CSS.Decimals, qt.Equals, -1)
+
+	v.Set("minify", true)
+	conf, err = DecodeConfig(v)
+	c.Assert(err, qt.IsNil)
+	c.Assert(conf.MinifyOutput, qt.Equals, true)
+
+	v.Set("minify", map[string]interface{}{
+		"minifyOutput": true,
+		"disableSVG":   true,
+		"html": map[string]interface{}{
+			"keepWhitespace": true,
+			"keepEndTags":    false,
+		},
+		"css": map[string]interface{}{
+			"decimals": 2,
+		},
+	})
+	conf, err = DecodeConfig(v)
+	c.Assert(err, qt.IsNil)
+	c.Assert(conf.MinifyOutput, qt.Equals, true)
+	c.Assert(conf.DisableSVG, qt.Equals, true)
+	c.Assert(conf.HTML.KeepWhitespace, qt.Equals, true)
+	c.Assert(conf.HTML.KeepEndTags, qt.Equals, false)
+	c.Assert(conf.HTML.KeepDocumentTags, qt.Equals, true)
+	c.Assert(conf.CSS.Decimals, qt.Equals, 2)
+	c.Assert(conf.CSS.KeepCSS2, qt.Equals, true)
+
+	// The --minify flag wins.
+	v.Set("minifyOutput", false)
+	conf, err = DecodeConfig(v)
+	c.Assert(err, qt.IsNil)
+	c.Assert(conf.MinifyOutput, qt.Equals, false)
+}
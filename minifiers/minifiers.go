@@ -19,27 +19,34 @@ package minifiers
 import (
 	"io"
 	"regexp"
+	"strings"
 
+	"github.com/gobwas/glob"
+	"github.com/pkg/errors"
+
+	"github.com/gohugoio/hugo/config"
+	hglob "github.com/gohugoio/hugo/hugofs/glob"
 	"github.com/gohugoio/hugo/output"
 	"github.com/gohugoio/hugo/transform"
 
 	"github.com/gohugoio/hugo/media"
 	"github.com/tdewolff/minify/v2"
-	"github.com/tdewolff/minify/v2/css"
-	"github.com/tdewolff/minify/v2/html"
 	"github.com/tdewolff/minify/v2/js"
 	"github.com/tdewolff/minify/v2/json"
-	"github.com/tdewolff/minify/v2/svg"
-	"github.com/tdewolff/minify/v2/xml"
 )
 
 // Client wraps a minifier.
 type Client struct {
 	m *minify.M
+
+	// Whether the published output should be minified.
+	MinifyOutput bool
+
+	excludeOutputFormats []string
+	excludePaths         []glob.Glob
 }
 
 // Transformer returns a func that can be used in the transformer publishing chain.
-// TODO(bep) minify config etc
 func (m Client) Transformer(mediatype media.Type) transform.Transformer {
 	_, params, min := m.m.Match(mediatype.Type())
 	if min == nil {
@@ -62,39 +69,78 @@ func (m Client) Minify(mediatype media.Type, dst io.Writer, src io.Reader) error
 // New creates a new Client with the provided MIME types as the mapping foundation.
 // The HTML minifier is also registered for additional HTML types (AMP etc.) in the
 // provided list of output formats.
-func New(mediaTypes media.Types, outputFormats output.Formats) Client {
-	m := minify.New()
-	htmlMin := &html.Minifier{
-		KeepDocumentTags:        true,
-		KeepConditionalComments: true,
-		KeepEndTags:             true,
-		KeepDefaultAttrVals:     true,
+func New(mediaTypes media.Types, outputFormats output.Formats, cfg config.Provider) (Client, error) {
+	conf, err := DecodeConfig(cfg)
+	if err != nil {
+		return Client{}, err
 	}
 
-	cssMin := &css.Minifier{
-		Decimals: -1,
-		KeepCSS2: true,
-	}
+	m := minify.New()
 
 	// We use the Type definition of the media types defined in the site if found.
-	addMinifier(m, mediaTypes, "css", cssMin)
-	addMinifierFunc(m, mediaTypes, "js", js.Minify)
-	m.AddFuncRegexp(regexp.MustCompile("^(application|text)/(x-)?(java|ecma)script$"), js.Minify)
-	m.AddFuncRegexp(regexp.MustCompile(`^(application|text)/(x-|ld\+)?json$`), json.Minify)
-	addMinifierFunc(m, mediaTypes, "json", json.Minify)
-	addMinifierFunc(m, mediaTypes, "svg", svg.Minify)
-	addMinifierFunc(m, mediaTypes, "xml", xml.Minify)
+	if !conf.DisableCSS {
+		addMinifier(m, mediaTypes, "css", &conf.CSS)
+	}
+	if !conf.DisableJS {
+		addMinifierFunc(m, mediaTypes, "js", js.Minify)
+		m.AddFuncRegexp(regexp.MustCompile("^(application|text)/(x-)?(java|ecma)script$"), js.Minify)
+	}
+	if !conf.DisableJSON {
+		m.AddFuncRegexp(regexp.MustCompile(`^(application|text)/(x-|ld\+)?json$`), json.Minify)
+		addMinifierFunc(m, mediaTypes, "json", json.Minify)
+	}
+	if !conf.DisableSVG {
+		addMinifier(m, mediaTypes, "svg", &conf.SVG)
+	}
+	if !conf.DisableXML {
+		addMinifier(m, mediaTypes, "xml", &conf.XML)
+	}
 
 	// HTML
-	addMinifier(m, mediaTypes, "html", htmlMin)
-	for _, of := range outputFormats {
-		if of.IsHTML {
-			m.Add(of.MediaType.Type(), htmlMin)
+	if !conf.DisableHTML {
+		addMinifier(m, mediaTypes, "html", &conf.HTML)
+		for _, of := range outputFormats {
+			if of.IsHTML {
+				m.Add(of.MediaType.Type(), &conf.HTML)
+			}
 		}
 	}
 
-	return Client{m: m}
+	client := Client{m: m, MinifyOutput: conf.MinifyOutput, excludeOutputFormats: conf.ExcludeOutputFormats}
+
+	for _, pattern := range conf.ExcludePaths {
+		g, err := hglob.GetGlob(hglob.NormalizePath(pattern))
+		if err != nil {
+			return Client{}, errors.Wrapf(err, "invalid minify exclude path %q", pattern)
+		}
+		client.excludePaths = append(client.excludePaths, g)
+	}
+
+	return client, nil
+
+}
+
+// IsExcluded returns whether the published output in the given output format
+// and target path should be left untouched by the minifier.
+func (m Client) IsExcluded(f output.Format, targetPath string) bool {
+	for _, name := range m.excludeOutputFormats {
+		if strings.EqualFold(name, f.Name) {
+			return true
+		}
+	}
+
+	if len(m.excludePaths) == 0 {
+		return false
+	}
+
+	targetPath = hglob.NormalizePath(targetPath)
+	for _, g := range m.excludePaths {
+		if g.Match(targetPath) {
+			return true
+		}
+	}
 
+	return false
 }
 
 func addMinifier(m *minify.M, mt media.Types, suffix string, min minify.Minifier) {
@@ -22,11 +22,14 @@ import (
 
 	qt "github.com/frankban/quicktest"
 	"github.com/gohugoio/hugo/output"
+	"github.com/spf13/viper"
 )
 
 func TestNew(t *testing.T) {
 	c := qt.New(t)
-	m := New(media.DefaultTypes, output.DefaultFormats)
+	v := viper.New()
+	m, err := New(media.DefaultTypes, output.DefaultFormats, v)
+	c.Assert(err, qt.IsNil)
 
 	var rawJS string
 	var minJS string
@@ -74,7 +77,9 @@ func TestNew(t *testing.T) {
 
 func TestBugs(t *testing.T) {
 	c := qt.New(t)
-	m := New(media.DefaultTypes, output.DefaultFormats)
+	v := viper.New()
+	m, err := New(media.DefaultTypes, output.DefaultFormats, v)
+	c.Assert(err, qt.IsNil)
 
 	for _, test := range []struct {
 		tp                media.Type
@@ -91,3 +96,62 @@ func TestBugs(t *testing.T) {
 	}
 
 }
+
+func TestConfiguredMinifiers(t *testing.T) {
+	c := qt.New(t)
+	v := viper.New()
+	v.Set("minify", map[string]interface{}{
+		"disableJSON": true,
+		"html": map[string]interface{}{
+			"keepWhitespace": true,
+		},
+		"xml": map[string]interface{}{
+			"keepWhitespace": true,
+		},
+	})
+
+	m, err := New(media.DefaultTypes, output.DefaultFormats, v)
+	c.Assert(err, qt.IsNil)
+
+	for _, test := range []struct {
+		tp                media.Type
+		rawString         string
+		expectedMinString string
+	}{
+		{media.HTMLType, "<p>Hello  <b>Hugo</b>   !</p>\n\n<p>Bye</p>", "<p>Hello <b>Hugo</b> !</p>\n<p>Bye</p>"},
+		{media.XMLType, "<hello>  <a>Hugo!</a>  </hello>", "<hello> <a>Hugo!</a> </hello>"},
+		{media.CSSType, " body { color: blue; }  ", "body{color:blue}"},
+	} {
+		var b bytes.Buffer
+
+		c.Assert(m.Minify(test.tp, &b, strings.NewReader(test.rawString)), qt.IsNil)
+		c.Assert(b.String(), qt.Equals, test.expectedMinString)
+	}
+
+	var b bytes.Buffer
+	c.Assert(m.Minify(media.JSONType, &b, strings.NewReader(`{ "a": 1 }`)), qt.Not(qt.IsNil))
+}
+
+func TestIsExcluded(t *testing.T) {
+	c := qt.New(t)
+	v := viper.New()
+	v.Set("minify", map[string]interface{}{
+		"excludeOutputFormats": []string{"amp"},
+		"excludePaths":         []string{"js/vendor/**", "google*.html"},
+	})
+
+	m, err := New(media.DefaultTypes, output.DefaultFormats, v)
+	c.Assert(err, qt.IsNil)
+
+	c.Assert(m.IsExcluded(output.AMPFormat, "/amp/index.html"), qt.Equals, true)
+	c.Assert(m.IsExcluded(output.HTMLFormat, "/index.html"), qt.Equals, false)
+	c.Assert(m.IsExcluded(output.HTMLFormat, "/google1234.html"), qt.Equals, true)
+	c.Assert(m.IsExcluded(output.HTMLFormat, "/posts/google1234.html"), qt.Equals, false)
+	c.Assert(m.IsExcluded(output.HTMLFormat, "/js/vendor/lib/jquery.js"), qt.Equals, true)
+
+	v.Set("minify", map[string]interface{}{
+		"excludePaths": []string{"js/[vendor"},
+	})
+	_, err = New(media.DefaultTypes, output.DefaultFormats, v)
+	c.Assert(err, qt.Not(qt.IsNil))
+}
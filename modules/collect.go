@@ -548,8 +548,12 @@ func (c *collector) normalizeMounts(owner Module, mounts []Mount) ([]Mount, erro
 		mnt.Source = filepath.Clean(mnt.Source)
 		mnt.Target = filepath.Clean(mnt.Target)
 
-		// Verify that Source exists
-		sourceDir := filepath.Join(dir, mnt.Source)
+		// Verify that Source exists. Source may be relative to the module
+		// dir, possibly outside of it (e.g. "../shared"), or absolute.
+		sourceDir := mnt.Source
+		if !filepath.IsAbs(sourceDir) {
+			sourceDir = filepath.Join(dir, sourceDir)
+		}
 		_, err := c.fs.Stat(sourceDir)
 		if err != nil {
 			continue
@@ -14,6 +14,7 @@
 package navigation
 
 import (
+	"github.com/gohugoio/hugo/common/maps"
 	"github.com/gohugoio/hugo/common/types"
 	"github.com/gohugoio/hugo/compare"
 
@@ -38,6 +39,13 @@ type MenuEntry struct {
 	Weight        int
 	Parent        string
 	Children      Menu
+
+	// The ref to the page this entry links to, e.g. "/blog". Set for
+	// entries in the site config.
+	PageRef string
+
+	// User defined params, with lower cased keys.
+	Params map[string]interface{}
 }
 
 func (m *MenuEntry) URL() string {
@@ -99,6 +107,18 @@ func (m *MenuEntry) IsEqual(inme *MenuEntry) bool {
 	return m.hopefullyUniqueID() == inme.hopefullyUniqueID() && m.Parent == inme.Parent
 }
 
+// isSamePage returns whether this menu entry points to the page p, either
+// because it is associated with p or because its configured URL is p's.
+func (m *MenuEntry) isSamePage(p Page) bool {
+	if types.IsNil(p) {
+		return false
+	}
+	if !types.IsNil(m.Page) {
+		return m.Page == p
+	}
+	return m.ConfiguredURL != "" && m.ConfiguredURL == p.RelPermalink()
+}
+
 // IsSameResource returns whether the two menu entries points to the same
 // resource (URL).
 func (m *MenuEntry) IsSameResource(inme *MenuEntry) bool {
@@ -126,6 +146,11 @@ func (m *MenuEntry) MarshallMap(ime map[string]interface{}) {
 			m.Identifier = cast.ToString(v)
 		case "parent":
 			m.Parent = cast.ToString(v)
+		case "pageref":
+			m.PageRef = cast.ToString(v)
+		case "params":
+			m.Params = cast.ToStringMap(v)
+			maps.ToLower(m.Params)
 		}
 	}
 }
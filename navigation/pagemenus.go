@@ -133,15 +133,18 @@ func (pm *pageMenus) HasMenuCurrent(menuID string, me *MenuEntry) bool {
 
 	menus := pm.pagem.Menus()
 
-	if m, ok := menus[menuID]; ok {
+	m, hasPageMenu := menus[menuID]
 
-		for _, child := range me.Children {
-			if child.IsEqual(m) {
-				return true
-			}
-			if pm.HasMenuCurrent(menuID, child) {
-				return true
-			}
+	for _, child := range me.Children {
+		if hasPageMenu && child.IsEqual(m) {
+			return true
+		}
+		// Entries defined in the site config are not in the page's menus.
+		if child.isSamePage(pm.p) {
+			return true
+		}
+		if pm.HasMenuCurrent(menuID, child) {
+			return true
 		}
 	}
 
@@ -175,6 +178,11 @@ func (pm *pageMenus) IsMenuCurrent(menuID string, inme *MenuEntry) bool {
 		}
 	}
 
+	// Entries defined in the site config are not in the page's menus.
+	if inme.isSamePage(pm.p) {
+		return true
+	}
+
 	if pm.p == nil || pm.p.IsPage() {
 		return false
 	}
@@ -27,6 +27,24 @@ var reservedSections = map[string]bool{
 	"partials":   true,
 }
 
+// Output formats with an embedded default layout.
+var internalFormatLayouts = map[string]string{
+	RSSFormat.Name:         "_internal/_default/rss.xml",
+	AtomFormat.Name:        "_internal/_default/atom.xml",
+	JSONFeedFormat.Name:    "_internal/_default/jsonfeed.json",
+	SearchIndexFormat.Name: "_internal/_default/searchindex.json",
+}
+
+// The formats with an internal template that share their media type with
+// other formats, e.g. JSON and XML. They only look for layouts with their
+// own name, e.g. jsonfeed.json or list.searchindex.json, so a site's
+// index.json or index.xml does not replace the internal template.
+var formatNameOnlyLayouts = map[string]bool{
+	AtomFormat.Name:        true,
+	JSONFeedFormat.Name:    true,
+	SearchIndexFormat.Name: true,
+}
+
 // LayoutDescriptor describes how a layout should be chosen. This is
 // typically built from a Page.
 type LayoutDescriptor struct {
@@ -158,9 +176,9 @@ func resolvePageTemplate(d LayoutDescriptor, f Format) []string {
 
 	}
 
-	isRSS := f.Name == RSSFormat.Name
-	if isRSS {
-		// The historic and common rss.xml case
+	if _, found := internalFormatLayouts[f.Name]; found {
+		// The historic and common rss.xml case, e.g. atom.xml and
+		// searchindex.json for the other internal formats.
 		b.addLayoutVariations("")
 	}
 
@@ -174,8 +192,8 @@ func resolvePageTemplate(d LayoutDescriptor, f Format) []string {
 
 	layouts := b.resolveVariations()
 
-	if isRSS {
-		layouts = append(layouts, "_internal/_default/rss.xml")
+	if internalLayout, found := internalFormatLayouts[f.Name]; found {
+		layouts = append(layouts, internalLayout)
 	}
 
 	return layouts
@@ -196,7 +214,9 @@ func (l *layoutBuilder) resolveVariations() []string {
 		variations = append(variations, name)
 	}
 
-	variations = append(variations, "")
+	if !formatNameOnlyLayouts[l.f.Name] {
+		variations = append(variations, "")
+	}
 
 	for _, typeVar := range l.typeVariations {
 		for _, variation := range variations {
@@ -87,8 +87,12 @@ func CreateTemplateNames(d TemplateLookupDescriptor) (TemplateNames, error) {
 	isPlainText := false
 	outputFormat, found := d.OutputFormats.FromFilename(filename)
 
-	if found && outputFormat.IsPlainText {
-		isPlainText = true
+	if found {
+		isPlainText = outputFormat.IsPlainText
+	} else {
+		// The suffix may be shared by several output formats, e.g. a custom
+		// text/plain format and robots.txt.
+		isPlainText = d.OutputFormats.isPlainTextSuffix(strings.TrimPrefix(filepath.Ext(filename), "."))
 	}
 
 	var ext, outFormat string
@@ -161,3 +161,25 @@ func TestLayoutBase(t *testing.T) {
 	}
 
 }
+
+func TestLayoutBaseSharedPlainTextSuffix(t *testing.T) {
+	c := qt.New(t)
+
+	textFormat := Format{Name: "Text", MediaType: RobotsTxtFormat.MediaType, IsPlainText: true}
+	htmlTextFormat := Format{Name: "HTMLText", MediaType: RobotsTxtFormat.MediaType}
+
+	create := func(formats Formats) TemplateNames {
+		id, err := CreateTemplateNames(TemplateLookupDescriptor{
+			WorkingDir:    "/sites/mysite/",
+			RelPath:       "_default/single.txt",
+			OutputFormats: formats,
+			ContainsAny:   func(filename string, subslices [][]byte) (bool, error) { return false, nil },
+			FileExists:    func(filename string) (bool, error) { return false, nil },
+		})
+		c.Assert(err, qt.IsNil)
+		return id
+	}
+
+	c.Assert(create(Formats{HTMLFormat, RobotsTxtFormat, textFormat}).Name, qt.Equals, "_text/_default/single.txt")
+	c.Assert(create(Formats{HTMLFormat, RobotsTxtFormat, htmlTextFormat}).Name, qt.Equals, "_default/single.txt")
+}
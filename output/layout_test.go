@@ -136,6 +136,35 @@ func TestLayout(t *testing.T) {
 
 }
 
+func TestLayoutInternalFormats(t *testing.T) {
+	c := qt.New(t)
+
+	l := NewLayoutHandler()
+
+	// These look for the historic rss.xml style layout, but must not pick
+	// up the layouts without the format name, e.g. a site's index.json.
+	for _, f := range []Format{AtomFormat, JSONFeedFormat, SearchIndexFormat} {
+		layouts, err := l.For(LayoutDescriptor{Kind: "home"}, f)
+		c.Assert(err, qt.IsNil)
+		c.Assert(strings.TrimPrefix(layouts[len(layouts)-1], "_text/"), qt.Equals, internalFormatLayouts[f.Name])
+		var found bool
+		for _, layout := range layouts {
+			layout = strings.TrimPrefix(layout, "_text/")
+			if layout == strings.ToLower(f.Name)+"."+f.MediaType.Suffix() {
+				found = true
+			}
+			c.Assert(layout, qt.Not(qt.Equals), "index."+f.MediaType.Suffix(), qt.Commentf(f.Name))
+			c.Assert(layout, qt.Not(qt.Equals), "_default/list."+f.MediaType.Suffix(), qt.Commentf(f.Name))
+		}
+		c.Assert(found, qt.Equals, true, qt.Commentf(f.Name))
+	}
+
+	layouts, err := l.For(LayoutDescriptor{Kind: "home"}, RSSFormat)
+	c.Assert(err, qt.IsNil)
+	c.Assert(layouts, qt.Contains, "rss.xml")
+	c.Assert(layouts, qt.Contains, "index.xml")
+}
+
 func BenchmarkLayout(b *testing.B) {
 	c := qt.New(b)
 	descriptor := LayoutDescriptor{Kind: "taxonomyTerm", Section: "categories"}
@@ -24,6 +24,7 @@ import (
 	"github.com/mitchellh/mapstructure"
 
 	"github.com/gohugoio/hugo/media"
+	"github.com/gohugoio/hugo/resources/images"
 )
 
 // Format represents an output representation, usually to a file on disk.
@@ -81,6 +82,33 @@ type Format struct {
 
 	// Setting this to a non-zero value will be used as the first sort criteria.
 	Weight int `json:"weight"`
+
+	// Image processing defaults used when rendering this output format,
+	// e.g. a lower JPEG quality for AMP.
+	Imaging ImageDefaults `json:"imaging"`
+}
+
+// ImageDefaults holds the image processing defaults for an output format.
+// The zero values mean that the site's imaging config is used.
+type ImageDefaults struct {
+	// The default JPEG quality (1-100).
+	Quality int `json:"quality"`
+
+	// The image format to convert processed images to, e.g. "jpg" or "png".
+	// Explicit formats, as in ProcessMany, take precedence.
+	Format string `json:"format"`
+}
+
+func (d ImageDefaults) validate() error {
+	if d.Quality < 0 || d.Quality > 100 {
+		return fmt.Errorf("imaging quality must be a number between 1 and 100, got %d", d.Quality)
+	}
+	if d.Format != "" {
+		if _, found := images.ImageFormatFromName(d.Format); !found {
+			return fmt.Errorf("unsupported imaging format %q, must be one of jpg, png, gif, tif or bmp", d.Format)
+		}
+	}
+	return nil
 }
 
 // An ordered list of built-in output formats.
@@ -96,6 +124,14 @@ var (
 		// See https://www.ampproject.org/learn/overview/
 	}
 
+	AtomFormat = Format{
+		Name:      "Atom",
+		MediaType: media.AtomType,
+		BaseName:  "atom",
+		NoUgly:    true,
+		Rel:       "alternate",
+	}
+
 	CalendarFormat = Format{
 		Name:        "Calendar",
 		MediaType:   media.CalendarType,
@@ -142,6 +178,16 @@ var (
 		Rel:         "alternate",
 	}
 
+	// JSONFeedFormat is a JSON Feed, see https://jsonfeed.org/version/1.1
+	JSONFeedFormat = Format{
+		Name:        "JSONFeed",
+		MediaType:   media.JSONType,
+		BaseName:    "feed",
+		NoUgly:      true,
+		IsPlainText: true,
+		Rel:         "alternate",
+	}
+
 	RobotsTxtFormat = Format{
 		Name:        "ROBOTS",
 		MediaType:   media.TextType,
@@ -158,6 +204,18 @@ var (
 		Rel:       "alternate",
 	}
 
+	// SearchIndexFormat is a JSON index of the site's content suitable for
+	// client-side search libraries such as Lunr or Fuse.
+	SearchIndexFormat = Format{
+		Name:           "SearchIndex",
+		MediaType:      media.JSONType,
+		BaseName:       "searchindex",
+		NoUgly:         true,
+		IsPlainText:    true,
+		NotAlternative: true,
+		Rel:            "alternate",
+	}
+
 	SitemapFormat = Format{
 		Name:      "Sitemap",
 		MediaType: media.XMLType,
@@ -170,13 +228,16 @@ var (
 // DefaultFormats contains the default output formats supported by Hugo.
 var DefaultFormats = Formats{
 	AMPFormat,
+	AtomFormat,
 	CalendarFormat,
 	CSSFormat,
 	CSVFormat,
 	HTMLFormat,
 	JSONFormat,
+	JSONFeedFormat,
 	RobotsTxtFormat,
 	RSSFormat,
+	SearchIndexFormat,
 	SitemapFormat,
 }
 
@@ -222,6 +283,24 @@ func (formats Formats) GetBySuffix(suffix string) (f Format, found bool) {
 	return
 }
 
+// isPlainTextSuffix returns whether all formats with the given suffix are
+// plain text, and there is at least one of them.
+func (formats Formats) isPlainTextSuffix(suffix string) bool {
+	if suffix == "" {
+		return false
+	}
+	found := false
+	for _, ff := range formats {
+		if strings.EqualFold(suffix, ff.MediaType.Suffix()) {
+			if !ff.IsPlainText {
+				return false
+			}
+			found = true
+		}
+	}
+	return found
+}
+
 // GetByName gets a format by its identifier name.
 func (formats Formats) GetByName(name string) (f Format, found bool) {
 	for _, ff := range formats {
@@ -316,6 +395,12 @@ func DecodeFormats(mediaTypes media.Types, maps ...map[string]interface{}) (Form
 		}
 	}
 
+	for _, ff := range f {
+		if err := ff.Imaging.validate(); err != nil {
+			return f, fmt.Errorf("output format %q: %s", ff.Name, err)
+		}
+	}
+
 	sort.Sort(f)
 
 	return f, nil
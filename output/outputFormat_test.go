@@ -229,6 +229,45 @@ func TestDecodeFormats(t *testing.T) {
 				c.Assert(xml.BaseName, qt.Equals, "myredefined")
 				c.Assert(xml.MediaType, eq, media.XMLType)
 			}},
+		{
+			"Imaging defaults",
+			[]map[string]interface{}{
+				{
+					"AMP": map[string]interface{}{
+						"imaging": map[string]interface{}{
+							"quality": 50,
+							"format":  "jpg",
+						}}}},
+			false,
+			func(t *testing.T, name string, f Formats) {
+				amp, _ := f.GetByName("AMP")
+				c.Assert(amp.Imaging, qt.Equals, ImageDefaults{Quality: 50, Format: "jpg"})
+				c.Assert(amp.Path, qt.Equals, "amp")
+			}},
+		{
+			"Imaging invalid quality",
+			[]map[string]interface{}{
+				{
+					"AMP": map[string]interface{}{
+						"imaging": map[string]interface{}{
+							"quality": 101,
+						}}}},
+			true,
+			func(t *testing.T, name string, f Formats) {
+
+			}},
+		{
+			"Imaging unsupported format",
+			[]map[string]interface{}{
+				{
+					"AMP": map[string]interface{}{
+						"imaging": map[string]interface{}{
+							"format": "webp",
+						}}}},
+			true,
+			func(t *testing.T, name string, f Formats) {
+
+			}},
 	}
 
 	for _, test := range tests {
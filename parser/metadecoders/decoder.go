@@ -17,7 +17,9 @@ import (
 	"bytes"
 	"encoding/csv"
 	"encoding/json"
+	"encoding/xml"
 	"fmt"
+	"io"
 	"strings"
 
 	"github.com/gohugoio/hugo/common/herrors"
@@ -168,6 +170,8 @@ func (d Decoder) unmarshal(data []byte, f Format, v interface{}) error {
 		}
 	case CSV:
 		return d.unmarshalCSV(data, v)
+	case XML:
+		err = d.unmarshalXML(data, v)
 
 	default:
 		return errors.Errorf("unmarshal of format %q is not supported", f)
@@ -203,6 +207,32 @@ func (d Decoder) unmarshalCSV(data []byte, v interface{}) error {
 
 }
 
+// UnmarshalCSVWithHeader unmarshals CSV data into a []interface{} with a map
+// for every record, using the first record as the header row with the keys.
+func (d Decoder) UnmarshalCSVWithHeader(data []byte) (interface{}, error) {
+	var v interface{}
+	if err := d.unmarshalCSV(data, &v); err != nil {
+		return nil, err
+	}
+
+	records := v.([][]string)
+	if len(records) == 0 {
+		return make([]interface{}, 0), nil
+	}
+
+	header := records[0]
+	rows := make([]interface{}, len(records)-1)
+	for i, record := range records[1:] {
+		row := make(map[string]interface{}, len(header))
+		for j, key := range header {
+			row[key] = record[j]
+		}
+		rows[i] = row
+	}
+
+	return rows, nil
+}
+
 func (d Decoder) unmarshalORG(data []byte, v interface{}) error {
 	config := org.New()
 	config.Log = jww.WARN
@@ -231,6 +261,95 @@ func (d Decoder) unmarshalORG(data []byte, v interface{}) error {
 	return nil
 }
 
+// unmarshalXML unmarshals XML into a map with the content of the root element.
+// Attributes are stored with a "-" prefix, e.g. "-id", and the text of an element
+// with attributes or child elements in "#text". Repeated elements become slices.
+func (d Decoder) unmarshalXML(data []byte, v interface{}) error {
+	dec := xml.NewDecoder(bytes.NewReader(data))
+
+	var root map[string]interface{}
+
+	for root == nil {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			return errors.New("no root element found")
+		}
+		if err != nil {
+			return err
+		}
+
+		if start, ok := tok.(xml.StartElement); ok {
+			elem, err := decodeXMLElement(dec, start)
+			if err != nil {
+				return err
+			}
+			if m, ok := elem.(map[string]interface{}); ok {
+				root = m
+			} else {
+				root = map[string]interface{}{"#text": elem}
+			}
+		}
+	}
+
+	switch v.(type) {
+	case *map[string]interface{}:
+		*v.(*map[string]interface{}) = root
+	case *interface{}:
+		*v.(*interface{}) = root
+	default:
+		return errors.Errorf("XML cannot be unmarshaled into %T", v)
+	}
+
+	return nil
+}
+
+// decodeXMLElement decodes the element started by start. It returns a string
+// for elements with text only, else a map.
+func decodeXMLElement(dec *xml.Decoder, start xml.StartElement) (interface{}, error) {
+	m := make(map[string]interface{})
+	for _, attr := range start.Attr {
+		m["-"+attr.Name.Local] = attr.Value
+	}
+
+	var text strings.Builder
+
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			child, err := decodeXMLElement(dec, t)
+			if err != nil {
+				return nil, err
+			}
+			name := t.Name.Local
+			if existing, found := m[name]; found {
+				if s, ok := existing.([]interface{}); ok {
+					m[name] = append(s, child)
+				} else {
+					m[name] = []interface{}{existing, child}
+				}
+			} else {
+				m[name] = child
+			}
+		case xml.CharData:
+			text.Write(t)
+		case xml.EndElement:
+			s := strings.TrimSpace(text.String())
+			if len(m) == 0 {
+				return s, nil
+			}
+			if s != "" {
+				m["#text"] = s
+			}
+			return m, nil
+		}
+	}
+}
+
 func toFileError(f Format, err error) error {
 	return herrors.ToFileError(string(f), err)
 }
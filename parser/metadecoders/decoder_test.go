@@ -39,8 +39,10 @@ func TestUnmarshalToMap(t *testing.T) {
 		{"a:\n  true: 1\n  false: 2", YAML, map[string]interface{}{"a": map[string]interface{}{"true": 1, "false": 2}}},
 		{`{ "a": "b" }`, JSON, expect},
 		{`#+a: b`, ORG, expect},
+		{`<root><a>b</a></root>`, XML, expect},
 		// errors
 		{`a = b`, TOML, false},
+		{`<root><a>b</root>`, XML, false},
 		{`a,b,c`, CSV, false}, // Use Unmarshal for CSV
 	} {
 		msg := qt.Commentf("%d: %s", i, test.format)
@@ -72,9 +74,18 @@ func TestUnmarshalToInterface(t *testing.T) {
 		{`a = "b"`, TOML, expect},
 		{`a: "b"`, YAML, expect},
 		{`a,b,c`, CSV, [][]string{{"a", "b", "c"}}},
+		{`<?xml version="1.0"?><root><a>b</a></root>`, XML, expect},
+		{`<root id="r"><a lang="en">b</a><c>1</c><c>2</c><d><e>f</e></d>text</root>`, XML, map[string]interface{}{
+			"-id":   "r",
+			"a":     map[string]interface{}{"-lang": "en", "#text": "b"},
+			"c":     []interface{}{"1", "2"},
+			"d":     map[string]interface{}{"e": "f"},
+			"#text": "text",
+		}},
 		{"a: Easy!\nb:\n  c: 2\n  d: [3, 4]", YAML, map[string]interface{}{"a": "Easy!", "b": map[string]interface{}{"c": 2, "d": []interface{}{3, 4}}}},
 		// errors
 		{`a = "`, TOML, false},
+		{``, XML, false},
 	} {
 		msg := qt.Commentf("%d: %s", i, test.format)
 		m, err := d.Unmarshal([]byte(test.data), test.format)
@@ -89,6 +100,28 @@ func TestUnmarshalToInterface(t *testing.T) {
 
 }
 
+func TestUnmarshalCSVWithHeader(t *testing.T) {
+	c := qt.New(t)
+
+	rows, err := Default.UnmarshalCSVWithHeader([]byte("name,instrument\nBrecker,saxophone\nBlake,piano\n"))
+	c.Assert(err, qt.IsNil)
+	c.Assert(rows, qt.DeepEquals, []interface{}{
+		map[string]interface{}{"name": "Brecker", "instrument": "saxophone"},
+		map[string]interface{}{"name": "Blake", "instrument": "piano"},
+	})
+
+	rows, err = Default.UnmarshalCSVWithHeader([]byte("name,instrument\n"))
+	c.Assert(err, qt.IsNil)
+	c.Assert(rows, qt.HasLen, 0)
+
+	rows, err = Default.UnmarshalCSVWithHeader(nil)
+	c.Assert(err, qt.IsNil)
+	c.Assert(rows, qt.DeepEquals, []interface{}{})
+
+	_, err = Default.UnmarshalCSVWithHeader([]byte("name,instrument\nBrecker\n"))
+	c.Assert(err, qt.Not(qt.IsNil))
+}
+
 func TestUnmarshalStringTo(t *testing.T) {
 	c := qt.New(t)
 
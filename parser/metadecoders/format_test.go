@@ -36,6 +36,7 @@ func TestFormatFromString(t *testing.T) {
 		{"config.toml", TOML},
 		{"tOMl", TOML},
 		{"org", ORG},
+		{"xml", XML},
 		{"foo", ""},
 	} {
 		c.Assert(FormatFromString(test.s), qt.Equals, test.expect)
@@ -51,6 +52,7 @@ func TestFormatFromMediaType(t *testing.T) {
 		{media.JSONType, JSON},
 		{media.YAMLType, YAML},
 		{media.TOMLType, TOML},
+		{media.XMLType, XML},
 		{media.CalendarType, ""},
 	} {
 		c.Assert(FormatFromMediaType(test.m), qt.Equals, test.expect)
@@ -88,6 +90,9 @@ func TestFormatFromContentString(t *testing.T) {
 		{`foo:"bar"`, YAML},
 		{`{ "foo": "bar"`, JSON},
 		{`a,b,c"`, CSV},
+		{`<foo>bar</foo>`, XML},
+		{`
+<?xml version="1.0"?><foo>bar</foo>`, XML},
 		{`asdfasdf`, Format("")},
 		{``, Format("")},
 	} {
@@ -0,0 +1,128 @@
+// Copyright 2019 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package publisher
+
+import (
+	"io"
+	"sort"
+	"strings"
+	"sync"
+
+	"golang.org/x/net/html"
+)
+
+// HTMLElements holds the HTML element names, classes and IDs found in the
+// published HTML files.
+type HTMLElements struct {
+	Tags    []string `json:"tags"`
+	Classes []string `json:"classes"`
+	IDs     []string `json:"ids"`
+}
+
+// HTMLElementsCollector collects the HTML elements used in the published
+// HTML files, e.g. to be used with tools such as PurgeCSS.
+// It is safe for concurrent use.
+type HTMLElementsCollector struct {
+	mu sync.Mutex
+
+	tags    map[string]bool
+	classes map[string]bool
+	ids     map[string]bool
+}
+
+// NewHTMLElementsCollector creates a new HTMLElementsCollector.
+func NewHTMLElementsCollector() *HTMLElementsCollector {
+	return &HTMLElementsCollector{
+		tags:    make(map[string]bool),
+		classes: make(map[string]bool),
+		ids:     make(map[string]bool),
+	}
+}
+
+// HTMLElements returns the collected elements, sorted.
+func (c *HTMLElementsCollector) HTMLElements() HTMLElements {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return HTMLElements{
+		Tags:    sortedKeys(c.tags),
+		Classes: sortedKeys(c.classes),
+		IDs:     sortedKeys(c.ids),
+	}
+}
+
+// collect reads the HTML document in r and adds its elements.
+func (c *HTMLElementsCollector) collect(r io.Reader) error {
+	var (
+		tags    []string
+		classes []string
+		ids     []string
+	)
+
+	z := html.NewTokenizer(r)
+
+	for {
+		tt := z.Next()
+		if tt == html.ErrorToken {
+			if err := z.Err(); err != io.EOF {
+				return err
+			}
+			break
+		}
+
+		if tt != html.StartTagToken && tt != html.SelfClosingTagToken {
+			continue
+		}
+
+		name, hasAttr := z.TagName()
+		tags = append(tags, string(name))
+
+		for hasAttr {
+			var key, val []byte
+			key, val, hasAttr = z.TagAttr()
+			switch string(key) {
+			case "class":
+				classes = append(classes, strings.Fields(string(val))...)
+			case "id":
+				if id := strings.TrimSpace(string(val)); id != "" {
+					ids = append(ids, id)
+				}
+			}
+		}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, v := range tags {
+		c.tags[v] = true
+	}
+	for _, v := range classes {
+		c.classes[v] = true
+	}
+	for _, v := range ids {
+		c.ids[v] = true
+	}
+
+	return nil
+}
+
+func sortedKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
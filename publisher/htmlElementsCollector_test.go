@@ -0,0 +1,36 @@
+// Copyright 2019 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package publisher
+
+import (
+	"strings"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestHTMLElementsCollector(t *testing.T) {
+	c := qt.New(t)
+
+	collector := NewHTMLElementsCollector()
+
+	c.Assert(collector.collect(strings.NewReader(`<div id="main" class="foo  bar"><p class="foo">Hello</p><br/></div>`)), qt.IsNil)
+	c.Assert(collector.collect(strings.NewReader(`<DIV class="baz"><style>.not { }</style></DIV>`)), qt.IsNil)
+
+	c.Assert(collector.HTMLElements(), qt.DeepEquals, HTMLElements{
+		Tags:    []string{"br", "div", "p", "style"},
+		Classes: []string{"bar", "baz", "foo"},
+		IDs:     []string{"main"},
+	})
+}
@@ -0,0 +1,197 @@
+// Copyright 2019 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package publisher
+
+import (
+	"bytes"
+	"io"
+	"net/url"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/spf13/afero"
+	"golang.org/x/net/html"
+)
+
+// Link is a href or src attribute value found in a published HTML file.
+type Link struct {
+	// The attribute value.
+	URL string
+
+	// The line number in the published file, starting on 1.
+	Line int
+}
+
+// LinksCollector collects the links in the published HTML files, e.g. to
+// check them for broken links when the build is done.
+// It is safe for concurrent use.
+type LinksCollector struct {
+	mu sync.Mutex
+
+	// Target path, with "/" separators and a leading "/" => links.
+	links map[string][]Link
+
+	// The target paths collected since the last call to RemoveStale.
+	collected map[string]bool
+}
+
+// NewLinksCollector creates a new LinksCollector.
+func NewLinksCollector() *LinksCollector {
+	return &LinksCollector{
+		links:     make(map[string][]Link),
+		collected: make(map[string]bool),
+	}
+}
+
+// RemoveStale removes the links of the files not published since the last
+// call to RemoveStale, unless keep returns true for their target path.
+func (c *LinksCollector) RemoveStale(keep func(targetPath string) bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for targetPath := range c.links {
+		if !c.collected[targetPath] && !keep(targetPath) {
+			delete(c.links, targetPath)
+		}
+	}
+	c.collected = make(map[string]bool)
+}
+
+// Links returns the collected links keyed by the target path of the
+// published file, e.g. "/blog/mypost/index.html".
+func (c *LinksCollector) Links() map[string][]Link {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	links := make(map[string][]Link, len(c.links))
+	for k, v := range c.links {
+		links[k] = v
+	}
+	return links
+}
+
+// collect reads the HTML document in r published to targetPath and
+// replaces any links previously collected for it.
+func (c *LinksCollector) collect(targetPath string, r io.Reader) error {
+	var links []Link
+
+	line := 1
+	z := html.NewTokenizer(r)
+
+	for {
+		tt := z.Next()
+		if tt == html.ErrorToken {
+			if err := z.Err(); err != io.EOF {
+				return err
+			}
+			break
+		}
+
+		tokenLine := line
+		line += bytes.Count(z.Raw(), []byte("\n"))
+
+		if tt != html.StartTagToken && tt != html.SelfClosingTagToken {
+			continue
+		}
+
+		_, hasAttr := z.TagName()
+		for hasAttr {
+			var key, val []byte
+			key, val, hasAttr = z.TagAttr()
+			switch string(key) {
+			case "href", "src":
+				links = append(links, Link{URL: string(val), Line: tokenLine})
+			}
+		}
+	}
+
+	targetPath = "/" + strings.TrimPrefix(filepath.ToSlash(targetPath), "/")
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.links[targetPath] = links
+	c.collected[targetPath] = true
+
+	return nil
+}
+
+// ResolveLink resolves link found in dir to a path relative to the publish
+// dir, starting with a "/". It returns false if link is not internal, which
+// includes the links on the same host outside of basePath, the path of the
+// baseURL, e.g. "/docs/".
+func ResolveLink(link, dir, basePath, baseURL string) (string, bool) {
+	link = strings.TrimSpace(link)
+	if link == "" || strings.HasPrefix(link, "#") {
+		return "", false
+	}
+
+	if baseURL != "" && strings.HasPrefix(link, baseURL) {
+		link = "/" + strings.TrimPrefix(strings.TrimPrefix(link, baseURL), "/")
+		dir = "/"
+		basePath = "/"
+	}
+
+	u, err := url.Parse(link)
+	if err != nil || u.Scheme != "" || u.Host != "" || strings.HasPrefix(link, "//") {
+		return "", false
+	}
+
+	p := u.Path
+	if p == "" {
+		return "", false
+	}
+
+	if strings.HasPrefix(p, "/") {
+		switch {
+		case p+"/" == basePath:
+			p = "/"
+		case !strings.HasPrefix(p, basePath):
+			// Outside of the site, but still on this host.
+			return "", false
+		default:
+			p = "/" + strings.TrimPrefix(p, basePath)
+		}
+	} else {
+		p = dir + p
+	}
+
+	trailingSlash := strings.HasSuffix(p, "/")
+	p = path.Clean(p)
+	if trailingSlash && p != "/" {
+		p += "/"
+	}
+
+	return p, true
+}
+
+// LinkTargetExists returns whether the link target p, as returned by
+// ResolveLink, is a published file in fs, either directly or as the
+// index.html of a directory.
+func LinkTargetExists(fs afero.Fs, p string) bool {
+	candidates := []string{p}
+	if strings.HasSuffix(p, "/") {
+		candidates = append(candidates, p+"index.html")
+	} else {
+		candidates = append(candidates, p+"/index.html")
+	}
+	for _, candidate := range candidates {
+		if fi, err := fs.Stat(filepath.FromSlash(strings.TrimPrefix(candidate, "/"))); err == nil && !fi.IsDir() {
+			return true
+		}
+	}
+	return false
+}
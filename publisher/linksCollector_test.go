@@ -0,0 +1,89 @@
+// Copyright 2019 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package publisher
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/spf13/afero"
+)
+
+func TestLinksCollector(t *testing.T) {
+	c := qt.New(t)
+
+	collector := NewLinksCollector()
+
+	c.Assert(collector.collect(filepath.FromSlash("blog/index.html"), strings.NewReader(`<html>
+<head><link href="/css/main.css" rel="stylesheet"></head>
+<body>
+<!-- a
+comment -->
+<a
+  href="/p1/">P1</a><img src="img.png"/>
+</body>
+</html>`)), qt.IsNil)
+	c.Assert(collector.collect("index.html", strings.NewReader(`<a href="/p1/">P1</a>`)), qt.IsNil)
+	// Published again, e.g. on rebuild.
+	c.Assert(collector.collect("index.html", strings.NewReader(`<a href="/p2/">P2</a>`)), qt.IsNil)
+
+	c.Assert(collector.Links(), qt.DeepEquals, map[string][]Link{
+		"/blog/index.html": {{URL: "/css/main.css", Line: 2}, {URL: "/p1/", Line: 6}, {URL: "img.png", Line: 7}},
+		"/index.html":      {{URL: "/p2/", Line: 1}},
+	})
+}
+
+func TestResolveLink(t *testing.T) {
+	c := qt.New(t)
+
+	for _, test := range []struct {
+		link     string
+		dir      string
+		basePath string
+		expect   string
+		internal bool
+	}{
+		{"/p1/", "/", "/", "/p1/", true},
+		{"../p1/", "/posts/", "/", "/p1/", true},
+		{"img.png?v=1#x", "/posts/", "/", "/posts/img.png", true},
+		{"/docs/p1/", "/", "/docs/", "/p1/", true},
+		{"/docs", "/", "/docs/", "/", true},
+		{"/other/p1/", "/", "/docs/", "", false},
+		{"https://example.org/p1/", "/posts/", "/", "/p1/", true},
+		{"https://gohugo.io/", "/", "/", "", false},
+		{"//cdn.example.com/a.js", "/", "/", "", false},
+		{"mailto:a@example.org", "/", "/", "", false},
+		{"#top", "/", "/", "", false},
+	} {
+		got, internal := ResolveLink(test.link, test.dir, test.basePath, "https://example.org")
+		c.Assert(internal, qt.Equals, test.internal, qt.Commentf(test.link))
+		c.Assert(got, qt.Equals, test.expect, qt.Commentf(test.link))
+	}
+}
+
+func TestLinkTargetExists(t *testing.T) {
+	c := qt.New(t)
+
+	fs := afero.NewMemMapFs()
+	c.Assert(afero.WriteFile(fs, filepath.FromSlash("blog/index.html"), []byte("blog"), 0666), qt.IsNil)
+	c.Assert(afero.WriteFile(fs, filepath.FromSlash("blog/img.png"), []byte("img"), 0666), qt.IsNil)
+
+	c.Assert(LinkTargetExists(fs, "/blog/"), qt.Equals, true)
+	c.Assert(LinkTargetExists(fs, "/blog"), qt.Equals, true)
+	c.Assert(LinkTargetExists(fs, "/blog/img.png"), qt.Equals, true)
+	c.Assert(LinkTargetExists(fs, "/blog/nope.png"), qt.Equals, false)
+	c.Assert(LinkTargetExists(fs, "/nope/"), qt.Equals, false)
+}
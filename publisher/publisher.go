@@ -14,10 +14,12 @@
 package publisher
 
 import (
+	"bytes"
 	"errors"
 	"io"
 	"sync/atomic"
 
+	"github.com/gohugoio/hugo/config"
 	"github.com/gohugoio/hugo/media"
 
 	"github.com/gohugoio/hugo/minifiers"
@@ -56,6 +58,10 @@ type Descriptor struct {
 	// injected on the home page for HTML type of output formats.
 	AddHugoGeneratorTag bool
 
+	// If set, a robots meta tag with this content, e.g. "noindex, nofollow",
+	// is injected in the header of HTML output that does not have one.
+	Robots string
+
 	// If set, will replace all relative URLs with this one.
 	AbsURLPath string
 
@@ -70,16 +76,22 @@ type DestinationPublisher struct {
 	fs     afero.Fs
 	minify bool
 	min    minifiers.Client
+
+	// May be nil.
+	htmlElementsCollector *HTMLElementsCollector
+
+	// May be nil.
+	linksCollector *LinksCollector
 }
 
 // NewDestinationPublisher creates a new DestinationPublisher.
-func NewDestinationPublisher(fs afero.Fs, outputFormats output.Formats, mediaTypes media.Types, minify bool) DestinationPublisher {
-	pub := DestinationPublisher{fs: fs}
-	if minify {
-		pub.min = minifiers.New(mediaTypes, outputFormats)
-		pub.minify = true
-	}
-	return pub
+// If htmlElementsCollector is set, the elements in every published HTML file
+// are added to it. The same goes for linksCollector and the links.
+func NewDestinationPublisher(fs afero.Fs, outputFormats output.Formats, mediaTypes media.Types, cfg config.Provider, htmlElementsCollector *HTMLElementsCollector, linksCollector *LinksCollector) (pub DestinationPublisher, err error) {
+	pub = DestinationPublisher{fs: fs, htmlElementsCollector: htmlElementsCollector, linksCollector: linksCollector}
+	pub.min, err = minifiers.New(mediaTypes, outputFormats, cfg)
+	pub.minify = pub.min.MinifyOutput
+	return
 }
 
 // Publish applies any relevant transformations and writes the file
@@ -111,7 +123,22 @@ func (p DestinationPublisher) Publish(d Descriptor) error {
 	}
 	defer f.Close()
 
+	var collected *bytes.Buffer
+	if (p.htmlElementsCollector != nil || p.linksCollector != nil) && d.OutputFormat.IsHTML {
+		collected = bp.GetBuffer()
+		defer bp.PutBuffer(collected)
+		src = io.TeeReader(src, collected)
+	}
+
 	_, err = io.Copy(f, src)
+	if err == nil && collected != nil {
+		if p.linksCollector != nil {
+			err = p.linksCollector.collect(d.TargetPath, bytes.NewReader(collected.Bytes()))
+		}
+		if err == nil && p.htmlElementsCollector != nil {
+			err = p.htmlElementsCollector.collect(collected)
+		}
+	}
 	if err == nil && d.StatCounter != nil {
 		atomic.AddUint64(d.StatCounter, uint64(1))
 	}
@@ -148,9 +175,13 @@ func (p DestinationPublisher) createTransformerChain(f Descriptor) transform.Cha
 			transformers = append(transformers, metainject.HugoGenerator)
 		}
 
+		if f.Robots != "" {
+			transformers = append(transformers, metainject.Robots(f.Robots))
+		}
+
 	}
 
-	if p.minify {
+	if p.minify && !p.min.IsExcluded(f.OutputFormat, f.TargetPath) {
 		minifyTransformer := p.min.Transformer(f.OutputFormat.MediaType)
 		if minifyTransformer != nil {
 			transformers = append(transformers, minifyTransformer)
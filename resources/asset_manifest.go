@@ -0,0 +1,77 @@
+// Copyright 2019 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"strings"
+	"sync"
+)
+
+// AssetManifest maps the source paths of resources to the target paths
+// of their fingerprinted versions, e.g. "scss/main.scss" to
+// "css/main.6d8a….css". It is safe for concurrent use.
+type AssetManifest struct {
+	mu      sync.RWMutex
+	entries map[string]string
+}
+
+// NewAssetManifest creates a new, empty AssetManifest.
+func NewAssetManifest() *AssetManifest {
+	return &AssetManifest{entries: make(map[string]string)}
+}
+
+// Add adds an entry to the manifest, replacing any existing entry for
+// source. Unix styled slashes are expected. Add is a no-op if m is nil.
+func (m *AssetManifest) Add(source, fingerprinted string) {
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.entries[strings.TrimPrefix(source, "/")] = strings.TrimPrefix(fingerprinted, "/")
+}
+
+// Remove removes the entries for the given source paths, e.g. when the
+// source has changed. It is a no-op if m is nil.
+func (m *AssetManifest) Remove(sources ...string) {
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, source := range sources {
+		delete(m.entries, strings.TrimPrefix(source, "/"))
+	}
+}
+
+// Reset removes all entries from the manifest. It is a no-op if m is nil.
+func (m *AssetManifest) Reset() {
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.entries = make(map[string]string)
+}
+
+// Entries returns a copy of the manifest entries.
+func (m *AssetManifest) Entries() map[string]string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	entries := make(map[string]string, len(m.entries))
+	for k, v := range m.entries {
+		entries[k] = v
+	}
+	return entries
+}
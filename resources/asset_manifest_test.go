@@ -0,0 +1,41 @@
+// Copyright 2019 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestAssetManifest(t *testing.T) {
+	c := qt.New(t)
+
+	var nilManifest *AssetManifest
+	nilManifest.Add("/css/main.css", "/css/main.123.css")
+
+	m := NewAssetManifest()
+	m.Add("/css/main.css", "/css/main.123.css")
+	m.Add("js/main.js", "js/main.123.js")
+	m.Add("/css/main.css", "/css/main.456.css")
+
+	entries := m.Entries()
+	c.Assert(entries, qt.DeepEquals, map[string]string{
+		"css/main.css": "css/main.456.css",
+		"js/main.js":   "js/main.123.js",
+	})
+
+	entries["foo"] = "bar"
+	c.Assert(m.Entries(), qt.HasLen, 2)
+}
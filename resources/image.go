@@ -14,6 +14,7 @@
 package resources
 
 import (
+	"errors"
 	"fmt"
 	"image"
 	"image/draw"
@@ -21,6 +22,8 @@ import (
 	_ "image/png"
 	"os"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/gohugoio/hugo/resources/internal"
 
@@ -30,7 +33,10 @@ import (
 
 	"github.com/disintegration/gift"
 	"github.com/gohugoio/hugo/helpers"
+	"github.com/gohugoio/hugo/hugofs"
+	"github.com/gohugoio/hugo/output"
 	"github.com/gohugoio/hugo/resources/images"
+	"github.com/spf13/cast"
 
 	// Blind import for image.Decode
 
@@ -124,14 +130,94 @@ func (i *imageResource) Filter(filters ...gift.Filter) (resource.Image, error) {
 	conf := i.Proc.GetDefaultImageConfig("filter")
 	conf.Key = internal.HashString(filters)
 
+	if i.getSpec().ImageAudit != nil {
+		// The filters decide the size, e.g. a social card.
+		b := gift.New(filters...).Bounds(image.Rect(0, 0, i.Width(), i.Height()))
+		conf.Width, conf.Height = b.Dx(), b.Dy()
+	}
+
 	return i.doWithImageConfig(conf, func(src image.Image) (image.Image, error) {
 		return i.Proc.Filter(src, filters...)
 	})
 }
 
-func (i *imageResource) isJPEG() bool {
-	name := strings.ToLower(i.getResourcePaths().relTargetDirFile.file)
-	return strings.HasSuffix(name, ".jpg") || strings.HasSuffix(name, ".jpeg")
+// ProcessMany processes the image with the given spec, e.g. "resize 800x",
+// once for every format in the comma separated list of formats, e.g.
+// "png,jpg". The source image is decoded and processed only once and
+// then encoded to all of the formats. This is useful to create the
+// sources in a <picture> element.
+func (i *imageResource) ProcessMany(formats, spec string) ([]resource.Image, error) {
+	parts := strings.Fields(spec)
+	if len(parts) < 2 {
+		return nil, fmt.Errorf("invalid image spec %q, must be on the form \"<action> <options>\", e.g. \"resize 800x\"", spec)
+	}
+
+	action := strings.ToLower(parts[0])
+	switch action {
+	case "resize", "fit", "fill":
+	default:
+		return nil, fmt.Errorf("invalid image action %q, must be one of resize, fit or fill", parts[0])
+	}
+
+	conf, err := i.decodeImageConfig(action, strings.Join(parts[1:], " "))
+	if err != nil {
+		return nil, err
+	}
+
+	iconf, err := i.imagingConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		convertInit sync.Once
+		converted   image.Image
+		convertErr  error
+	)
+
+	// Shared between the formats so the filters are applied only once.
+	convert := func(src image.Image) (image.Image, error) {
+		convertInit.Do(func() {
+			converted, convertErr = i.Proc.ApplyFiltersFromConfig(src, conf)
+		})
+		return converted, convertErr
+	}
+
+	var (
+		decodeInit sync.Once
+		src        image.Image
+		decodeErr  error
+	)
+
+	decode := func() (image.Image, error) {
+		decodeInit.Do(func() {
+			src, decodeErr = i.decodeSource()
+		})
+		return src, decodeErr
+	}
+
+	var imgs []resource.Image
+	for _, name := range strings.Split(formats, ",") {
+		name = strings.TrimSpace(name)
+		format, found := images.ImageFormatFromName(name)
+		if !found {
+			return nil, fmt.Errorf("unsupported image format %q, must be one of jpg, png, gif, tif or bmp", name)
+		}
+
+		fconf := conf
+		fconf.TargetFormat = format
+		if format == images.JPEG && fconf.Quality <= 0 {
+			fconf.Quality = iconf.Quality
+		}
+
+		img, err := i.doWithImageConfigAndSource(fconf, decode, convert)
+		if err != nil {
+			return nil, err
+		}
+		imgs = append(imgs, img)
+	}
+
+	return imgs, nil
 }
 
 // Serialize image processing. The imaging library spins up its own set of Go routines,
@@ -144,16 +230,26 @@ const imageProcWorkers = 1
 var imageProcSem = make(chan bool, imageProcWorkers)
 
 func (i *imageResource) doWithImageConfig(conf images.ImageConfig, f func(src image.Image) (image.Image, error)) (resource.Image, error) {
+	return i.doWithImageConfigAndSource(conf, i.decodeSource, f)
+}
+
+func (i *imageResource) doWithImageConfigAndSource(conf images.ImageConfig, decode func() (image.Image, error), f func(src image.Image) (image.Image, error)) (resource.Image, error) {
+	if i.getSpec().ImageAudit != nil {
+		return i.audit(conf), nil
+	}
+
 	return i.getSpec().imageCache.getOrCreate(i, conf, func() (*imageResource, image.Image, error) {
 		imageProcSem <- true
 		defer func() {
 			<-imageProcSem
 		}()
 
+		defer i.getSpec().BuildTimer.MeasureSince("images/"+conf.Action, time.Now())
+
 		errOp := conf.Action
 		errPath := i.getSourceFilename()
 
-		src, err := i.decodeSource()
+		src, err := decode()
 		if err != nil {
 			return nil, nil, &os.PathError{Op: errOp, Path: errPath, Err: err}
 		}
@@ -163,7 +259,17 @@ func (i *imageResource) doWithImageConfig(conf images.ImageConfig, f func(src im
 			return nil, nil, &os.PathError{Op: errOp, Path: errPath, Err: err}
 		}
 
-		if i.Format == images.PNG {
+		targetFormat := i.targetFormat(conf)
+
+		if targetFormat == images.JPEG && i.Format != images.JPEG {
+			// JPEG has no alpha channel, so draw the image on a white background.
+			tmp := image.NewRGBA(converted.Bounds())
+			draw.Draw(tmp, tmp.Bounds(), image.White, image.Point{}, draw.Src)
+			draw.Draw(tmp, tmp.Bounds(), converted, converted.Bounds().Min, draw.Over)
+			converted = tmp
+		}
+
+		if targetFormat == images.PNG {
 			// Apply the colour palette from the source
 			if paletted, ok := src.(*image.Paletted); ok {
 				tmp := image.NewPaletted(converted.Bounds(), paletted.Palette)
@@ -174,20 +280,36 @@ func (i *imageResource) doWithImageConfig(conf images.ImageConfig, f func(src im
 
 		ci := i.clone(converted)
 		ci.setBasePath(conf)
+		ci.setFormat(conf.TargetFormat)
 
 		return ci, converted, nil
 	})
 }
 
 func (i *imageResource) decodeImageConfig(action, spec string) (images.ImageConfig, error) {
-	conf, err := images.DecodeImageConfig(action, spec, i.Proc.Cfg)
+	iconf, err := i.imagingConfig()
+	if err != nil {
+		return images.ImageConfig{}, err
+	}
+
+	conf, err := images.DecodeImageConfig(action, spec, iconf)
 	if err != nil {
 		return conf, err
 	}
 
-	iconf := i.Proc.Cfg
+	if iconf.OnUpscale != images.OnUpscaleIgnore && conf.IsUpscale(i.Width(), i.Height()) {
+		msg := fmt.Sprintf("%s %q upscales the %dx%d image %q", action, spec, i.Width(), i.Height(), i.getSourceFilename())
+		if iconf.OnUpscale == images.OnUpscaleError {
+			return conf, errors.New(msg)
+		}
+		helpers.DistinctWarnLog.Println(msg)
+	}
 
-	if conf.Quality <= 0 && i.isJPEG() {
+	if f, found := images.ImageFormatFromName(i.outputFormatImaging().Format); found && f != i.Format {
+		conf.TargetFormat = f
+	}
+
+	if conf.Quality <= 0 && i.targetFormat(conf) == images.JPEG {
 		// We need a quality setting for all JPEGs
 		conf.Quality = iconf.Quality
 	}
@@ -195,6 +317,43 @@ func (i *imageResource) decodeImageConfig(action, spec string) (images.ImageConf
 	return conf, nil
 }
 
+// outputFormatImaging returns the image processing defaults of the output
+// format currently being rendered, if any.
+func (i *imageResource) outputFormatImaging() output.ImageDefaults {
+	if i.getSpec().CurrentOutputFormat == nil {
+		return output.ImageDefaults{}
+	}
+	return i.getSpec().CurrentOutputFormat().Imaging
+}
+
+// imagingConfig returns the image processing defaults for this image, which
+// is the site config with the defaults of the current output format and any
+// "imaging" settings in the resource params (e.g. set in the front matter
+// resources block) applied on top.
+func (i *imageResource) imagingConfig() (images.Imaging, error) {
+	defaults := i.Proc.Cfg
+	if q := i.outputFormatImaging().Quality; q > 0 {
+		defaults.Quality = q
+	}
+
+	v, found := i.Params()["imaging"]
+	if !found {
+		return defaults, nil
+	}
+
+	m, err := cast.ToStringMapE(v)
+	if err != nil {
+		return defaults, _errors.Wrapf(err, "invalid imaging params for %q", i.Name())
+	}
+
+	conf, err := images.DecodeConfigWithDefaults(m, defaults)
+	if err != nil {
+		return conf, _errors.Wrapf(err, "invalid imaging params for %q", i.Name())
+	}
+
+	return conf, nil
+}
+
 func (i *imageResource) decodeSource() (image.Image, error) {
 	f, err := i.ReadSeekCloser()
 	if err != nil {
@@ -221,6 +380,47 @@ func (i *imageResource) clone(img image.Image) *imageResource {
 	}
 }
 
+// audit records conf in the image audit and returns an unprocessed
+// image with the target path and the estimated dimensions of the image
+// conf would create. It is never published.
+func (i *imageResource) audit(conf images.ImageConfig) resource.Image {
+	targetFormat := i.targetFormat(conf)
+	key := conf.GetKey(targetFormat)
+	if targetFormat != i.Format {
+		key += "_" + strings.TrimPrefix(targetFormat.DefaultExtension(), ".")
+	}
+	source := i.getSourceFilename()
+	if fi, ok := i.getFileInfo().(hugofs.FileMetaInfo); ok && fi.Meta().Filename() != "" {
+		source = fi.Meta().Filename()
+	}
+	i.getSpec().ImageAudit.Add(source, key)
+
+	ci := i.clone(nil)
+	ci.Image = ci.WithSize(conf.TargetSize(i.Width(), i.Height()))
+	ci.setBasePath(conf)
+	ci.setFormat(conf.TargetFormat)
+
+	return newResourceAdapter(i.getSpec(), false, ci)
+}
+
+// targetFormat returns the format conf encodes the image to.
+func (i *imageResource) targetFormat(conf images.ImageConfig) images.Format {
+	if conf.TargetFormat != 0 {
+		return conf.TargetFormat
+	}
+	return i.Format
+}
+
+// setFormat sets the format and media type of i, a processed image, to f,
+// unless f is the zero value.
+func (i *imageResource) setFormat(f images.Format) {
+	if f == 0 || f == i.Format {
+		return
+	}
+	i.Format = f
+	i.setMediaType(i.getSpec().mediaTypeFromExt(f.DefaultExtension()))
+}
+
 func (i *imageResource) setBasePath(conf images.ImageConfig) {
 	i.getResourcePaths().relTargetDirFile = i.relTargetPathFromConfig(conf)
 }
@@ -229,6 +429,8 @@ func (i *imageResource) relTargetPathFromConfig(conf images.ImageConfig) dirFile
 	p1, p2 := helpers.FileAndExt(i.getResourcePaths().relTargetDirFile.file)
 	if conf.Action == "trace" {
 		p2 = ".svg"
+	} else if targetFormat := i.targetFormat(conf); targetFormat != i.Format {
+		p2 = targetFormat.DefaultExtension()
 	}
 
 	h, _ := i.hash()
@@ -237,7 +439,7 @@ func (i *imageResource) relTargetPathFromConfig(conf images.ImageConfig) dirFile
 	// Do not change for no good reason.
 	const md5Threshold = 100
 
-	key := conf.GetKey(i.Format)
+	key := conf.GetKey(i.targetFormat(conf))
 
 	// It is useful to have the key in clear text, but when nesting transforms, it
 	// can easily be too long to read, and maybe even too long
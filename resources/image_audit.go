@@ -0,0 +1,94 @@
+// Copyright 2019 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"sort"
+	"sync"
+)
+
+// ImageAudit collects the image processing requested by the templates,
+// grouped by source image, when the images are not processed, e.g. with
+// the --imageAudit flag. It is safe for concurrent use.
+type ImageAudit struct {
+	mu      sync.Mutex
+	sources map[string]map[string]int
+}
+
+// ImageAuditSource is a source image and the processed versions of it
+// the templates requested.
+type ImageAuditSource struct {
+	Source   string
+	Requests int
+	Outputs  []ImageAuditOutput
+}
+
+// ImageAuditOutput is a processed version of an image, identified by its
+// image config key, and the number of times it was requested.
+type ImageAuditOutput struct {
+	Key      string
+	Requests int
+}
+
+// NewImageAudit creates a new, empty ImageAudit.
+func NewImageAudit() *ImageAudit {
+	return &ImageAudit{sources: make(map[string]map[string]int)}
+}
+
+// Add records a request for the version of the source image identified by
+// key. Add is a no-op if a is nil.
+func (a *ImageAudit) Add(source, key string) {
+	if a == nil {
+		return
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	outputs, found := a.sources[source]
+	if !found {
+		outputs = make(map[string]int)
+		a.sources[source] = outputs
+	}
+	outputs[key]++
+}
+
+// Reset removes all the recorded requests. Reset is a no-op if a is nil.
+func (a *ImageAudit) Reset() {
+	if a == nil {
+		return
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.sources = make(map[string]map[string]int)
+}
+
+// Sources returns the source images sorted by name, with their outputs
+// sorted by key.
+func (a *ImageAudit) Sources() []ImageAuditSource {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	sources := make([]ImageAuditSource, 0, len(a.sources))
+	for source, outputs := range a.sources {
+		s := ImageAuditSource{Source: source}
+		for key, requests := range outputs {
+			s.Requests += requests
+			s.Outputs = append(s.Outputs, ImageAuditOutput{Key: key, Requests: requests})
+		}
+		sort.Slice(s.Outputs, func(i, j int) bool { return s.Outputs[i].Key < s.Outputs[j].Key })
+		sources = append(sources, s)
+	}
+	sort.Slice(sources, func(i, j int) bool { return sources[i].Source < sources[j].Source })
+
+	return sources
+}
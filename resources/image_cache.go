@@ -16,14 +16,17 @@ package resources
 import (
 	"image"
 	"io"
+	"path"
 	"path/filepath"
 	"strings"
 	"sync"
 
 	"github.com/gohugoio/hugo/resources/images"
 
+	"github.com/BurntSushi/locker"
 	"github.com/gohugoio/hugo/cache/filecache"
 	"github.com/gohugoio/hugo/helpers"
+	"github.com/gohugoio/hugo/hugofs"
 )
 
 type imageCache struct {
@@ -33,6 +36,9 @@ type imageCache struct {
 
 	mu    sync.RWMutex
 	store map[string]*resourceAdapter
+
+	// Named locks on the in-memory store keys.
+	nlocker *locker.Locker
 }
 
 func (c *imageCache) isInCache(key string) bool {
@@ -84,6 +90,30 @@ func (c *imageCache) getOrCreate(
 		return cachedImage, nil
 	}
 
+	// Make sure concurrent requests for the same image variant wait for
+	// the first one instead of reading it from the file cache again.
+	c.nlocker.Lock(key)
+	defer c.nlocker.Unlock(key)
+
+	c.mu.RLock()
+	cachedImage, found = c.store[key]
+	c.mu.RUnlock()
+
+	if found {
+		return cachedImage, nil
+	}
+
+	// The file cache is shared between the languages and output formats,
+	// so key it by the source file rather than the target path. This way
+	// the same image is only processed once even if it is published to
+	// different paths, e.g. in translations with different URLs.
+	fileKey := key
+	if fi, ok := parent.getFileInfo().(hugofs.FileMetaInfo); ok {
+		if p := fi.Meta().Path(); p != "" {
+			fileKey = c.normalizeKey(path.Join(path.Dir(filepath.ToSlash(p)), relTarget.file))
+		}
+	}
+
 	var img *imageResource
 
 	// These funcs are protected by a named lock.
@@ -94,6 +124,7 @@ func (c *imageCache) getOrCreate(
 		rp := img.getResourcePaths()
 		rp.relTargetDirFile.file = relTarget.file
 		img.setSourceFilename(info.Name)
+		img.setFormat(conf.TargetFormat)
 
 		w, err := img.openDestinationsForWriting()
 		if err != nil {
@@ -133,7 +164,7 @@ func (c *imageCache) getOrCreate(
 	//  but the count of processed image variations for this site.
 	c.pathSpec.ProcessingStats.Incr(&c.pathSpec.ProcessingStats.ProcessedImages)
 
-	_, err := c.fileCache.ReadOrCreate(key, read, create)
+	_, err := c.fileCache.ReadOrCreate(fileKey, read, create)
 	if err != nil {
 		return nil, err
 	}
@@ -155,5 +186,5 @@ func (c *imageCache) getOrCreate(
 }
 
 func newImageCache(fileCache *filecache.Cache, ps *helpers.PathSpec) *imageCache {
-	return &imageCache{fileCache: fileCache, pathSpec: ps, store: make(map[string]*resourceAdapter)}
+	return &imageCache{fileCache: fileCache, pathSpec: ps, store: make(map[string]*resourceAdapter), nlocker: locker.NewLocker()}
 }
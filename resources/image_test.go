@@ -50,6 +50,81 @@ var eq = qt.CmpEquals(
 	}),
 )
 
+func TestImageTransformWithResourceParams(t *testing.T) {
+	c := qt.New(t)
+
+	image := fetchSunset(c)
+
+	c.Assert(AssignMetadata([]map[string]interface{}{
+		{
+			"src": "*",
+			"params": map[string]interface{}{
+				"imaging": map[string]interface{}{
+					"quality":        90,
+					"anchor":         "TopLeft",
+					"resampleFilter": "Lanczos",
+				},
+			},
+		},
+	}, image), qt.IsNil)
+
+	resized, err := image.Resize("300x200")
+	c.Assert(err, qt.IsNil)
+	c.Assert(resized.RelPermalink(), qt.Equals, "/a/sunset_hu59e56ffff1bc1d8d122b1403d34e039f_90587_300x200_resize_q90_lanczos.jpg")
+
+	filled, err := image.Fill("200x200")
+	c.Assert(err, qt.IsNil)
+	c.Assert(filled.RelPermalink(), qt.Equals, "/a/sunset_hu59e56ffff1bc1d8d122b1403d34e039f_90587_200x200_fill_q90_lanczos_topleft.jpg")
+
+	// The spec wins over the resource params.
+	resized, err = image.Resize("300x200 q50 Box")
+	c.Assert(err, qt.IsNil)
+	c.Assert(resized.RelPermalink(), qt.Equals, "/a/sunset_hu59e56ffff1bc1d8d122b1403d34e039f_90587_300x200_resize_q50_box.jpg")
+
+	invalid := fetchSunset(c)
+	c.Assert(AssignMetadata([]map[string]interface{}{
+		{
+			"src": "*",
+			"params": map[string]interface{}{
+				"imaging": map[string]interface{}{
+					"anchor": "Nowhere",
+				},
+			},
+		},
+	}, invalid), qt.IsNil)
+
+	_, err = invalid.Fill("200x200")
+	c.Assert(err, qt.Not(qt.IsNil))
+}
+
+func TestImageOnUpscale(t *testing.T) {
+	c := qt.New(t)
+
+	image := fetchSunset(c)
+
+	c.Assert(AssignMetadata([]map[string]interface{}{
+		{
+			"src": "*",
+			"params": map[string]interface{}{
+				"imaging": map[string]interface{}{
+					"onUpscale": "error",
+				},
+			},
+		},
+	}, image), qt.IsNil)
+
+	_, err := image.Resize("300x")
+	c.Assert(err, qt.IsNil)
+	_, err = image.Fit("2000x2000")
+	c.Assert(err, qt.IsNil)
+
+	_, err = image.Resize("1000x")
+	c.Assert(err, qt.Not(qt.IsNil))
+	c.Assert(err.Error(), qt.Contains, `resize "1000x" upscales the 900x562 image`)
+	_, err = image.Fill("600x600")
+	c.Assert(err, qt.Not(qt.IsNil))
+}
+
 func TestImageTransformBasic(t *testing.T) {
 	c := qt.New(t)
 
@@ -67,6 +142,9 @@ func TestImageTransformBasic(t *testing.T) {
 	c.Assert(image.RelPermalink(), qt.Equals, "/a/sunset.jpg")
 	c.Assert(image.ResourceType(), qt.Equals, "image")
 	assertWidthHeight(image, 900, 562)
+	c.Assert(image.AspectRatio(), qt.Equals, 900.0/562.0)
+	c.Assert(image.IsLandscape(), qt.Equals, true)
+	c.Assert(image.IsPortrait(), qt.Equals, false)
 
 	resized, err := image.Resize("300x200")
 	c.Assert(err, qt.IsNil)
@@ -88,6 +166,9 @@ func TestImageTransformBasic(t *testing.T) {
 	resizedAndRotated, err := image.Resize("x200 r90")
 	c.Assert(err, qt.IsNil)
 	assertWidthHeight(resizedAndRotated, 125, 200)
+	c.Assert(resizedAndRotated.AspectRatio(), qt.Equals, 0.625)
+	c.Assert(resizedAndRotated.IsPortrait(), qt.Equals, true)
+	c.Assert(resizedAndRotated.IsLandscape(), qt.Equals, false)
 	assertFileCache(c, fileCache, resizedAndRotated.RelPermalink(), 125, 200)
 
 	assertWidthHeight(resized, 300, 200)
@@ -124,6 +205,95 @@ func TestImageTransformBasic(t *testing.T) {
 	assertFileCache(c, fileCache, filledAgain.RelPermalink(), 200, 100)
 }
 
+func TestImageProcessMany(t *testing.T) {
+	c := qt.New(t)
+
+	image := fetchSunset(c)
+
+	fileCache := image.(specProvider).getSpec().FileCaches.ImageCache().Fs
+
+	imgs, err := image.ProcessMany("png, jpg", "resize 300x")
+	c.Assert(err, qt.IsNil)
+	c.Assert(imgs, qt.HasLen, 2)
+
+	png, jpg := imgs[0], imgs[1]
+	c.Assert(png.RelPermalink(), qt.Equals, "/a/sunset_hu59e56ffff1bc1d8d122b1403d34e039f_90587_300x0_resize_q68_linear_2.png")
+	c.Assert(png.MediaType().Type(), qt.Equals, "image/png")
+	c.Assert(png.Width(), qt.Equals, 300)
+	c.Assert(png.Height(), qt.Equals, 187)
+	assertFileCache(c, fileCache, png.RelPermalink(), 300, 187)
+
+	resized, err := image.Resize("300x")
+	c.Assert(err, qt.IsNil)
+	c.Assert(jpg.RelPermalink(), qt.Equals, resized.RelPermalink())
+	c.Assert(jpg.MediaType().Type(), qt.Equals, image.MediaType().Type())
+
+	_, err = image.ProcessMany("webp,jpg", "resize 300x")
+	c.Assert(err, qt.ErrorMatches, `unsupported image format "webp".*`)
+
+	_, err = image.ProcessMany("png", "300x")
+	c.Assert(err, qt.Not(qt.IsNil))
+
+	_, err = image.ProcessMany("png", "crop 300x")
+	c.Assert(err, qt.ErrorMatches, `invalid image action "crop".*`)
+}
+
+func TestImageAudit(t *testing.T) {
+	c := qt.New(t)
+
+	image := fetchSunset(c)
+	spec := image.(specProvider).getSpec()
+	spec.ImageAudit = NewImageAudit()
+
+	resized, err := image.Resize("300x")
+	c.Assert(err, qt.IsNil)
+	c.Assert(resized.Width(), qt.Equals, 300)
+	c.Assert(resized.Height(), qt.Equals, 187)
+	c.Assert(resized.RelPermalink(), qt.Equals, "/a/sunset_hu59e56ffff1bc1d8d122b1403d34e039f_90587_300x0_resize_q68_linear.jpg")
+
+	_, err = image.Resize("300x")
+	c.Assert(err, qt.IsNil)
+
+	fitted, err := resized.Fit("50x50")
+	c.Assert(err, qt.IsNil)
+	c.Assert(fitted.Width(), qt.Equals, 50)
+	c.Assert(fitted.Height(), qt.Equals, 31)
+
+	filled, err := image.Fill("100x100 r90")
+	c.Assert(err, qt.IsNil)
+	c.Assert(filled.Width(), qt.Equals, 100)
+
+	imgs, err := image.ProcessMany("png,jpg", "resize x100")
+	c.Assert(err, qt.IsNil)
+	c.Assert(imgs[0].Width(), qt.Equals, 160)
+	c.Assert(imgs[0].MediaType().Type(), qt.Equals, "image/png")
+
+	sources := spec.ImageAudit.Sources()
+	c.Assert(sources, qt.HasLen, 1)
+	c.Assert(sources[0].Requests, qt.Equals, 6)
+	c.Assert(sources[0].Outputs, qt.DeepEquals, []ImageAuditOutput{
+		{Key: "0x100_resize_q68_linear", Requests: 1},
+		{Key: "0x100_resize_q68_linear_2_png", Requests: 1},
+		{Key: "100x100_fill_q68_r90_linear_left", Requests: 1},
+		{Key: "300x0_resize_q68_linear", Requests: 2},
+		{Key: "50x50_fit_q68_linear", Requests: 1},
+	})
+
+	// The filters decide the size of the filtered image.
+	filtered, err := image.Filter(gift.Resize(200, 0, gift.LinearResampling), gift.Rotate90())
+	c.Assert(err, qt.IsNil)
+	c.Assert(filtered.Width(), qt.Equals, 125)
+	c.Assert(filtered.Height(), qt.Equals, 200)
+
+	spec.ImageAudit.Reset()
+	c.Assert(spec.ImageAudit.Sources(), qt.HasLen, 0)
+
+	// Nothing is processed.
+	fileCache := spec.FileCaches.ImageCache().Fs
+	_, err = fileCache.Stat(filepath.FromSlash("/a/sunset_hu59e56ffff1bc1d8d122b1403d34e039f_90587_300x0_resize_q68_linear.jpg"))
+	c.Assert(err, qt.Not(qt.IsNil))
+}
+
 // https://github.com/gohugoio/hugo/issues/4261
 func TestImageTransformLongFilename(t *testing.T) {
 	c := qt.New(t)
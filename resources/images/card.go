@@ -0,0 +1,378 @@
+// Copyright 2019 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package images
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/disintegration/gift"
+	"github.com/mitchellh/mapstructure"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/sfnt"
+	"golang.org/x/image/math/fixed"
+	"golang.org/x/image/vector"
+)
+
+const (
+	// CardWidth and CardHeight is the size of the Open Graph cards
+	// created by CardFilters.
+	CardWidth  = 1200
+	CardHeight = 630
+
+	cardLineHeight = 1.25
+)
+
+// CardOptions configures the social card created by CardFilters.
+type CardOptions struct {
+	// The title text color, as a hex color, e.g. "#ffffff".
+	Color string
+
+	// The gradient drawn on top of the background, from the top to the
+	// bottom of the card. Hex colors with an optional alpha channel,
+	// e.g. "#00000000" and "#000000cc".
+	GradientStart string
+	GradientEnd   string
+
+	// The title font size in pixels.
+	FontSize float64
+
+	// The space in pixels between the edges of the card and the
+	// title and logo.
+	Padding int
+
+	// The logo is scaled to this height in pixels.
+	LogoHeight int
+
+	color         color.NRGBA
+	gradientStart color.NRGBA
+	gradientEnd   color.NRGBA
+}
+
+var defaultCardOptions = CardOptions{
+	Color:         "#ffffff",
+	GradientStart: "#00000000",
+	GradientEnd:   "#000000cc",
+	FontSize:      64,
+	Padding:       64,
+	LogoHeight:    96,
+}
+
+// DecodeCardOptions creates a CardOptions from the given map, with the
+// defaults applied for any missing value.
+func DecodeCardOptions(m map[string]interface{}) (CardOptions, error) {
+	opts := defaultCardOptions
+	if err := mapstructure.WeakDecode(m, &opts); err != nil {
+		return opts, err
+	}
+
+	if opts.FontSize <= 0 {
+		return opts, fmt.Errorf("invalid card fontSize %v", opts.FontSize)
+	}
+	if opts.Padding < 0 || opts.Padding*2 >= CardHeight {
+		return opts, fmt.Errorf("invalid card padding %d", opts.Padding)
+	}
+	if opts.LogoHeight <= 0 {
+		return opts, fmt.Errorf("invalid card logoHeight %d", opts.LogoHeight)
+	}
+
+	var err error
+	if opts.color, err = parseHexColor(opts.Color); err != nil {
+		return opts, err
+	}
+	if opts.gradientStart, err = parseHexColor(opts.GradientStart); err != nil {
+		return opts, err
+	}
+	if opts.gradientEnd, err = parseHexColor(opts.GradientEnd); err != nil {
+		return opts, err
+	}
+
+	return opts, nil
+}
+
+// CardFilters returns the filters that turns a background image into a
+// CardWidth x CardHeight social card: the background is cropped to fill
+// the card, a gradient is drawn on top of it followed by the logo in the
+// top left corner and the title in the bottom left corner.
+//
+// The logo is optional. logoKey must identify the logo content, e.g. a
+// hash of it, as it is used in the cache key together with a hash of the
+// title.
+func CardFilters(title string, logo image.Image, logoKey string, opts CardOptions) ([]gift.Filter, error) {
+	fnt, err := getCardFont()
+	if err != nil {
+		return nil, err
+	}
+
+	filters := []gift.Filter{
+		filter{
+			Options: newFilterOpts("fill", CardWidth, CardHeight),
+			Filter:  gift.ResizeToFill(CardWidth, CardHeight, gift.LanczosResampling, gift.CenterAnchor),
+		},
+		filter{
+			Options: newFilterOpts("gradient", opts.GradientStart, opts.GradientEnd),
+			Filter:  gradientFilter{start: opts.gradientStart, end: opts.gradientEnd},
+		},
+	}
+
+	if logo != nil {
+		filters = append(filters, filter{
+			Options: newFilterOpts("overlay", logoKey, opts.LogoHeight, opts.Padding),
+			Filter: overlayFilter{
+				src: logo,
+				g:   gift.New(gift.Resize(0, opts.LogoHeight, gift.LanczosResampling)),
+				x:   opts.Padding,
+				y:   opts.Padding,
+			},
+		})
+	}
+
+	titleHash := md5.Sum([]byte(title))
+
+	filters = append(filters, filter{
+		Options: newFilterOpts("text", hex.EncodeToString(titleHash[:]), opts.Color, opts.FontSize, opts.Padding),
+		Filter: textFilter{
+			text:     title,
+			font:     fnt,
+			color:    opts.color,
+			fontSize: opts.FontSize,
+			padding:  opts.Padding,
+		},
+	})
+
+	return filters, nil
+}
+
+// parseHexColor parses colors on the form #rgb, #rrggbb and #rrggbbaa.
+func parseHexColor(s string) (color.NRGBA, error) {
+	hexStr := strings.TrimPrefix(s, "#")
+	if len(hexStr) == 3 {
+		hexStr = string([]byte{hexStr[0], hexStr[0], hexStr[1], hexStr[1], hexStr[2], hexStr[2]})
+	}
+	if len(hexStr) == 6 {
+		hexStr += "ff"
+	}
+
+	v, err := strconv.ParseUint(hexStr, 16, 32)
+	if err != nil || len(hexStr) != 8 {
+		return color.NRGBA{}, fmt.Errorf("invalid hex color %q", s)
+	}
+
+	return color.NRGBA{R: uint8(v >> 24), G: uint8(v >> 16), B: uint8(v >> 8), A: uint8(v)}, nil
+}
+
+func copySrc(dst draw.Image, src image.Image) {
+	draw.Draw(dst, dst.Bounds(), src, src.Bounds().Min, draw.Src)
+}
+
+// gradientFilter draws a vertical gradient on top of the source image.
+type gradientFilter struct {
+	start, end color.NRGBA
+}
+
+func (f gradientFilter) Bounds(srcBounds image.Rectangle) image.Rectangle {
+	return srcBounds
+}
+
+func (f gradientFilter) Draw(dst draw.Image, src image.Image, options *gift.Options) {
+	copySrc(dst, src)
+
+	b := dst.Bounds()
+	lerp := func(a, b uint8, t float64) uint8 {
+		return uint8(float64(a) + (float64(b)-float64(a))*t + 0.5)
+	}
+
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		var t float64
+		if b.Dy() > 1 {
+			t = float64(y-b.Min.Y) / float64(b.Dy()-1)
+		}
+		c := color.NRGBA{
+			R: lerp(f.start.R, f.end.R, t),
+			G: lerp(f.start.G, f.end.G, t),
+			B: lerp(f.start.B, f.end.B, t),
+			A: lerp(f.start.A, f.end.A, t),
+		}
+		draw.Draw(dst, image.Rect(b.Min.X, y, b.Max.X, y+1), image.NewUniform(c), image.Point{}, draw.Over)
+	}
+}
+
+// overlayFilter draws src, transformed by g, on top of the source image
+// at position x, y.
+type overlayFilter struct {
+	src  image.Image
+	g    *gift.GIFT
+	x, y int
+}
+
+func (f overlayFilter) Bounds(srcBounds image.Rectangle) image.Rectangle {
+	return srcBounds
+}
+
+func (f overlayFilter) Draw(dst draw.Image, src image.Image, options *gift.Options) {
+	copySrc(dst, src)
+
+	overlay := image.NewNRGBA(f.g.Bounds(f.src.Bounds()))
+	f.g.Draw(overlay, f.src)
+
+	pt := dst.Bounds().Min.Add(image.Pt(f.x, f.y))
+	draw.Draw(dst, overlay.Bounds().Sub(overlay.Bounds().Min).Add(pt), overlay, overlay.Bounds().Min, draw.Over)
+}
+
+var (
+	cardFontInit sync.Once
+	cardFont     *sfnt.Font
+	cardFontErr  error
+)
+
+func getCardFont() (*sfnt.Font, error) {
+	cardFontInit.Do(func() {
+		cardFont, cardFontErr = sfnt.Parse(gobold.TTF)
+	})
+	return cardFont, cardFontErr
+}
+
+// textFilter draws text on top of the source image, wrapped to fit the
+// width and aligned to the bottom left corner.
+type textFilter struct {
+	text     string
+	font     *sfnt.Font
+	color    color.NRGBA
+	fontSize float64
+	padding  int
+}
+
+func (f textFilter) Bounds(srcBounds image.Rectangle) image.Rectangle {
+	return srcBounds
+}
+
+func (f textFilter) Draw(dst draw.Image, src image.Image, options *gift.Options) {
+	copySrc(dst, src)
+
+	fnt := f.font
+	b := dst.Bounds()
+	ppem := fixed.Int26_6(f.fontSize * 64)
+	lineHeight := f.fontSize * cardLineHeight
+
+	var buf sfnt.Buffer
+	measure := func(s string) float64 {
+		var width fixed.Int26_6
+		prev := sfnt.GlyphIndex(0)
+		for _, r := range s {
+			x, _ := fnt.GlyphIndex(&buf, r)
+			if prev != 0 {
+				kern, _ := fnt.Kern(&buf, prev, x, ppem, 0)
+				width += kern
+			}
+			advance, _ := fnt.GlyphAdvance(&buf, x, ppem, 0)
+			width += advance
+			prev = x
+		}
+		return float64(width) / 64
+	}
+
+	maxWidth := float64(b.Dx() - 2*f.padding)
+	maxLines := int(float64(b.Dy()-2*f.padding) / lineHeight)
+	if maxLines < 1 {
+		maxLines = 1
+	}
+
+	lines := wrapText(f.text, maxWidth, measure)
+	if len(lines) > maxLines {
+		lines = lines[:maxLines]
+		lines[maxLines-1] += "…"
+	}
+
+	r := vector.NewRasterizer(b.Dx(), b.Dy())
+	r.DrawOp = draw.Over
+
+	// The baseline of the last line, leaving room for the descenders.
+	baseline := float64(b.Dy()-f.padding) - (lineHeight - f.fontSize) - f.fontSize*0.2
+	baseline -= float64(len(lines)-1) * lineHeight
+
+	for _, line := range lines {
+		var dot fixed.Int26_6
+		prev := sfnt.GlyphIndex(0)
+		for _, ru := range line {
+			x, _ := fnt.GlyphIndex(&buf, ru)
+			if prev != 0 {
+				kern, _ := fnt.Kern(&buf, prev, x, ppem, 0)
+				dot += kern
+			}
+			segments, err := fnt.LoadGlyph(&buf, x, ppem, nil)
+			if err == nil {
+				addGlyph(r, segments, float32(f.padding)+float32(dot)/64, float32(baseline))
+			}
+			advance, _ := fnt.GlyphAdvance(&buf, x, ppem, 0)
+			dot += advance
+			prev = x
+		}
+		baseline += lineHeight
+	}
+
+	r.Draw(dst, b, image.NewUniform(f.color), image.Point{})
+}
+
+func addGlyph(r *vector.Rasterizer, segments []sfnt.Segment, x, y float32) {
+	pt := func(p fixed.Point26_6) (float32, float32) {
+		return x + float32(p.X)/64, y + float32(p.Y)/64
+	}
+	for _, seg := range segments {
+		switch seg.Op {
+		case sfnt.SegmentOpMoveTo:
+			r.MoveTo(pt(seg.Args[0]))
+		case sfnt.SegmentOpLineTo:
+			r.LineTo(pt(seg.Args[0]))
+		case sfnt.SegmentOpQuadTo:
+			x1, y1 := pt(seg.Args[0])
+			x2, y2 := pt(seg.Args[1])
+			r.QuadTo(x1, y1, x2, y2)
+		case sfnt.SegmentOpCubeTo:
+			x1, y1 := pt(seg.Args[0])
+			x2, y2 := pt(seg.Args[1])
+			x3, y3 := pt(seg.Args[2])
+			r.CubeTo(x1, y1, x2, y2, x3, y3)
+		}
+	}
+}
+
+// wrapText splits text into lines no wider than maxWidth as reported by
+// measure. Words wider than maxWidth get a line of their own.
+func wrapText(text string, maxWidth float64, measure func(s string) float64) []string {
+	var lines []string
+	var line string
+	for _, word := range strings.Fields(text) {
+		if line == "" {
+			line = word
+			continue
+		}
+		if candidate := line + " " + word; measure(candidate) <= maxWidth {
+			line = candidate
+			continue
+		}
+		lines = append(lines, line)
+		line = word
+	}
+	if line != "" {
+		lines = append(lines, line)
+	}
+	return lines
+}
@@ -0,0 +1,121 @@
+// Copyright 2019 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package images
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"testing"
+
+	"github.com/disintegration/gift"
+	"github.com/gohugoio/hugo/resources/internal"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestDecodeCardOptions(t *testing.T) {
+	c := qt.New(t)
+
+	opts, err := DecodeCardOptions(nil)
+	c.Assert(err, qt.IsNil)
+	c.Assert(opts.FontSize, qt.Equals, float64(64))
+	c.Assert(opts.color, qt.Equals, color.NRGBA{R: 255, G: 255, B: 255, A: 255})
+	c.Assert(opts.gradientEnd, qt.Equals, color.NRGBA{A: 204})
+
+	opts, err = DecodeCardOptions(map[string]interface{}{
+		"color":    "#f00",
+		"fontSize": "48",
+		"padding":  10,
+	})
+	c.Assert(err, qt.IsNil)
+	c.Assert(opts.color, qt.Equals, color.NRGBA{R: 255, A: 255})
+	c.Assert(opts.FontSize, qt.Equals, float64(48))
+	c.Assert(opts.Padding, qt.Equals, 10)
+
+	for _, m := range []map[string]interface{}{
+		{"color": "red"},
+		{"gradientEnd": "#12345"},
+		{"fontSize": 0},
+		{"padding": 400},
+		{"logoHeight": -1},
+	} {
+		_, err = DecodeCardOptions(m)
+		c.Assert(err, qt.Not(qt.IsNil), qt.Commentf("%v", m))
+	}
+}
+
+func TestWrapText(t *testing.T) {
+	c := qt.New(t)
+
+	measure := func(s string) float64 {
+		return float64(len(s))
+	}
+
+	c.Assert(wrapText("The quick brown fox jumps", 10, measure), qt.DeepEquals, []string{"The quick", "brown fox", "jumps"})
+	c.Assert(wrapText("Supercalifragilistic is long", 10, measure), qt.DeepEquals, []string{"Supercalifragilistic", "is long"})
+	c.Assert(wrapText("  ", 10, measure), qt.HasLen, 0)
+}
+
+func TestCardFilters(t *testing.T) {
+	c := qt.New(t)
+
+	opts, err := DecodeCardOptions(map[string]interface{}{
+		"gradientStart": "#000000",
+		"gradientEnd":   "#000000",
+	})
+	c.Assert(err, qt.IsNil)
+
+	logo := image.NewNRGBA(image.Rect(0, 0, 20, 10))
+	draw.Draw(logo, logo.Bounds(), image.NewUniform(color.NRGBA{R: 255, A: 255}), image.Point{}, draw.Src)
+
+	bg := image.NewNRGBA(image.Rect(0, 0, 300, 200))
+
+	cardFilters := func(title string, logo image.Image, logoKey string) []gift.Filter {
+		filters, err := CardFilters(title, logo, logoKey, opts)
+		c.Assert(err, qt.IsNil)
+		return filters
+	}
+
+	render := func(title string) *image.RGBA {
+		g := gift.New(cardFilters(title, logo, "logo.png")...)
+		dst := image.NewRGBA(g.Bounds(bg.Bounds()))
+		g.Draw(dst, bg)
+		return dst
+	}
+
+	card := render("Hugo")
+	c.Assert(card.Bounds(), qt.Equals, image.Rect(0, 0, CardWidth, CardHeight))
+
+	// The logo is scaled to 96 pixels in height.
+	c.Assert(card.RGBAAt(opts.Padding+10, opts.Padding+10), qt.Equals, color.RGBA{R: 255, A: 255})
+	c.Assert(card.RGBAAt(opts.Padding+10, opts.Padding+100), qt.Equals, color.RGBA{A: 255})
+
+	// Some of the title is drawn in white in the bottom left corner.
+	var white int
+	for y := CardHeight / 2; y < CardHeight; y++ {
+		for x := 0; x < CardWidth/2; x++ {
+			if card.RGBAAt(x, y) == (color.RGBA{R: 255, G: 255, B: 255, A: 255}) {
+				white++
+			}
+		}
+	}
+	c.Assert(white > 100, qt.Equals, true)
+
+	// The cache key changes with the title.
+	c.Assert(internal.HashString(cardFilters("Hugo", logo, "logo.png")), qt.Equals, internal.HashString(cardFilters("Hugo", logo, "logo.png")))
+	c.Assert(internal.HashString(cardFilters("Hugo", logo, "logo.png")), qt.Not(qt.Equals), internal.HashString(cardFilters("Gohugo", logo, "logo.png")))
+	c.Assert(internal.HashString(cardFilters("Hugo", logo, "logo.png")), qt.Not(qt.Equals), internal.HashString(cardFilters("Hugo", nil, "")))
+	c.Assert(internal.HashString(cardFilters("Hugo", logo, "logo.png")), qt.Not(qt.Equals), internal.HashString(cardFilters("Hugo", logo, "logo2.png")))
+}
@@ -16,6 +16,7 @@ package images
 import (
 	"errors"
 	"fmt"
+	"math"
 	"strconv"
 	"strings"
 
@@ -29,6 +30,13 @@ const (
 	defaultResampleFilter = "box"
 )
 
+// The valid values of the onUpscale imaging setting.
+const (
+	OnUpscaleIgnore = "ignore"
+	OnUpscaleWarn   = "warn"
+	OnUpscaleError  = "error"
+)
+
 var (
 	imageFormats = map[string]Format{
 		".jpg":  JPEG,
@@ -87,8 +95,24 @@ func ImageFormatFromExt(ext string) (Format, bool) {
 	return f, found
 }
 
+// ImageFormatFromName returns the image format with the given name, e.g.
+// "jpg" or "png", if it is one Hugo can encode.
+func ImageFormatFromName(name string) (Format, bool) {
+	return ImageFormatFromExt("." + strings.ToLower(name))
+}
+
 func DecodeConfig(m map[string]interface{}) (Imaging, error) {
-	var i Imaging
+	return decodeConfig(m, Imaging{})
+}
+
+// DecodeConfigWithDefaults decodes the image processing settings in m on
+// top of defaults, e.g. the imaging params of a bundle resource on top of
+// the site config.
+func DecodeConfigWithDefaults(m map[string]interface{}, defaults Imaging) (Imaging, error) {
+	return decodeConfig(m, defaults)
+}
+
+func decodeConfig(m map[string]interface{}, i Imaging) (Imaging, error) {
 	if err := mapstructure.WeakDecode(m, &i); err != nil {
 		return i, err
 	}
@@ -108,6 +132,15 @@ func DecodeConfig(m map[string]interface{}) (Imaging, error) {
 		}
 	}
 
+	if i.OnUpscale == "" {
+		i.OnUpscale = OnUpscaleIgnore
+	} else {
+		i.OnUpscale = strings.ToLower(i.OnUpscale)
+		if i.OnUpscale != OnUpscaleIgnore && i.OnUpscale != OnUpscaleWarn && i.OnUpscale != OnUpscaleError {
+			return i, fmt.Errorf("%q is not a valid onUpscale value, must be one of %q, %q or %q", i.OnUpscale, OnUpscaleIgnore, OnUpscaleWarn, OnUpscaleError)
+		}
+	}
+
 	if i.ResampleFilter == "" {
 		i.ResampleFilter = defaultResampleFilter
 	} else {
@@ -229,6 +262,70 @@ type ImageConfig struct {
 
 	Anchor    gift.Anchor
 	AnchorStr string
+
+	// The format to encode the new image to. The zero value means the
+	// format of the existing image.
+	TargetFormat Format
+}
+
+// IsUpscale reports whether i would scale up an image with the given
+// width and height.
+func (i ImageConfig) IsUpscale(width, height int) bool {
+	if r := (i.Rotate%360 + 360) % 360; r == 90 || r == 270 {
+		width, height = height, width
+	}
+
+	switch strings.ToLower(i.Action) {
+	case "resize", "fill":
+		return i.Width > width || i.Height > height
+	}
+
+	// Fit never scales up.
+	return false
+}
+
+// TargetSize returns the estimated dimensions of an image with the given
+// width and height processed with i.
+func (i ImageConfig) TargetSize(width, height int) (int, int) {
+	if r := (i.Rotate%360 + 360) % 360; r == 90 || r == 270 {
+		width, height = height, width
+	}
+
+	if width <= 0 || height <= 0 {
+		return i.Width, i.Height
+	}
+
+	scale := func(v int, f float64) int {
+		return int(math.Max(1, math.Floor(float64(v)*f+0.5)))
+	}
+
+	switch strings.ToLower(i.Action) {
+	case "resize":
+		switch {
+		case i.Width == 0 && i.Height == 0:
+			return width, height
+		case i.Width == 0:
+			return scale(width, float64(i.Height)/float64(height)), i.Height
+		case i.Height == 0:
+			return i.Width, scale(height, float64(i.Width)/float64(width))
+		}
+		return i.Width, i.Height
+	case "fit":
+		if width <= i.Width && height <= i.Height {
+			return width, height
+		}
+		f := math.Min(float64(i.Width)/float64(width), float64(i.Height)/float64(height))
+		return scale(width, f), scale(height, f)
+	case "fill":
+		return i.Width, i.Height
+	case "filter":
+		// The size of the filtered image, if known.
+		if i.Width > 0 && i.Height > 0 {
+			return i.Width, i.Height
+		}
+	}
+
+	return width, height
 }
 
 func (i ImageConfig) GetKey(format Format) string {
@@ -279,4 +376,8 @@ type Imaging struct {
 
 	// The anchor to use in Fill. Default is "smart", i.e. Smart Crop.
 	Anchor string
+
+	// What to do when Resize or Fill would scale up an image: "ignore",
+	// "warn" or "error". Default is "ignore".
+	OnUpscale string
 }
@@ -64,6 +64,40 @@ func TestDecodeConfig(t *testing.T) {
 	})
 	c.Assert(err, qt.IsNil)
 	c.Assert(imaging.Anchor, qt.Equals, "smart")
+	c.Assert(imaging.OnUpscale, qt.Equals, OnUpscaleIgnore)
+
+	imaging, err = DecodeConfig(map[string]interface{}{
+		"onUpscale": "Warn",
+	})
+	c.Assert(err, qt.IsNil)
+	c.Assert(imaging.OnUpscale, qt.Equals, OnUpscaleWarn)
+
+	_, err = DecodeConfig(map[string]interface{}{
+		"onUpscale": "asdf",
+	})
+	c.Assert(err, qt.Not(qt.IsNil))
+}
+
+func TestImageConfigIsUpscale(t *testing.T) {
+	c := qt.New(t)
+
+	for _, test := range []struct {
+		spec   string
+		action string
+		expect bool
+	}{
+		{"300x", "resize", false},
+		{"1000x", "resize", true},
+		{"x600", "resize", true},
+		{"x600 r90", "resize", false},
+		{"900x562", "fill", false},
+		{"1000x100", "fill", true},
+		{"2000x2000", "fit", false},
+	} {
+		conf, err := DecodeImageConfig(test.action, test.spec, Imaging{})
+		c.Assert(err, qt.IsNil)
+		c.Assert(conf.IsUpscale(900, 562), qt.Equals, test.expect, qt.Commentf("%s %s", test.action, test.spec))
+	}
 }
 
 func TestDecodeImageConfig(t *testing.T) {
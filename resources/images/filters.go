@@ -15,6 +15,10 @@
 package images
 
 import (
+	"image"
+	"image/color"
+	"image/draw"
+
 	"github.com/disintegration/gift"
 	"github.com/spf13/cast"
 )
@@ -25,6 +29,17 @@ const filterAPIVersion = 0
 type Filters struct {
 }
 
+// AutoContrast creates a filter that stretches the histogram of an image,
+// e.g. a scanned or a poorly exposed photo, to cover the full tonal range.
+// The cutoff parameter is the percentage of the darkest and the lightest
+// pixels to ignore when finding the range to stretch, typically in range (0, 5).
+func (*Filters) AutoContrast(cutoff interface{}) gift.Filter {
+	return filter{
+		Options: newFilterOpts(cutoff),
+		Filter:  autoContrastFilter{cutoff: cast.ToFloat64(cutoff)},
+	}
+}
+
 // Brightness creates a filter that changes the brightness of an image.
 // The percentage parameter must be in range (-100, 100).
 func (*Filters) Brightness(percentage interface{}) gift.Filter {
@@ -63,6 +78,31 @@ func (*Filters) Contrast(percentage interface{}) gift.Filter {
 	}
 }
 
+// Duotone creates a filter that maps the shadows of an image to the
+// shadow color and the highlights to the highlight color, with the tones
+// in between blended from the two. The colors are hex colors, e.g. "#1e3264".
+func (*Filters) Duotone(shadow, highlight interface{}) (gift.Filter, error) {
+	s, err := parseHexColor(cast.ToString(shadow))
+	if err != nil {
+		return nil, err
+	}
+	h, err := parseHexColor(cast.ToString(highlight))
+	if err != nil {
+		return nil, err
+	}
+
+	sr, sg, sb := colorToFloats(s)
+	hr, hg, hb := colorToFloats(h)
+
+	return filter{
+		Options: newFilterOpts(shadow, highlight),
+		Filter: gift.ColorFunc(func(r, g, b, a float32) (float32, float32, float32, float32) {
+			l := luminance(r, g, b)
+			return sr + (hr-sr)*l, sg + (hg-sg)*l, sb + (hb-sb)*l, a
+		}),
+	}, nil
+}
+
 // Gamma creates a filter that performs a gamma correction on an image.
 // The gamma parameter must be positive. Gamma = 1 gives the original image.
 // Gamma less than 1 darkens the image and gamma greater than 1 lightens it.
@@ -137,6 +177,31 @@ func (*Filters) Sigmoid(midpoint, factor interface{}) gift.Filter {
 	}
 }
 
+// Tint creates a filter that blends the colors of an image with the given
+// hex color, e.g. "#ff6600".
+// The percentage parameter specifies the strength of the effect, it must be in range (0, 100).
+func (*Filters) Tint(tint, percentage interface{}) (gift.Filter, error) {
+	c, err := parseHexColor(cast.ToString(tint))
+	if err != nil {
+		return nil, err
+	}
+
+	cr, cg, cb := colorToFloats(c)
+	p := cast.ToFloat32(percentage) / 100
+	if p < 0 {
+		p = 0
+	} else if p > 1 {
+		p = 1
+	}
+
+	return filter{
+		Options: newFilterOpts(tint, percentage),
+		Filter: gift.ColorFunc(func(r, g, b, a float32) (float32, float32, float32, float32) {
+			return r + (cr-r)*p, g + (cg-g)*p, b + (cb-b)*p, a
+		}),
+	}, nil
+}
+
 // UnsharpMask creates a filter that sharpens an image.
 // The sigma parameter is used in a gaussian function and affects the radius of effect.
 // Sigma must be positive. Sharpen radius roughly equals 3 * sigma.
@@ -149,6 +214,74 @@ func (*Filters) UnsharpMask(sigma, amount, threshold interface{}) gift.Filter {
 	}
 }
 
+type autoContrastFilter struct {
+	cutoff float64
+}
+
+func (f autoContrastFilter) Bounds(srcBounds image.Rectangle) image.Rectangle {
+	return srcBounds
+}
+
+func (f autoContrastFilter) Draw(dst draw.Image, src image.Image, options *gift.Options) {
+	img := image.NewNRGBA(image.Rect(0, 0, src.Bounds().Dx(), src.Bounds().Dy()))
+	draw.Draw(img, img.Bounds(), src, src.Bounds().Min, draw.Src)
+
+	// Build the luminance histogram of the visible pixels.
+	var (
+		hist  [256]int
+		total int
+	)
+	for i := 0; i < len(img.Pix); i += 4 {
+		if img.Pix[i+3] == 0 {
+			continue
+		}
+		hist[(299*int(img.Pix[i])+587*int(img.Pix[i+1])+114*int(img.Pix[i+2]))/1000]++
+		total++
+	}
+
+	cut := int(float64(total) * f.cutoff / 100)
+	low, high := 0, 255
+	for count := 0; low < 255; low++ {
+		if count += hist[low]; count > cut {
+			break
+		}
+	}
+	for count := 0; high > 0; high-- {
+		if count += hist[high]; count > cut {
+			break
+		}
+	}
+
+	if high > low {
+		var lut [256]uint8
+		for i := range lut {
+			v := (i - low) * 255 / (high - low)
+			if v < 0 {
+				v = 0
+			} else if v > 255 {
+				v = 255
+			}
+			lut[i] = uint8(v)
+		}
+		for i := 0; i < len(img.Pix); i += 4 {
+			img.Pix[i] = lut[img.Pix[i]]
+			img.Pix[i+1] = lut[img.Pix[i+1]]
+			img.Pix[i+2] = lut[img.Pix[i+2]]
+		}
+	}
+
+	draw.Draw(dst, dst.Bounds(), img, image.Point{}, draw.Src)
+}
+
+func colorToFloats(c color.NRGBA) (r, g, b float32) {
+	return float32(c.R) / 255, float32(c.G) / 255, float32(c.B) / 255
+}
+
+// luminance returns the relative luminance of the given color.
+func luminance(r, g, b float32) float32 {
+	return 0.299*r + 0.587*g + 0.114*b
+}
+
 type filter struct {
 	Options filterOpts
 	gift.Filter
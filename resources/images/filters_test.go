@@ -0,0 +1,94 @@
+// Copyright 2019 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package images
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/disintegration/gift"
+	"github.com/gohugoio/hugo/resources/internal"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestColorFilters(t *testing.T) {
+	c := qt.New(t)
+	f := &Filters{}
+
+	src := image.NewNRGBA(image.Rect(0, 0, 3, 1))
+	src.SetNRGBA(0, 0, color.NRGBA{A: 255})
+	src.SetNRGBA(1, 0, color.NRGBA{R: 255, G: 255, B: 255, A: 255})
+	src.SetNRGBA(2, 0, color.NRGBA{R: 255, G: 255, B: 255, A: 128})
+
+	apply := func(filter gift.Filter) *image.NRGBA {
+		g := gift.New(filter)
+		dst := image.NewNRGBA(g.Bounds(src.Bounds()))
+		g.Draw(dst, src)
+		return dst
+	}
+
+	duotone, err := f.Duotone("#1e3264", "#ffcc00")
+	c.Assert(err, qt.IsNil)
+	dst := apply(duotone)
+	c.Assert(dst.NRGBAAt(0, 0), qt.Equals, color.NRGBA{R: 0x1e, G: 0x32, B: 0x64, A: 255})
+	c.Assert(dst.NRGBAAt(1, 0), qt.Equals, color.NRGBA{R: 0xff, G: 0xcc, B: 0x00, A: 255})
+	c.Assert(dst.NRGBAAt(2, 0).A, qt.Equals, uint8(128))
+
+	tint, err := f.Tint("#ff0000", 50)
+	c.Assert(err, qt.IsNil)
+	dst = apply(tint)
+	c.Assert(dst.NRGBAAt(0, 0), qt.Equals, color.NRGBA{R: 128, A: 255})
+	c.Assert(dst.NRGBAAt(1, 0), qt.Equals, color.NRGBA{R: 255, G: 128, B: 128, A: 255})
+
+	_, err = f.Duotone("blue", "#fff")
+	c.Assert(err, qt.Not(qt.IsNil))
+	_, err = f.Tint("#ff00", 50)
+	c.Assert(err, qt.Not(qt.IsNil))
+
+	tint2, _ := f.Tint("#ff0000", 60)
+	c.Assert(internal.HashString(tint), qt.Not(qt.Equals), internal.HashString(tint2))
+}
+
+func TestAutoContrast(t *testing.T) {
+	c := qt.New(t)
+	f := &Filters{}
+
+	// A low contrast gradient from gray level 100 to 149.
+	src := image.NewNRGBA(image.Rect(0, 0, 50, 2))
+	for x := 0; x < 50; x++ {
+		src.SetNRGBA(x, 0, color.NRGBA{R: uint8(100 + x), G: uint8(100 + x), B: uint8(100 + x), A: 255})
+		src.SetNRGBA(x, 1, color.NRGBA{R: uint8(100 + x), G: uint8(100 + x), B: uint8(100 + x), A: 255})
+	}
+
+	apply := func(filter gift.Filter) *image.NRGBA {
+		g := gift.New(filter)
+		dst := image.NewNRGBA(g.Bounds(src.Bounds()))
+		g.Draw(dst, src)
+		return dst
+	}
+
+	dst := apply(f.AutoContrast(0))
+	c.Assert(dst.NRGBAAt(0, 0), qt.Equals, color.NRGBA{A: 255})
+	c.Assert(dst.NRGBAAt(49, 1), qt.Equals, color.NRGBA{R: 255, G: 255, B: 255, A: 255})
+	c.Assert(dst.NRGBAAt(25, 0).R, qt.Equals, uint8(130))
+
+	// Ignore the 10% darkest and lightest pixels.
+	dst = apply(f.AutoContrast(10))
+	c.Assert(dst.NRGBAAt(5, 0), qt.Equals, color.NRGBA{A: 255})
+	c.Assert(dst.NRGBAAt(44, 0), qt.Equals, color.NRGBA{R: 255, G: 255, B: 255, A: 255})
+
+	c.Assert(internal.HashString(f.AutoContrast(0)), qt.Not(qt.Equals), internal.HashString(f.AutoContrast(1)))
+}
@@ -106,6 +106,25 @@ func (i *Image) Width() int {
 	return i.config.Width
 }
 
+// AspectRatio returns i's width divided by its height, or 0 if the
+// height is unknown.
+func (i *Image) AspectRatio() float64 {
+	if i.Height() == 0 {
+		return 0
+	}
+	return float64(i.Width()) / float64(i.Height())
+}
+
+// IsPortrait returns whether i is taller than it is wide.
+func (i *Image) IsPortrait() bool {
+	return i.Height() > i.Width()
+}
+
+// IsLandscape returns whether i is wider than it is tall.
+func (i *Image) IsLandscape() bool {
+	return i.Width() > i.Height()
+}
+
 func (i Image) WithImage(img image.Image) *Image {
 	i.Spec = nil
 	i.imageConfig = &imageConfig{
@@ -116,6 +135,18 @@ func (i Image) WithImage(img image.Image) *Image {
 	return &i
 }
 
+// WithSize returns a copy of i with the given dimensions, used for images
+// that are not processed.
+func (i Image) WithSize(width, height int) *Image {
+	i.Spec = nil
+	i.imageConfig = &imageConfig{
+		config:       image.Config{Width: width, Height: height},
+		configLoaded: true,
+	}
+
+	return &i
+}
+
 func (i Image) WithSpec(s Spec) *Image {
 	i.Spec = s
 	i.imageConfig = &imageConfig{}
@@ -222,6 +253,20 @@ const (
 	BMP
 )
 
+var formatExtensions = map[Format]string{
+	JPEG: ".jpg",
+	PNG:  ".png",
+	GIF:  ".gif",
+	TIFF: ".tif",
+	BMP:  ".bmp",
+}
+
+// DefaultExtension returns the file extension, with the leading dot, used
+// for images encoded to f.
+func (f Format) DefaultExtension() string {
+	return formatExtensions[f]
+}
+
 type imageConfig struct {
 	config       image.Config
 	configInit   sync.Once
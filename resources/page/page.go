@@ -73,11 +73,16 @@ type ContentProvider interface {
 	Plain() string
 	PlainWords() []string
 	Summary() template.HTML
+	PlainSummary() string
 	Truncated() bool
 	FuzzyWordCount() int
 	WordCount() int
 	ReadingTime() int
 	Len() int
+
+	// Stats returns statistics about the rendered content, e.g. the
+	// number of words and code blocks.
+	Stats() ContentStats
 }
 
 // FileProvider provides the source file.
@@ -109,6 +114,7 @@ type InSectionPositioner interface {
 // InternalDependencies is considered an internal interface.
 type InternalDependencies interface {
 	GetRelatedDocsHandler() *RelatedDocsHandler
+	GetPageIndexHandler() *PageIndexHandler
 }
 
 // OutputFormatsProvider provides the OutputFormats of a Page.
@@ -226,6 +232,9 @@ type PageWithoutContent interface {
 	ChildCareProvider
 	TreeProvider
 
+	// Taxonomy terms
+	TermsProvider
+
 	// Horizontal navigation
 	InSectionPositioner
 	PageRenderProvider
@@ -249,11 +258,20 @@ type PageWithoutContent interface {
 	ShortcodeInfoProvider
 	compare.Eqer
 	maps.Scratcher
+	StoreProvider
 	RelatedKeywordsProvider
 
 	DeprecatedWarningPageMethods
 }
 
+// StoreProvider provides a store that lives for the whole build.
+type StoreProvider interface {
+	// Store returns a Scratch that, unlike .Scratch, is never reset
+	// between builds or output formats. It is safe to use from
+	// templates executed in parallel.
+	Store() *maps.Scratch
+}
+
 // Positioner provides next/prev navigation.
 type Positioner interface {
 	Next() Page
@@ -318,9 +336,22 @@ type TranslationsProvider interface {
 	Translations() Pages
 }
 
+// TermsProvider provides the taxonomy terms of a Page.
+type TermsProvider interface {
+	// GetTerms returns the taxonomy term pages of this page for the given
+	// taxonomy, e.g. GetTerms("tags"), in the order they are set in front matter.
+	GetTerms(taxonomy string) Pages
+}
+
 // TreeProvider provides section tree navigation.
 type TreeProvider interface {
 
+	// Ancestors returns the page's ancestor sections, nearest first and
+	// ending with the home page.
+	// Note that this will return an empty list for the home page and for
+	// pages that is not regular or section pages.
+	Ancestors() Pages
+
 	// IsAncestor returns whether the current page is an ancestor of the given
 	// Note that this method is not relevant for taxonomy lists and taxonomy terms pages.
 	IsAncestor(other interface{}) (bool, error)
@@ -0,0 +1,31 @@
+// Copyright 2019 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package page
+
+// ContentStats holds statistics about the rendered content of a Page. It
+// is calculated once per output format when the content is rendered.
+type ContentStats struct {
+	// The number of words. Each CJK character counts as a word if the
+	// page's language is a CJK language, see hasCJKLanguage.
+	Words int
+
+	// The number of characters, not counting whitespace.
+	Characters int
+
+	// The estimated reading time in minutes, see wordsPerMinute.
+	ReadingTime int
+
+	// The number of code blocks.
+	CodeBlocks int
+}
@@ -101,6 +101,7 @@ func generateMarshalJSON(c *codegen.Inspector) error {
 		reflect.TypeOf((*page.InSectionPositioner)(nil)).Elem(),
 		reflect.TypeOf((*page.PaginatorProvider)(nil)).Elem(),
 		reflect.TypeOf((*maps.Scratcher)(nil)).Elem(),
+		reflect.TypeOf((*page.StoreProvider)(nil)).Elem(),
 	}
 
 	methods := c.MethodsFromTypes(
@@ -35,11 +35,13 @@ func MarshalPageToJSON(p Page) ([]byte, error) {
 	plain := p.Plain()
 	plainWords := p.PlainWords()
 	summary := p.Summary()
+	plainSummary := p.PlainSummary()
 	truncated := p.Truncated()
 	fuzzyWordCount := p.FuzzyWordCount()
 	wordCount := p.WordCount()
 	readingTime := p.ReadingTime()
 	length := p.Len()
+	stats := p.Stats()
 	tableOfContents := p.TableOfContents()
 	rawContent := p.RawContent()
 	mediaType := p.MediaType()
@@ -91,11 +93,13 @@ func MarshalPageToJSON(p Page) ([]byte, error) {
 		Plain                    string
 		PlainWords               []string
 		Summary                  template.HTML
+		PlainSummary             string
 		Truncated                bool
 		FuzzyWordCount           int
 		WordCount                int
 		ReadingTime              int
 		Len                      int
+		Stats                    ContentStats
 		TableOfContents          template.HTML
 		RawContent               string
 		MediaType                media.Type
@@ -146,11 +150,13 @@ func MarshalPageToJSON(p Page) ([]byte, error) {
 		Plain:                    plain,
 		PlainWords:               plainWords,
 		Summary:                  summary,
+		PlainSummary:             plainSummary,
 		Truncated:                truncated,
 		FuzzyWordCount:           fuzzyWordCount,
 		WordCount:                wordCount,
 		ReadingTime:              readingTime,
 		Len:                      length,
+		Stats:                    stats,
 		TableOfContents:          tableOfContents,
 		RawContent:               rawContent,
 		MediaType:                mediaType,
@@ -75,6 +75,14 @@ func (p *nopPage) LanguagePrefix() string {
 	return ""
 }
 
+func (p *nopPage) Ancestors() Pages {
+	return nil
+}
+
+func (p *nopPage) GetTerms(taxonomy string) Pages {
+	return nil
+}
+
 func (p *nopPage) AlternativeOutputFormats() OutputFormats {
 	return nil
 }
@@ -160,6 +168,10 @@ func (p *nopPage) FirstSection() Page {
 	return nil
 }
 
+func (p *nopPage) Stats() (stats ContentStats) {
+	return
+}
+
 func (p *nopPage) FuzzyWordCount() int {
 	return 0
 }
@@ -324,6 +336,10 @@ func (p *nopPage) Plain() string {
 	return ""
 }
 
+func (p *nopPage) PlainSummary() string {
+	return ""
+}
+
 func (p *nopPage) PlainWords() []string {
 	return nil
 }
@@ -387,6 +403,10 @@ func (p *nopPage) Scratch() *maps.Scratch {
 	return nil
 }
 
+func (p *nopPage) Store() *maps.Scratch {
+	return nil
+}
+
 func (p *nopPage) RelatedKeywords(cfg related.IndexConfig) ([]related.Keyword, error) {
 	return nil, nil
 }
@@ -65,6 +65,10 @@ type TargetPathDescriptor struct {
 	// Used to create paginator links.
 	Addends string
 
+	// The path element used in paginator links, e.g. "page". Falls back to
+	// the paginatePath set in the site config if empty.
+	PaginatePath string
+
 	// The expanded permalink if defined for the section, ready to use.
 	ExpandedPermalink string
 
@@ -97,12 +97,18 @@ var (
 )
 
 // GroupBy groups by the value in the given field or method name and with the given order.
+// The key can also be a path into the page Params prefixed with "Params.",
+// e.g. "Params.author.name", which is the same as calling GroupByParam.
 // Valid values for order is asc, desc, rev and reverse.
 func (p Pages) GroupBy(key string, order ...string) (PagesGroup, error) {
 	if len(p) < 1 {
 		return nil, nil
 	}
 
+	if strings.HasPrefix(strings.ToLower(key), paramsKeyPrefix) {
+		return p.GroupByParam(key[len(paramsKeyPrefix):], order...)
+	}
+
 	direction := "asc"
 
 	if len(order) > 0 && (strings.ToLower(order[0]) == "desc" || strings.ToLower(order[0]) == "rev" || strings.ToLower(order[0]) == "reverse") {
@@ -165,6 +171,7 @@ func (p Pages) GroupBy(key string, order ...string) (PagesGroup, error) {
 }
 
 // GroupByParam groups by the given page parameter key's value and with the given order.
+// The key may be a path to a nested value, e.g. "author.name".
 // Valid values for order is asc, desc, rev and reverse.
 func (p Pages) GroupByParam(key string, order ...string) (PagesGroup, error) {
 	if len(p) < 1 {
@@ -185,6 +185,30 @@ func TestGroupByParam(t *testing.T) {
 	}
 }
 
+func TestGroupByNestedParam(t *testing.T) {
+	t.Parallel()
+	c := qt.New(t)
+	pages := preparePageGroupTestPages(t)
+	for _, p := range pages {
+		p.Params()["author"] = map[string]interface{}{
+			"name": p.Params()["custom_param"],
+		}
+	}
+	expect := PagesGroup{
+		{Key: "bar", Pages: Pages{pages[1], pages[3]}},
+		{Key: "baz", Pages: Pages{pages[4]}},
+		{Key: "foo", Pages: Pages{pages[0], pages[2]}},
+	}
+
+	groups, err := pages.GroupByParam("author.name")
+	c.Assert(err, qt.IsNil)
+	c.Assert(reflect.DeepEqual(groups, expect), qt.Equals, true)
+
+	groups, err = pages.GroupBy("Params.author.name")
+	c.Assert(err, qt.IsNil)
+	c.Assert(reflect.DeepEqual(groups, expect), qt.Equals, true)
+}
+
 func TestGroupByParamInReverseOrder(t *testing.T) {
 	t.Parallel()
 	pages := preparePageGroupTestPages(t)
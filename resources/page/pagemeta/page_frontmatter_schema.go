@@ -0,0 +1,242 @@
+// Copyright 2019 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pagemeta
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gohugoio/hugo/config"
+	"github.com/mitchellh/mapstructure"
+	"github.com/pkg/errors"
+	"github.com/spf13/cast"
+)
+
+// The severities of front matter schema violations.
+const (
+	FrontMatterSchemaSeverityWarning = "warning"
+	FrontMatterSchemaSeverityError   = "error"
+)
+
+// The content type whose rules apply to all pages.
+const frontMatterSchemaDefaultType = "_default"
+
+var frontMatterSchemaFieldTypes = map[string]bool{
+	"string": true,
+	"int":    true,
+	"float":  true,
+	"bool":   true,
+	"date":   true,
+	"slice":  true,
+	"map":    true,
+}
+
+// FrontMatterSchema validates the front matter of pages against the rules
+// set in frontmatter.schema, keyed by content type, i.e. the page's type
+// front matter field or its section, e.g.:
+//
+//	[frontmatter]
+//	schemaSeverity = "error"
+//	[frontmatter.schema.posts]
+//	required = ["description", "date"]
+//	[frontmatter.schema.posts.types]
+//	description = "string"
+//	tags = "slice"
+//
+// The rules for the "_default" content type apply to all pages.
+type FrontMatterSchema struct {
+	severity string
+	rules    map[string]frontMatterRules
+}
+
+type frontMatterRules struct {
+	Required []string
+	Types    map[string]string
+}
+
+// FrontMatterViolation describes a front matter field that does not
+// follow the schema.
+type FrontMatterViolation struct {
+	// The front matter key, lower case.
+	Key string
+
+	// Set if the field is present, but of the wrong type.
+	Present bool
+
+	Message string
+}
+
+// NewFrontMatterSchema creates a new FrontMatterSchema from the given
+// configuration. It returns nil if no schema is configured.
+func NewFrontMatterSchema(cfg config.Provider) (*FrontMatterSchema, error) {
+	if !cfg.IsSet("frontmatter.schema") {
+		return nil, nil
+	}
+
+	s := &FrontMatterSchema{
+		severity: strings.ToLower(cfg.GetString("frontmatter.schemaSeverity")),
+		rules:    make(map[string]frontMatterRules),
+	}
+
+	switch s.severity {
+	case "":
+		s.severity = FrontMatterSchemaSeverityWarning
+	case FrontMatterSchemaSeverityWarning, FrontMatterSchemaSeverityError:
+	default:
+		return nil, errors.Errorf("invalid frontmatter.schemaSeverity %q, must be either %q or %q", s.severity, FrontMatterSchemaSeverityWarning, FrontMatterSchemaSeverityError)
+	}
+
+	for contentType, v := range cfg.GetStringMap("frontmatter.schema") {
+		var rules frontMatterRules
+		if err := mapstructure.WeakDecode(v, &rules); err != nil {
+			return nil, errors.Wrapf(err, "failed to decode frontmatter.schema.%s", contentType)
+		}
+
+		for i, key := range rules.Required {
+			rules.Required[i] = strings.ToLower(key)
+		}
+
+		types := make(map[string]string)
+		for key, typ := range rules.Types {
+			typ = strings.ToLower(typ)
+			if !frontMatterSchemaFieldTypes[typ] {
+				return nil, errors.Errorf("invalid type %q for %q in frontmatter.schema.%s, must be one of string, int, float, bool, date, slice or map", typ, key, contentType)
+			}
+			types[strings.ToLower(key)] = typ
+		}
+		rules.Types = types
+
+		s.rules[strings.ToLower(contentType)] = rules
+	}
+
+	return s, nil
+}
+
+// Severity returns the severity of the violations, either
+// FrontMatterSchemaSeverityWarning or FrontMatterSchemaSeverityError.
+func (s *FrontMatterSchema) Severity() string {
+	return s.severity
+}
+
+// Validate validates the front matter of a page of the given content type
+// and returns the violations, ordered by key. The rules for the content
+// type are merged with the "_default" rules, so a key required by both is
+// only reported once, and its field type overrides the default one.
+func (s *FrontMatterSchema) Validate(contentType string, frontmatter map[string]interface{}) []FrontMatterViolation {
+	if s == nil {
+		return nil
+	}
+
+	fm := make(map[string]interface{})
+	for k, v := range frontmatter {
+		fm[strings.ToLower(k)] = v
+	}
+
+	required := make(map[string]bool)
+	types := make(map[string]string)
+
+	for _, t := range []string{frontMatterSchemaDefaultType, strings.ToLower(contentType)} {
+		rules, found := s.rules[t]
+		if !found {
+			continue
+		}
+		for _, key := range rules.Required {
+			required[key] = true
+		}
+		for key, typ := range rules.Types {
+			types[key] = typ
+		}
+	}
+
+	var violations []FrontMatterViolation
+
+	for key := range required {
+		if v, found := fm[key]; !found || v == nil || v == "" {
+			violations = append(violations, FrontMatterViolation{
+				Key:     key,
+				Message: fmt.Sprintf("required front matter field %q is missing", key),
+			})
+		}
+	}
+
+	for key, typ := range types {
+		v, found := fm[key]
+		if !found || isFrontMatterType(v, typ) {
+			continue
+		}
+		violations = append(violations, FrontMatterViolation{
+			Key:     key,
+			Present: true,
+			Message: fmt.Sprintf("front matter field %q must be of type %s, got %T", key, typ, v),
+		})
+	}
+
+	sort.Slice(violations, func(i, j int) bool {
+		if violations[i].Key == violations[j].Key {
+			// A missing field first.
+			return !violations[i].Present
+		}
+		return violations[i].Key < violations[j].Key
+	})
+
+	return violations
+}
+
+func isFrontMatterType(v interface{}, typ string) bool {
+	switch typ {
+	case "string":
+		_, ok := v.(string)
+		return ok
+	case "int":
+		switch vv := v.(type) {
+		case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+			return true
+		case float64:
+			// JSON decodes all numbers as float64.
+			return vv == math.Trunc(vv) && !math.IsInf(vv, 0)
+		case float32:
+			return float64(vv) == math.Trunc(float64(vv)) && !math.IsInf(float64(vv), 0)
+		}
+	case "float":
+		switch v.(type) {
+		case float32, float64, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+			return true
+		}
+	case "bool":
+		_, ok := v.(bool)
+		return ok
+	case "date":
+		switch vv := v.(type) {
+		case time.Time:
+			return true
+		case string:
+			_, err := cast.ToTimeE(vv)
+			return err == nil
+		}
+	case "slice":
+		switch v.(type) {
+		case []interface{}, []string, []map[string]interface{}:
+			return true
+		}
+	case "map":
+		switch v.(type) {
+		case map[string]interface{}, map[interface{}]interface{}:
+			return true
+		}
+	}
+	return false
+}
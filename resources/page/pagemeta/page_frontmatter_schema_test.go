@@ -0,0 +1,107 @@
+// Copyright 2019 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pagemeta
+
+import (
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestFrontMatterSchema(t *testing.T) {
+	t.Parallel()
+
+	c := qt.New(t)
+
+	cfg := viper.New()
+
+	s, err := NewFrontMatterSchema(cfg)
+	c.Assert(err, qt.IsNil)
+	c.Assert(s, qt.IsNil)
+	c.Assert(s.Validate("posts", nil), qt.HasLen, 0)
+
+	cfg.Set("frontmatter", map[string]interface{}{
+		"schema": map[string]interface{}{
+			"_default": map[string]interface{}{
+				"required": []string{"title"},
+				"types": map[string]interface{}{
+					"weight": "float",
+				},
+			},
+			"posts": map[string]interface{}{
+				// title is also required by _default, but only reported once.
+				"required": []string{"Description", "date", "title"},
+				"types": map[string]interface{}{
+					"description": "string",
+					"date":        "date",
+					"weight":      "int",
+					"tags":        "slice",
+					"draft":       "bool",
+					"ratio":       "float",
+					"author":      "map",
+				},
+			},
+		},
+	})
+
+	s, err = NewFrontMatterSchema(cfg)
+	c.Assert(err, qt.IsNil)
+	c.Assert(s.Severity(), qt.Equals, FrontMatterSchemaSeverityWarning)
+
+	c.Assert(s.Validate("posts", map[string]interface{}{
+		"Title":       "Post",
+		"description": "The post",
+		"date":        time.Now(),
+		"weight":      32,
+		"tags":        []interface{}{"a"},
+		"draft":       false,
+		"ratio":       1,
+		"author":      map[string]interface{}{"name": "Jo"},
+	}), qt.HasLen, 0)
+
+	c.Assert(s.Validate("posts", map[string]interface{}{
+		"date":   "2019-12-01",
+		"weight": "32",
+		"tags":   "a",
+	}), qt.DeepEquals, []FrontMatterViolation{
+		{Key: "description", Message: `required front matter field "description" is missing`},
+		{Key: "tags", Present: true, Message: `front matter field "tags" must be of type slice, got string`},
+		{Key: "title", Message: `required front matter field "title" is missing`},
+		{Key: "weight", Present: true, Message: `front matter field "weight" must be of type int, got string`},
+	})
+
+	c.Assert(s.Validate("docs", map[string]interface{}{"date": "not a date"}), qt.HasLen, 1)
+	c.Assert(s.Validate("docs", map[string]interface{}{"title": "D", "weight": 1.5}), qt.HasLen, 0)
+	c.Assert(s.Validate("posts", map[string]interface{}{"title": "P", "description": "P", "date": "2019-12-01", "weight": 1.5}), qt.HasLen, 1)
+	// JSON front matter decodes all numbers as float64.
+	c.Assert(s.Validate("posts", map[string]interface{}{"title": "P", "description": "P", "date": "2019-12-01", "weight": float64(32)}), qt.HasLen, 0)
+	c.Assert(s.Validate("posts", map[string]interface{}{"title": "P", "description": "", "date": "not a date"}), qt.HasLen, 2)
+
+	cfg.Set("frontmatter.schemaSeverity", "error")
+	s, err = NewFrontMatterSchema(cfg)
+	c.Assert(err, qt.IsNil)
+	c.Assert(s.Severity(), qt.Equals, FrontMatterSchemaSeverityError)
+
+	cfg.Set("frontmatter.schemaSeverity", "fatal")
+	_, err = NewFrontMatterSchema(cfg)
+	c.Assert(err, qt.Not(qt.IsNil))
+
+	cfg.Set("frontmatter.schemaSeverity", "")
+	cfg.Set("frontmatter.schema.posts.types", map[string]interface{}{"weight": "number"})
+	_, err = NewFrontMatterSchema(cfg)
+	c.Assert(err, qt.Not(qt.IsNil))
+}
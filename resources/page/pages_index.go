@@ -0,0 +1,213 @@
+// Copyright 2019 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package page
+
+import (
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/gohugoio/hugo/common/maps"
+	"github.com/pkg/errors"
+	"github.com/spf13/cast"
+)
+
+// WhereParam returns the pages with the given value for the param key, e.g.
+// {{ site.RegularPages.WhereParam "category" "go" }}. If the param is a list,
+// e.g. tags, it is enough that one of its values matches. Nested params can
+// be given with a dot, e.g. "author.name".
+//
+// The key must be listed in pageIndexes in the site config. The index of a
+// page collection is built on first use and then shared by all templates,
+// which makes this much cheaper than where for lookups done on every page.
+func (p Pages) WhereParam(key string, value interface{}) (Pages, error) {
+	if len(p) == 0 {
+		return nil, nil
+	}
+
+	d, ok := p[0].(InternalDependencies)
+	if !ok {
+		return nil, errors.Errorf("invalid type %T in WhereParam", p[0])
+	}
+
+	key = strings.ToLower(key)
+
+	idx, err := d.GetPageIndexHandler().getOrCreateIndex(key, p)
+	if err != nil {
+		return nil, err
+	}
+
+	v, err := cast.ToStringE(value)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid value for param %q in WhereParam", key)
+	}
+
+	// The cached slices are shared, so make sure that appending to the
+	// result always allocates a new slice.
+	result := idx[v]
+	return result[:len(result):len(result)], nil
+}
+
+// pageIndex maps a param value to the pages with that value.
+type pageIndex map[string]Pages
+
+type cachedPageIndex struct {
+	p Pages
+
+	indices map[string]pageIndex
+}
+
+// pagesKey is a cheap fingerprint of a page collection, so only collections
+// that are likely to be equal need to be compared page by page.
+type pagesKey struct {
+	len         int
+	first, last Page
+}
+
+func newPagesKey(p Pages) pagesKey {
+	return pagesKey{len: len(p), first: p[0], last: p[len(p)-1]}
+}
+
+// PageIndexHandler holds the param indices for the page collections queried
+// with WhereParam.
+type PageIndexHandler struct {
+	keys map[string]bool
+
+	indices map[pagesKey][]*cachedPageIndex
+	mu      sync.RWMutex
+}
+
+// NewPageIndexHandler creates a new PageIndexHandler for the given param keys.
+func NewPageIndexHandler(keys []string) *PageIndexHandler {
+	m := make(map[string]bool)
+	for _, k := range keys {
+		m[strings.ToLower(k)] = true
+	}
+	return &PageIndexHandler{keys: m, indices: make(map[pagesKey][]*cachedPageIndex)}
+}
+
+func (s *PageIndexHandler) Clone() *PageIndexHandler {
+	return &PageIndexHandler{keys: s.keys, indices: make(map[pagesKey][]*cachedPageIndex)}
+}
+
+// This assumes that a lock has been acquired.
+// p must not be empty.
+func (s *PageIndexHandler) getIndex(key string, p Pages) (*cachedPageIndex, pageIndex) {
+	candidates := s.indices[newPagesKey(p)]
+
+	// Most lookups are on the same collection, e.g. .Site.RegularPages,
+	// so look for the same slice before comparing the pages.
+	for _, ci := range candidates {
+		if &ci.p[0] == &p[0] {
+			return ci, ci.indices[key]
+		}
+	}
+
+	for _, ci := range candidates {
+		if pagesEqual(p, ci.p) {
+			return ci, ci.indices[key]
+		}
+	}
+
+	return nil, nil
+}
+
+func (s *PageIndexHandler) getOrCreateIndex(key string, p Pages) (pageIndex, error) {
+	if !s.keys[key] {
+		keys := make([]string, 0, len(s.keys))
+		for k := range s.keys {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		return nil, errors.Errorf("param %q is not indexed, add it to pageIndexes in your site config (indexed params: %q)", key, keys)
+	}
+
+	s.mu.RLock()
+	_, idx := s.getIndex(key, p)
+	s.mu.RUnlock()
+	if idx != nil {
+		return idx, nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ci, idx := s.getIndex(key, p)
+	if idx != nil {
+		return idx, nil
+	}
+
+	if ci == nil {
+		ci = &cachedPageIndex{p: p, indices: make(map[string]pageIndex)}
+		k := newPagesKey(p)
+		s.indices[k] = append(s.indices[k], ci)
+	}
+
+	idx = make(pageIndex)
+	for _, pp := range p {
+		v, err := maps.GetNestedParam(key, ".", pp.Params())
+		if err != nil {
+			return nil, err
+		}
+		values, err := indexValues(v)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to index param %q in %q", key, pp.Path())
+		}
+		for _, vv := range values {
+			idx[vv] = append(idx[vv], pp)
+		}
+	}
+
+	ci.indices[key] = idx
+
+	return idx, nil
+}
+
+func indexValues(v interface{}) ([]string, error) {
+	switch vv := v.(type) {
+	case nil:
+		return nil, nil
+	case []string:
+		return dedupe(vv), nil
+	case []interface{}:
+		values := make([]string, len(vv))
+		for i, e := range vv {
+			s, err := cast.ToStringE(e)
+			if err != nil {
+				return nil, err
+			}
+			values[i] = s
+		}
+		return dedupe(values), nil
+	default:
+		s, err := cast.ToStringE(v)
+		if err != nil {
+			return nil, err
+		}
+		return []string{s}, nil
+	}
+}
+
+// dedupe removes duplicate values so a page is only indexed once per value.
+func dedupe(values []string) []string {
+	seen := make(map[string]bool)
+	result := values[:0:0]
+	for _, v := range values {
+		if !seen[v] {
+			seen[v] = true
+			result = append(result, v)
+		}
+	}
+	return result
+}
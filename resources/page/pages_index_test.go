@@ -0,0 +1,131 @@
+// Copyright 2019 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package page
+
+import (
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestWhereParam(t *testing.T) {
+	c := qt.New(t)
+
+	t.Parallel()
+
+	pages := Pages{
+		&testPage{
+			title: "Page 1",
+			params: map[string]interface{}{
+				"category": "go",
+				"tags":     []interface{}{"hugo", "says", "hugo"},
+				"author":   map[string]interface{}{"name": "Jo"},
+			},
+		},
+		&testPage{
+			title: "Page 2",
+			params: map[string]interface{}{
+				"category": "rust",
+				"tags":     []string{"hugo", "rocks"},
+			},
+		},
+		&testPage{
+			title: "Page 3",
+			params: map[string]interface{}{
+				"category": "go",
+				"author":   map[string]interface{}{"name": "Mo"},
+			},
+		},
+	}
+
+	titles := func(p Pages) []string {
+		var s []string
+		for _, pp := range p {
+			s = append(s, pp.Title())
+		}
+		return s
+	}
+
+	result, err := pages.WhereParam("category", "go")
+	c.Assert(err, qt.IsNil)
+	c.Assert(titles(result), qt.DeepEquals, []string{"Page 1", "Page 3"})
+
+	result, err = pages.WhereParam("Tags", "hugo")
+	c.Assert(err, qt.IsNil)
+	c.Assert(titles(result), qt.DeepEquals, []string{"Page 1", "Page 2"})
+
+	result, err = pages.WhereParam("author.name", "Mo")
+	c.Assert(err, qt.IsNil)
+	c.Assert(titles(result), qt.DeepEquals, []string{"Page 3"})
+
+	result, err = pages.WhereParam("category", "haskell")
+	c.Assert(err, qt.IsNil)
+	c.Assert(result, qt.HasLen, 0)
+
+	result, err = pages[1:].WhereParam("category", "go")
+	c.Assert(err, qt.IsNil)
+	c.Assert(titles(result), qt.DeepEquals, []string{"Page 3"})
+
+	_, err = pages.WhereParam("series", "hugo")
+	c.Assert(err, qt.ErrorMatches, `param "series" is not indexed.*`)
+}
+
+func TestWhereParamAppend(t *testing.T) {
+	c := qt.New(t)
+
+	var pages Pages
+	for _, title := range []string{"Page 1", "Page 2", "Page 3"} {
+		pages = append(pages, &testPage{title: title, params: map[string]interface{}{"category": "go"}})
+	}
+
+	result1, err := pages.WhereParam("category", "go")
+	c.Assert(err, qt.IsNil)
+	result2, err := pages.WhereParam("category", "go")
+	c.Assert(err, qt.IsNil)
+
+	appended1 := append(result1, &testPage{title: "Appended 1"})
+	appended2 := append(result2, &testPage{title: "Appended 2"})
+
+	c.Assert(appended1[3].Title(), qt.Equals, "Appended 1")
+	c.Assert(appended2[3].Title(), qt.Equals, "Appended 2")
+	c.Assert(result1, qt.HasLen, 3)
+}
+
+func TestWhereParamCachedIndex(t *testing.T) {
+	c := qt.New(t)
+
+	var pages Pages
+	for _, title := range []string{"Page 1", "Page 2", "Page 3"} {
+		pages = append(pages, &testPage{title: title, params: map[string]interface{}{"category": "go"}})
+	}
+
+	h := pages[0].(InternalDependencies).GetPageIndexHandler()
+
+	_, err := pages.WhereParam("category", "go")
+	c.Assert(err, qt.IsNil)
+
+	// The same collection, a copy of it and a sub slice.
+	_, err = pages.WhereParam("category", "go")
+	c.Assert(err, qt.IsNil)
+	_, err = append(Pages(nil), pages...).WhereParam("category", "go")
+	c.Assert(err, qt.IsNil)
+	result, err := pages[1:].WhereParam("category", "go")
+	c.Assert(err, qt.IsNil)
+	c.Assert(result, qt.HasLen, 2)
+
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	c.Assert(h.indices[newPagesKey(pages)], qt.HasLen, 1)
+	c.Assert(h.indices[newPagesKey(pages[1:])], qt.HasLen, 1)
+}
@@ -24,6 +24,7 @@ import (
 	"github.com/gohugoio/hugo/resources/resource"
 
 	qt "github.com/frankban/quicktest"
+	"github.com/spf13/cast"
 )
 
 var eq = qt.CmpEquals(hqt.DeepAllowUnexported(
@@ -289,3 +290,58 @@ func createSortTestPages(num int) Pages {
 
 	return pages
 }
+
+func TestPageSortBy(t *testing.T) {
+	t.Parallel()
+	c := qt.New(t)
+
+	titles := []string{"p10", "p2", "p1", "p3", "p20"}
+	dates := []string{"2019-05-01", "2019-01-01", "2018-02-01", "2019-03-01", "2018-03-01"}
+	weights := []int{0, 2, 1, 1, 1}
+
+	pages := make(Pages, len(titles))
+	for i := range titles {
+		p := newTestPage()
+		p.title = titles[i]
+		p.date = cast.ToTime(dates[i])
+		p.weight = weights[i]
+		p.params["author"] = map[string]interface{}{"name": titles[len(titles)-1-i]}
+		pages[i] = p
+	}
+
+	titlesOf := func(pages Pages) []string {
+		var s []string
+		for _, p := range pages {
+			s = append(s, p.Title())
+		}
+		return s
+	}
+
+	sorted, err := pages.SortBy("Date:year:desc", "Weight", "Title:natural")
+	c.Assert(err, qt.IsNil)
+	c.Assert(titlesOf(sorted), qt.DeepEquals, []string{"p3", "p2", "p10", "p1", "p20"})
+
+	sorted, err = pages.SortBy("Title")
+	c.Assert(err, qt.IsNil)
+	c.Assert(titlesOf(sorted), qt.DeepEquals, []string{"p1", "p10", "p2", "p20", "p3"})
+
+	sorted, err = pages.SortBy("Title:natural:desc")
+	c.Assert(err, qt.IsNil)
+	c.Assert(titlesOf(sorted), qt.DeepEquals, []string{"p20", "p10", "p3", "p2", "p1"})
+
+	sorted, err = pages.SortBy("Params.author.name:natural")
+	c.Assert(err, qt.IsNil)
+	c.Assert(titlesOf(sorted), qt.DeepEquals, []string{"p1", "p3", "p2", "p20", "p10"})
+
+	// cached
+	sorted2, _ := pages.SortBy("Date:year:desc", "Weight", "Title:natural")
+	sorted3, _ := pages.SortBy("Date:year:desc", "Weight", "Title:natural")
+	c.Assert(pagesEqual(sorted2, sorted3), qt.Equals, true)
+
+	_, err = pages.SortBy("Foo")
+	c.Assert(err, qt.Not(qt.IsNil))
+	_, err = pages.SortBy("Date:week")
+	c.Assert(err, qt.Not(qt.IsNil))
+	_, err = pages.SortBy()
+	c.Assert(err, qt.Not(qt.IsNil))
+}
@@ -19,6 +19,7 @@ import (
 	"html/template"
 	"math"
 	"reflect"
+	"strings"
 
 	"github.com/gohugoio/hugo/config"
 
@@ -250,30 +251,101 @@ func splitPageGroups(pageGroups PagesGroup, size int) []paginatedElement {
 	return split
 }
 
-func ResolvePagerSize(cfg config.Provider, options ...interface{}) (int, error) {
-	if len(options) == 0 {
-		return cfg.GetInt("paginate"), nil
+// PaginateOptions holds the options given to Paginate and Paginator.
+type PaginateOptions struct {
+	// The number of elements per pager.
+	PagerSize int
+
+	// If set, the pages are grouped by date before they are paginated,
+	// "year" or "month".
+	GroupBy string
+}
+
+// paginateGroupByFormats maps the valid GroupBy values to the date format
+// used as the group key.
+var paginateGroupByFormats = map[string]string{
+	"year":  "2006",
+	"month": "2006-01",
+}
+
+// ResolvePaginateOptions resolves the options given to Paginate and
+// Paginator: an optional pager size, defaulting to defaultPagerSize, and
+// an optional "year" or "month" to group the pages by.
+func ResolvePaginateOptions(defaultPagerSize int, options ...interface{}) (PaginateOptions, error) {
+	opts := PaginateOptions{PagerSize: defaultPagerSize}
+
+	if len(options) > 2 {
+		return opts, errors.New("too many arguments, 'pager size' and 'group by' are the only options")
 	}
 
+	var sizeSet bool
+	for _, o := range options {
+		if s, ok := o.(string); ok {
+			if _, found := paginateGroupByFormats[strings.ToLower(s)]; found {
+				if opts.GroupBy != "" {
+					return opts, errors.New("'group by' can only be set once")
+				}
+				opts.GroupBy = strings.ToLower(s)
+				continue
+			}
+		}
+
+		pas, err := cast.ToIntE(o)
+		if err != nil || pas <= 0 || sizeSet {
+			return opts, errors.New(("'pager size' must be a positive integer and 'group by' one of \"year\" or \"month\""))
+		}
+		opts.PagerSize = pas
+		sizeSet = true
+	}
+
+	return opts, nil
+}
+
+// ResolvePagerSize resolves the pager size given to Paginate and Paginator,
+// defaulting to the paginate setting in cfg.
+// Use ResolvePaginateOptions to also resolve the "year" or "month" grouping.
+func ResolvePagerSize(cfg config.Provider, options ...interface{}) (int, error) {
 	if len(options) > 1 {
-		return -1, errors.New("too many arguments, 'pager size' is currently the only option")
+		return -1, errors.New("too many arguments, 'pager size' is the only option")
 	}
 
-	pas, err := cast.ToIntE(options[0])
+	opts, err := ResolvePaginateOptions(cfg.GetInt("paginate"), options...)
+	if err != nil {
+		return -1, err
+	}
 
-	if err != nil || pas <= 0 {
+	if opts.GroupBy != "" {
 		return -1, errors.New(("'pager size' must be a positive integer"))
 	}
 
-	return pas, nil
+	return opts.PagerSize, nil
 }
 
+// Paginate splits seq into pagers of pagerSize elements.
+// Use PaginateWithOptions to also group the pages by date.
 func Paginate(td TargetPathDescriptor, seq interface{}, pagerSize int) (*Paginator, error) {
+	return PaginateWithOptions(td, seq, PaginateOptions{PagerSize: pagerSize})
+}
+
+// PaginateWithOptions splits seq into pagers as configured in opts.
+func PaginateWithOptions(td TargetPathDescriptor, seq interface{}, opts PaginateOptions) (*Paginator, error) {
+	pagerSize := opts.PagerSize
 
 	if pagerSize <= 0 {
 		return nil, errors.New("'paginate' configuration setting must be positive to paginate")
 	}
 
+	if opts.GroupBy != "" {
+		pages, err := ToPages(seq)
+		if err != nil {
+			return nil, err
+		}
+		seq, err = pages.GroupByDate(paginateGroupByFormats[opts.GroupBy])
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	urlFactory := newPaginationURLFactory(td)
 
 	var paginator *Paginator
@@ -394,7 +466,11 @@ func newPaginationURLFactory(d TargetPathDescriptor) paginationURLFactory {
 		pathDescriptor := d
 		var rel string
 		if pageNumber > 1 {
-			rel = fmt.Sprintf("/%s/%d/", d.PathSpec.PaginatePath, pageNumber)
+			paginatePath := d.PaginatePath
+			if paginatePath == "" {
+				paginatePath = d.PathSpec.PaginatePath
+			}
+			rel = fmt.Sprintf("/%s/%d/", paginatePath, pageNumber)
 			pathDescriptor.Addends = rel
 		}
 
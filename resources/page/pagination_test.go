@@ -219,6 +219,8 @@ func TestPaginationURLFactory(t *testing.T) {
 					TargetPathDescriptor{Kind: KindHome, Type: output.HTMLFormat}, "http://example.com/", 32, "/zoo/32/", "/zoo/32.html"},
 				{"JSON home page 42",
 					TargetPathDescriptor{Kind: KindHome, Type: output.JSONFormat}, "http://example.com/", 42, "/zoo/42/index.json", "/zoo/42.json"},
+				{"HTML section page 2 with paginate path",
+					TargetPathDescriptor{Kind: KindSection, Sections: []string{"blog"}, PaginatePath: "side", Type: output.HTMLFormat}, "http://example.com/", 2, "/blog/side/2/", "/blog/side/2.html"},
 			}
 
 			for _, test := range tests {
@@ -246,6 +248,58 @@ func TestPaginationURLFactory(t *testing.T) {
 	}
 }
 
+func TestResolvePaginateOptions(t *testing.T) {
+	t.Parallel()
+	c := qt.New(t)
+
+	for _, test := range []struct {
+		options  []interface{}
+		expected PaginateOptions
+		isErr    bool
+	}{
+		{nil, PaginateOptions{PagerSize: 10}, false},
+		{[]interface{}{5}, PaginateOptions{PagerSize: 5}, false},
+		{[]interface{}{"5"}, PaginateOptions{PagerSize: 5}, false},
+		{[]interface{}{"Year"}, PaginateOptions{PagerSize: 10, GroupBy: "year"}, false},
+		{[]interface{}{3, "month"}, PaginateOptions{PagerSize: 3, GroupBy: "month"}, false},
+		{[]interface{}{"month", 3}, PaginateOptions{PagerSize: 3, GroupBy: "month"}, false},
+		{[]interface{}{0}, PaginateOptions{}, true},
+		{[]interface{}{"week"}, PaginateOptions{}, true},
+		{[]interface{}{3, 4}, PaginateOptions{}, true},
+		{[]interface{}{"year", "month"}, PaginateOptions{}, true},
+		{[]interface{}{3, "month", "year"}, PaginateOptions{}, true},
+	} {
+		opts, err := ResolvePaginateOptions(10, test.options...)
+		if test.isErr {
+			c.Assert(err, qt.Not(qt.IsNil), qt.Commentf("%v", test.options))
+			continue
+		}
+		c.Assert(err, qt.IsNil)
+		c.Assert(opts, qt.Equals, test.expected)
+	}
+}
+
+func TestResolvePagerSize(t *testing.T) {
+	t.Parallel()
+	c := qt.New(t)
+
+	cfg := viper.New()
+	cfg.Set("paginate", 10)
+
+	size, err := ResolvePagerSize(cfg)
+	c.Assert(err, qt.IsNil)
+	c.Assert(size, qt.Equals, 10)
+
+	size, err = ResolvePagerSize(cfg, 5)
+	c.Assert(err, qt.IsNil)
+	c.Assert(size, qt.Equals, 5)
+
+	for _, options := range [][]interface{}{{0}, {"year"}, {3, 4}} {
+		_, err = ResolvePagerSize(cfg, options...)
+		c.Assert(err, qt.Not(qt.IsNil), qt.Commentf("%v", options))
+	}
+}
+
 func TestProbablyEqualPageLists(t *testing.T) {
 	t.Parallel()
 	fivePages := createTestPages(5)
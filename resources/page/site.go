@@ -20,6 +20,7 @@ import (
 	"github.com/gohugoio/hugo/config"
 
 	"github.com/gohugoio/hugo/common/hugo"
+	"github.com/gohugoio/hugo/common/maps"
 	"github.com/gohugoio/hugo/langs"
 	"github.com/gohugoio/hugo/navigation"
 )
@@ -41,6 +42,9 @@ type Site interface {
 	Menus() navigation.Menus
 	Params() map[string]interface{}
 	Data() map[string]interface{}
+
+	// Store returns a Scratch that lives for the whole build.
+	Store() *maps.Scratch
 }
 
 // Sites represents an ordered list of sites (languages).
@@ -55,8 +59,9 @@ func (s Sites) First() Site {
 }
 
 type testSite struct {
-	h hugo.Info
-	l *langs.Language
+	h     hugo.Info
+	l     *langs.Language
+	store *maps.Scratch
 }
 
 func (t testSite) Hugo() hugo.Info {
@@ -115,10 +120,15 @@ func (t testSite) Data() map[string]interface{} {
 	return nil
 }
 
+func (t testSite) Store() *maps.Scratch {
+	return t.store
+}
+
 // NewDummyHugoSite creates a new minimal test site.
 func NewDummyHugoSite(cfg config.Provider) Site {
 	return testSite{
-		h: hugo.NewInfo(hugo.EnvironmentProduction),
-		l: langs.NewLanguage("en", cfg),
+		h:     hugo.NewInfo(hugo.EnvironmentProduction, false),
+		l:     langs.NewLanguage("en", cfg),
+		store: maps.NewScratch(),
 	}
 }
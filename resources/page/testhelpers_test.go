@@ -46,6 +46,8 @@ var (
 
 var relatedDocsHandler = NewRelatedDocsHandler(related.DefaultConfig)
 
+var pageIndexHandler = NewPageIndexHandler([]string{"category", "tags", "author.name"})
+
 func newTestPage() *testPage {
 	return newTestPageWithFile("/a/b/c.md")
 }
@@ -121,6 +123,14 @@ func (p *testPage) AlternativeOutputFormats() OutputFormats {
 	panic("not implemented")
 }
 
+func (p *testPage) Ancestors() Pages {
+	panic("not implemented")
+}
+
+func (p *testPage) GetTerms(taxonomy string) Pages {
+	panic("not implemented")
+}
+
 func (p *testPage) Author() Author {
 	return Author{}
 
@@ -208,6 +218,10 @@ func (p *testPage) FirstSection() Page {
 	panic("not implemented")
 }
 
+func (p *testPage) Stats() ContentStats {
+	panic("not implemented")
+}
+
 func (p *testPage) FuzzyWordCount() int {
 	return p.fuzzyWordCount
 }
@@ -224,6 +238,10 @@ func (p *testPage) GetRelatedDocsHandler() *RelatedDocsHandler {
 	return relatedDocsHandler
 }
 
+func (p *testPage) GetPageIndexHandler() *PageIndexHandler {
+	return pageIndexHandler
+}
+
 func (p *testPage) GitInfo() *gitmap.GitInfo {
 	return nil
 }
@@ -391,6 +409,10 @@ func (p *testPage) Plain() string {
 	panic("not implemented")
 }
 
+func (p *testPage) PlainSummary() string {
+	panic("not implemented")
+}
+
 func (p *testPage) PlainWords() []string {
 	panic("not implemented")
 }
@@ -459,6 +481,10 @@ func (p *testPage) Scratch() *maps.Scratch {
 	panic("not implemented")
 }
 
+func (p *testPage) Store() *maps.Scratch {
+	panic("not implemented")
+}
+
 func (p *testPage) RelatedKeywords(cfg related.IndexConfig) ([]related.Keyword, error) {
 	v, err := p.Param(cfg.Name)
 	if err != nil {
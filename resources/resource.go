@@ -15,6 +15,7 @@ package resources
 
 import (
 	"fmt"
+	"html/template"
 	"io"
 	"io/ioutil"
 	"os"
@@ -111,6 +112,7 @@ type baseResourceInternal interface {
 
 	// Internal
 	cloneWithUpdates(*transformationUpdate) (baseResource, error)
+	setMediaType(media.Type)
 	tryTransformedFileCache(key string, u *transformationUpdate) io.ReadCloser
 
 	specProvider
@@ -169,6 +171,7 @@ func (d dirFile) path() string {
 }
 
 type fileInfo interface {
+	getFileInfo() os.FileInfo
 	getSourceFilename() string
 	setSourceFilename(string)
 	setSourceFs(afero.Fs)
@@ -303,6 +306,10 @@ func (l *genericResource) initContent() error {
 	return err
 }
 
+func (l *genericResource) setMediaType(mediaType media.Type) {
+	l.mediaType = mediaType
+}
+
 func (l *genericResource) setName(name string) {
 	l.name = name
 }
@@ -335,11 +342,34 @@ func (r *genericResource) tryTransformedFileCache(key string, u *transformationU
 	u.sourceFilename = &fi.Name
 	mt, _ := r.spec.MediaTypes.GetByType(meta.MediaTypeV)
 	u.mediaType = mt
-	u.data = meta.MetaData
+	u.data = restoreTransformedData(meta.MetaData)
 	u.targetPath = meta.Target
 	return f
 }
 
+// transformedDataTypes holds the types of the Data values set by the
+// transformations that are not plain strings, see integrity.
+var transformedDataTypes = map[string]func(s string) interface{}{
+	"Integrity": func(s string) interface{} { return template.HTMLAttr(s) },
+	"CSPHash":   func(s string) interface{} { return template.HTMLAttr(s) },
+	"Inline":    func(s string) interface{} { return template.HTML(s) },
+}
+
+// restoreTransformedData restores the types of the Data values decoded as
+// strings from the file cache, so e.g. .Data.Inline is not escaped.
+func restoreTransformedData(data map[string]interface{}) map[string]interface{} {
+	for k, v := range data {
+		s, ok := v.(string)
+		if !ok {
+			continue
+		}
+		if restore, found := transformedDataTypes[k]; found {
+			data[k] = restore(s)
+		}
+	}
+	return data
+}
+
 func (r *genericResource) mergeData(in map[string]interface{}) {
 	if len(in) == 0 {
 		return
@@ -555,6 +585,10 @@ func (fi *resourceFileInfo) ReadSeekCloser() (hugio.ReadSeekCloser, error) {
 	return f, nil
 }
 
+func (fi *resourceFileInfo) getFileInfo() os.FileInfo {
+	return fi.fi
+}
+
 func (fi *resourceFileInfo) getSourceFilename() string {
 	return fi.sourceFilename
 }
@@ -59,6 +59,19 @@ func IsExpired(d Dated) bool {
 	return d.ExpiryDate().Before(time.Now())
 }
 
+// ScheduledDate returns the next date at which the argument changes from
+// future to published or from published to expired, or the zero time if
+// there is none.
+func ScheduledDate(d Dated) time.Time {
+	if IsFuture(d) {
+		return d.PublishDate()
+	}
+	if !d.ExpiryDate().IsZero() && d.ExpiryDate().After(time.Now()) {
+		return d.ExpiryDate()
+	}
+	return time.Time{}
+}
+
 // IsZeroDates returns true if all of the dates are zero.
 func IsZeroDates(d Dated) bool {
 	return d.Date().IsZero() && d.Lastmod().IsZero() && d.ExpiryDate().IsZero() && d.PublishDate().IsZero()
@@ -17,13 +17,15 @@ import (
 	"strings"
 	"time"
 
+	"github.com/gohugoio/hugo/common/maps"
 	"github.com/gohugoio/hugo/helpers"
 
 	"github.com/spf13/cast"
 )
 
 // GetParam will return the param with the given key from the Resource,
-// nil if not found.
+// nil if not found. The key may be a dot separated path to a nested value,
+// e.g. "author.name".
 func GetParam(r Resource, key string) interface{} {
 	return getParam(r, key, false)
 }
@@ -35,7 +37,7 @@ func GetParamToLower(r Resource, key string) interface{} {
 }
 
 func getParam(r Resource, key string, stringToLower bool) interface{} {
-	v := r.Params()[strings.ToLower(key)]
+	v, _ := maps.GetNestedParam(key, ".", r.Params())
 
 	if v == nil {
 		return nil
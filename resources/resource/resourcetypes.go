@@ -45,10 +45,14 @@ type Image interface {
 type ImageOps interface {
 	Height() int
 	Width() int
+	AspectRatio() float64
+	IsPortrait() bool
+	IsLandscape() bool
 	Fill(spec string) (Image, error)
 	Fit(spec string) (Image, error)
 	Resize(spec string) (Image, error)
 	Filter(filters ...gift.Filter) (Image, error)
+	ProcessMany(formats, spec string) ([]Image, error)
 }
 
 type ResourceTypesProvider interface {
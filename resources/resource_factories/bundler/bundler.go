@@ -66,6 +66,8 @@ func (r *multiReadSeekCloser) Close() error {
 
 // Concat concatenates the list of Resource objects.
 func (c *Client) Concat(targetPath string, r resource.Resources) (resource.Resource, error) {
+	targetPath = c.rs.TargetPathForLanguage(targetPath)
+
 	// The CACHE_OTHER will make sure this will be re-created and published on rebuilds.
 	return c.rs.ResourceCache.GetOrCreate(path.Join(resources.CACHE_OTHER, targetPath), func() (resource.Resource, error) {
 		var resolvedm media.Type
@@ -27,6 +27,7 @@ import (
 	"github.com/gohugoio/hugo/cache/filecache"
 	"github.com/gohugoio/hugo/common/loggers"
 	"github.com/gohugoio/hugo/media"
+	"github.com/gohugoio/hugo/metrics"
 	"github.com/gohugoio/hugo/output"
 	"github.com/gohugoio/hugo/resources/images"
 	"github.com/gohugoio/hugo/resources/page"
@@ -35,6 +36,9 @@ import (
 	"github.com/spf13/afero"
 )
 
+// The placeholder for the language code in target paths.
+const langPlaceholder = ":lang"
+
 func NewSpec(
 	s *helpers.PathSpec,
 	fileCaches filecache.Caches,
@@ -95,12 +99,40 @@ type Spec struct {
 	imageCache    *imageCache
 	ResourceCache *ResourceCache
 	FileCaches    filecache.Caches
+
+	// Measures the resource transformations and image processing.
+	// This may be nil.
+	BuildTimer *metrics.BuildTimer
+
+	// Collects the fingerprinted resources, set with build.writeAssetManifest.
+	// This may be nil.
+	AssetManifest *AssetManifest
+
+	// Collects the requested image processing instead of processing the
+	// images, set with imageAudit. This may be nil.
+	ImageAudit *ImageAudit
+
+	// CurrentOutputFormat returns the output format currently being rendered,
+	// used to apply its image processing defaults.
+	// This may be nil.
+	CurrentOutputFormat func() output.Format
 }
 
 func (r *Spec) New(fd ResourceSourceDescriptor) (resource.Resource, error) {
 	return r.newResourceFor(fd)
 }
 
+// TargetPathForLanguage replaces any :lang placeholder in targetPath with
+// the current language code, e.g. "css/main.:lang.css" becomes
+// "css/main.ar.css". As the target path is part of the cache key of the
+// transformations and bundles using it, this makes them language scoped.
+func (r *Spec) TargetPathForLanguage(targetPath string) string {
+	if r.Language == nil || !strings.Contains(targetPath, langPlaceholder) {
+		return targetPath
+	}
+	return strings.Replace(targetPath, langPlaceholder, r.Language.Lang, -1)
+}
+
 func (r *Spec) CacheStats() string {
 	r.imageCache.mu.RLock()
 	defer r.imageCache.mu.RUnlock()
@@ -239,22 +271,7 @@ func (r *Spec) newResource(sourceFs afero.Fs, fd ResourceSourceDescriptor) (reso
 	}
 
 	ext := strings.ToLower(filepath.Ext(fd.RelTargetFilename))
-	mimeType, found := r.MediaTypes.GetFirstBySuffix(strings.TrimPrefix(ext, "."))
-	// TODO(bep) we need to handle these ambigous types better, but in this context
-	// we most likely want the application/xml type.
-	if mimeType.Suffix() == "xml" && mimeType.SubType == "rss" {
-		mimeType, found = r.MediaTypes.GetByType("application/xml")
-	}
-
-	if !found {
-		// A fallback. Note that mime.TypeByExtension is slow by Hugo standards,
-		// so we should configure media types to avoid this lookup for most
-		// situations.
-		mimeStr := mime.TypeByExtension(ext)
-		if mimeStr != "" {
-			mimeType, _ = media.FromStringAndExt(mimeStr, ext)
-		}
-	}
+	mimeType := r.mediaTypeFromExt(ext)
 
 	gr := r.newGenericResourceWithBase(
 		sourceFs,
@@ -282,6 +299,29 @@ func (r *Spec) newResource(sourceFs afero.Fs, fd ResourceSourceDescriptor) (reso
 
 }
 
+// mediaTypeFromExt returns the media type for the given file extension, e.g.
+// ".jpg".
+func (r *Spec) mediaTypeFromExt(ext string) media.Type {
+	mimeType, found := r.MediaTypes.GetFirstBySuffix(strings.TrimPrefix(ext, "."))
+	// TODO(bep) we need to handle these ambigous types better, but in this context
+	// we most likely want the application/xml type.
+	if mimeType.Suffix() == "xml" && mimeType.SubType == "rss" {
+		mimeType, found = r.MediaTypes.GetByType("application/xml")
+	}
+
+	if !found {
+		// A fallback. Note that mime.TypeByExtension is slow by Hugo standards,
+		// so we should configure media types to avoid this lookup for most
+		// situations.
+		mimeStr := mime.TypeByExtension(ext)
+		if mimeStr != "" {
+			mimeType, _ = media.FromStringAndExt(mimeStr, ext)
+		}
+	}
+
+	return mimeType
+}
+
 func (r *Spec) newResourceFor(fd ResourceSourceDescriptor) (resource.Resource, error) {
 	if fd.OpenReadSeekCloser == nil {
 		if fd.SourceFile != nil && fd.SourceFilename != "" {
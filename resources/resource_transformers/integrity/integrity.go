@@ -22,7 +22,10 @@ import (
 	"hash"
 	"html/template"
 	"io"
+	"io/ioutil"
+	"regexp"
 
+	"github.com/gohugoio/hugo/media"
 	"github.com/gohugoio/hugo/resources/internal"
 
 	"github.com/pkg/errors"
@@ -82,6 +85,62 @@ func (t *fingerprintTransformation) Transform(ctx *resources.ResourceTransformat
 	return nil
 }
 
+type inlineTransformation struct {
+	algo string
+}
+
+func (t *inlineTransformation) Key() internal.ResourceTransformationKey {
+	return internal.NewResourceTransformationKey("inline", t.algo)
+}
+
+// closingTagRe matches closing style and script tags, which would end the
+// inlined element early.
+var closingTagRe = regexp.MustCompile(`(?i)</(style|script)`)
+
+// Transform escapes the CSS or JavaScript content so it can be embedded
+// in a style or script element, and adds that element and the CSP hash
+// of the escaped content to the Data.
+func (t *inlineTransformation) Transform(ctx *resources.ResourceTransformationCtx) error {
+	var element string
+	switch ctx.InMediaType.Type() {
+	case media.CSSType.Type():
+		element = "style"
+	case media.JavascriptType.Type():
+		element = "script"
+	default:
+		return errors.Errorf("inline: %s is not supported, must be CSS or JavaScript", ctx.InMediaType.Type())
+	}
+
+	if t.algo == "md5" {
+		return errors.New("inline: md5 is not supported by Content Security Policy, use either sha256, sha384 or sha512")
+	}
+
+	h, err := newHash(t.algo)
+	if err != nil {
+		return err
+	}
+
+	b, err := ioutil.ReadAll(ctx.From)
+	if err != nil {
+		return err
+	}
+	b = closingTagRe.ReplaceAll(b, []byte(`<\/$1`))
+
+	h.Write(b)
+	d, err := digest(h)
+	if err != nil {
+		return err
+	}
+
+	if _, err := ctx.To.Write(b); err != nil {
+		return err
+	}
+
+	ctx.Data["CSPHash"] = integrity(t.algo, d)
+	ctx.Data["Inline"] = template.HTML("<" + element + ">" + string(b) + "</" + element + ">")
+	return nil
+}
+
 func newHash(algo string) (hash.Hash, error) {
 	switch algo {
 	case "md5":
@@ -111,6 +170,20 @@ func (c *Client) Fingerprint(res resources.ResourceTransformer, algo string) (re
 	return res.Transform(&fingerprintTransformation{algo: algo})
 }
 
+// Inline prepares the given CSS or JavaScript resource to be embedded in
+// the page. The style or script element is set in .Data.Inline and the
+// hash to allow it in a Content Security Policy, e.g. "sha256-…", in
+// .Data.CSPHash.
+// It defaults to sha256 if no hash algorithm is given, the options are
+// sha256, sha384 or sha512.
+func (c *Client) Inline(res resources.ResourceTransformer, algo string) (resource.Resource, error) {
+	if algo == "" {
+		algo = defaultHashAlgo
+	}
+
+	return res.Transform(&inlineTransformation{algo: algo})
+}
+
 func integrity(algo string, sum []byte) template.HTMLAttr {
 	encoded := base64.StdEncoding.EncodeToString(sum)
 	return template.HTMLAttr(algo + "-" + encoded)
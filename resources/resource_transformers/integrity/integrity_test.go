@@ -70,3 +70,46 @@ func TestTransform(t *testing.T) {
 	c.Assert(err, qt.IsNil)
 	c.Assert(content, qt.Equals, "Hugo Rocks!")
 }
+
+func TestInline(t *testing.T) {
+	c := qt.New(t)
+
+	spec, err := htesting.NewTestResourceSpec()
+	c.Assert(err, qt.IsNil)
+	client := New(spec)
+
+	r, err := htesting.NewResourceTransformerForSpec(spec, "main.css", "body { color: red; }")
+	c.Assert(err, qt.IsNil)
+
+	transformed, err := client.Inline(r, "")
+	c.Assert(err, qt.IsNil)
+	data := transformed.Data().(map[string]interface{})
+	c.Assert(data["Inline"], qt.Equals, template.HTML("<style>body { color: red; }</style>"))
+	c.Assert(data["CSPHash"], qt.Equals, template.HTMLAttr("sha256-XeYlw2NVzOfB1UCIJqCyGr+0n7bA4fFslFpvKu84IAw="))
+
+	r, err = htesting.NewResourceTransformerForSpec(spec, "main.js", `var s = "</script><script>alert(1)</SCRIPT>";`)
+	c.Assert(err, qt.IsNil)
+
+	transformed, err = client.Inline(r, "sha512")
+	c.Assert(err, qt.IsNil)
+	data = transformed.Data().(map[string]interface{})
+	c.Assert(data["Inline"], qt.Equals, template.HTML(`<script>var s = "<\/script><script>alert(1)<\/SCRIPT>";</script>`))
+	c.Assert(string(data["CSPHash"].(template.HTMLAttr)), qt.Contains, "sha512-")
+	content, err := transformed.(resource.ContentProvider).Content()
+	c.Assert(err, qt.IsNil)
+	c.Assert(content, qt.Equals, `var s = "<\/script><script>alert(1)<\/SCRIPT>";`)
+
+	r, err = htesting.NewResourceTransformerForSpec(spec, "hugo.txt", "Hugo Rocks!")
+	c.Assert(err, qt.IsNil)
+	transformed, err = client.Inline(r, "")
+	c.Assert(err, qt.IsNil)
+	_, err = transformed.(resource.ContentProvider).Content()
+	c.Assert(err, qt.Not(qt.IsNil))
+
+	r, err = htesting.NewResourceTransformerForSpec(spec, "md5.css", "body {}")
+	c.Assert(err, qt.IsNil)
+	transformed, err = client.Inline(r, "md5")
+	c.Assert(err, qt.IsNil)
+	_, err = transformed.(resource.ContentProvider).Content()
+	c.Assert(err, qt.Not(qt.IsNil))
+}
@@ -27,7 +27,8 @@ func TestTransform(t *testing.T) {
 
 	spec, err := htesting.NewTestResourceSpec()
 	c.Assert(err, qt.IsNil)
-	client := New(spec)
+	client, err := New(spec)
+	c.Assert(err, qt.IsNil)
 
 	r, err := htesting.NewResourceTransformerForSpec(spec, "hugo.html", "<h1>   Hugo Rocks!   </h1>")
 	c.Assert(err, qt.IsNil)
@@ -67,7 +67,7 @@ func (t *executeAsTemplateTransform) Transform(ctx *resources.ResourceTransforma
 func (c *Client) ExecuteAsTemplate(res resources.ResourceTransformer, targetPath string, data interface{}) (resource.Resource, error) {
 	return res.Transform(&executeAsTemplateTransform{
 		rs:           c.rs,
-		targetPath:   helpers.ToSlashTrimLeading(targetPath),
+		targetPath:   helpers.ToSlashTrimLeading(c.rs.TargetPathForLanguage(targetPath)),
 		textTemplate: c.textTemplate,
 		data:         data,
 	})
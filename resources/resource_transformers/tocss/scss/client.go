@@ -41,6 +41,9 @@ type Options struct {
 	// to .css, e.g. "scss/main.scss" becomes "scss/main.css". You can
 	// control this by setting this, e.g. "styles/main.css" will create
 	// a Resource with that as a base for RelPermalink etc.
+	// Any :lang placeholder is replaced with the current language code,
+	// e.g. "css/main.:lang.css", which also makes Hugo cache the result
+	// per language.
 	TargetPath string
 
 	// Hugo automatically adds the entry directories (where the main.scss lives)
@@ -70,6 +73,8 @@ type options struct {
 }
 
 func (c *Client) ToCSS(res resources.ResourceTransformer, opts Options) (resource.Resource, error) {
+	opts.TargetPath = c.rs.TargetPathForLanguage(opts.TargetPath)
+
 	internalOptions := options{
 		from: opts,
 	}
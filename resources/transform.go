@@ -20,6 +20,7 @@ import (
 	"path"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/disintegration/gift"
 	"github.com/spf13/afero"
@@ -152,6 +153,10 @@ type resourceAdapter struct {
 	*resourceAdapterInner
 }
 
+func (r *resourceAdapter) AspectRatio() float64 {
+	return r.getImageOps().AspectRatio()
+}
+
 func (r *resourceAdapter) Content() (interface{}, error) {
 	r.init(false, true)
 	if r.transformationsErr != nil {
@@ -181,6 +186,14 @@ func (r *resourceAdapter) Height() int {
 	return r.getImageOps().Height()
 }
 
+func (r *resourceAdapter) IsLandscape() bool {
+	return r.getImageOps().IsLandscape()
+}
+
+func (r *resourceAdapter) IsPortrait() bool {
+	return r.getImageOps().IsPortrait()
+}
+
 func (r *resourceAdapter) Key() string {
 	r.init(false, false)
 	return r.target.(resource.Identifier).Key()
@@ -206,6 +219,10 @@ func (r *resourceAdapter) Permalink() string {
 	return r.target.Permalink()
 }
 
+func (r *resourceAdapter) ProcessMany(formats, spec string) ([]resource.Image, error) {
+	return r.getImageOps().ProcessMany(formats, spec)
+}
+
 func (r *resourceAdapter) Publish() error {
 	r.init(false, false)
 
@@ -386,7 +403,10 @@ func (r *resourceAdapter) transform(publish, setContent bool) error {
 			}
 		}
 
-		if err = tr.Transform(tctx); err != nil {
+		start := time.Now()
+		err = tr.Transform(tctx)
+		r.spec.BuildTimer.MeasureSince("assets/"+tr.Key().Name, start)
+		if err != nil {
 			if writeToFileCache && err == herrors.ErrFeatureNotAvailable {
 				// This transformation is not available in this
 				// Hugo installation (scss not compiled in, PostCSS not available etc.)
@@ -502,9 +522,18 @@ func (r *resourceAdapter) initTransform(publish, setContent bool) {
 			r.publishOnce = nil
 		}
 
+		sourcePath := r.target.TargetPath()
+
 		r.transformationsErr = r.transform(publish, setContent)
 		if r.transformationsErr != nil {
 			r.spec.Logger.ERROR.Printf("Transformation failed: %s", r.transformationsErr)
+			return
+		}
+
+		// Record this in the asset manifest even if the result was
+		// fetched from one of the caches.
+		if r.hasTransformation("fingerprint") {
+			r.spec.AssetManifest.Add(sourcePath, r.target.TargetPath())
 		}
 	})
 
@@ -513,6 +542,15 @@ func (r *resourceAdapter) initTransform(publish, setContent bool) {
 	}
 }
 
+func (r *resourceAdapter) hasTransformation(name string) bool {
+	for _, tr := range r.transformations {
+		if tr.Key().Name == name {
+			return true
+		}
+	}
+	return false
+}
+
 type resourceAdapterInner struct {
 	target transformableResource
 
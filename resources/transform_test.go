@@ -16,6 +16,7 @@ package resources
 import (
 	"encoding/base64"
 	"fmt"
+	"html/template"
 	"io"
 	"path/filepath"
 	"strconv"
@@ -212,7 +213,9 @@ func TestTransform(t *testing.T) {
 						ctx.AddOutPathIdentifier("." + "cached")
 						ctx.OutMediaType = media.CSVType
 						ctx.Data = map[string]interface{}{
-							"Hugo": "Rocks!",
+							"Hugo":      "Rocks!",
+							"Integrity": template.HTMLAttr("sha256-abc"),
+							"Inline":    template.HTML("<style>color is green</style>"),
 						}
 						fmt.Fprint(ctx.To, in)
 						return nil
@@ -237,8 +240,10 @@ func TestTransform(t *testing.T) {
 			c.Assert(content, qt.Equals, "color is green", msg)
 			c.Assert(tr.MediaType(), eq, media.CSVType)
 			c.Assert(tr.Data(), qt.DeepEquals, map[string]interface{}{
-				"Hugo": "Rocks!",
-			})
+				"Hugo":      "Rocks!",
+				"Integrity": template.HTMLAttr("sha256-abc"),
+				"Inline":    template.HTML("<style>color is green</style>"),
+			}, msg)
 
 			assertNoDuplicateWrites(c, spec)
 			assertShouldExist(c, spec, "public/f1.cached.txt", true)
@@ -18,6 +18,9 @@ import (
 	"fmt"
 	"reflect"
 	"strings"
+	"time"
+
+	"github.com/spf13/cast"
 )
 
 // Where returns a filtered subset of a given data type.
@@ -68,6 +71,21 @@ func (ns *Namespace) checkCondition(v, mv reflect.Value, op string) (bool, error
 		return false, nil
 	}
 
+	// Allow dates to be compared with date strings, e.g. "2020-01-31".
+	if v.Type() == timeType && mv.Kind() == reflect.String {
+		t, err := cast.ToTimeE(mv.String())
+		if err != nil {
+			return false, fmt.Errorf("unable to compare a date with %q: %s", mv.String(), err)
+		}
+		mv = reflect.ValueOf(t)
+	} else if mv.Type() == timeType && v.Kind() == reflect.String {
+		t, err := cast.ToTimeE(v.String())
+		if err != nil {
+			return false, fmt.Errorf("unable to compare %q with a date: %s", v.String(), err)
+		}
+		v = reflect.ValueOf(t)
+	}
+
 	if v.Kind() == reflect.Bool && mv.Kind() == reflect.Bool {
 		switch op {
 		case "", "=", "==", "eq":
@@ -134,7 +152,9 @@ func (ns *Namespace) checkCondition(v, mv reflect.Value, op string) (bool, error
 			return false, nil
 		}
 
-		if v.Kind() != reflect.Interface && mv.Type().Elem().Kind() != reflect.Interface && mv.Type().Elem() != v.Type() && v.Kind() != reflect.Array && v.Kind() != reflect.Slice {
+		isDateInDateStrings := v.Type() == timeType && mv.Type().Elem().Kind() == reflect.String
+
+		if v.Kind() != reflect.Interface && mv.Type().Elem().Kind() != reflect.Interface && mv.Type().Elem() != v.Type() && v.Kind() != reflect.Array && v.Kind() != reflect.Slice && !isDateInDateStrings {
 			return false, nil
 		}
 		switch v.Kind() {
@@ -168,7 +188,9 @@ func (ns *Namespace) checkCondition(v, mv reflect.Value, op string) (bool, error
 				iv := toTimeUnix(v)
 				ivp = &iv
 				for i := 0; i < mv.Len(); i++ {
-					ima = append(ima, toTimeUnix(mv.Index(i)))
+					if aTime, err := toTime(mv.Index(i)); err == nil {
+						ima = append(ima, aTime.Unix())
+					}
 				}
 			}
 		case reflect.Array, reflect.Slice:
@@ -245,6 +267,14 @@ func (ns *Namespace) checkCondition(v, mv reflect.Value, op string) (bool, error
 			} else if smvp != nil {
 				r, _ = ns.In(*smvp, *svp)
 			}
+		case slv != nil && slmv != nil:
+			// A slice is considered to be in another slice if they
+			// have at least one element in common.
+			var err error
+			r, err = ns.hasIntersection(slv, slmv)
+			if err != nil {
+				return false, err
+			}
 		default:
 			return false, nil
 		}
@@ -253,26 +283,26 @@ func (ns *Namespace) checkCondition(v, mv reflect.Value, op string) (bool, error
 		}
 		return r, nil
 	case "intersect":
-		r, err := ns.Intersect(slv, slmv)
-		if err != nil {
-			return false, err
-		}
-
-		if reflect.TypeOf(r).Kind() == reflect.Slice {
-			s := reflect.ValueOf(r)
-
-			if s.Len() > 0 {
-				return true, nil
-			}
-			return false, nil
-		}
-		return false, errors.New("invalid intersect values")
+		return ns.hasIntersection(slv, slmv)
 	default:
 		return false, errors.New("no such operator")
 	}
 	return false, nil
 }
 
+// hasIntersection reports whether the two slices have any elements in common.
+func (ns *Namespace) hasIntersection(l1, l2 interface{}) (bool, error) {
+	r, err := ns.Intersect(l1, l2)
+	if err != nil {
+		return false, err
+	}
+
+	if reflect.TypeOf(r).Kind() == reflect.Slice {
+		return reflect.ValueOf(r).Len() > 0, nil
+	}
+	return false, errors.New("invalid intersect values")
+}
+
 func evaluateSubElem(obj reflect.Value, elemName string) (reflect.Value, error) {
 	if !obj.IsValid() {
 		return zero, errors.New("can't evaluate an invalid value")
@@ -337,7 +367,15 @@ func evaluateSubElem(obj reflect.Value, elemName string) (reflect.Value, error)
 	case reflect.Map:
 		kv := reflect.ValueOf(elemName)
 		if kv.Type().AssignableTo(obj.Type().Key()) {
-			return obj.MapIndex(kv), nil
+			v := obj.MapIndex(kv)
+			if !v.IsValid() {
+				// Page and site params are stored with lower case keys,
+				// so Params.Author.Name works as well as Params.author.name.
+				if lkv := reflect.ValueOf(strings.ToLower(elemName)); lkv.String() != elemName {
+					v = obj.MapIndex(lkv)
+				}
+			}
+			return v, nil
 		}
 		return zero, fmt.Errorf("%s isn't a key of map type %s", elemName, typ)
 	}
@@ -377,7 +415,8 @@ func (ns *Namespace) checkWhereArray(seqv, kv, mv reflect.Value, path []string,
 				var err error
 				vvv, err = evaluateSubElem(vvv, elemName)
 				if err != nil {
-					continue
+					vvv = zero
+					break
 				}
 			}
 		} else {
@@ -486,6 +525,20 @@ func toString(v reflect.Value) (string, error) {
 	return "", errors.New("unable to convert value to string")
 }
 
+// toTime returns the time value if possible. Strings are parsed as dates.
+func toTime(v reflect.Value) (time.Time, error) {
+	switch v.Kind() {
+	case reflect.String:
+		return cast.ToTimeE(v.String())
+	case reflect.Interface:
+		return toTime(v.Elem())
+	}
+	if v.Type() == timeType {
+		return v.Interface().(time.Time), nil
+	}
+	return time.Time{}, errors.New("unable to convert value to time")
+}
+
 func toTimeUnix(v reflect.Value) int64 {
 	if v.Kind() == reflect.Interface {
 		return toTimeUnix(v.Elem())
@@ -545,6 +545,46 @@ func TestWhere(t *testing.T) {
 				"bar": []interface{}{map[interface{}]interface{}{"a": 3, "b": 4}},
 			},
 		},
+		{
+			seq: []map[string]interface{}{
+				{"a": 1, "params": map[string]interface{}{"author": map[string]interface{}{"name": "Jo"}}},
+				{"a": 2, "params": map[string]interface{}{"author": map[string]interface{}{"name": "Bo"}}},
+				{"a": 3, "params": map[string]interface{}{"author": "Lo"}},
+			},
+			key: "params.Author.Name", match: "Bo",
+			expect: []map[string]interface{}{
+				{"a": 2, "params": map[string]interface{}{"author": map[string]interface{}{"name": "Bo"}}},
+			},
+		},
+		{
+			seq: []map[string]interface{}{
+				{"a": 1, "tags": []string{"a", "b"}},
+				{"a": 2, "tags": []string{"c"}},
+			},
+			key: "tags", op: "in", match: []string{"b", "d"},
+			expect: []map[string]interface{}{
+				{"a": 1, "tags": []string{"a", "b"}},
+			},
+		},
+		{
+			seq: []TstX{
+				{A: "a", B: "b"}, {A: "c", B: "d"},
+			},
+			key: "A", op: "not in", match: []string{"a", "e"},
+			expect: []TstX{
+				{A: "c", B: "d"},
+			},
+		},
+		{
+			seq: []map[string]interface{}{
+				{"a": 1, "date": time.Date(2019, time.December, 31, 0, 0, 0, 0, time.UTC)},
+				{"a": 2, "date": time.Date(2020, time.January, 2, 0, 0, 0, 0, time.UTC)},
+			},
+			key: "date", op: ">=", match: "2020-01-01",
+			expect: []map[string]interface{}{
+				{"a": 2, "date": time.Date(2020, time.January, 2, 0, 0, 0, 0, time.UTC)},
+			},
+		},
 		{
 			seq: map[string]interface{}{
 				"foo": []interface{}{map[interface{}]interface{}{"a": 1, "b": 2}},
@@ -716,6 +756,22 @@ func TestCheckCondition(t *testing.T) {
 		{reflect.ValueOf([]string{"a"}), reflect.ValueOf([]interface{}{"a", "b"}), "intersect", expect{true, false}},
 		{reflect.ValueOf([]interface{}{1, 2}), reflect.ValueOf([]int{1}), "intersect", expect{true, false}},
 		{reflect.ValueOf([]int{1}), reflect.ValueOf([]interface{}{1, 2}), "intersect", expect{true, false}},
+
+		// Slices in slices
+		{reflect.ValueOf([]string{"a", "c"}), reflect.ValueOf([]string{"a", "b"}), "in", expect{true, false}},
+		{reflect.ValueOf([]string{"c"}), reflect.ValueOf([]string{"a", "b"}), "in", expect{false, false}},
+		{reflect.ValueOf([]string{"c"}), reflect.ValueOf([]string{"a", "b"}), "not in", expect{true, false}},
+		{reflect.ValueOf([]interface{}{"a"}), reflect.ValueOf([]string{"a", "b"}), "not in", expect{false, false}},
+
+		// Dates and date strings
+		{reflect.ValueOf(time.Date(2015, time.May, 26, 0, 0, 0, 0, time.UTC)), reflect.ValueOf("2015-05-26"), "", expect{true, false}},
+		{reflect.ValueOf(time.Date(2015, time.May, 26, 19, 18, 56, 0, time.UTC)), reflect.ValueOf("2015-05-01"), ">", expect{true, false}},
+		{reflect.ValueOf(time.Date(2015, time.May, 26, 19, 18, 56, 0, time.UTC)), reflect.ValueOf("2015-05-01"), "<=", expect{false, false}},
+		{reflect.ValueOf("2015-04-01"), reflect.ValueOf(time.Date(2015, time.May, 26, 19, 18, 56, 0, time.UTC)), "<", expect{true, false}},
+		{reflect.ValueOf("foo"), reflect.ValueOf(time.Date(2015, time.May, 26, 19, 18, 56, 0, time.UTC)), "<", expect{false, true}},
+		{reflect.ValueOf(time.Date(2015, time.May, 26, 0, 0, 0, 0, time.UTC)), reflect.ValueOf([]string{"2015-04-26", "2015-05-26"}), "in", expect{true, false}},
+		{reflect.ValueOf(time.Date(2015, time.May, 26, 0, 0, 0, 0, time.UTC)), reflect.ValueOf([]interface{}{"2015-04-26", "foo"}), "in", expect{false, false}},
+		{reflect.ValueOf(time.Date(2015, time.May, 26, 0, 0, 0, 0, time.UTC)), reflect.ValueOf("foo"), ">", expect{false, true}},
 	} {
 		result, err := ns.checkCondition(test.value, test.match, test.op)
 		if test.expect.isError {
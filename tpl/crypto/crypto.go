@@ -15,10 +15,16 @@
 package crypto
 
 import (
+	"crypto/hmac"
 	"crypto/md5"
 	"crypto/sha1"
 	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
 	"encoding/hex"
+	"fmt"
+	"hash"
+	"hash/fnv"
 
 	"github.com/spf13/cast"
 )
@@ -63,3 +69,74 @@ func (ns *Namespace) SHA256(in interface{}) (string, error) {
 	hash := sha256.Sum256([]byte(conv))
 	return hex.EncodeToString(hash[:]), nil
 }
+
+// FNV32a hashes the given input and returns its FNV-1a 32 bit hash.
+// This is a fast, non-cryptographic hash, useful for cache busting keys.
+func (ns *Namespace) FNV32a(in interface{}) (int, error) {
+	conv, err := cast.ToStringE(in)
+	if err != nil {
+		return 0, err
+	}
+
+	algorithm := fnv.New32a()
+	algorithm.Write([]byte(conv))
+	return int(algorithm.Sum32()), nil
+}
+
+// HMAC returns the keyed-hash message authentication code of message,
+// using key and the hash function h (md5, sha1, sha256 or sha512).
+// The optional encoding is one of hex (default), base64 or base64url,
+// the latter being unpadded and URL safe.
+func (ns *Namespace) HMAC(h, key, message interface{}, encoding ...interface{}) (string, error) {
+	hs, err := cast.ToStringE(h)
+	if err != nil {
+		return "", err
+	}
+
+	var hashFn func() hash.Hash
+	switch hs {
+	case "md5":
+		hashFn = md5.New
+	case "sha1":
+		hashFn = sha1.New
+	case "sha256":
+		hashFn = sha256.New
+	case "sha512":
+		hashFn = sha512.New
+	default:
+		return "", fmt.Errorf("hmac: %q is not a supported hash function", hs)
+	}
+
+	k, err := cast.ToStringE(key)
+	if err != nil {
+		return "", err
+	}
+
+	m, err := cast.ToStringE(message)
+	if err != nil {
+		return "", err
+	}
+
+	enc := "hex"
+	if len(encoding) > 0 {
+		enc, err = cast.ToStringE(encoding[0])
+		if err != nil {
+			return "", err
+		}
+	}
+
+	mac := hmac.New(hashFn, []byte(k))
+	mac.Write([]byte(m))
+	sum := mac.Sum(nil)
+
+	switch enc {
+	case "hex":
+		return hex.EncodeToString(sum), nil
+	case "base64":
+		return base64.StdEncoding.EncodeToString(sum), nil
+	case "base64url":
+		return base64.RawURLEncoding.EncodeToString(sum), nil
+	default:
+		return "", fmt.Errorf("hmac: %q is not a supported encoding", enc)
+	}
+}
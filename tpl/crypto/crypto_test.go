@@ -100,3 +100,64 @@ func TestSHA256(t *testing.T) {
 		c.Assert(result, qt.Equals, test.expect, errMsg)
 	}
 }
+
+func TestFNV32a(t *testing.T) {
+	t.Parallel()
+	c := qt.New(t)
+	ns := New()
+
+	for i, test := range []struct {
+		in     interface{}
+		expect interface{}
+	}{
+		{"Hugo Rocks!!", 1515779328},
+		{t, false},
+	} {
+		errMsg := qt.Commentf("[%d] %v", i, test.in)
+
+		result, err := ns.FNV32a(test.in)
+
+		if b, ok := test.expect.(bool); ok && !b {
+			c.Assert(err, qt.Not(qt.IsNil), errMsg)
+			continue
+		}
+
+		c.Assert(err, qt.IsNil, errMsg)
+		c.Assert(result, qt.Equals, test.expect, errMsg)
+	}
+}
+
+func TestHMAC(t *testing.T) {
+	t.Parallel()
+	c := qt.New(t)
+	ns := New()
+
+	for i, test := range []struct {
+		hash     interface{}
+		key      interface{}
+		msg      interface{}
+		encoding []interface{}
+		expect   interface{}
+	}{
+		{"sha256", "Secret key", "Hello world, gophers!", nil, "b6d11b6c53830b9d87036272ca9fe9d19306b8f9d8aa07b15da27d89e6e34f40"},
+		{"sha256", "Secret key", "Hello world, gophers!", []interface{}{"base64"}, "ttEbbFODC52HA2Jyyp/p0ZMGuPnYqgexXaJ9iebjT0A="},
+		{"sha256", "Secret key", "Hello world, gophers!", []interface{}{"base64url"}, "ttEbbFODC52HA2Jyyp_p0ZMGuPnYqgexXaJ9iebjT0A"},
+		{"md5", "Secret key", "Hello world, gophers!", nil, "36eb69b6bf2de96b6856fdee8bf89754"},
+		{"sha256", "Secret key", "Hello world, gophers!", []interface{}{"base32"}, false},
+		{"sha384", "Secret key", "Hello world, gophers!", nil, false},
+		{"sha256", t, "Hello world, gophers!", nil, false},
+		{"sha256", "Secret key", t, nil, false},
+	} {
+		errMsg := qt.Commentf("[%d] %v", i, test.hash)
+
+		result, err := ns.HMAC(test.hash, test.key, test.msg, test.encoding...)
+
+		if b, ok := test.expect.(bool); ok && !b {
+			c.Assert(err, qt.Not(qt.IsNil), errMsg)
+			continue
+		}
+
+		c.Assert(err, qt.IsNil, errMsg)
+		c.Assert(result, qt.Equals, test.expect, errMsg)
+	}
+}
@@ -51,6 +51,21 @@ func init() {
 			},
 		)
 
+		ns.AddMethodMapping(ctx.FNV32a,
+			nil,
+			[][2]string{
+				{`{{ crypto.FNV32a "Hugo Rocks!!" }}`, `1515779328`},
+			},
+		)
+
+		ns.AddMethodMapping(ctx.HMAC,
+			[]string{"hmac"},
+			[][2]string{
+				{`{{ hmac "sha256" "Secret key" "Hello world, gophers!" }}`, `b6d11b6c53830b9d87036272ca9fe9d19306b8f9d8aa07b15da27d89e6e34f40`},
+				{`{{ crypto.HMAC "sha256" "Secret key" "Hello world, gophers!" "base64url" }}`, `ttEbbFODC52HA2Jyyp_p0ZMGuPnYqgexXaJ9iebjT0A`},
+			},
+		)
+
 		return ns
 
 	}
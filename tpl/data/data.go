@@ -22,6 +22,7 @@ import (
 	"errors"
 	"net/http"
 	"strings"
+	"sync"
 
 	"github.com/gohugoio/hugo/cache/filecache"
 	"github.com/gohugoio/hugo/deps"
@@ -47,6 +48,11 @@ type Namespace struct {
 	cacheGetCSV  *filecache.Cache
 
 	client *http.Client
+
+	remoteInit  sync.Once
+	remoteData  []*remoteData
+	remoteFetch remoteFetch
+	remoteErr   error
 }
 
 // GetCSV expects a data separator and one or n-parts of a URL to a resource which
@@ -81,6 +87,10 @@ func (ns *Namespace) GetCSV(sep string, urlParts ...string) (d [][]string, err e
 	req.Header.Add("Accept", "text/csv")
 	req.Header.Add("Accept", "text/plain")
 
+	if err = ns.applyRemoteData(req); err != nil {
+		return nil, err
+	}
+
 	err = ns.getResource(cache, unmarshal, req)
 	if err != nil {
 		ns.deps.Log.ERROR.Printf("Failed to get CSV resource %q: %s", url, err)
@@ -113,6 +123,10 @@ func (ns *Namespace) GetJSON(urlParts ...string) (interface{}, error) {
 
 	req.Header.Add("Accept", "application/json")
 
+	if err := ns.applyRemoteData(req); err != nil {
+		return nil, err
+	}
+
 	err = ns.getResource(cache, unmarshal, req)
 	if err != nil {
 		ns.deps.Log.ERROR.Printf("Failed to get JSON resource %q: %s", url, err)
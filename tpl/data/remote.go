@@ -0,0 +1,165 @@
+// Copyright 2019 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package data
+
+import (
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/gohugoio/hugo/config"
+	"github.com/gohugoio/hugo/hugofs/glob"
+	"github.com/mitchellh/mapstructure"
+	"github.com/pkg/errors"
+)
+
+const (
+	remoteDataConfigKey  = "remoteData"
+	remoteFetchConfigKey = "remoteFetch"
+)
+
+// remoteFetch configures how remote resources are fetched.
+type remoteFetch struct {
+	// The timeout for each request. The default, 0, means no timeout.
+	Timeout time.Duration
+
+	// The number of retries when a request fails with a network error, a
+	// server error (5xx), 429 Too Many Requests or a body that can not be
+	// parsed.
+	Retries int
+
+	// The time to wait before the first retry. It doubles for each retry.
+	Backoff time.Duration
+
+	// If set, an expired copy in the cache is used when a request fails,
+	// with a warning instead of an error.
+	FallbackToCache bool
+}
+
+var defaultRemoteFetch = remoteFetch{
+	Retries: 1,
+	Backoff: 2 * time.Second,
+}
+
+func decodeRemoteFetch(cfg config.Provider) (remoteFetch, error) {
+	c := defaultRemoteFetch
+
+	if !cfg.IsSet(remoteFetchConfigKey) {
+		return c, nil
+	}
+
+	dc := &mapstructure.DecoderConfig{
+		Result:           &c,
+		DecodeHook:       mapstructure.StringToTimeDurationHookFunc(),
+		WeaklyTypedInput: true,
+	}
+
+	decoder, err := mapstructure.NewDecoder(dc)
+	if err != nil {
+		return c, err
+	}
+
+	if err := decoder.Decode(cfg.GetStringMap(remoteFetchConfigKey)); err != nil {
+		return c, errors.Wrap(err, "failed to decode remoteFetch config")
+	}
+
+	if c.Retries < 0 || c.Timeout < 0 || c.Backoff < 0 {
+		return c, errors.New("remoteFetch timeout, retries and backoff can not be negative")
+	}
+
+	return c, nil
+}
+
+// backoff returns the time to wait before the given retry, starting at 1.
+func (c remoteFetch) backoff(retry int) time.Duration {
+	return c.Backoff * time.Duration(1<<uint(retry-1))
+}
+
+// remoteData configures the requests for the remote resources with a URL
+// matching For. Environment variables in the header values, User and Password
+// are expanded, e.g. "Bearer ${API_TOKEN}", so secrets can be kept out of the
+// site config.
+type remoteData struct {
+	// A Glob pattern matched against the URL, e.g. "https://api.example.org/**".
+	For string
+
+	Headers map[string]string
+
+	// Basic authentication.
+	User     string
+	Password string
+
+	match func(s string) bool
+}
+
+func decodeRemoteData(cfg config.Provider) ([]*remoteData, error) {
+	var rds []*remoteData
+
+	if !cfg.IsSet(remoteDataConfigKey) {
+		return rds, nil
+	}
+
+	if err := mapstructure.WeakDecode(cfg.Get(remoteDataConfigKey), &rds); err != nil {
+		return nil, errors.Wrap(err, "failed to decode remoteData config")
+	}
+
+	for _, rd := range rds {
+		if rd.For == "" {
+			return nil, errors.New("remoteData config must have a URL pattern set in for")
+		}
+		g, err := glob.GetGlob(rd.For)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid remoteData pattern %q", rd.For)
+		}
+		rd.match = g.Match
+	}
+
+	return rds, nil
+}
+
+func (ns *Namespace) initRemote() error {
+	ns.remoteInit.Do(func() {
+		ns.remoteData, ns.remoteErr = decodeRemoteData(ns.deps.Cfg)
+		if ns.remoteErr == nil {
+			ns.remoteFetch, ns.remoteErr = decodeRemoteFetch(ns.deps.Cfg)
+		}
+	})
+	return ns.remoteErr
+}
+
+// applyRemoteData sets the headers and basic authentication from the first
+// remoteData config matching the request URL, if any.
+func (ns *Namespace) applyRemoteData(req *http.Request) error {
+	if err := ns.initRemote(); err != nil {
+		return err
+	}
+
+	url := strings.ToLower(req.URL.String())
+
+	for _, rd := range ns.remoteData {
+		if !rd.match(url) {
+			continue
+		}
+		for k, v := range rd.Headers {
+			req.Header.Set(k, os.ExpandEnv(v))
+		}
+		if rd.User != "" {
+			req.SetBasicAuth(os.ExpandEnv(rd.User), os.ExpandEnv(rd.Password))
+		}
+		return nil
+	}
+
+	return nil
+}
@@ -0,0 +1,204 @@
+// Copyright 2019 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package data
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/gohugoio/hugo/cache/filecache"
+	"github.com/gohugoio/hugo/helpers"
+	"github.com/spf13/afero"
+	"github.com/spf13/viper"
+)
+
+func TestRemoteData(t *testing.T) {
+	c := qt.New(t)
+
+	os.Setenv("HUGO_TEST_API_TOKEN", "s3cret")
+	defer os.Unsetenv("HUGO_TEST_API_TOKEN")
+
+	v := viper.New()
+	v.Set("contentDir", "content")
+	v.Set("remoteData", []map[string]interface{}{
+		{
+			"for": "http://api.example.org/**",
+			"headers": map[string]interface{}{
+				"Authorization": "Bearer ${HUGO_TEST_API_TOKEN}",
+				"Accept":        "application/vnd.api+json",
+			},
+		},
+		{
+			"for":      "http://csv.example.org/**",
+			"user":     "hugo",
+			"password": "$HUGO_TEST_API_TOKEN",
+		},
+	})
+
+	ns := New(newDeps(v))
+
+	srv, client := getTestServer(func(w http.ResponseWriter, r *http.Request) {
+		user, password, _ := r.BasicAuth()
+		fmt.Fprintf(w, `{"authorization": %q, "accept": %q, "user": %q, "password": %q}`,
+			r.Header.Get("Authorization"), r.Header.Get("Accept"), user, password)
+	})
+	defer srv.Close()
+	ns.client = client
+
+	got, err := ns.GetJSON("http://api.example.org/data")
+	c.Assert(err, qt.IsNil)
+	c.Assert(got, qt.DeepEquals, map[string]interface{}{
+		"authorization": "Bearer s3cret",
+		"accept":        "application/vnd.api+json",
+		"user":          "",
+		"password":      "",
+	})
+
+	got, err = ns.GetJSON("http://csv.example.org/data")
+	c.Assert(err, qt.IsNil)
+	c.Assert(got, qt.DeepEquals, map[string]interface{}{
+		"authorization": "Basic aHVnbzpzM2NyZXQ=",
+		"accept":        "application/json",
+		"user":          "hugo",
+		"password":      "s3cret",
+	})
+
+	// The credentials are not sent to other hosts.
+	got, err = ns.GetJSON("http://example.org/data")
+	c.Assert(err, qt.IsNil)
+	c.Assert(got, qt.DeepEquals, map[string]interface{}{
+		"authorization": "",
+		"accept":        "application/json",
+		"user":          "",
+		"password":      "",
+	})
+}
+
+func TestRemoteDataInvalidConfig(t *testing.T) {
+	c := qt.New(t)
+
+	v := viper.New()
+	v.Set("contentDir", "content")
+	v.Set("remoteData", []map[string]interface{}{
+		{"headers": map[string]interface{}{"Authorization": "Bearer foo"}},
+	})
+
+	ns := New(newDeps(v))
+
+	_, err := ns.GetJSON("http://api.example.org/data")
+	c.Assert(err, qt.Not(qt.IsNil))
+}
+
+func TestRemoteFetch(t *testing.T) {
+	c := qt.New(t)
+
+	newNs := func(fetch map[string]interface{}) *Namespace {
+		v := viper.New()
+		v.Set("contentDir", "content")
+		v.Set("remoteFetch", fetch)
+		return New(newDeps(v))
+	}
+
+	c.Run("Retry", func(c *qt.C) {
+		ns := newNs(map[string]interface{}{"retries": 2, "backoff": "1ms"})
+		var requests int32
+		srv, client := getTestServer(func(w http.ResponseWriter, r *http.Request) {
+			if atomic.AddInt32(&requests, 1) < 3 {
+				http.Error(w, http.StatusText(http.StatusServiceUnavailable), http.StatusServiceUnavailable)
+				return
+			}
+			w.Write([]byte(`{"a": "b"}`))
+		})
+		defer srv.Close()
+		ns.client = client
+
+		got, err := ns.GetJSON("http://example.org/retry")
+		c.Assert(err, qt.IsNil)
+		c.Assert(got, qt.DeepEquals, map[string]interface{}{"a": "b"})
+		c.Assert(atomic.LoadInt32(&requests), qt.Equals, int32(3))
+		c.Assert(int(ns.deps.Log.ErrorCounter.Count()), qt.Equals, 0)
+	})
+
+	c.Run("No retry on client error", func(c *qt.C) {
+		ns := newNs(map[string]interface{}{"retries": 2, "backoff": "1ms"})
+		var requests int32
+		srv, client := getTestServer(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&requests, 1)
+			http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		})
+		defer srv.Close()
+		ns.client = client
+
+		got, _ := ns.GetJSON("http://example.org/notfound")
+		c.Assert(got, qt.IsNil)
+		c.Assert(atomic.LoadInt32(&requests), qt.Equals, int32(1))
+		c.Assert(int(ns.deps.Log.ErrorCounter.Count()), qt.Equals, 1)
+	})
+
+	c.Run("Timeout", func(c *qt.C) {
+		ns := newNs(map[string]interface{}{"timeout": "10ms", "retries": 0})
+		srv, client := getTestServer(func(w http.ResponseWriter, r *http.Request) {
+			time.Sleep(200 * time.Millisecond)
+			w.Write([]byte(`{"a": "b"}`))
+		})
+		defer srv.Close()
+		ns.client = client
+
+		got, _ := ns.GetJSON("http://example.org/slow")
+		c.Assert(got, qt.IsNil)
+		c.Assert(int(ns.deps.Log.ErrorCounter.Count()), qt.Equals, 1)
+	})
+
+	c.Run("Fallback to cache", func(c *qt.C) {
+		ns := newNs(map[string]interface{}{"retries": 0, "fallbackToCache": true})
+		fs := afero.NewMemMapFs()
+		ns.cacheGetJSON = filecache.NewCache(fs, time.Hour, "")
+		var fail int32
+		srv, client := getTestServer(func(w http.ResponseWriter, r *http.Request) {
+			if atomic.LoadInt32(&fail) == 1 {
+				http.Error(w, http.StatusText(http.StatusBadGateway), http.StatusBadGateway)
+				return
+			}
+			w.Write([]byte(`{"a": "b"}`))
+		})
+		defer srv.Close()
+		ns.client = client
+
+		url := "http://example.org/flaky"
+		got, err := ns.GetJSON(url)
+		c.Assert(err, qt.IsNil)
+		c.Assert(got, qt.DeepEquals, map[string]interface{}{"a": "b"})
+
+		// Expire the cached copy.
+		old := time.Now().Add(-2 * time.Hour)
+		c.Assert(fs.Chtimes(helpers.MD5String(url), old, old), qt.IsNil)
+		atomic.StoreInt32(&fail, 1)
+
+		got, err = ns.GetJSON(url)
+		c.Assert(err, qt.IsNil)
+		c.Assert(got, qt.DeepEquals, map[string]interface{}{"a": "b"})
+		c.Assert(int(ns.deps.Log.ErrorCounter.Count()), qt.Equals, 0)
+	})
+
+	c.Run("Invalid config", func(c *qt.C) {
+		ns := newNs(map[string]interface{}{"retries": -1})
+		_, err := ns.GetJSON("http://example.org/data")
+		c.Assert(err, qt.Not(qt.IsNil))
+	})
+}
@@ -14,6 +14,7 @@
 package data
 
 import (
+	"context"
 	"io/ioutil"
 	"net/http"
 	"path/filepath"
@@ -28,46 +29,39 @@ import (
 	"github.com/spf13/afero"
 )
 
-var (
-	resSleep   = time.Second * 2 // if JSON decoding failed sleep for n seconds before retrying
-	resRetries = 1               // number of retries to load the JSON from URL
-)
-
 // getRemote loads the content of a remote file. This method is thread safe.
 func (ns *Namespace) getRemote(cache *filecache.Cache, unmarshal func([]byte) (bool, error), req *http.Request) error {
+	if err := ns.initRemote(); err != nil {
+		return err
+	}
+
+	fetch := ns.remoteFetch
 	url := req.URL.String()
 	id := helpers.MD5String(url)
 	var handled bool
-	var retry bool
 
-	_, b, err := cache.GetOrCreateBytes(id, func() ([]byte, error) {
+	create := func() ([]byte, error) {
 		var err error
 		handled = true
-		for i := 0; i <= resRetries; i++ {
-			ns.deps.Log.INFO.Printf("Downloading: %s ...", url)
-			var res *http.Response
-			res, err = ns.client.Do(req)
-			if err != nil {
-				return nil, err
+		for i := 0; i <= fetch.Retries; i++ {
+			if i > 0 {
+				sleep := fetch.backoff(i)
+				ns.deps.Log.INFO.Printf("Retry #%d for %s and sleeping for %s", i, url, sleep)
+				time.Sleep(sleep)
 			}
 
-			if isHTTPError(res) {
-				return nil, errors.Errorf("Failed to retrieve remote file: %s", http.StatusText(res.StatusCode))
-			}
-
-			var b []byte
-			b, err = ioutil.ReadAll(res.Body)
-
-			if err != nil {
-				return nil, err
-			}
-			res.Body.Close()
-
-			retry, err = unmarshal(b)
+			var (
+				b     []byte
+				retry bool
+			)
 
+			b, retry, err = ns.fetchRemote(req, fetch.Timeout)
 			if err == nil {
-				// Return it so it can be cached.
-				return b, nil
+				retry, err = unmarshal(b)
+				if err == nil {
+					// Return it so it can be cached.
+					return b, nil
+				}
 			}
 
 			if !retry {
@@ -75,13 +69,26 @@ func (ns *Namespace) getRemote(cache *filecache.Cache, unmarshal func([]byte) (b
 			}
 
 			ns.deps.Log.INFO.Printf("Cannot read remote resource %s: %s", url, err)
-			ns.deps.Log.INFO.Printf("Retry #%d for %s and sleeping for %s", i+1, url, resSleep)
-			time.Sleep(resSleep)
 		}
 
 		return nil, err
+	}
 
-	})
+	var (
+		b   []byte
+		err error
+	)
+
+	if fetch.FallbackToCache {
+		var info filecache.ItemInfo
+		info, b, err = cache.GetOrCreateBytesOrStale(id, create)
+		if info.Stale {
+			ns.deps.Log.WARN.Printf("Failed to get remote resource %q, using the expired copy in the cache: %s", url, err)
+			handled = false
+		}
+	} else {
+		_, b, err = cache.GetOrCreateBytes(id, create)
+	}
 
 	if !handled {
 		// This is cached content and should be correct.
@@ -91,6 +98,36 @@ func (ns *Namespace) getRemote(cache *filecache.Cache, unmarshal func([]byte) (b
 	return err
 }
 
+// fetchRemote does one request for req and returns the response body. If it
+// fails, it also returns whether it's worth retrying.
+func (ns *Namespace) fetchRemote(req *http.Request, timeout time.Duration) ([]byte, bool, error) {
+	if timeout > 0 {
+		ctx, cancel := context.WithTimeout(req.Context(), timeout)
+		defer cancel()
+		req = req.WithContext(ctx)
+	}
+
+	ns.deps.Log.INFO.Printf("Downloading: %s ...", req.URL)
+
+	res, err := ns.client.Do(req)
+	if err != nil {
+		return nil, true, err
+	}
+	defer res.Body.Close()
+
+	if isHTTPError(res) {
+		retry := res.StatusCode >= 500 || res.StatusCode == http.StatusTooManyRequests
+		return nil, retry, errors.Errorf("Failed to retrieve remote file: %s", http.StatusText(res.StatusCode))
+	}
+
+	b, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, true, err
+	}
+
+	return b, false, nil
+}
+
 // getLocal loads the content of a local file
 func getLocal(url string, fs afero.Fs, cfg config.Provider) ([]byte, error) {
 	filename := filepath.Join(cfg.GetString("workingDir"), url)
@@ -0,0 +1,256 @@
+// Copyright 2020 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package debug
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cast"
+)
+
+// defaultDepth is the max nesting level printed by Dump when no depth is given.
+const defaultDepth = 5
+
+// New returns a new instance of the debug-namespaced template functions.
+func New() *Namespace {
+	return &Namespace{}
+}
+
+// Namespace provides template functions for the "debug" namespace.
+type Namespace struct{}
+
+// Dump returns a pretty-printed, indented representation of the last argument.
+// An optional first argument sets the max nesting depth (default 5); deeper
+// values and reference cycles are replaced with a short placeholder.
+// Structs implementing fmt.Stringer (e.g. pages and resources) are printed
+// using their String method.
+//
+//	{{ debug.Dump .Params }}
+//	{{ .Site.Params | debug.Dump 2 }}
+func (ns *Namespace) Dump(args ...interface{}) (string, error) {
+	var (
+		v     interface{}
+		depth = defaultDepth
+	)
+
+	switch len(args) {
+	case 1:
+		v = args[0]
+	case 2:
+		d, err := cast.ToIntE(args[0])
+		if err != nil {
+			return "", errors.Wrap(err, "invalid depth")
+		}
+		if d < 1 {
+			return "", errors.Errorf("depth must be at least 1, got %d", d)
+		}
+		depth = d
+		v = args[1]
+	default:
+		return "", errors.New("must provide a value and an optional depth")
+	}
+
+	d := &dumper{maxDepth: depth, seen: make(map[seenKey]bool)}
+	d.dump(reflect.ValueOf(v), 0)
+
+	return d.b.String(), nil
+}
+
+type dumper struct {
+	b        strings.Builder
+	maxDepth int
+
+	// Addresses of the maps, slices and pointers on the current path,
+	// used to detect reference cycles.
+	seen map[seenKey]bool
+}
+
+type seenKey struct {
+	t reflect.Type
+	p uintptr
+}
+
+var stringerType = reflect.TypeOf((*fmt.Stringer)(nil)).Elem()
+
+func (d *dumper) dump(v reflect.Value, level int) {
+	if !v.IsValid() {
+		d.b.WriteString("nil")
+		return
+	}
+
+	if isStructLike(v) && v.Type().Implements(stringerType) {
+		if isNil(v) {
+			d.b.WriteString("nil")
+			return
+		}
+		d.b.WriteString(v.Interface().(fmt.Stringer).String())
+		return
+	}
+
+	switch v.Kind() {
+	case reflect.Interface:
+		if v.IsNil() {
+			d.b.WriteString("nil")
+			return
+		}
+		d.dump(v.Elem(), level)
+	case reflect.Ptr:
+		if v.IsNil() {
+			d.b.WriteString("nil")
+			return
+		}
+		if !d.enter(v) {
+			return
+		}
+		defer d.leave(v)
+		d.dump(v.Elem(), level)
+	case reflect.Map:
+		if v.IsNil() {
+			d.b.WriteString("nil")
+			return
+		}
+		if v.Len() == 0 {
+			d.b.WriteString("{}")
+			return
+		}
+		if level >= d.maxDepth {
+			fmt.Fprintf(&d.b, "{...%d keys}", v.Len())
+			return
+		}
+		if !d.enter(v) {
+			return
+		}
+		defer d.leave(v)
+
+		keys := v.MapKeys()
+		sort.Slice(keys, func(i, j int) bool {
+			return fmt.Sprint(keys[i].Interface()) < fmt.Sprint(keys[j].Interface())
+		})
+		d.b.WriteString("{\n")
+		for i, k := range keys {
+			d.indent(level + 1)
+			fmt.Fprintf(&d.b, "%q: ", fmt.Sprint(k.Interface()))
+			d.dump(v.MapIndex(k), level+1)
+			d.separator(i, len(keys))
+		}
+		d.indent(level)
+		d.b.WriteString("}")
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			d.b.WriteString("nil")
+			return
+		}
+		if v.Len() == 0 {
+			d.b.WriteString("[]")
+			return
+		}
+		if v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.Uint8 {
+			fmt.Fprintf(&d.b, "%q", v.Bytes())
+			return
+		}
+		if level >= d.maxDepth {
+			fmt.Fprintf(&d.b, "[...%d items]", v.Len())
+			return
+		}
+		if v.Kind() == reflect.Slice {
+			if !d.enter(v) {
+				return
+			}
+			defer d.leave(v)
+		}
+
+		d.b.WriteString("[\n")
+		for i := 0; i < v.Len(); i++ {
+			d.indent(level + 1)
+			d.dump(v.Index(i), level+1)
+			d.separator(i, v.Len())
+		}
+		d.indent(level)
+		d.b.WriteString("]")
+	case reflect.Struct:
+		t := v.Type()
+		var fields []int
+		for i := 0; i < t.NumField(); i++ {
+			if t.Field(i).PkgPath == "" {
+				fields = append(fields, i)
+			}
+		}
+		if len(fields) == 0 {
+			fmt.Fprintf(&d.b, "<%s>", t)
+			return
+		}
+		if level >= d.maxDepth {
+			fmt.Fprintf(&d.b, "%s{...}", t)
+			return
+		}
+		fmt.Fprintf(&d.b, "%s{\n", t)
+		for i, idx := range fields {
+			d.indent(level + 1)
+			fmt.Fprintf(&d.b, "%s: ", t.Field(idx).Name)
+			d.dump(v.Field(idx), level+1)
+			d.separator(i, len(fields))
+		}
+		d.indent(level)
+		d.b.WriteString("}")
+	case reflect.String:
+		fmt.Fprintf(&d.b, "%q", v.String())
+	case reflect.Func, reflect.Chan, reflect.UnsafePointer:
+		fmt.Fprintf(&d.b, "<%s>", v.Type())
+	default:
+		fmt.Fprintf(&d.b, "%v", v.Interface())
+	}
+}
+
+// enter marks v as being on the current path. It returns false and writes
+// a placeholder if v is already on it.
+func (d *dumper) enter(v reflect.Value) bool {
+	k := seenKey{t: v.Type(), p: v.Pointer()}
+	if d.seen[k] {
+		fmt.Fprintf(&d.b, "<cycle %s>", v.Type())
+		return false
+	}
+	d.seen[k] = true
+	return true
+}
+
+func (d *dumper) leave(v reflect.Value) {
+	delete(d.seen, seenKey{t: v.Type(), p: v.Pointer()})
+}
+
+func (d *dumper) indent(level int) {
+	d.b.WriteString(strings.Repeat("  ", level))
+}
+
+func (d *dumper) separator(i, n int) {
+	if i < n-1 {
+		d.b.WriteString(",")
+	}
+	d.b.WriteString("\n")
+}
+
+func isStructLike(v reflect.Value) bool {
+	k := v.Kind()
+	if k == reflect.Ptr {
+		k = v.Type().Elem().Kind()
+	}
+	return k == reflect.Struct
+}
+
+func isNil(v reflect.Value) bool {
+	return v.Kind() == reflect.Ptr && v.IsNil()
+}
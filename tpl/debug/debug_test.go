@@ -0,0 +1,75 @@
+// Copyright 2020 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package debug
+
+import (
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+type tstStringer struct{ s string }
+
+func (t *tstStringer) String() string { return "Stringer(" + t.s + ")" }
+
+type tstNode struct {
+	Name     string
+	Children []*tstNode
+	Parent   *tstNode
+	hidden   int
+}
+
+func TestDump(t *testing.T) {
+	c := qt.New(t)
+	ns := New()
+
+	cyclic := map[string]interface{}{"a": 1}
+	cyclic["self"] = cyclic
+
+	parent := &tstNode{Name: "p"}
+	parent.Children = []*tstNode{{Name: "c", Parent: parent}}
+
+	for i, test := range []struct {
+		args   []interface{}
+		expect interface{}
+	}{
+		{[]interface{}{nil}, "nil"},
+		{[]interface{}{"a"}, `"a"`},
+		{[]interface{}{42}, "42"},
+		{[]interface{}{[]string{}}, "[]"},
+		{[]interface{}{[]interface{}{1, "b", nil}}, "[\n  1,\n  \"b\",\n  nil\n]"},
+		{[]interface{}{map[string]interface{}{"b": 2, "a": []int{1}}}, "{\n  \"a\": [\n    1\n  ],\n  \"b\": 2\n}"},
+		{[]interface{}{&tstStringer{s: "x"}}, "Stringer(x)"},
+		{[]interface{}{[]*tstStringer{{s: "x"}, nil}}, "[\n  Stringer(x),\n  nil\n]"},
+		{[]interface{}{cyclic}, "{\n  \"a\": 1,\n  \"self\": <cycle map[string]interface {}>\n}"},
+		{[]interface{}{1, map[string]interface{}{"a": map[string]int{"b": 1}, "c": []int{1, 2}}}, "{\n  \"a\": {...1 keys},\n  \"c\": [...2 items]\n}"},
+		{[]interface{}{2, parent}, "debug.tstNode{\n  Name: \"p\",\n  Children: [\n    debug.tstNode{...}\n  ],\n  Parent: nil\n}"},
+		{[]interface{}{3, parent}, "debug.tstNode{\n  Name: \"p\",\n  Children: [\n    debug.tstNode{\n      Name: \"c\",\n      Children: nil,\n      Parent: <cycle *debug.tstNode>\n    }\n  ],\n  Parent: nil\n}"},
+		{[]interface{}{0, "a"}, false},
+		{[]interface{}{"x", "a"}, false},
+		{[]interface{}{}, false},
+	} {
+		errMsg := qt.Commentf("[%d] %v", i, test.args)
+
+		result, err := ns.Dump(test.args...)
+
+		if b, ok := test.expect.(bool); ok && !b {
+			c.Assert(err, qt.Not(qt.IsNil), errMsg)
+			continue
+		}
+
+		c.Assert(err, qt.IsNil, errMsg)
+		c.Assert(result, qt.Equals, test.expect, errMsg)
+	}
+}
@@ -17,18 +17,27 @@ package fmt
 import (
 	_fmt "fmt"
 
+	"github.com/gohugoio/hugo/common/loggers"
 	"github.com/gohugoio/hugo/deps"
 	"github.com/gohugoio/hugo/helpers"
 )
 
 // New returns a new instance of the fmt-namespaced template functions.
 func New(d *deps.Deps) *Namespace {
-	return &Namespace{helpers.NewDistinctLogger(d.Log.ERROR)}
+	return &Namespace{
+		logger:      d.Log,
+		errorLogger: helpers.NewDistinctLogger(d.Log.ERROR),
+		idLogger:    d.DistinctIDLog,
+	}
 }
 
 // Namespace provides template functions for the "fmt" namespace.
 type Namespace struct {
+	logger      *loggers.Logger
 	errorLogger *helpers.DistinctLogger
+
+	// Logs the messages with an ID once per build, shared by all languages.
+	idLogger *helpers.DistinctLogger
 }
 
 // Print returns string representation of the passed arguments.
@@ -53,3 +62,33 @@ func (ns *Namespace) Errorf(format string, a ...interface{}) string {
 	ns.errorLogger.Printf(format, a...)
 	return _fmt.Sprintf(format, a...)
 }
+
+// Erroridf formats according to a format specifier and logs an ERROR with
+// the given ID, once per build, which will fail the build. The error can
+// be ignored by adding the ID to ignoreLogs in the site configuration.
+// It returns an empty string.
+func (ns *Namespace) Erroridf(id, format string, a ...interface{}) string {
+	ns.logID("error", id, format, a...)
+	return ""
+}
+
+// Warnidf formats according to a format specifier and logs a WARNING with
+// the given ID, once per build. The warning can be ignored by adding the
+// ID to ignoreLogs in the site configuration.
+// It returns an empty string.
+func (ns *Namespace) Warnidf(id, format string, a ...interface{}) string {
+	ns.logID("warning", id, format, a...)
+	return ""
+}
+
+// logID logs the message in the log category named by the ID, so it can be
+// dropped with ignoreLogs or logSuppress.
+func (ns *Namespace) logID(kind, id, format string, a ...interface{}) {
+	category := ns.logger.Category(id)
+	logger := category.WARN
+	if kind == "error" {
+		logger = category.ERROR
+	}
+	msg := _fmt.Sprintf(format, a...)
+	ns.idLogger.PrintfTo(logger, "%s\nYou can suppress this %s by adding the following to your site configuration:\nignoreLogs = ['%s']", msg, kind, id)
+}
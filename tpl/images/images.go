@@ -15,7 +15,12 @@
 package images
 
 import (
+	"bytes"
+	"crypto/md5"
+	"encoding/hex"
 	"image"
+	"io/ioutil"
+	"strings"
 	"sync"
 
 	"github.com/disintegration/gift"
@@ -108,3 +113,97 @@ func (ns *Namespace) Filter(args ...interface{}) (resource.Image, error) {
 
 	return img.Filter(filters...)
 }
+
+// Card composes an Open Graph card of 1200x630 pixels from the background
+// image given as the last argument, with the title written in the bottom
+// left corner on top of a gradient. An optional map of options may be
+// given between the title and the image, see images.CardOptions. The
+// "logo" option may be set to an image resource that is drawn in the top
+// left corner.
+func (ns *Namespace) Card(args ...interface{}) (resource.Image, error) {
+	if len(args) < 2 || len(args) > 3 {
+		return nil, errors.New("must provide a title, optional options and an image")
+	}
+
+	title, err := cast.ToStringE(args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	img, ok := args[len(args)-1].(resource.Image)
+	if !ok {
+		return nil, errors.Errorf("type %T is not an image", args[len(args)-1])
+	}
+
+	var m map[string]interface{}
+	if len(args) == 3 {
+		m, err = cast.ToStringMapE(args[1])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var logo resource.Image
+	// Copy the options, as the map may be the one passed from the template.
+	cardOpts := make(map[string]interface{})
+	for k, v := range m {
+		if strings.EqualFold(k, "logo") {
+			if logo, ok = v.(resource.Image); !ok {
+				return nil, errors.Errorf("card logo of type %T is not an image", v)
+			}
+			continue
+		}
+		cardOpts[k] = v
+	}
+
+	opts, err := images.DecodeCardOptions(cardOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		logoImg image.Image
+		logoKey string
+	)
+	if logo != nil {
+		logoImg, logoKey, err = decodeImage(logo)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to decode card logo")
+		}
+	}
+
+	filters, err := images.CardFilters(title, logoImg, logoKey, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return img.Filter(filters...)
+}
+
+// decodeImage decodes img and returns it with a MD5 hash of its content.
+func decodeImage(img resource.Image) (image.Image, string, error) {
+	rp, ok := img.(resource.ReadSeekCloserProvider)
+	if !ok {
+		return nil, "", errors.Errorf("type %T does not provide its content", img)
+	}
+
+	f, err := rp.ReadSeekCloser()
+	if err != nil {
+		return nil, "", err
+	}
+	defer f.Close()
+
+	b, err := ioutil.ReadAll(f)
+	if err != nil {
+		return nil, "", err
+	}
+
+	decoded, _, err := image.Decode(bytes.NewReader(b))
+	if err != nil {
+		return nil, "", err
+	}
+
+	hash := md5.Sum(b)
+
+	return decoded, hex.EncodeToString(hash[:]), nil
+}
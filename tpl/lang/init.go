@@ -14,6 +14,7 @@
 package lang
 
 import (
+	"github.com/gohugoio/hugo/common/htime"
 	"github.com/gohugoio/hugo/deps"
 	"github.com/gohugoio/hugo/tpl/internal"
 )
@@ -22,7 +23,11 @@ const name = "lang"
 
 func init() {
 	f := func(d *deps.Deps) *internal.TemplateFuncsNamespace {
-		ctx := New(d)
+		lang := "en"
+		if d.Language != nil {
+			lang = d.Language.Lang
+		}
+		ctx := New(d, htime.GetTranslator(lang))
 
 		ns := &internal.TemplateFuncsNamespace{
 			Name:    name,
@@ -44,6 +49,34 @@ func init() {
 				{`{{ -98765.4321 | lang.NumFmt 2 }}`, `-98,765.43`},
 			},
 		)
+
+		ns.AddMethodMapping(ctx.FormatNumber,
+			nil,
+			[][2]string{
+				{`{{ 512.5032 | lang.FormatNumber 2 }}`, `512.50`},
+			},
+		)
+
+		ns.AddMethodMapping(ctx.FormatPercent,
+			nil,
+			[][2]string{
+				{`{{ 512.5032 | lang.FormatPercent 2 }}`, `512.50%`},
+			},
+		)
+
+		ns.AddMethodMapping(ctx.FormatCurrency,
+			nil,
+			[][2]string{
+				{`{{ 512.5032 | lang.FormatCurrency 2 "USD" }}`, `$512.50`},
+			},
+		)
+
+		ns.AddMethodMapping(ctx.FormatAccounting,
+			nil,
+			[][2]string{
+				{`{{ 512.5032 | lang.FormatAccounting 2 "NOK" }}`, `NOK512.50`},
+			},
+		)
 		return ns
 
 	}
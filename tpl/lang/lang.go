@@ -21,20 +21,24 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/go-playground/locales"
+	"github.com/go-playground/locales/currency"
 	"github.com/gohugoio/hugo/deps"
 	"github.com/spf13/cast"
 )
 
 // New returns a new instance of the lang-namespaced template functions.
-func New(deps *deps.Deps) *Namespace {
+func New(deps *deps.Deps, translator locales.Translator) *Namespace {
 	return &Namespace{
-		deps: deps,
+		translator: translator,
+		deps:       deps,
 	}
 }
 
 // Namespace provides template functions for the "lang" namespace.
 type Namespace struct {
-	deps *deps.Deps
+	translator locales.Translator
+	deps       *deps.Deps
 }
 
 // Translate returns a translated string for id.
@@ -47,6 +51,87 @@ func (ns *Namespace) Translate(id interface{}, args ...interface{}) (string, err
 	return ns.deps.Translate(sid, args...), nil
 }
 
+// FormatNumber formats number with the given precision using the
+// decimal and grouping conventions of the current language.
+func (ns *Namespace) FormatNumber(precision, number interface{}) (string, error) {
+	p, n, err := ns.castPrecisionNumber(precision, number)
+	if err != nil {
+		return "", err
+	}
+	return ns.translator.FmtNumber(n, p), nil
+}
+
+// FormatPercent formats number with the given precision as a percentage
+// using the conventions of the current language. Note that number is
+// not multiplied by 100, so 12.5 is formatted as "12.5%" in English.
+func (ns *Namespace) FormatPercent(precision, number interface{}) (string, error) {
+	p, n, err := ns.castPrecisionNumber(precision, number)
+	if err != nil {
+		return "", err
+	}
+	return ns.translator.FmtPercent(n, p), nil
+}
+
+// FormatCurrency formats number with the given precision as an amount
+// in the given ISO 4217 currency code, e.g. "USD" or "NOK", using the
+// currency symbol and conventions of the current language.
+func (ns *Namespace) FormatCurrency(precision, currency, number interface{}) (string, error) {
+	p, n, err := ns.castPrecisionNumber(precision, number)
+	if err != nil {
+		return "", err
+	}
+	c, err := toCurrency(currency)
+	if err != nil {
+		return "", err
+	}
+	return ns.translator.FmtCurrency(n, p, c), nil
+}
+
+// FormatAccounting formats number with the given precision as an amount
+// in the given ISO 4217 currency code using the accounting conventions of
+// the current language, e.g. negative amounts in parentheses.
+func (ns *Namespace) FormatAccounting(precision, currency, number interface{}) (string, error) {
+	p, n, err := ns.castPrecisionNumber(precision, number)
+	if err != nil {
+		return "", err
+	}
+	c, err := toCurrency(currency)
+	if err != nil {
+		return "", err
+	}
+	return ns.translator.FmtAccounting(n, p, c), nil
+}
+
+func (ns *Namespace) castPrecisionNumber(precision, number interface{}) (uint64, float64, error) {
+	p, err := cast.ToUint64E(precision)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	// Sanity check.
+	if p > 20 {
+		return 0, 0, fmt.Errorf("invalid precision: %d", precision)
+	}
+
+	n, err := cast.ToFloat64E(number)
+	if err != nil {
+		return 0, 0, err
+	}
+	return p, n, nil
+}
+
+func toCurrency(v interface{}) (currency.Type, error) {
+	s, err := cast.ToStringE(v)
+	if err != nil {
+		return 0, err
+	}
+	c, found := currencies[strings.ToUpper(s)]
+	if !found {
+		return 0, fmt.Errorf("unknown currency code: %q", s)
+	}
+	return c, nil
+}
+
 // NumFmt formats a number with the given precision using the
 // negative, decimal, and grouping options.  The `options`
 // parameter is a string consisting of `<negative> <decimal> <grouping>`.  The
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	qt "github.com/frankban/quicktest"
+	"github.com/gohugoio/hugo/common/htime"
 	"github.com/gohugoio/hugo/deps"
 )
 
@@ -11,7 +12,7 @@ func TestNumFormat(t *testing.T) {
 	t.Parallel()
 	c := qt.New(t)
 
-	ns := New(&deps.Deps{})
+	ns := New(&deps.Deps{}, htime.GetTranslator("en"))
 
 	cases := []struct {
 		prec  int
@@ -58,3 +59,58 @@ func TestNumFormat(t *testing.T) {
 		c.Assert(s, qt.Equals, cas.want)
 	}
 }
+
+func TestFormatNumbers(t *testing.T) {
+	t.Parallel()
+	c := qt.New(t)
+
+	nsNn := New(&deps.Deps{}, htime.GetTranslator("nn"))
+	nsEn := New(&deps.Deps{}, htime.GetTranslator("en"))
+	pi := 3.14159265359
+
+	c.Run("FormatNumber", func(c *qt.C) {
+		got, err := nsNn.FormatNumber(3, pi)
+		c.Assert(err, qt.IsNil)
+		c.Assert(got, qt.Equals, "3,142")
+
+		got, err = nsEn.FormatNumber(3, pi)
+		c.Assert(err, qt.IsNil)
+		c.Assert(got, qt.Equals, "3.142")
+
+		got, err = nsEn.FormatNumber(0, 1234567)
+		c.Assert(err, qt.IsNil)
+		c.Assert(got, qt.Equals, "1,234,567")
+	})
+
+	c.Run("FormatPercent", func(c *qt.C) {
+		got, err := nsEn.FormatPercent(3, 67.33333)
+		c.Assert(err, qt.IsNil)
+		c.Assert(got, qt.Equals, "67.333%")
+	})
+
+	c.Run("FormatCurrency", func(c *qt.C) {
+		got, err := nsEn.FormatCurrency(2, "USD", 20000)
+		c.Assert(err, qt.IsNil)
+		c.Assert(got, qt.Equals, "$20,000.00")
+
+		got, err = nsEn.FormatCurrency(2, "usd", 20000)
+		c.Assert(err, qt.IsNil)
+		c.Assert(got, qt.Equals, "$20,000.00")
+
+		_, err = nsEn.FormatCurrency(2, "ABC", 20000)
+		c.Assert(err, qt.Not(qt.IsNil))
+	})
+
+	c.Run("FormatAccounting", func(c *qt.C) {
+		got, err := nsEn.FormatAccounting(2, "USD", -20000)
+		c.Assert(err, qt.IsNil)
+		c.Assert(got, qt.Equals, "($20,000.00)")
+	})
+
+	c.Run("Invalid precision", func(c *qt.C) {
+		_, err := nsEn.FormatNumber(-1, pi)
+		c.Assert(err, qt.Not(qt.IsNil))
+		_, err = nsEn.FormatNumber(50, pi)
+		c.Assert(err, qt.Not(qt.IsNil))
+	})
+}
@@ -64,6 +64,22 @@ func init() {
 			},
 		)
 
+		ns.AddMethodMapping(ctx.Max,
+			nil,
+			[][2]string{
+				{"{{math.Max 1 2}}", "2"},
+				{"{{math.Max (slice 4 2 3) 1}}", "4"},
+			},
+		)
+
+		ns.AddMethodMapping(ctx.Min,
+			nil,
+			[][2]string{
+				{"{{math.Min 1 2}}", "1"},
+				{"{{math.Min (slice 4 2 3) 5}}", "2"},
+			},
+		)
+
 		ns.AddMethodMapping(ctx.Mod,
 			[]string{"mod"},
 			[][2]string{
@@ -85,6 +101,20 @@ func init() {
 			},
 		)
 
+		ns.AddMethodMapping(ctx.Pow,
+			nil,
+			[][2]string{
+				{"{{math.Pow 2 3}}", "8"},
+			},
+		)
+
+		ns.AddMethodMapping(ctx.Rem,
+			nil,
+			[][2]string{
+				{"{{math.Rem 7.5 2}}", "1.5"},
+			},
+		)
+
 		ns.AddMethodMapping(ctx.Round,
 			nil,
 			[][2]string{
@@ -92,6 +122,13 @@ func init() {
 			},
 		)
 
+		ns.AddMethodMapping(ctx.Sqrt,
+			nil,
+			[][2]string{
+				{"{{math.Sqrt 81}}", "9"},
+			},
+		)
+
 		ns.AddMethodMapping(ctx.Sub,
 			[]string{"sub"},
 			[][2]string{
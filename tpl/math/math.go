@@ -17,6 +17,7 @@ package math
 import (
 	"errors"
 	"math"
+	"reflect"
 
 	_math "github.com/gohugoio/hugo/common/math"
 
@@ -72,6 +73,52 @@ func (ns *Namespace) Log(a interface{}) (float64, error) {
 	return math.Log(af), nil
 }
 
+// Max returns the greatest of the given numbers. Slices of numbers are
+// flattened, so both math.Max 1 2 3 and math.Max (slice 1 2 3) work.
+func (ns *Namespace) Max(numbers ...interface{}) (float64, error) {
+	return ns.applyOpToNumbers("Max", math.Max, numbers...)
+}
+
+// Min returns the smallest of the given numbers. Slices of numbers are
+// flattened, so both math.Min 1 2 3 and math.Min (slice 1 2 3) work.
+func (ns *Namespace) Min(numbers ...interface{}) (float64, error) {
+	return ns.applyOpToNumbers("Min", math.Min, numbers...)
+}
+
+func (ns *Namespace) applyOpToNumbers(name string, op func(a, b float64) float64, numbers ...interface{}) (float64, error) {
+	var values []float64
+	for _, n := range numbers {
+		v := reflect.ValueOf(n)
+		switch v.Kind() {
+		case reflect.Slice, reflect.Array:
+			for i := 0; i < v.Len(); i++ {
+				f, err := cast.ToFloat64E(v.Index(i).Interface())
+				if err != nil {
+					return 0, errors.New(name + " operator can't be used with non-float value")
+				}
+				values = append(values, f)
+			}
+		default:
+			f, err := cast.ToFloat64E(n)
+			if err != nil {
+				return 0, errors.New(name + " operator can't be used with non-float value")
+			}
+			values = append(values, f)
+		}
+	}
+
+	if len(values) == 0 {
+		return 0, errors.New(name + " operator needs at least one number")
+	}
+
+	result := values[0]
+	for _, v := range values[1:] {
+		result = op(result, v)
+	}
+
+	return result, nil
+}
+
 // Mod returns a % b.
 func (ns *Namespace) Mod(a, b interface{}) (int64, error) {
 	ai, erra := cast.ToInt64E(a)
@@ -103,6 +150,35 @@ func (ns *Namespace) Mul(a, b interface{}) (interface{}, error) {
 	return _math.DoArithmetic(a, b, '*')
 }
 
+// Pow returns a raised to the power of b.
+func (ns *Namespace) Pow(a, b interface{}) (float64, error) {
+	af, erra := cast.ToFloat64E(a)
+	bf, errb := cast.ToFloat64E(b)
+
+	if erra != nil || errb != nil {
+		return 0, errors.New("Pow operator can't be used with non-float value")
+	}
+
+	return math.Pow(af, bf), nil
+}
+
+// Rem returns the floating-point remainder of a / b, with the same sign as a.
+// Unlike Mod, it also works with float values, e.g. math.Rem 7.5 2 is 1.5.
+func (ns *Namespace) Rem(a, b interface{}) (float64, error) {
+	af, erra := cast.ToFloat64E(a)
+	bf, errb := cast.ToFloat64E(b)
+
+	if erra != nil || errb != nil {
+		return 0, errors.New("Rem operator can't be used with non-float value")
+	}
+
+	if bf == 0 {
+		return 0, errors.New("the number can't be divided by zero at remainder operation")
+	}
+
+	return math.Mod(af, bf), nil
+}
+
 // Round returns the nearest integer, rounding half away from zero.
 func (ns *Namespace) Round(x interface{}) (float64, error) {
 	xf, err := cast.ToFloat64E(x)
@@ -113,6 +189,16 @@ func (ns *Namespace) Round(x interface{}) (float64, error) {
 	return _round(xf), nil
 }
 
+// Sqrt returns the square root of a number.
+func (ns *Namespace) Sqrt(a interface{}) (float64, error) {
+	af, err := cast.ToFloat64E(a)
+	if err != nil {
+		return 0, errors.New("Sqrt operator can't be used with non integer or float value")
+	}
+
+	return math.Sqrt(af), nil
+}
+
 // Sub subtracts two numbers.
 func (ns *Namespace) Sub(a, b interface{}) (interface{}, error) {
 	return _math.DoArithmetic(a, b, '-')
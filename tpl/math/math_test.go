@@ -273,3 +273,141 @@ func TestRound(t *testing.T) {
 		c.Assert(result, qt.Equals, test.expect)
 	}
 }
+
+func TestPow(t *testing.T) {
+	t.Parallel()
+	c := qt.New(t)
+
+	ns := New()
+
+	for _, test := range []struct {
+		a      interface{}
+		b      interface{}
+		expect interface{}
+	}{
+		{0, 0, float64(1)},
+		{2, 0, float64(1)},
+		{2, 3, float64(8)},
+		{-2, 3, float64(-8)},
+		{2, -1, float64(0.5)},
+		{4, 0.5, float64(2)},
+		{"4", "2", float64(16)},
+		{"abc", 2, false},
+		{2, "abc", false},
+	} {
+
+		result, err := ns.Pow(test.a, test.b)
+
+		if b, ok := test.expect.(bool); ok && !b {
+			c.Assert(err, qt.Not(qt.IsNil))
+			continue
+		}
+
+		c.Assert(err, qt.IsNil)
+		c.Assert(result, qt.Equals, test.expect)
+	}
+}
+
+func TestSqrt(t *testing.T) {
+	t.Parallel()
+	c := qt.New(t)
+
+	ns := New()
+
+	for _, test := range []struct {
+		a      interface{}
+		expect interface{}
+	}{
+		{81, float64(9)},
+		{0.25, float64(0.5)},
+		{0, float64(0)},
+		{"abc", false},
+	} {
+
+		result, err := ns.Sqrt(test.a)
+
+		if b, ok := test.expect.(bool); ok && !b {
+			c.Assert(err, qt.Not(qt.IsNil))
+			continue
+		}
+
+		c.Assert(err, qt.IsNil)
+		c.Assert(result, qt.Equals, test.expect)
+	}
+
+	result, err := ns.Sqrt(-1)
+	c.Assert(err, qt.IsNil)
+	c.Assert(math.IsNaN(result), qt.Equals, true)
+}
+
+func TestRem(t *testing.T) {
+	t.Parallel()
+	c := qt.New(t)
+
+	ns := New()
+
+	for _, test := range []struct {
+		a      interface{}
+		b      interface{}
+		expect interface{}
+	}{
+		{7, 2, float64(1)},
+		{7.5, 2, float64(1.5)},
+		{-7.5, 2, float64(-1.5)},
+		{6, 3, float64(0)},
+		{7, 0, false},
+		{"abc", 2, false},
+		{2, "abc", false},
+	} {
+
+		result, err := ns.Rem(test.a, test.b)
+
+		if b, ok := test.expect.(bool); ok && !b {
+			c.Assert(err, qt.Not(qt.IsNil))
+			continue
+		}
+
+		c.Assert(err, qt.IsNil)
+		c.Assert(result, qt.Equals, test.expect)
+	}
+}
+
+func TestMaxMin(t *testing.T) {
+	t.Parallel()
+	c := qt.New(t)
+
+	ns := New()
+
+	for _, test := range []struct {
+		numbers   []interface{}
+		expectMax interface{}
+		expectMin interface{}
+	}{
+		{[]interface{}{1}, float64(1), float64(1)},
+		{[]interface{}{1, 2}, float64(2), float64(1)},
+		{[]interface{}{-1.5, 2.5, "3"}, float64(3), float64(-1.5)},
+		{[]interface{}{[]int{4, 2, 3}}, float64(4), float64(2)},
+		{[]interface{}{[]interface{}{4, 2.5}, 1, []float64{7}}, float64(7), float64(1)},
+		{[]interface{}{}, false, false},
+		{[]interface{}{[]int{}}, false, false},
+		{[]interface{}{1, "abc"}, false, false},
+		{[]interface{}{[]string{"abc"}}, false, false},
+	} {
+
+		max, err := ns.Max(test.numbers...)
+		if b, ok := test.expectMax.(bool); ok && !b {
+			c.Assert(err, qt.Not(qt.IsNil))
+		} else {
+			c.Assert(err, qt.IsNil)
+			c.Assert(max, qt.Equals, test.expectMax)
+		}
+
+		min, err := ns.Min(test.numbers...)
+		if b, ok := test.expectMin.(bool); ok && !b {
+			c.Assert(err, qt.Not(qt.IsNil))
+		} else {
+			c.Assert(err, qt.IsNil)
+			c.Assert(min, qt.Equals, test.expectMin)
+		}
+	}
+}
@@ -29,17 +29,24 @@ import (
 // New returns a new instance of the os-namespaced template functions.
 func New(d *deps.Deps) *Namespace {
 
-	var rfs afero.Fs
+	var rfs, wfs afero.Fs
 	if d.Fs != nil {
 		rfs = d.Fs.WorkingDir
+		wfs = d.Fs.WorkingDir
 		if d.PathSpec != nil && d.PathSpec.BaseFs != nil {
-			rfs = afero.NewReadOnlyFs(afero.NewCopyOnWriteFs(d.PathSpec.BaseFs.Content.Fs, d.Fs.WorkingDir))
+			// The Work filesystem is the project folder with any themes and
+			// modules layered below it.
+			if d.PathSpec.BaseFs.Work != nil {
+				wfs = d.PathSpec.BaseFs.Work
+			}
+			rfs = afero.NewReadOnlyFs(afero.NewCopyOnWriteFs(d.PathSpec.BaseFs.Content.Fs, wfs))
 		}
 
 	}
 
 	return &Namespace{
 		readFileFs: rfs,
+		workFs:     wfs,
 		deps:       d,
 	}
 }
@@ -47,6 +54,7 @@ func New(d *deps.Deps) *Namespace {
 // Namespace provides template functions for the "os" namespace.
 type Namespace struct {
 	readFileFs afero.Fs
+	workFs     afero.Fs
 	deps       *deps.Deps
 }
 
@@ -88,7 +96,8 @@ func readFile(fs afero.Fs, filename string) (string, error) {
 	return string(b), nil
 }
 
-// ReadFile reads the file named by filename relative to the configured WorkingDir.
+// ReadFile reads the file named by filename relative to the configured WorkingDir,
+// falling back to the same path in any theme or module.
 // It returns the contents as a string.
 // There is an upper size limit set at 1 megabytes.
 func (ns *Namespace) ReadFile(i interface{}) (string, error) {
@@ -104,14 +113,15 @@ func (ns *Namespace) ReadFile(i interface{}) (string, error) {
 	return readFile(ns.readFileFs, s)
 }
 
-// ReadDir lists the directory contents relative to the configured WorkingDir.
+// ReadDir lists the directory contents relative to the configured WorkingDir,
+// merged with the same directory in any theme or module.
 func (ns *Namespace) ReadDir(i interface{}) ([]_os.FileInfo, error) {
 	path, err := cast.ToStringE(i)
 	if err != nil {
 		return nil, err
 	}
 
-	list, err := afero.ReadDir(ns.deps.Fs.WorkingDir, path)
+	list, err := afero.ReadDir(ns.workFs, path)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read directory %q: %s", path, err)
 	}
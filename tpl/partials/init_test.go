@@ -21,6 +21,7 @@ import (
 	"github.com/gohugoio/hugo/deps"
 	"github.com/gohugoio/hugo/htesting/hqt"
 	"github.com/gohugoio/hugo/tpl/internal"
+	"github.com/spf13/viper"
 )
 
 func TestInit(t *testing.T) {
@@ -30,6 +31,7 @@ func TestInit(t *testing.T) {
 
 	for _, nsf := range internal.TemplateFuncsNamespaceRegistry {
 		ns = nsf(&deps.Deps{
+			Cfg:                 viper.New(),
 			BuildStartListeners: &deps.Listeners{},
 			Log:                 loggers.NewErrorLogger(),
 		})
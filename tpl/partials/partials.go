@@ -23,8 +23,12 @@ import (
 	"strings"
 	"sync"
 	texttemplate "text/template"
+	"time"
 
+	"github.com/gohugoio/hugo/config"
 	"github.com/gohugoio/hugo/tpl"
+	"github.com/mitchellh/mapstructure"
+	"github.com/pkg/errors"
 
 	bp "github.com/gohugoio/hugo/bufferpool"
 	"github.com/gohugoio/hugo/deps"
@@ -34,21 +38,104 @@ import (
 // NOTE: It's currently unused.
 var TestTemplateProvider deps.ResourceProvider
 
+const (
+	partialCacheConfigKey = "partialCache"
+
+	// Cache partials per output format.
+	partialCacheScopeOutputFormat = "outputformat"
+)
+
+// partialCacheConfig configures partialCached.
+// Note that partials are always cached per language.
+type partialCacheConfig struct {
+	// Set to "outputFormat" to cache partials per output format,
+	// e.g. to get different versions for HTML and AMP.
+	Scope string
+
+	// The max age of a cached partial. The default, 0, means that
+	// it is kept until the next build.
+	MaxAge time.Duration
+}
+
+// ValidateCacheConfig returns an error if the partialCache config is not valid.
+func ValidateCacheConfig(cfg config.Provider) error {
+	_, err := decodePartialCacheConfig(cfg)
+	return err
+}
+
+func decodePartialCacheConfig(cfg config.Provider) (partialCacheConfig, error) {
+	var c partialCacheConfig
+
+	if !cfg.IsSet(partialCacheConfigKey) {
+		return c, nil
+	}
+
+	dc := &mapstructure.DecoderConfig{
+		Result:           &c,
+		DecodeHook:       mapstructure.StringToTimeDurationHookFunc(),
+		WeaklyTypedInput: true,
+	}
+
+	decoder, err := mapstructure.NewDecoder(dc)
+	if err != nil {
+		return c, err
+	}
+
+	if err := decoder.Decode(cfg.GetStringMap(partialCacheConfigKey)); err != nil {
+		return c, errors.Wrap(err, "failed to decode partialCache config")
+	}
+
+	c.Scope = strings.ToLower(c.Scope)
+	if c.Scope != "" && c.Scope != partialCacheScopeOutputFormat {
+		return c, errors.Errorf("invalid partialCache scope %q", c.Scope)
+	}
+
+	return c, nil
+}
+
+type partialCacheKey struct {
+	name    string
+	variant string
+	format  string
+}
+
+type partialCacheEntry struct {
+	v       interface{}
+	created time.Time
+}
+
 // partialCache represents a cache of partials protected by a mutex.
 type partialCache struct {
 	sync.RWMutex
-	p map[string]interface{}
+	p map[partialCacheKey]partialCacheEntry
 }
 
 func (p *partialCache) clear() {
 	p.Lock()
 	defer p.Unlock()
-	p.p = make(map[string]interface{})
+	p.p = make(map[partialCacheKey]partialCacheEntry)
+}
+
+func (p *partialCache) clearNamed(names ...string) {
+	p.Lock()
+	defer p.Unlock()
+	for _, name := range names {
+		name = normalizePartialName(name)
+		for k := range p.p {
+			if k.name == name {
+				delete(p.p, k)
+			}
+		}
+	}
 }
 
 // New returns a new instance of the templates-namespaced template functions.
+// An invalid partialCache config is reported when the sites are created, see
+// ValidateCacheConfig, and returned from partialCached.
 func New(deps *deps.Deps) *Namespace {
-	cache := &partialCache{p: make(map[string]interface{})}
+	cacheConfig, cacheConfigErr := decodePartialCacheConfig(deps.Cfg)
+
+	cache := &partialCache{p: make(map[partialCacheKey]partialCacheEntry)}
 	deps.BuildStartListeners.Add(
 		func() {
 			cache.clear()
@@ -57,6 +144,8 @@ func New(deps *deps.Deps) *Namespace {
 	return &Namespace{
 		deps:           deps,
 		cachedPartials: cache,
+		cacheConfig:    cacheConfig,
+		cacheConfigErr: cacheConfigErr,
 	}
 }
 
@@ -64,28 +153,45 @@ func New(deps *deps.Deps) *Namespace {
 type Namespace struct {
 	deps           *deps.Deps
 	cachedPartials *partialCache
+	cacheConfig    partialCacheConfig
+	cacheConfigErr error
 }
 
+func normalizePartialName(name string) string {
+	return strings.TrimPrefix(name, "partials/")
+}
+
+// errPartialReturned is used to stop the execution of a partial
+// when a return statement is reached.
+var errPartialReturned = errors.New("partial returned")
+
 // contextWrapper makes room for a return value in a partial invocation.
 type contextWrapper struct {
-	Arg    interface{}
-	Result interface{}
+	Arg      interface{}
+	Result   interface{}
+	returned bool
+}
+
+// Args returns Arg in a slice. The partial template ranges over this to
+// set the dot to Arg.
+func (c *contextWrapper) Args() []interface{} {
+	return []interface{}{c.Arg}
 }
 
-// Set sets the return value and returns an empty string.
-func (c *contextWrapper) Set(in interface{}) string {
+// Set sets the return value and stops the template execution.
+func (c *contextWrapper) Set(in interface{}) (string, error) {
 	c.Result = in
-	return ""
+	c.returned = true
+	return "", errPartialReturned
 }
 
 // Include executes the named partial.
-// If the partial contains a return statement, that value will be returned.
+// If the partial contains a return statement, the value of the first
+// return statement executed will be returned.
 // Else, the rendered output will be returned:
 // A string if the partial is a text/template, or template.HTML when html/template.
 func (ns *Namespace) Include(name string, contextList ...interface{}) (interface{}, error) {
-	if strings.HasPrefix(name, "partials/") {
-		name = name[8:]
-	}
+	name = normalizePartialName(name)
 	var context interface{}
 
 	if len(contextList) == 0 {
@@ -130,7 +236,9 @@ func (ns *Namespace) Include(name string, contextList ...interface{}) (interface
 	}
 
 	if err := templ.Execute(w, context); err != nil {
-		return "", err
+		if ctx, ok := context.(*contextWrapper); !ok || !ctx.returned {
+			return "", err
+		}
 	}
 
 	var result interface{}
@@ -155,24 +263,48 @@ func (ns *Namespace) Include(name string, contextList ...interface{}) (interface
 // string parameter (a string slice actually, but be only use a variadic
 // argument to make it optional) can be passed so that a given partial can have
 // multiple uses. The cache is created with name+variant as the key.
+// The cache is scoped by language and, if configured, by output format.
 func (ns *Namespace) IncludeCached(name string, context interface{}, variant ...string) (interface{}, error) {
-	key := name
+	if ns.cacheConfigErr != nil {
+		return nil, ns.cacheConfigErr
+	}
+
+	if ns.deps.PartialCachedIncluded != nil {
+		ns.deps.PartialCachedIncluded()
+	}
+
+	key := partialCacheKey{name: normalizePartialName(name)}
 	if len(variant) > 0 {
 		for i := 0; i < len(variant); i++ {
-			key += variant[i]
+			key.variant += variant[i]
 		}
 	}
+	if ns.cacheConfig.Scope == partialCacheScopeOutputFormat && ns.deps.CurrentOutputFormat != nil {
+		key.format = ns.deps.CurrentOutputFormat().Name
+	}
 	return ns.getOrCreate(key, name, context)
 }
 
-func (ns *Namespace) getOrCreate(key, name string, context interface{}) (interface{}, error) {
+// ClearCached removes the given partials, all variants, from the partialCached
+// cache, so they get executed again on the next invocation.
+// With no arguments the entire cache is cleared.
+func (ns *Namespace) ClearCached(names ...string) string {
+	if len(names) == 0 {
+		ns.cachedPartials.clear()
+	} else {
+		ns.cachedPartials.clearNamed(names...)
+	}
+	return ""
+}
+
+func (ns *Namespace) getOrCreate(key partialCacheKey, name string, context interface{}) (interface{}, error) {
 
 	ns.cachedPartials.RLock()
-	p, ok := ns.cachedPartials.p[key]
+	e, ok := ns.cachedPartials.p[key]
 	ns.cachedPartials.RUnlock()
 
-	if ok {
-		return p, nil
+	if ok && !ns.expired(e) {
+		return e.v, nil
 	}
 
 	p, err := ns.Include(name, context)
@@ -183,10 +315,14 @@ func (ns *Namespace) getOrCreate(key, name string, context interface{}) (interfa
 	ns.cachedPartials.Lock()
 	defer ns.cachedPartials.Unlock()
 	// Double-check.
-	if p2, ok := ns.cachedPartials.p[key]; ok {
-		return p2, nil
+	if e2, ok := ns.cachedPartials.p[key]; ok && !ns.expired(e2) {
+		return e2.v, nil
 	}
-	ns.cachedPartials.p[key] = p
+	ns.cachedPartials.p[key] = partialCacheEntry{v: p, created: time.Now()}
 
 	return p, nil
 }
+
+func (ns *Namespace) expired(e partialCacheEntry) bool {
+	return ns.cacheConfig.MaxAge > 0 && time.Since(e.created) > ns.cacheConfig.MaxAge
+}
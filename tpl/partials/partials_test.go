@@ -0,0 +1,51 @@
+// Copyright 2017 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package partials
+
+import (
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/gohugoio/hugo/deps"
+	"github.com/spf13/viper"
+)
+
+func TestDecodePartialCacheConfig(t *testing.T) {
+	c := qt.New(t)
+
+	v := viper.New()
+	conf, err := decodePartialCacheConfig(v)
+	c.Assert(err, qt.IsNil)
+	c.Assert(conf, qt.Equals, partialCacheConfig{})
+
+	v.Set("partialCache", map[string]interface{}{
+		"scope":  "outputFormat",
+		"maxAge": "10s",
+	})
+	conf, err = decodePartialCacheConfig(v)
+	c.Assert(err, qt.IsNil)
+	c.Assert(conf.Scope, qt.Equals, partialCacheScopeOutputFormat)
+	c.Assert(conf.MaxAge, qt.Equals, 10*time.Second)
+
+	v.Set("partialCache", map[string]interface{}{
+		"scope": "page",
+	})
+	_, err = decodePartialCacheConfig(v)
+	c.Assert(err, qt.Not(qt.IsNil))
+
+	ns := New(&deps.Deps{Cfg: v, BuildStartListeners: &deps.Listeners{}})
+	_, err = ns.IncludeCached("p.html", nil)
+	c.Assert(err, qt.ErrorMatches, `invalid partialCache scope "page"`)
+}
@@ -45,6 +45,11 @@ func init() {
 			[][2]string{},
 		)
 
+		ns.AddMethodMapping(ctx.Inline,
+			nil,
+			[][2]string{},
+		)
+
 		ns.AddMethodMapping(ctx.Minify,
 			[]string{"minify"},
 			[][2]string{},
@@ -45,13 +45,19 @@ func New(deps *deps.Deps) (*Namespace, error) {
 	if err != nil {
 		return nil, err
 	}
+
+	minifyClient, err := minifier.New(deps.ResourceSpec)
+	if err != nil {
+		return nil, err
+	}
+
 	return &Namespace{
 		deps:            deps,
 		scssClient:      scssClient,
 		createClient:    create.New(deps.ResourceSpec),
 		bundlerClient:   bundler.New(deps.ResourceSpec),
 		integrityClient: integrity.New(deps.ResourceSpec),
-		minifyClient:    minifier.New(deps.ResourceSpec),
+		minifyClient:    minifyClient,
 		postcssClient:   postcss.New(deps.ResourceSpec),
 		templatesClient: templates.New(deps.ResourceSpec, deps.TextTmpl),
 	}, nil
@@ -187,28 +193,25 @@ func (ns *Namespace) ExecuteAsTemplate(args ...interface{}) (resource.Resource,
 // Fingerprint transforms the given Resource with a MD5 hash of the content in
 // the RelPermalink and Permalink.
 func (ns *Namespace) Fingerprint(args ...interface{}) (resource.Resource, error) {
-	if len(args) < 1 || len(args) > 2 {
-		return nil, errors.New("must provide a Resource and (optional) crypto algo")
+	r, algo, err := ns.resolveAlgoArgs(args)
+	if err != nil {
+		return nil, err
 	}
 
-	var algo string
-	resIdx := 0
-
-	if len(args) == 2 {
-		resIdx = 1
-		var err error
-		algo, err = cast.ToStringE(args[0])
-		if err != nil {
-			return nil, err
-		}
-	}
+	return ns.integrityClient.Fingerprint(r, algo)
+}
 
-	r, ok := args[resIdx].(resources.ResourceTransformer)
-	if !ok {
-		return nil, fmt.Errorf("%T can not be transformed", args[resIdx])
+// Inline prepares the given CSS or JavaScript Resource to be embedded in the
+// page: .Data.Inline holds the style or script element and .Data.CSPHash the
+// hash to allow it in a Content Security Policy. An optional hash algorithm,
+// sha256 (default), sha384 or sha512, can be given as the first argument.
+func (ns *Namespace) Inline(args ...interface{}) (resource.Resource, error) {
+	r, algo, err := ns.resolveAlgoArgs(args)
+	if err != nil {
+		return nil, err
 	}
 
-	return ns.integrityClient.Fingerprint(r, algo)
+	return ns.integrityClient.Inline(r, algo)
 }
 
 // Minify minifies the given Resource using the MediaType to pick the correct
@@ -282,6 +285,33 @@ func (ns *Namespace) resolveIfFirstArgIsString(args []interface{}) (resources.Re
 	return v2, v1, ok2
 }
 
+// resolveAlgoArgs resolves the Resource and the optional crypto algo
+// preceding it.
+func (ns *Namespace) resolveAlgoArgs(args []interface{}) (resources.ResourceTransformer, string, error) {
+	if len(args) < 1 || len(args) > 2 {
+		return nil, "", errors.New("must provide a Resource and (optional) crypto algo")
+	}
+
+	var algo string
+	resIdx := 0
+
+	if len(args) == 2 {
+		resIdx = 1
+		var err error
+		algo, err = cast.ToStringE(args[0])
+		if err != nil {
+			return nil, "", err
+		}
+	}
+
+	r, ok := args[resIdx].(resources.ResourceTransformer)
+	if !ok {
+		return nil, "", fmt.Errorf("%T can not be transformed", args[resIdx])
+	}
+
+	return r, algo, nil
+}
+
 // This roundabout way of doing it is needed to get both pipeline behaviour and options as arguments.
 func (ns *Namespace) resolveArgs(args []interface{}) (resources.ResourceTransformer, map[string]interface{}, error) {
 	if len(args) == 0 {
@@ -36,6 +36,14 @@ func init() {
 			},
 		)
 
+		ns.AddMethodMapping(ctx.Try,
+			[]string{"try"},
+			[][2]string{
+				{`{{ with try "math.Div" 6 0 }}{{ with .Err }}Error: {{ . }}{{ end }}{{ end }}`, `Error: can&#39;t divide the value by 0`},
+				{`{{ with try "math.Add" 1 2 }}{{ .Value }}{{ end }}`, `3`},
+			},
+		)
+
 		return ns
 
 	}
@@ -15,7 +15,13 @@
 package templates
 
 import (
+	"fmt"
+	"reflect"
+	"strings"
+
 	"github.com/gohugoio/hugo/deps"
+	"github.com/gohugoio/hugo/tpl"
+	"github.com/pkg/errors"
 )
 
 // New returns a new instance of the templates-namespaced template functions.
@@ -38,3 +44,144 @@ func (ns *Namespace) Exists(name string) bool {
 	return found
 
 }
+
+// TryValue is the result of a call wrapped in Try.
+type TryValue struct {
+	// Value is the value returned from the function when it succeeded.
+	Value interface{}
+
+	// Err is the error returned from, or the panic raised in, the function.
+	Err error
+}
+
+// Try invokes the template function with the given name and arguments and
+// captures any error in the returned TryValue instead of failing the build,
+// e.g. {{ with try "resources.Get" "logo.png" }}{{ with .Err }}...{{ end }}{{ end }}.
+// Namespaced functions are named as "namespace.Method".
+func (ns *Namespace) Try(name interface{}, args ...interface{}) (*TryValue, error) {
+	fname, ok := name.(string)
+	if !ok {
+		return nil, errors.New("try: function name must be a string")
+	}
+
+	getter, ok := ns.deps.Tmpl.(tpl.TemplateFuncsGetter)
+	if !ok {
+		return nil, errors.New("try: template funcs not available")
+	}
+
+	return tryCall(getter.GetFuncs(), fname, args...)
+}
+
+func tryCall(funcs map[string]interface{}, name string, args ...interface{}) (result *TryValue, err error) {
+	fn, err := lookupFunc(funcs, name)
+	if err != nil {
+		return nil, err
+	}
+
+	result = &TryValue{}
+
+	defer func() {
+		if r := recover(); r != nil {
+			result.Value = nil
+			result.Err = fmt.Errorf("%s: panic: %v", name, r)
+		}
+	}()
+
+	in, err := prepareArgs(fn.Type(), args)
+	if err != nil {
+		result.Err = errors.Wrap(err, name)
+		return result, nil
+	}
+
+	out := fn.Call(in)
+
+	if n := len(out); n > 0 && out[n-1].Type() == errorType {
+		if !out[n-1].IsNil() {
+			result.Err = out[n-1].Interface().(error)
+			return result, nil
+		}
+		out = out[:n-1]
+	}
+
+	if len(out) > 0 {
+		result.Value = out[0].Interface()
+	}
+
+	return result, nil
+}
+
+var errorType = reflect.TypeOf((*error)(nil)).Elem()
+
+func lookupFunc(funcs map[string]interface{}, name string) (reflect.Value, error) {
+	nsName, method := name, ""
+	if i := strings.Index(name, "."); i != -1 {
+		nsName, method = name[:i], name[i+1:]
+	}
+
+	f, found := funcs[nsName]
+	if !found {
+		return reflect.Value{}, errors.Errorf("try: function %q not found", name)
+	}
+
+	fn := reflect.ValueOf(f)
+
+	if method == "" {
+		return fn, nil
+	}
+
+	// Namespaces are registered as funcs returning the namespace context.
+	ft := fn.Type()
+	if fn.Kind() != reflect.Func || ft.NumOut() != 1 || !(ft.NumIn() == 0 || (ft.NumIn() == 1 && ft.IsVariadic())) {
+		return reflect.Value{}, errors.Errorf("try: %q is not a namespace", nsName)
+	}
+
+	ctx := fn.Call(nil)[0]
+	if ctx.Kind() == reflect.Interface {
+		ctx = ctx.Elem()
+	}
+
+	m := ctx.MethodByName(method)
+	if !m.IsValid() {
+		return reflect.Value{}, errors.Errorf("try: function %q not found", name)
+	}
+
+	return m, nil
+}
+
+func prepareArgs(typ reflect.Type, args []interface{}) ([]reflect.Value, error) {
+	numIn := typ.NumIn()
+	if typ.IsVariadic() {
+		if len(args) < numIn-1 {
+			return nil, errors.Errorf("wrong number of args: got %d, want at least %d", len(args), numIn-1)
+		}
+	} else if len(args) != numIn {
+		return nil, errors.Errorf("wrong number of args: got %d, want %d", len(args), numIn)
+	}
+
+	in := make([]reflect.Value, len(args))
+	for i, arg := range args {
+		var argType reflect.Type
+		if typ.IsVariadic() && i >= numIn-1 {
+			argType = typ.In(numIn - 1).Elem()
+		} else {
+			argType = typ.In(i)
+		}
+
+		if arg == nil {
+			in[i] = reflect.Zero(argType)
+			continue
+		}
+
+		v := reflect.ValueOf(arg)
+		switch {
+		case v.Type().AssignableTo(argType):
+		case v.Type().ConvertibleTo(argType) && v.Kind() != reflect.String && argType.Kind() != reflect.String:
+			v = v.Convert(argType)
+		default:
+			return nil, errors.Errorf("arg %d: cannot use %T as %s", i, arg, argType)
+		}
+		in[i] = v
+	}
+
+	return in, nil
+}
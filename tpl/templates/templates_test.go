@@ -0,0 +1,86 @@
+// Copyright 2018 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package templates
+
+import (
+	"errors"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+type tstNamespace struct{}
+
+func (tstNamespace) Div(a, b int) (int, error) {
+	if b == 0 {
+		return 0, errors.New("division by zero")
+	}
+	return a / b, nil
+}
+
+func (tstNamespace) Join(sep string, parts ...string) string {
+	s := ""
+	for i, p := range parts {
+		if i > 0 {
+			s += sep
+		}
+		s += p
+	}
+	return s
+}
+
+func TestTryCall(t *testing.T) {
+	t.Parallel()
+	c := qt.New(t)
+
+	ns := tstNamespace{}
+	funcs := map[string]interface{}{
+		"tst":   func(args ...interface{}) interface{} { return ns },
+		"upper": func(s string) string { return s + "!" },
+		"boom":  func() string { panic("kaboom") },
+		"fail":  func() error { return errors.New("failed") },
+	}
+
+	for _, test := range []struct {
+		name   string
+		args   []interface{}
+		expect interface{}
+		err    string
+	}{
+		{"tst.Div", []interface{}{6, 3}, 2, ""},
+		{"tst.Div", []interface{}{6, 0}, nil, "division by zero"},
+		{"tst.Join", []interface{}{"-", "a", "b"}, "a-b", ""},
+		{"upper", []interface{}{"hi"}, "hi!", ""},
+		{"upper", []interface{}{}, nil, "upper: wrong number of args: got 0, want 1"},
+		{"upper", []interface{}{42}, nil, "upper: arg 0: cannot use int as string"},
+		{"boom", nil, nil, "boom: panic: kaboom"},
+		{"fail", nil, nil, "failed"},
+	} {
+		result, err := tryCall(funcs, test.name, test.args...)
+		c.Assert(err, qt.IsNil)
+		c.Assert(result.Value, qt.Equals, test.expect, qt.Commentf("%s", test.name))
+		if test.err == "" {
+			c.Assert(result.Err, qt.IsNil)
+		} else {
+			c.Assert(result.Err, qt.ErrorMatches, test.err)
+		}
+	}
+
+	_, err := tryCall(funcs, "nope", 1)
+	c.Assert(err, qt.Not(qt.IsNil))
+	_, err = tryCall(funcs, "tst.Nope", 1)
+	c.Assert(err, qt.Not(qt.IsNil))
+	_, err = tryCall(funcs, "upper.Foo", 1)
+	c.Assert(err, qt.Not(qt.IsNil))
+}
@@ -14,6 +14,7 @@
 package time
 
 import (
+	"github.com/gohugoio/hugo/common/htime"
 	"github.com/gohugoio/hugo/deps"
 	"github.com/gohugoio/hugo/tpl/internal"
 )
@@ -22,7 +23,11 @@ const name = "time"
 
 func init() {
 	f := func(d *deps.Deps) *internal.TemplateFuncsNamespace {
-		ctx := New()
+		lang := "en"
+		if d.Language != nil {
+			lang = d.Language.Lang
+		}
+		ctx := New(htime.NewTimeFormatter(htime.GetTranslator(lang)))
 
 		ns := &internal.TemplateFuncsNamespace{
 			Name: name,
@@ -50,6 +55,7 @@ func init() {
 			[]string{"dateFormat"},
 			[][2]string{
 				{`dateFormat: {{ dateFormat "Monday, Jan 2, 2006" "2015-01-21" }}`, `dateFormat: Wednesday, Jan 21, 2015`},
+				{`{{ "2015-01-21" | time.Format ":date_long" }}`, `January 21, 2015`},
 			},
 		)
 
@@ -18,16 +18,22 @@ import (
 	"fmt"
 	_time "time"
 
+	"github.com/gohugoio/hugo/common/htime"
+
 	"github.com/spf13/cast"
 )
 
 // New returns a new instance of the time-namespaced template functions.
-func New() *Namespace {
-	return &Namespace{}
+func New(timeFormatter htime.TimeFormatter) *Namespace {
+	return &Namespace{
+		timeFormatter: timeFormatter,
+	}
 }
 
 // Namespace provides template functions for the "time" namespace.
-type Namespace struct{}
+type Namespace struct {
+	timeFormatter htime.TimeFormatter
+}
 
 // AsTime converts the textual representation of the datetime string into
 // a time.Time interface.
@@ -42,14 +48,17 @@ func (ns *Namespace) AsTime(v interface{}) (interface{}, error) {
 
 // Format converts the textual representation of the datetime string into
 // the other form or returns it of the time.Time value. These are formatted
-// with the layout string
+// with the layout string, and month and weekday names are translated to the
+// current language. The layout can also be one of the localized aliases
+// :date_full, :date_long, :date_medium, :date_short, :time_full, :time_long,
+// :time_medium or :time_short.
 func (ns *Namespace) Format(layout string, v interface{}) (string, error) {
 	t, err := cast.ToTimeE(v)
 	if err != nil {
 		return "", err
 	}
 
-	return t.Format(layout), nil
+	return ns.timeFormatter.Format(t, layout), nil
 }
 
 // Now returns the current local time.
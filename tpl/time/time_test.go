@@ -16,12 +16,14 @@ package time
 import (
 	"testing"
 	"time"
+
+	"github.com/gohugoio/hugo/common/htime"
 )
 
 func TestFormat(t *testing.T) {
 	t.Parallel()
 
-	ns := New()
+	ns := New(htime.NewTimeFormatter(htime.GetTranslator("en")))
 
 	for i, test := range []struct {
 		layout string
@@ -38,6 +40,8 @@ func TestFormat(t *testing.T) {
 		{time.RFC1123, time.Date(2016, time.March, 3, 4, 5, 0, 0, time.UTC), "Thu, 03 Mar 2016 04:05:00 UTC"},
 		{time.RFC3339, "Thu, 03 Mar 2016 04:05:00 UTC", "2016-03-03T04:05:00Z"},
 		{time.RFC1123, "2016-03-03T04:05:00Z", "Thu, 03 Mar 2016 04:05:00 UTC"},
+		{":date_long", time.Date(2016, time.March, 3, 4, 5, 0, 0, time.UTC), "March 3, 2016"},
+		{":date_short", "2016-03-03T04:05:00Z", "3/3/16"},
 	} {
 		result, err := ns.Format(test.layout, test.value)
 		if b, ok := test.expect.(bool); ok && !b {
@@ -56,10 +60,36 @@ func TestFormat(t *testing.T) {
 	}
 }
 
+func TestFormatLocalized(t *testing.T) {
+	t.Parallel()
+
+	ns := New(htime.NewTimeFormatter(htime.GetTranslator("de")))
+
+	for i, test := range []struct {
+		layout string
+		value  interface{}
+		expect string
+	}{
+		{"Monday, 2. January 2006", "2015-03-21", "Samstag, 21. März 2015"},
+		{"Mon Jan 2", "2015-03-21", "Sa. März 21"},
+		{":date_full", "2015-03-21", "Samstag, 21. März 2015"},
+		{":date_medium", "2015-03-21", "21.03.2015"},
+	} {
+		result, err := ns.Format(test.layout, test.value)
+		if err != nil {
+			t.Errorf("[%d] DateFormat failed: %s", i, err)
+			continue
+		}
+		if result != test.expect {
+			t.Errorf("[%d] DateFormat got %v but expected %v", i, result, test.expect)
+		}
+	}
+}
+
 func TestDuration(t *testing.T) {
 	t.Parallel()
 
-	ns := New()
+	ns := New(htime.NewTimeFormatter(htime.GetTranslator("en")))
 
 	for i, test := range []struct {
 		unit   interface{}
@@ -18,7 +18,89 @@ package embedded
 
 // EmbeddedTemplates represents all embedded templates.
 var EmbeddedTemplates = [][2]string{
-	{`_default/robots.txt`, `User-agent: *`},
+	{`_default/atom.xml`, `{{- $pctx := . -}}
+{{- if .IsHome -}}{{ $pctx = .Site }}{{- end -}}
+{{- $pages := $pctx.RegularPages -}}
+{{- $limit := .Site.Config.Services.RSS.Limit -}}
+{{- if ge $limit 1 -}}
+{{- $pages = $pages | first $limit -}}
+{{- end -}}
+{{- $fullContent := .Site.Config.Services.RSS.FullContent -}}
+{{- printf "<?xml version=\"1.0\" encoding=\"utf-8\" standalone=\"yes\" ?>" | safeHTML }}
+<feed xmlns="http://www.w3.org/2005/Atom"{{ with .Site.LanguageCode }} xml:lang="{{.}}"{{ end }}>
+  <title>{{ if eq  .Title  .Site.Title }}{{ .Site.Title }}{{ else }}{{ with .Title }}{{.}} on {{ end }}{{ .Site.Title }}{{ end }}</title>
+  <subtitle>Recent content {{ if ne  .Title  .Site.Title }}{{ with .Title }}in {{.}} {{ end }}{{ end }}on {{ .Site.Title }}</subtitle>
+  <link href="{{ .Permalink }}" rel="alternate" type="text/html" />
+  {{- with .OutputFormats.Get "Atom" }}
+  {{ printf "<link href=%q rel=\"self\" type=%q />" .Permalink .MediaType | safeHTML }}
+  {{- end }}
+  <id>{{ .Permalink }}</id>
+  <updated>{{ if not .Date.IsZero }}{{ .Date.Format "2006-01-02T15:04:05Z07:00" | safeHTML }}{{ else }}{{ now.Format "2006-01-02T15:04:05Z07:00" | safeHTML }}{{ end }}</updated>
+  <generator uri="https://gohugo.io/">Hugo</generator>{{ with .Site.Author.name }}
+  <author>
+    <name>{{.}}</name>{{ with $.Site.Author.email }}
+    <email>{{.}}</email>{{ end }}
+  </author>{{ end }}{{ with .Site.Copyright }}
+  <rights>{{.}}</rights>{{ end }}
+  {{- range $pages }}
+  <entry>
+    <title>{{ .Title }}</title>
+    <link href="{{ .Permalink }}" rel="alternate" type="text/html" />
+    <id>{{ .Permalink }}</id>
+    <published>{{ .Date.Format "2006-01-02T15:04:05Z07:00" | safeHTML }}</published>
+    <updated>{{ .Lastmod.Format "2006-01-02T15:04:05Z07:00" | safeHTML }}</updated>
+    {{- if $fullContent }}
+    <content type="html">{{ .Content | html }}</content>
+    {{- else }}
+    <summary type="html">{{ .Summary | html }}</summary>
+    {{- end }}
+  </entry>
+  {{- end }}
+</feed>
+`},
+	{`_default/jsonfeed.json`, `{{- $pctx := . -}}
+{{- if .IsHome -}}{{ $pctx = .Site }}{{- end -}}
+{{- $pages := $pctx.RegularPages -}}
+{{- $limit := .Site.Config.Services.RSS.Limit -}}
+{{- if ge $limit 1 -}}
+{{- $pages = $pages | first $limit -}}
+{{- end -}}
+{
+  "version": "https://jsonfeed.org/version/1.1",
+  "title": {{ if eq .Title .Site.Title }}{{ .Site.Title | jsonify }}{{ else }}{{ with .Title }}{{ printf "%s on %s" . $.Site.Title | jsonify }}{{ else }}{{ .Site.Title | jsonify }}{{ end }}{{ end }},
+  "home_page_url": {{ .Permalink | jsonify }},
+  {{- with .OutputFormats.Get "JSONFeed" }}
+  "feed_url": {{ .Permalink | jsonify }},
+  {{- end }}
+  "description": {{ printf "Recent content %son %s" (cond (ne .Title .Site.Title) (printf "in %s " .Title) "") .Site.Title | jsonify }},
+  {{- with .Site.LanguageCode }}
+  "language": {{ . | jsonify }},
+  {{- end }}
+  {{- with .Site.Author.name }}
+  "authors": [{ "name": {{ . | jsonify }} }],
+  {{- end }}
+  "items": [
+    {{- range $i, $p := $pages }}{{ if $i }},{{ end }}
+    {
+      "id": {{ .Permalink | jsonify }},
+      "url": {{ .Permalink | jsonify }},
+      "title": {{ .Title | jsonify }},
+      "summary": {{ .Summary | plainify | jsonify }},
+      "content_html": {{ .Content | jsonify }},
+      "date_published": {{ .Date.Format "2006-01-02T15:04:05Z07:00" | jsonify }}
+      {{- if not .Lastmod.IsZero }},
+      "date_modified": {{ .Lastmod.Format "2006-01-02T15:04:05Z07:00" | jsonify }}
+      {{- end }}
+      {{- with .Params.tags }},
+      "tags": {{ . | jsonify }}
+      {{- end }}
+    }
+    {{- end }}
+  ]
+}
+`},
+	{`_default/robots.txt`, `User-agent: *{{ if not hugo.IsProduction }}
+Disallow: /{{ end }}`},
 	{`_default/rss.xml`, `{{- $pctx := . -}}
 {{- if .IsHome -}}{{ $pctx = .Site }}{{- end -}}
 {{- $pages := $pctx.RegularPages -}}
@@ -48,11 +130,37 @@ var EmbeddedTemplates = [][2]string{
       <pubDate>{{ .Date.Format "Mon, 02 Jan 2006 15:04:05 -0700" | safeHTML }}</pubDate>
       {{ with .Site.Author.email }}<author>{{.}}{{ with $.Site.Author.name }} ({{.}}){{end}}</author>{{end}}
       <guid>{{ .Permalink }}</guid>
-      <description>{{ .Summary | html }}</description>
+      <description>{{ if $.Site.Config.Services.RSS.FullContent }}{{ .Content | html }}{{ else }}{{ .Summary | html }}{{ end }}</description>
     </item>
     {{ end }}
   </channel>
 </rss>`},
+	{`_default/searchindex.json`, `{{- $cfg := .Site.Config.Services.SearchIndex -}}
+{{- $ctx := . -}}
+{{- $index := newScratch -}}
+{{- $index.Set "items" slice -}}
+{{- range $p := .Site.Pages -}}
+{{- if and (in $cfg.Kinds $p.Kind) (or $ctx.IsHome ($p.IsDescendant $ctx)) -}}
+{{- $s := newScratch -}}
+{{- $s.Set "item" dict -}}
+{{- range $cfg.Fields -}}
+{{- if eq . "title" }}{{ $s.SetInMap "item" "title" $p.Title }}{{ end -}}
+{{- if eq . "permalink" }}{{ $s.SetInMap "item" "permalink" $p.Permalink }}{{ end -}}
+{{- if eq . "summary" }}{{ $s.SetInMap "item" "summary" ($p.Summary | plainify | htmlUnescape) }}{{ end -}}
+{{- if eq . "tags" }}{{ $s.SetInMap "item" "tags" ($p.Params.tags | default slice) }}{{ end -}}
+{{- if eq . "section" }}{{ $s.SetInMap "item" "section" $p.Section }}{{ end -}}
+{{- if eq . "date" }}{{ $s.SetInMap "item" "date" ($p.Date.Format "2006-01-02T15:04:05Z07:00") }}{{ end -}}
+{{- if eq . "content" -}}
+{{- $content := trim ($p.Plain | htmlUnescape) "\n " -}}
+{{- if ge $cfg.ContentLength 1 }}{{ $content = substr $content 0 $cfg.ContentLength }}{{ end -}}
+{{- $s.SetInMap "item" "content" $content -}}
+{{- end -}}
+{{- end -}}
+{{- $index.Add "items" (slice ($s.Get "item")) -}}
+{{- end -}}
+{{- end -}}
+{{- $index.Get "items" | jsonify -}}
+`},
 	{`_default/sitemap.xml`, `{{ printf "<?xml version=\"1.0\" encoding=\"utf-8\" standalone=\"yes\" ?>" | safeHTML }}
 <urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9"
   xmlns:xhtml="http://www.w3.org/1999/xhtml">
@@ -71,7 +179,10 @@ var EmbeddedTemplates = [][2]string{
                 rel="alternate"
                 hreflang="{{ .Language.Lang }}"
                 href="{{ .Permalink }}"
-                />{{ end }}
+                />{{ end }}{{ range .Sitemap.Images }}
+    <image:image xmlns:image="http://www.google.com/schemas/sitemap-image/1.1">
+      <image:loc>{{ . | absURL }}</image:loc>
+    </image:image>{{ end }}
   </url>
   {{ end }}
 </urlset>`},
@@ -272,6 +383,9 @@ if (!doNotTrack) {
 </ul>
 {{ end }}
 `},
+	{`robots.html`, `{{- $robots := .Params.robots -}}
+{{- if not hugo.IsProduction }}{{ $robots = "noindex, nofollow" }}{{ end -}}
+{{- with $robots }}<meta name="robots" content="{{ . }}" />{{ end -}}`},
 	{`schema.html`, `<meta itemprop="name" content="{{ .Title }}">
 <meta itemprop="description" content="{{ with .Description }}{{ . }}{{ else }}{{if .IsPage}}{{ .Summary }}{{ else }}{{ with .Site.Params.description }}{{ . }}{{ end }}{{ end }}{{ end }}">
 
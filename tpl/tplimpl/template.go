@@ -303,6 +303,10 @@ func (t *templateHandler) clone(d *deps.Deps) *templateHandler {
 
 	d.Tmpl = c
 
+	// The text template used for e.g. resources.ExecuteAsTemplate must
+	// get the template funcs for this language, so create a new one.
+	d.TextTmpl = c.NewTextTemplate()
+
 	c.initFuncs()
 
 	for k, v := range t.html.overlays {
@@ -1012,9 +1016,21 @@ var embeddedTemplatesAliases = map[string][]string{
 	"shortcodes/twitter.html": {"shortcodes/tweet.html"},
 }
 
+// Embedded templates that must be parsed with text/template.
+var embeddedTextTemplates = map[string]bool{
+	"_default/jsonfeed.json":    true,
+	"_default/searchindex.json": true,
+}
+
 func (t *templateHandler) loadEmbedded() error {
 	for _, kv := range embedded.EmbeddedTemplates {
 		name, templ := kv[0], kv[1]
+		if embeddedTextTemplates[name] {
+			if err := t.AddTemplate(textTmplNamePrefix+internalPathPrefix+name, templ); err != nil {
+				return err
+			}
+			continue
+		}
 		if err := t.addInternalTemplate(name, templ); err != nil {
 			return err
 		}
@@ -63,9 +63,6 @@ type templateContext struct {
 
 	// Contains some info about the template
 	tpl.Info
-
-	// Store away the return node in partials.
-	returnNode *parse.CommandNode
 }
 
 func (c templateContext) getIfNotVisited(name string) *parse.Tree {
@@ -126,11 +123,10 @@ func applyTemplateTransformers(typ templateType, templ *parse.Tree, lookupFn fun
 	c := newTemplateContext(lookupFn)
 	c.typ = typ
 
-	_, err := c.applyTransformations(templ.Root)
+	err := c.applyTransformations(templ.Root)
 
-	if err == nil && c.returnNode != nil {
-		// This is a partial with a return statement.
-		c.Info.HasReturn = true
+	if err == nil && c.Info.HasReturn {
+		// This is a partial with one or more return statements.
 		templ.Root = c.wrapInPartialReturnWrapper(templ.Root)
 	}
 
@@ -138,10 +134,17 @@ func applyTemplateTransformers(typ templateType, templ *parse.Tree, lookupFn fun
 }
 
 const (
-	partialReturnWrapperTempl = `{{ $_hugo_dot := $ }}{{ $ := .Arg }}{{ with .Arg }}{{ $_hugo_dot.Set ("PLACEHOLDER") }}{{ end }}`
+	// The range sets the dot to .Arg, also when .Arg is nil or otherwise
+	// falsy, which a with would not.
+	partialReturnWrapperTempl = `{{ $_hugo_dot := $ }}{{ $ := .Arg }}{{ range .Args }}{{ $_hugo_dot.Set ("PLACEHOLDER") }}{{ end }}`
 )
 
-var partialReturnWrapper *parse.ListNode
+var (
+	partialReturnWrapper *parse.ListNode
+
+	// The command every return statement is rewritten to.
+	partialReturnSetter *parse.CommandNode
+)
 
 func init() {
 	templ, err := texttemplate.New("").Parse(partialReturnWrapperTempl)
@@ -149,18 +152,15 @@ func init() {
 		panic(err)
 	}
 	partialReturnWrapper = templ.Tree.Root
+	rangeNode := partialReturnWrapper.Nodes[2].(*parse.RangeNode)
+	partialReturnSetter = rangeNode.List.Nodes[0].(*parse.ActionNode).Pipe.Cmds[0]
+	rangeNode.List.Nodes = nil
 }
 
 func (c *templateContext) wrapInPartialReturnWrapper(n *parse.ListNode) *parse.ListNode {
 	wrapper := partialReturnWrapper.CopyList()
-	withNode := wrapper.Nodes[2].(*parse.WithNode)
-	retn := withNode.List.Nodes[0]
-	setCmd := retn.(*parse.ActionNode).Pipe.Cmds[0]
-	setPipe := setCmd.Args[1].(*parse.PipeNode)
-	// Replace PLACEHOLDER with the real return value.
-	// Note that this is a PipeNode, so it will be wrapped in parens.
-	setPipe.Cmds = []*parse.CommandNode{c.returnNode}
-	withNode.List.Nodes = append(n.Nodes, retn)
+	rangeNode := wrapper.Nodes[2].(*parse.RangeNode)
+	rangeNode.List.Nodes = n.Nodes
 
 	return wrapper
 
@@ -195,7 +195,7 @@ func (c *templateContext) wrapWithGetIf(p *parse.PipeNode) {
 // 1) Make all .Params.CamelCase and similar into lowercase.
 // 2) Wraps every with and if pipe in getif
 // 3) Collects some information about the template content.
-func (c *templateContext) applyTransformations(n parse.Node) (bool, error) {
+func (c *templateContext) applyTransformations(n parse.Node) error {
 	switch x := n.(type) {
 	case *parse.ListNode:
 		if x != nil {
@@ -223,16 +223,13 @@ func (c *templateContext) applyTransformations(n parse.Node) (bool, error) {
 			c.decl[x.Decl[0].Ident[0]] = x.Cmds[0].String()
 		}
 
-		for i, cmd := range x.Cmds {
-			keep, _ := c.applyTransformations(cmd)
-			if !keep {
-				x.Cmds = append(x.Cmds[:i], x.Cmds[i+1:]...)
-			}
+		for _, cmd := range x.Cmds {
+			c.applyTransformations(cmd)
 		}
 
 	case *parse.CommandNode:
 		c.collectInner(x)
-		keep := c.collectReturnNode(x)
+		c.collectReturnNode(x)
 
 		for _, elem := range x.Args {
 			switch an := elem.(type) {
@@ -249,10 +246,9 @@ func (c *templateContext) applyTransformations(n parse.Node) (bool, error) {
 				}
 			}
 		}
-		return keep, c.err
 	}
 
-	return true, c.err
+	return c.err
 }
 
 func (c *templateContext) applyTransformationsToNodes(nodes ...parse.Node) {
@@ -354,25 +350,33 @@ func (c *templateContext) collectInner(n *parse.CommandNode) {
 
 }
 
-func (c *templateContext) collectReturnNode(n *parse.CommandNode) bool {
-	if c.typ != templatePartial || c.returnNode != nil {
-		return true
+// collectReturnNode rewrites a return statement in a partial, e.g.
+// {{ return $v }}, into {{ $_hugo_dot.Set ($v) }}, which stores the value
+// and stops the template execution.
+// A partial can have any number of return statements, the first one
+// executed wins.
+func (c *templateContext) collectReturnNode(n *parse.CommandNode) {
+	if c.typ != templatePartial {
+		return
 	}
 
 	if len(n.Args) < 2 {
-		return true
+		return
 	}
 
 	ident, ok := n.Args[0].(*parse.IdentifierNode)
 	if !ok || ident.Ident != "return" {
-		return true
+		return
 	}
 
-	c.returnNode = n
-	// Remove the "return" identifiers
-	c.returnNode.Args = c.returnNode.Args[1:]
+	c.Info.HasReturn = true
 
-	return false
+	setCmd := partialReturnSetter.Copy().(*parse.CommandNode)
+	setPipe := setCmd.Args[1].(*parse.PipeNode)
+	// Replace PLACEHOLDER with the real return value.
+	// Note that this is a PipeNode, so it will be wrapped in parens.
+	setPipe.Cmds[0].Args = n.Args[1:]
+	n.Args = setCmd.Args
 
 }
 
@@ -93,6 +93,11 @@ func TestTemplateFuncsExamples(t *testing.T) {
 	v.Set("baseURL", "http://mysite.com/hugo/")
 	v.Set("CurrentContentLanguage", langs.NewLanguage("en", v))
 
+	// Recreate the project module so it is rooted in the working dir.
+	mod, err := modules.CreateProjectModule(v)
+	c.Assert(err, qt.IsNil)
+	v.Set("allModules", modules.Modules{mod})
+
 	fs := hugofs.NewMem(v)
 
 	afero.WriteFile(fs.Source, filepath.Join(workingDir, "files", "README.txt"), []byte("Hugo Rocks!"), 0755)
@@ -28,7 +28,7 @@ import (
 )
 
 // Unmarshal unmarshals the data given, which can be either a string
-// or a Resource. Supported formats are JSON, TOML, YAML, and CSV.
+// or a Resource. Supported formats are JSON, TOML, YAML, CSV and XML.
 // You can optionally provide an options map as the first argument.
 func (ns *Namespace) Unmarshal(args ...interface{}) (interface{}, error) {
 	if len(args) < 1 || len(args) > 2 {
@@ -122,6 +122,13 @@ func TestUnmarshal(t *testing.T) {
 			c.Assert([][]string{{"a", "b", "c"}}, qt.DeepEquals, r)
 
 		}},
+		{`<root><slogan>Hugo Rocks!</slogan></root>`, nil, func(m map[string]interface{}) {
+			assertSlogan(m)
+		}},
+		{testContentResource{key: "r1", content: `<?xml version="1.0" encoding="utf-8"?>
+<root><slogan>Hugo Rocks!</slogan></root>`, mime: media.XMLType}, nil, func(m map[string]interface{}) {
+			assertSlogan(m)
+		}},
 		{"a,b,c", nil, func(r [][]string) {
 			c.Assert([][]string{{"a", "b", "c"}}, qt.DeepEquals, r)
 
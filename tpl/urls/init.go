@@ -59,6 +59,14 @@ func init() {
 			[][2]string{},
 		)
 
+		ns.AddMethodMapping(ctx.JoinPath,
+			nil,
+			[][2]string{
+				{`{{ urls.JoinPath "https://example.org" "docs" "../images/" }}`, `https://example.org/images/`},
+				{`{{ urls.JoinPath "/blog/" (slice "2020" "post.html") }}`, `/blog/2020/post.html`},
+			},
+		)
+
 		ns.AddMethodMapping(ctx.Anchorize,
 			[]string{"anchorize"},
 			[][2]string{
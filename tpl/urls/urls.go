@@ -20,11 +20,14 @@ import (
 
 	"html/template"
 	"net/url"
+	"path"
+	"path/filepath"
+	"strings"
 
 	"github.com/gohugoio/hugo/common/urls"
 	"github.com/gohugoio/hugo/deps"
+	"github.com/gohugoio/hugo/helpers"
 	_errors "github.com/pkg/errors"
-	"github.com/russross/blackfriday"
 	"github.com/spf13/cast"
 )
 
@@ -63,6 +66,66 @@ func (ns *Namespace) Parse(rawurl interface{}) (*url.URL, error) {
 	return url.Parse(s)
 }
 
+// JoinPath joins the path elements to the path of the URL given as the
+// first element, adding separating slashes as needed. The resulting path is
+// cleaned of any ./ or ../ elements and duplicate slashes; a trailing slash in
+// the last element is preserved. Slices of elements are flattened.
+func (ns *Namespace) JoinPath(elements ...interface{}) (string, error) {
+	var selements []string
+	for _, elem := range elements {
+		switch v := elem.(type) {
+		case []string:
+			selements = append(selements, v...)
+		case []interface{}:
+			for _, e := range v {
+				s, err := cast.ToStringE(e)
+				if err != nil {
+					return "", err
+				}
+				selements = append(selements, s)
+			}
+		default:
+			s, err := cast.ToStringE(elem)
+			if err != nil {
+				return "", err
+			}
+			selements = append(selements, s)
+		}
+	}
+
+	if len(selements) == 0 {
+		return "", nil
+	}
+
+	u, err := url.Parse(selements[0])
+	if err != nil {
+		return "", _errors.Wrap(err, "Error in JoinPath")
+	}
+
+	last := selements[len(selements)-1]
+	if len(selements) == 1 {
+		last = u.Path
+	}
+
+	parts := []string{u.Path}
+	for _, e := range selements[1:] {
+		parts = append(parts, filepath.ToSlash(e))
+	}
+
+	p := path.Join(parts...)
+	if p != "" && p != "/" && strings.HasSuffix(last, "/") {
+		p += "/"
+	}
+	if u.Host != "" && p != "" && !strings.HasPrefix(p, "/") {
+		p = "/" + p
+	}
+
+	u.Path = p
+	u.RawPath = ""
+
+	return u.String(), nil
+}
+
 // RelURL takes a given string and prepends the relative path according to a
 // page's position in the project directory structure.
 func (ns *Namespace) RelURL(a interface{}) (template.HTML, error) {
@@ -80,16 +143,21 @@ func (ns *Namespace) URLize(a interface{}) (string, error) {
 	if err != nil {
 		return "", nil
 	}
+	if ns.deps.ContentSpec.BlackFriday.HeadingIDType == helpers.HeadingIDTypeASCII {
+		// Fold to ASCII the same way as the heading IDs.
+		return ns.deps.PathSpec.URLEscape(helpers.ASCIIAnchorName(ns.deps.PathSpec.MakePathSanitized(s))), nil
+	}
 	return ns.deps.PathSpec.URLize(s), nil
 }
 
-// Anchorize creates sanitized anchor names that are compatible with Blackfriday.
+// Anchorize creates sanitized anchor names that are compatible with the
+// heading IDs in Markdown, see headingIDType in the Blackfriday config.
 func (ns *Namespace) Anchorize(a interface{}) (string, error) {
 	s, err := cast.ToStringE(a)
 	if err != nil {
 		return "", nil
 	}
-	return blackfriday.SanitizedAnchorName(s), nil
+	return helpers.SanitizeAnchorName(s, ns.deps.ContentSpec.BlackFriday.HeadingIDType), nil
 }
 
 // Ref returns the absolute URL path to a given content item.
@@ -67,3 +67,44 @@ func TestParse(t *testing.T) {
 			qt.CmpEquals(hqt.DeepAllowUnexported(&url.URL{}, url.Userinfo{})), test.expect)
 	}
 }
+
+func TestJoinPath(t *testing.T) {
+	t.Parallel()
+	c := qt.New(t)
+
+	for _, test := range []struct {
+		elements []interface{}
+		expect   interface{}
+	}{
+		{[]interface{}{}, ""},
+		{[]interface{}{""}, ""},
+		{[]interface{}{"a"}, "a"},
+		{[]interface{}{"/a/b/c/"}, "/a/b/c/"},
+		{[]interface{}{"a", "b"}, "a/b"},
+		{[]interface{}{"/a", "b/"}, "/a/b/"},
+		{[]interface{}{"https://example.org"}, "https://example.org"},
+		{[]interface{}{"https://example.org", "a"}, "https://example.org/a"},
+		{[]interface{}{"https://example.org/", "a", "b/"}, "https://example.org/a/b/"},
+		{[]interface{}{"https://example.org/a", "../../b"}, "https://example.org/b"},
+		{[]interface{}{"https://example.org/a", "./b", "//c"}, "https://example.org/a/b/c"},
+		{[]interface{}{"https://example.org/a?q=1#top", "b"}, "https://example.org/a/b?q=1#top"},
+		{[]interface{}{"https://example.org", "a b"}, "https://example.org/a%20b"},
+		{[]interface{}{"https://example.org", []string{"a", "b"}}, "https://example.org/a/b"},
+		{[]interface{}{"https://example.org", []interface{}{"a", 3}}, "https://example.org/a/3"},
+		// errors
+		{[]interface{}{"https://example.org", tstNoStringer{}}, false},
+		{[]interface{}{"https://example.org", []interface{}{tstNoStringer{}}}, false},
+		{[]interface{}{":foo"}, false},
+	} {
+
+		result, err := ns.JoinPath(test.elements...)
+
+		if b, ok := test.expect.(bool); ok && !b {
+			c.Assert(err, qt.Not(qt.IsNil))
+			continue
+		}
+
+		c.Assert(err, qt.IsNil)
+		c.Assert(result, qt.Equals, test.expect)
+	}
+}
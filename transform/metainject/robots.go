@@ -0,0 +1,51 @@
+// Copyright 2019 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metainject
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+
+	"github.com/gohugoio/hugo/helpers"
+	"github.com/gohugoio/hugo/transform"
+)
+
+var robotsTagCheck = regexp.MustCompile(`(?i)<meta\s+name=['|"]?robots['|"]?`)
+var headStartRe = regexp.MustCompile(`(?i)<head(\s[^>]*)?>`)
+
+// Robots returns a transformer that injects a robots meta tag with the
+// given content, e.g. "noindex, nofollow", if none present.
+func Robots(content string) transform.Transformer {
+	tag := fmt.Sprintf(`<meta name="robots" content="%s" />`, html.EscapeString(content))
+
+	return func(ft transform.FromTo) error {
+		b := ft.From().Bytes()
+
+		if !robotsTagCheck.Match(b) {
+			if loc := headStartRe.FindIndex(b); loc != nil {
+				newcontent := make([]byte, 0, len(b)+len(tag)+2)
+				newcontent = append(newcontent, b[:loc[1]]...)
+				newcontent = append(newcontent, "\n\t"+tag...)
+				b = append(newcontent, b[loc[1]:]...)
+			}
+		}
+
+		if _, err := ft.To().Write(b); err != nil {
+			helpers.DistinctWarnLog.Println("Failed to inject robots tag:", err)
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,55 @@
+// Copyright 2019 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metainject
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/gohugoio/hugo/transform"
+)
+
+func TestRobotsInject(t *testing.T) {
+	const tag = `<meta name="robots" content="noindex, nofollow" />`
+
+	for i, this := range []struct {
+		in     string
+		expect string
+	}{
+		{`<head>
+	<foo />
+</head>`, `<head>
+	` + tag + `
+	<foo />
+</head>`},
+		{`<HEAD lang="en"></HEAD>`, `<HEAD lang="en">
+	` + tag + `</HEAD>`},
+		{`<head><meta name="robots" content="index" /></head>`, `<head><meta name="robots" content="index" /></head>`},
+		{`<head><META NAME='ROBOTS' content='none' /></head>`, `<head><META NAME='ROBOTS' content='none' /></head>`},
+		{`<header></header>`, `<header></header>`},
+		{"", ""},
+		{"</head>", "</head>"},
+	} {
+		in := strings.NewReader(this.in)
+		out := new(bytes.Buffer)
+
+		tr := transform.New(Robots("noindex, nofollow"))
+		tr.Apply(out, in)
+
+		if out.String() != this.expect {
+			t.Errorf("[%d] Expected \n%q got \n%q", i, this.expect, out.String())
+		}
+	}
+}
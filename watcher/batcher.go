@@ -21,7 +21,7 @@ import (
 
 // Batcher batches file watch events in a given interval.
 type Batcher struct {
-	*fsnotify.Watcher
+	fileWatcher
 	interval time.Duration
 	done     chan struct{}
 
@@ -29,20 +29,30 @@ type Batcher struct {
 }
 
 // New creates and starts a Batcher with the given time interval.
-func New(interval time.Duration) (*Batcher, error) {
-	watcher, err := fsnotify.NewWatcher()
+// If poll is set, the file system is polled for changes every intervalPoll
+// instead of relying on file system events.
+func New(intervalBatcher, intervalPoll time.Duration, poll bool) (*Batcher, error) {
+	var w fileWatcher
+
+	if poll {
+		w = newPoller(intervalPoll)
+	} else {
+		fw, err := fsnotify.NewWatcher()
+		if err != nil {
+			return nil, err
+		}
+		w = fsnotifyWatcher{fw}
+	}
 
 	batcher := &Batcher{}
-	batcher.Watcher = watcher
-	batcher.interval = interval
+	batcher.fileWatcher = w
+	batcher.interval = intervalBatcher
 	batcher.done = make(chan struct{}, 1)
 	batcher.Events = make(chan []fsnotify.Event, 1)
 
-	if err == nil {
-		go batcher.run()
-	}
+	go batcher.run()
 
-	return batcher, err
+	return batcher, nil
 }
 
 func (b *Batcher) run() {
@@ -51,7 +61,7 @@ func (b *Batcher) run() {
 OuterLoop:
 	for {
 		select {
-		case ev := <-b.Watcher.Events:
+		case ev := <-b.fileWatcher.Events():
 			evs = append(evs, ev)
 		case <-tick:
 			if len(evs) == 0 {
@@ -69,5 +79,5 @@ OuterLoop:
 // Close stops the watching of the files.
 func (b *Batcher) Close() {
 	b.done <- struct{}{}
-	b.Watcher.Close()
+	b.fileWatcher.Close()
 }
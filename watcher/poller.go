@@ -0,0 +1,266 @@
+// Copyright 2019 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package watcher
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+var errPollerClosed = errors.New("poller is closed")
+
+// fileWatcher is implemented by both the fsnotify based watcher and the poller.
+type fileWatcher interface {
+	Events() <-chan fsnotify.Event
+	Errors() <-chan error
+	Add(name string) error
+	Remove(name string) error
+	Close() error
+}
+
+// fsnotifyWatcher adapts a fsnotify.Watcher to the fileWatcher interface.
+type fsnotifyWatcher struct {
+	*fsnotify.Watcher
+}
+
+func (w fsnotifyWatcher) Events() <-chan fsnotify.Event {
+	return w.Watcher.Events
+}
+
+func (w fsnotifyWatcher) Errors() <-chan error {
+	return w.Watcher.Errors
+}
+
+// poller watches files and directories by checking them for changes at
+// a given interval. This works where file system events are not
+// available, e.g. on some network shares and Docker bind mounts.
+type poller struct {
+	interval time.Duration
+
+	events chan fsnotify.Event
+	errors chan error
+
+	mu      sync.Mutex
+	watches map[string]chan struct{}
+	closed  bool
+}
+
+func newPoller(interval time.Duration) *poller {
+	return &poller{
+		interval: interval,
+		events:   make(chan fsnotify.Event),
+		errors:   make(chan error),
+		watches:  make(map[string]chan struct{}),
+	}
+}
+
+func (p *poller) Events() <-chan fsnotify.Event {
+	return p.events
+}
+
+func (p *poller) Errors() <-chan error {
+	return p.errors
+}
+
+// Add starts polling name, a file or a directory. For directories, the
+// files directly inside it are also checked.
+func (p *poller) Add(name string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.closed {
+		return errPollerClosed
+	}
+
+	if _, found := p.watches[name]; found {
+		return nil
+	}
+
+	state, err := readPollState(name)
+	if err != nil {
+		return err
+	}
+
+	stop := make(chan struct{})
+	p.watches[name] = stop
+
+	go p.watch(name, state, stop)
+
+	return nil
+}
+
+// Remove stops polling name.
+func (p *poller) Remove(name string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.remove(name)
+}
+
+func (p *poller) remove(name string) error {
+	if p.closed {
+		return errPollerClosed
+	}
+
+	stop, found := p.watches[name]
+	if !found {
+		return errors.New("can't remove non-existent poller watch")
+	}
+
+	close(stop)
+	delete(p.watches, name)
+
+	return nil
+}
+
+// Close stops all polling.
+func (p *poller) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.closed {
+		return nil
+	}
+
+	for name := range p.watches {
+		p.remove(name)
+	}
+	p.closed = true
+
+	return nil
+}
+
+func (p *poller) watch(name string, state map[string]os.FileInfo, stop chan struct{}) {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+
+		newState, err := readPollState(name)
+		if err != nil {
+			if os.IsNotExist(err) {
+				p.send(fsnotify.Event{Name: name, Op: fsnotify.Remove}, stop)
+				p.mu.Lock()
+				if !p.closed {
+					if s, found := p.watches[name]; found && s == stop {
+						delete(p.watches, name)
+					}
+				}
+				p.mu.Unlock()
+				return
+			}
+			if !p.sendErr(err, stop) {
+				return
+			}
+			continue
+		}
+
+		for _, ev := range pollStateChanges(state, newState) {
+			if !p.send(ev, stop) {
+				return
+			}
+		}
+
+		state = newState
+	}
+}
+
+func (p *poller) send(ev fsnotify.Event, stop chan struct{}) bool {
+	select {
+	case p.events <- ev:
+		return true
+	case <-stop:
+		return false
+	}
+}
+
+func (p *poller) sendErr(err error, stop chan struct{}) bool {
+	select {
+	case p.errors <- err:
+		return true
+	case <-stop:
+		return false
+	}
+}
+
+// readPollState returns the file info for name, and if name is a directory,
+// for the files in it, keyed by filename.
+func readPollState(name string) (map[string]os.FileInfo, error) {
+	fi, err := os.Stat(name)
+	if err != nil {
+		return nil, err
+	}
+
+	state := map[string]os.FileInfo{name: fi}
+
+	if !fi.IsDir() {
+		return state, nil
+	}
+
+	fis, err := ioutil.ReadDir(name)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, fi := range fis {
+		state[filepath.Join(name, fi.Name())] = fi
+	}
+
+	return state, nil
+}
+
+// pollStateChanges returns the events needed to get from the old to the new
+// state, sorted by filename.
+func pollStateChanges(oldState, newState map[string]os.FileInfo) []fsnotify.Event {
+	var events []fsnotify.Event
+
+	for filename, fi := range newState {
+		oldFi, found := oldState[filename]
+		switch {
+		case !found:
+			events = append(events, fsnotify.Event{Name: filename, Op: fsnotify.Create})
+		case fi.IsDir():
+			// A directory's modification time changes when files are added
+			// or removed, which is handled above and below.
+		case fi.ModTime() != oldFi.ModTime() || fi.Size() != oldFi.Size():
+			events = append(events, fsnotify.Event{Name: filename, Op: fsnotify.Write})
+		case fi.Mode() != oldFi.Mode():
+			events = append(events, fsnotify.Event{Name: filename, Op: fsnotify.Chmod})
+		}
+	}
+
+	for filename := range oldState {
+		if _, found := newState[filename]; !found {
+			events = append(events, fsnotify.Event{Name: filename, Op: fsnotify.Remove})
+		}
+	}
+
+	sort.Slice(events, func(i, j int) bool {
+		return events[i].Name < events[j].Name
+	})
+
+	return events
+}
@@ -0,0 +1,93 @@
+// Copyright 2019 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package watcher
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/fsnotify/fsnotify"
+)
+
+func TestPoller(t *testing.T) {
+	c := qt.New(t)
+
+	dir, err := ioutil.TempDir("", "hugo-poller")
+	c.Assert(err, qt.IsNil)
+	defer os.RemoveAll(dir)
+
+	filename := filepath.Join(dir, "p1.md")
+	c.Assert(ioutil.WriteFile(filename, []byte("a"), 0644), qt.IsNil)
+
+	p := newPoller(10 * time.Millisecond)
+	defer p.Close()
+
+	c.Assert(p.Add(dir), qt.IsNil)
+	c.Assert(p.Add(filepath.Join(dir, "doesnotexist")), qt.Not(qt.IsNil))
+
+	next := func() fsnotify.Event {
+		select {
+		case ev := <-p.Events():
+			return ev
+		case <-time.After(5 * time.Second):
+			c.Fatal("timed out waiting for event")
+		}
+		return fsnotify.Event{}
+	}
+
+	c.Assert(ioutil.WriteFile(filename, []byte("ab"), 0644), qt.IsNil)
+	c.Assert(next(), qt.Equals, fsnotify.Event{Name: filename, Op: fsnotify.Write})
+
+	created := filepath.Join(dir, "p2.md")
+	c.Assert(ioutil.WriteFile(created, []byte("a"), 0644), qt.IsNil)
+	c.Assert(next(), qt.Equals, fsnotify.Event{Name: created, Op: fsnotify.Create})
+
+	c.Assert(os.Remove(created), qt.IsNil)
+	c.Assert(next(), qt.Equals, fsnotify.Event{Name: created, Op: fsnotify.Remove})
+
+	c.Assert(p.Remove(dir), qt.IsNil)
+	c.Assert(p.Remove(dir), qt.Not(qt.IsNil))
+}
+
+func TestPollStateChanges(t *testing.T) {
+	c := qt.New(t)
+
+	dir, err := ioutil.TempDir("", "hugo-poller")
+	c.Assert(err, qt.IsNil)
+	defer os.RemoveAll(dir)
+
+	for _, name := range []string{"a.md", "b.md", "c.md"} {
+		c.Assert(ioutil.WriteFile(filepath.Join(dir, name), []byte("a"), 0644), qt.IsNil)
+	}
+
+	before, err := readPollState(dir)
+	c.Assert(err, qt.IsNil)
+
+	c.Assert(ioutil.WriteFile(filepath.Join(dir, "a.md"), []byte("changed"), 0644), qt.IsNil)
+	c.Assert(os.Remove(filepath.Join(dir, "b.md")), qt.IsNil)
+	c.Assert(ioutil.WriteFile(filepath.Join(dir, "d.md"), []byte("a"), 0644), qt.IsNil)
+
+	after, err := readPollState(dir)
+	c.Assert(err, qt.IsNil)
+
+	c.Assert(pollStateChanges(before, after), qt.DeepEquals, []fsnotify.Event{
+		{Name: filepath.Join(dir, "a.md"), Op: fsnotify.Write},
+		{Name: filepath.Join(dir, "b.md"), Op: fsnotify.Remove},
+		{Name: filepath.Join(dir, "d.md"), Op: fsnotify.Create},
+	})
+}